@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+// operationsPath is the prefix of the operations API. POST /operations/{id}
+// executes the operation with the input in the request body.
+const operationsPath = "/operations/"
+
+// operationRequest is the body of an operation call. An empty body executes
+// the operation without input data.
+type operationRequest struct {
+	Data     any               `json:"data"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// operationResponse is the body of a successful operation call.
+type operationResponse struct {
+	Data any `json:"data"`
+}
+
+// errorResponse is the body of a failed call.
+type errorResponse struct {
+	Code  failure.Code `json:"code,omitempty"`
+	Error string       `json:"error"`
+}
+
+// newAPIHandler serves the operations of the system. Errors are answered with
+// the status code of their error code, see statusCode.
+func newAPIHandler(system component.System) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+operationsPath+"{id}", func(w http.ResponseWriter, r *http.Request) {
+		var request operationRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, failure.Wrap(err, component.ErrInvalidInputType, "request body"))
+			return
+		}
+
+		output, err := system.ExecuteOperation(infraContext.WrapContext(r.Context()),
+			component.ComponentID(r.PathValue("id")),
+			component.Input{Data: request.Data, Metadata: request.Metadata})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, operationResponse{Data: output.Data})
+	})
+	return mux
+}
+
+// writeError answers with the status code and the code of err.
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusCode(err), errorResponse{Code: failure.CodeOf(err), Error: err.Error()})
+}
+
+// writeJSON writes body as JSON with the status code.
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// newAPIServer creates the service serving the operations of system on addr.
+func newAPIServer(addr string, system component.System) *infraComponent.HTTPService {
+	return infraComponent.NewHTTPService(component.ComponentConfig{ID: "operations-api", Name: "Operations API"},
+		"operations API", addr, newAPIHandler(system))
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/test/unit/mocks"
+)
+
+func TestAPIHandler(t *testing.T) {
+	call := func(t *testing.T, system component.System, path, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		newAPIHandler(system).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		var decoded map[string]interface{}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&decoded))
+		return rec, decoded
+	}
+
+	t.Run("Executes the operation", func(t *testing.T) {
+		system := mocks.NewMockSystem(t)
+		system.EXPECT().ExecuteOperation(mock.Anything, component.ComponentID("sum"),
+			component.Input{Data: []interface{}{1.0, 2.0}, Metadata: map[string]string{"key": "k1"}}).
+			Return(component.Output{Data: 3}, nil)
+
+		rec, body := call(t, system, "/operations/sum", `{"data": [1, 2], "metadata": {"key": "k1"}}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 3.0, body["data"])
+	})
+
+	t.Run("Answers errors with their status and code", func(t *testing.T) {
+		system := mocks.NewMockSystem(t)
+		system.EXPECT().ExecuteOperation(mock.Anything, component.ComponentID("missing"), component.Input{}).
+			Return(component.Output{}, failure.New(component.ErrOperationNotFound, "operation 'missing'"))
+
+		rec, body := call(t, system, "/operations/missing", "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, string(component.ErrOperationNotFound), body["code"])
+		assert.Contains(t, body["error"], "operation 'missing'")
+	})
+
+	t.Run("Rejects malformed bodies", func(t *testing.T) {
+		rec, body := call(t, mocks.NewMockSystem(t), "/operations/sum", "{")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, string(component.ErrInvalidInputType), body["code"])
+	})
+}
@@ -1,8 +1,78 @@
-/*
-Copyright © 2024 NAME HERE <EMAIL ADDRESS>
-*/
+// Command server runs the runtime with the plugins found in the plugin
+// directories and serves their operations over HTTP until it receives SIGINT
+// or SIGTERM.
+//
+// The health, metrics and admin endpoints are enabled with their flags or with
+// the health.address, metrics.address and admin.address configuration keys.
 package main
 
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	"github.com/fintechain/skeleton/pkg/runtime"
+)
+
+// options are the command line options of the server.
+type options struct {
+	addr        string
+	pluginDirs  string
+	healthAddr  string
+	metricsAddr string
+	adminAddr   string
+}
+
 func main() {
-	//cmd.Execute()
+	var opts options
+	flag.StringVar(&opts.addr, "addr", "127.0.0.1:8080", "address of the operations API")
+	flag.StringVar(&opts.pluginDirs, "plugins", "", "comma-separated plugin directories")
+	flag.StringVar(&opts.healthAddr, "health", "", "address of the health endpoint")
+	flag.StringVar(&opts.metricsAddr, "metrics", "", "address of the metrics endpoint")
+	flag.StringVar(&opts.adminAddr, "admin", "", "address of the admin endpoint")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run starts the runtime and the operations API, and waits for the runtime
+// to stop.
+func run(opts options) error {
+	builder := runtime.NewBuilder().WithSignalHandling(true)
+	if opts.pluginDirs != "" {
+		builder.WithPluginDirectories(strings.Split(opts.pluginDirs, ",")...)
+	}
+	if opts.healthAddr != "" {
+		builder.WithHealthEndpoint(opts.healthAddr)
+	}
+	if opts.metricsAddr != "" {
+		builder.WithMetricsEndpoint(opts.metricsAddr)
+	}
+	if opts.adminAddr != "" {
+		builder.WithAdminEndpoint(opts.adminAddr)
+	}
+
+	app, err := builder.Build()
+	if err != nil {
+		return err
+	}
+	ctx := infraContext.NewContext()
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+
+	api := newAPIServer(opts.addr, app.Runtime())
+	if err := api.Start(ctx); err != nil {
+		return errors.Join(err, app.Stop(ctx))
+	}
+	fmt.Printf("Serving operations on http://%s%s{id}\n", api.Addr(), operationsPath)
+
+	err = app.Wait()
+	return errors.Join(err, api.Stop(ctx))
 }
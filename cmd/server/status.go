@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	"github.com/fintechain/skeleton/internal/domain/jobs"
+	"github.com/fintechain/skeleton/internal/domain/queue"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/scheduler"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	"github.com/fintechain/skeleton/internal/domain/workflow"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+)
+
+// statusCodes maps error codes onto the HTTP status codes the API answers
+// with. Codes that are not listed are internal errors.
+var statusCodes = map[failure.Code]int{
+	// Unknown resources
+	component.ErrComponentNotFound: http.StatusNotFound,
+	component.ErrItemNotFound:      http.StatusNotFound,
+	component.ErrOperationNotFound: http.StatusNotFound,
+	component.ErrServiceNotFound:   http.StatusNotFound,
+	component.ErrPluginNotFound:    http.StatusNotFound,
+	storage.ErrKeyNotFound:         http.StatusNotFound,
+	storage.ErrStoreNotFound:       http.StatusNotFound,
+	jobs.ErrJobNotFound:            http.StatusNotFound,
+	queue.ErrJobNotFound:           http.StatusNotFound,
+	scheduler.ErrScheduleNotFound:  http.StatusNotFound,
+	idempotency.ErrRecordNotFound:  http.StatusNotFound,
+
+	// Rejected requests
+	component.ErrInvalidItem:           http.StatusBadRequest,
+	component.ErrInvalidInputType:      http.StatusBadRequest,
+	component.ErrInputValidationFailed: http.StatusBadRequest,
+	idempotency.ErrKeyRequired:         http.StatusBadRequest,
+	queue.ErrInvalidJob:                http.StatusBadRequest,
+	scheduler.ErrInvalidSchedule:       http.StatusBadRequest,
+	scheduler.ErrInvalidCron:           http.StatusBadRequest,
+	workflow.ErrInvalidFlow:            http.StatusBadRequest,
+	introspection.ErrUnknownFormat:     http.StatusBadRequest,
+	component.ErrCapabilityDenied:      http.StatusForbidden,
+
+	// Conflicts with the current state
+	component.ErrComponentExists:       http.StatusConflict,
+	component.ErrItemAlreadyExists:     http.StatusConflict,
+	component.ErrPluginAlreadyLoaded:   http.StatusConflict,
+	storage.ErrStoreExists:             http.StatusConflict,
+	idempotency.ErrKeyConflict:         http.StatusConflict,
+	scheduler.ErrScheduleExists:        http.StatusConflict,
+	jobs.ErrJobNotFinished:             http.StatusConflict,
+	jobs.ErrJobFinished:                http.StatusConflict,
+	component.ErrServiceAlreadyRunning: http.StatusConflict,
+
+	// Temporarily unavailable
+	component.ErrServiceNotRunning: http.StatusServiceUnavailable,
+	component.ErrSystemNotStarted:  http.StatusServiceUnavailable,
+	resilience.ErrCircuitOpen:      http.StatusServiceUnavailable,
+	infraRuntime.ErrShuttingDown:   http.StatusServiceUnavailable,
+
+	// Timeouts
+	resilience.ErrOperationTimeout:     http.StatusGatewayTimeout,
+	context.ErrContextDeadlineExceeded: http.StatusGatewayTimeout,
+}
+
+// statusCode returns the HTTP status code for an error returned by the
+// framework, from the first error code found in its tree by failure.CodeOf.
+// A joined error therefore answers with the status of its first coded error.
+func statusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if status, ok := statusCodes[failure.CodeOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+)
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"No error", nil, http.StatusOK},
+		{"Plain error", errors.New("boom"), http.StatusInternalServerError},
+		{"Unmapped code", failure.New(component.ErrServiceStartFailed, "db"), http.StatusInternalServerError},
+		{"Sentinel code", storage.ErrKeyNotFound, http.StatusNotFound},
+		{"Failure error", failure.New(component.ErrOperationNotFound, "op"), http.StatusNotFound},
+		{"Capability denied", failure.New(component.ErrCapabilityDenied, "plugin 'p'"), http.StatusForbidden},
+		{"Conflict", failure.New(storage.ErrStoreExists, "orders"), http.StatusConflict},
+		{"Shutting down", failure.New(infraRuntime.ErrShuttingDown, "rejected"), http.StatusServiceUnavailable},
+		{"Timeout", failure.New(resilience.ErrOperationTimeout, "op"), http.StatusGatewayTimeout},
+		{"Deadline", context.ErrContextDeadlineExceeded, http.StatusGatewayTimeout},
+		{"Wrapped by fmt", fmt.Errorf("calling: %w", failure.New(resilience.ErrCircuitOpen, "op")), http.StatusServiceUnavailable},
+		{"Outermost code wins", failure.Wrap(storage.ErrKeyNotFound, component.ErrInvalidInputType, "lookup"), http.StatusBadRequest},
+		{"First joined code wins", errors.Join(errors.New("plain"), storage.ErrKeyNotFound, component.ErrComponentExists), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, statusCode(tt.err))
+		})
+	}
+}
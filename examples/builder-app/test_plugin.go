@@ -7,6 +7,7 @@ import (
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	"github.com/fintechain/skeleton/internal/domain/plugin"
 	"github.com/fintechain/skeleton/internal/domain/runtime"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
@@ -228,7 +229,7 @@ func (o *TestOperation) Execute(ctx context.Context, input component.Input) (com
 	// Parse input
 	data, ok := input.Data.(map[string]interface{})
 	if !ok {
-		return component.Output{}, fmt.Errorf("invalid input data format")
+		return component.Output{}, failure.New(component.ErrInvalidInputType, "invalid input data format")
 	}
 
 	// Extract input values
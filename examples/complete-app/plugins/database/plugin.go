@@ -5,7 +5,9 @@ import (
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
 	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/runtime"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
 )
 
 // DatabasePlugin provides database functionality as a plugin.
@@ -19,7 +21,9 @@ type DatabasePlugin struct {
 	connectionString  string
 }
 
-// NewDatabasePlugin creates a new database plugin.
+// NewDatabasePlugin creates a new database plugin. The driver and data source
+// are defaults; plugins.database-plugin.driver and plugins.database-plugin.dsn
+// in the configuration take precedence.
 func NewDatabasePlugin(driverName, dataSource string) *DatabasePlugin {
 	config := component.ComponentConfig{
 		ID:          "database-plugin",
@@ -56,6 +60,20 @@ func (d *DatabasePlugin) Initialize(ctx context.Context, system component.System
 	// Store system reference for framework services access
 	d.system = system
 
+	// Read settings from the plugin's configuration section
+	if provider, ok := system.(runtime.PluginConfigurationProvider); ok {
+		section := provider.PluginConfiguration(d.ID())
+		section.SetDefault("driver", d.dbType)
+		section.SetDefault("dsn", d.connectionString)
+		section.AddRule("driver", infraConfig.Required())
+		section.AddRule("dsn", infraConfig.Required())
+
+		d.dbType = section.GetString("driver")
+		d.connectionString = section.GetString("dsn")
+		d.connectionService.driverName = d.dbType
+		d.connectionService.connectionString = d.connectionString
+	}
+
 	// 1. Initialize the components this plugin provides
 	if err := d.connectionService.Initialize(ctx, system); err != nil {
 		return err
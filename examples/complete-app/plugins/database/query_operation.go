@@ -8,6 +8,7 @@ import (
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	domainRuntime "github.com/fintechain/skeleton/internal/domain/runtime"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
 	"github.com/fintechain/skeleton/pkg/event"
@@ -50,7 +51,7 @@ func (q *QueryOperation) Initialize(ctx context.Context, system component.System
 func (q *QueryOperation) Execute(ctx context.Context, input component.Input) (component.Output, error) {
 	data, ok := input.Data.(map[string]interface{})
 	if !ok {
-		return component.Output{}, fmt.Errorf("invalid input data format")
+		return component.Output{}, failure.New(component.ErrInvalidInputType, "invalid input data format")
 	}
 
 	query, _ := data["query"].(string)
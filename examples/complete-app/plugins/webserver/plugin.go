@@ -45,6 +45,14 @@ func (w *WebServerPlugin) PluginType() plugin.PluginType {
 	return plugin.TypeIntegration
 }
 
+// Dependencies declares the plugins the web server relies on.
+// The plugin manager initializes and starts the database plugin first.
+func (w *WebServerPlugin) Dependencies() []plugin.Dependency {
+	return []plugin.Dependency{
+		{ID: "database-plugin", VersionRange: "^1.0.0"},
+	}
+}
+
 // Initialize sets up the plugin and registers its components.
 // This is where the plugin orchestrates its components.
 func (w *WebServerPlugin) Initialize(ctx context.Context, system component.System) error {
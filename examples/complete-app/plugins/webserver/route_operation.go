@@ -6,6 +6,7 @@ import (
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
 )
 
@@ -46,7 +47,7 @@ func (r *RouteOperation) Initialize(ctx context.Context, system component.System
 func (r *RouteOperation) Execute(ctx context.Context, input component.Input) (component.Output, error) {
 	data, ok := input.Data.(map[string]interface{})
 	if !ok {
-		return component.Output{}, fmt.Errorf("invalid input data format")
+		return component.Output{}, failure.New(component.ErrInvalidInputType, "invalid input data format")
 	}
 
 	method, _ := data["method"].(string)
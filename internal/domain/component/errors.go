@@ -1,52 +1,64 @@
 // Package component provides interfaces and types for the component system.
 package component
 
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
 // Standard component error codes
 const (
 	// Component lifecycle errors
-	ErrComponentNotFound           = "component.component_not_found"
-	ErrComponentExists             = "component.component_exists"
-	ErrInvalidComponentType        = "component.invalid_component_type"
-	ErrComponentNotInitialized     = "component.component_not_initialized"
-	ErrComponentAlreadyInitialized = "component.component_already_initialized"
-	ErrComponentDisposed           = "component.component_disposed"
-	ErrInvalidComponentConfig      = "component.invalid_component_config"
+	ErrComponentNotFound           failure.Code = "component.component_not_found"
+	ErrComponentExists             failure.Code = "component.component_exists"
+	ErrInvalidComponentType        failure.Code = "component.invalid_component_type"
+	ErrComponentNotInitialized     failure.Code = "component.component_not_initialized"
+	ErrComponentAlreadyInitialized failure.Code = "component.component_already_initialized"
+	ErrComponentDisposed           failure.Code = "component.component_disposed"
+	ErrInvalidComponentConfig      failure.Code = "component.invalid_component_config"
 
 	// Factory errors
-	ErrFactoryNotFound = "component.factory_not_found"
+	ErrFactoryNotFound failure.Code = "component.factory_not_found"
 
 	// Registry errors
-	ErrRegistryFull      = "component.registry_full"
-	ErrItemNotFound      = "component.item_not_found"
-	ErrItemAlreadyExists = "component.item_already_exists"
-	ErrInvalidItem       = "component.invalid_item"
+	ErrRegistryFull      failure.Code = "component.registry_full"
+	ErrItemNotFound      failure.Code = "component.item_not_found"
+	ErrItemAlreadyExists failure.Code = "component.item_already_exists"
+	ErrInvalidItem       failure.Code = "component.invalid_item"
 
 	// Dependency errors
-	ErrDependencyNotFound = "component.dependency_not_found"
-	ErrCircularDependency = "component.circular_dependency"
+	ErrDependencyNotFound failure.Code = "component.dependency_not_found"
+	ErrCircularDependency failure.Code = "component.circular_dependency"
 
 	// Service errors
-	ErrServiceNotFound       = "component.service_not_found"
-	ErrServiceNotRunning     = "component.service_not_running"
-	ErrServiceAlreadyRunning = "component.service_already_running"
-	ErrServiceStartFailed    = "component.service_start_failed"
-	ErrServiceStopFailed     = "component.service_stop_failed"
+	ErrServiceNotFound       failure.Code = "component.service_not_found"
+	ErrServiceNotRunning     failure.Code = "component.service_not_running"
+	ErrServiceAlreadyRunning failure.Code = "component.service_already_running"
+	ErrServiceStartFailed    failure.Code = "component.service_start_failed"
+	ErrServiceStopFailed     failure.Code = "component.service_stop_failed"
 
 	// Plugin errors
-	ErrPluginNotFound        = "component.plugin_not_found"
-	ErrPluginAlreadyLoaded   = "component.plugin_already_loaded"
-	ErrPluginLoadFailed      = "component.plugin_load_failed"
-	ErrPluginUnloadFailed    = "component.plugin_unload_failed"
-	ErrPluginDiscoveryFailed = "component.plugin_discovery_failed"
+	ErrPluginNotFound        failure.Code = "component.plugin_not_found"
+	ErrPluginAlreadyLoaded   failure.Code = "component.plugin_already_loaded"
+	ErrPluginLoadFailed      failure.Code = "component.plugin_load_failed"
+	ErrPluginUnloadFailed    failure.Code = "component.plugin_unload_failed"
+	ErrPluginDiscoveryFailed failure.Code = "component.plugin_discovery_failed"
+	ErrCapabilityDenied      failure.Code = "component.capability_denied"
 
 	// System errors
-	ErrSystemNotInitialized = "component.system_not_initialized"
-	ErrSystemNotStarted     = "component.system_not_started"
-	ErrSystemAlreadyStarted = "component.system_already_started"
-	ErrOperationNotFound    = "component.operation_not_found"
-	ErrOperationFailed      = "component.operation_failed"
+	ErrSystemNotInitialized failure.Code = "component.system_not_initialized"
+	ErrSystemNotStarted     failure.Code = "component.system_not_started"
+	ErrSystemAlreadyStarted failure.Code = "component.system_already_started"
+	ErrOperationNotFound    failure.Code = "component.operation_not_found"
+	ErrOperationFailed      failure.Code = "component.operation_failed"
+	ErrInvalidInputType     failure.Code = "component.invalid_input_type"
+	ErrInvalidOutputType    failure.Code = "component.invalid_output_type"
+
+	// Schema errors
+	ErrInvalidSchema          failure.Code = "component.invalid_schema"
+	ErrInputValidationFailed  failure.Code = "component.input_validation_failed"
+	ErrOutputValidationFailed failure.Code = "component.output_validation_failed"
 
 	// Infrastructure errors
-	ErrEventBusNotAvailable     = "component.event_bus_not_available"
-	ErrStoreManagerNotAvailable = "component.store_manager_not_available"
+	ErrEventBusNotAvailable     failure.Code = "component.event_bus_not_available"
+	ErrStoreManagerNotAvailable failure.Code = "component.store_manager_not_available"
 )
@@ -0,0 +1,60 @@
+package component
+
+import (
+	"time"
+)
+
+// Component lifecycle event topics
+const (
+	// TopicComponentRegistered is triggered when a component is added to the registry.
+	TopicComponentRegistered = "component.registered"
+
+	// TopicComponentUnregistered is triggered when a component is removed from the registry.
+	TopicComponentUnregistered = "component.unregistered"
+
+	// TopicComponentInitialized is triggered when a component is initialized.
+	TopicComponentInitialized = "component.initialized"
+
+	// TopicComponentDisposed is triggered when a component is disposed.
+	TopicComponentDisposed = "component.disposed"
+
+	// TopicServiceStarted is triggered when a service starts.
+	TopicServiceStarted = "service.started"
+
+	// TopicServiceStopped is triggered when a service stops, including on its own without failure.
+	TopicServiceStopped = "service.stopped"
+
+	// TopicServiceFailed is triggered when a service fails, or fails to start or stop.
+	TopicServiceFailed = "service.failed"
+)
+
+// LifecycleListener receives the lifecycle transitions of a component as the
+// topic of the transition and, for failures, the error.
+type LifecycleListener func(topic string, err error)
+
+// ObservableComponent is implemented by components that report their own
+// lifecycle transitions, such as those embedding the base implementations.
+type ObservableComponent interface {
+	// SetLifecycleListener installs the listener; nil removes it.
+	SetLifecycleListener(listener LifecycleListener)
+}
+
+// CreateComponentEventPayload creates a lifecycle event payload for the given
+// component with additional data. A non-nil err is included as "error".
+func CreateComponentEventPayload(id ComponentID, typ ComponentType, err error, additionalData map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{
+		"componentId":   string(id),
+		"componentType": string(typ),
+		"timestamp":     time.Now(),
+	}
+	if err != nil {
+		payload["error"] = err.Error()
+	}
+
+	// Merge additional data if provided
+	for k, v := range additionalData {
+		payload[k] = v
+	}
+
+	return payload
+}
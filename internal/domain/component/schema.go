@@ -0,0 +1,87 @@
+// Package component provides interfaces and types for the component system.
+package component
+
+import (
+	"encoding/json"
+
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Schema is a JSON Schema describing operation input or output. It covers
+// the keywords the runtime validates; other keywords are ignored when parsing.
+type Schema struct {
+	Type        string `json:"type,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Format      string `json:"format,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Enum        []any  `json:"enum,omitempty"`
+
+	// Object keywords
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+
+	// Array keywords
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+	MaxItems *int    `json:"maxItems,omitempty"`
+
+	// String keywords
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+
+	// Numeric keywords
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+}
+
+// ParseSchema parses a JSON Schema document.
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, failure.Wrap(err, ErrInvalidSchema, "")
+	}
+	return &schema, nil
+}
+
+// MustParseSchema parses a JSON Schema document and panics if it is invalid.
+// It is intended for schemas declared as package-level literals.
+func MustParseSchema(data string) *Schema {
+	schema, err := ParseSchema([]byte(data))
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// SchemaProvider is implemented by operations that describe their input and
+// output. Either schema may be nil, in which case that side is not validated.
+type SchemaProvider interface {
+	// InputSchema returns the schema of Input.Data.
+	InputSchema() *Schema
+
+	// OutputSchema returns the schema of Output.Data.
+	OutputSchema() *Schema
+}
+
+// OperationSchema describes the input and output of a registered operation.
+type OperationSchema struct {
+	OperationID ComponentID `json:"operationId"`
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Input       *Schema     `json:"input,omitempty"`
+	Output      *Schema     `json:"output,omitempty"`
+}
+
+// SchemaRegistry is implemented by registries that expose operation schemas,
+// so that CLIs and API gateways can generate forms and documentation.
+type SchemaRegistry interface {
+	// OperationSchema returns the schemas of a registered operation.
+	OperationSchema(id ComponentID) (OperationSchema, error)
+
+	// OperationSchemas returns the schemas of all registered operations,
+	// ordered by operation ID.
+	OperationSchemas() []OperationSchema
+}
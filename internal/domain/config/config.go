@@ -3,6 +3,8 @@ package config
 
 import (
 	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/failure"
 )
 
 // Configuration provides type-safe access to application configuration values.
@@ -38,6 +40,32 @@ type Configuration interface {
 	Exists(key string) bool
 }
 
+// Section is a Configuration view rooted at a key prefix. Keys passed to its
+// accessors are relative to the prefix, so GetString("url") on the section
+// "plugins.pricing" reads "plugins.pricing.url". Registered defaults are used
+// for keys the underlying configuration does not set.
+type Section interface {
+	Configuration
+
+	// Prefix returns the absolute key prefix of the section.
+	Prefix() string
+
+	// SetDefault registers the default value of a relative key.
+	SetDefault(key string, value interface{})
+
+	// AddRule registers a validation rule for a relative key.
+	AddRule(key string, rule Rule)
+
+	// Validate checks every registered rule against the effective values.
+	Validate() error
+}
+
+// Rule validates a configuration value. exists is false when neither the
+// configuration nor a default provides the key. The error is the reason
+// only, such as "is required": Validate reports it with the key under
+// ErrConfigValidationFailed, so rules return plain errors.
+type Rule func(value interface{}, exists bool) error
+
 // ConfigurationSource provides configuration values from a specific source.
 type ConfigurationSource interface {
 	// LoadConfig loads configuration data from the source.
@@ -50,11 +78,11 @@ type ConfigurationSource interface {
 // Common error codes for configuration operations.
 const (
 	// ErrConfigNotFound indicates that a requested configuration key does not exist.
-	ErrConfigNotFound = "config.not_found"
+	ErrConfigNotFound failure.Code = "config.not_found"
 
 	// ErrConfigWrongType indicates that a configuration value cannot be converted to the requested type.
-	ErrConfigWrongType = "config.wrong_type"
+	ErrConfigWrongType failure.Code = "config.wrong_type"
 
 	// ErrConfigLoadFailed indicates that a configuration source failed to load.
-	ErrConfigLoadFailed = "config.load_failed"
+	ErrConfigLoadFailed failure.Code = "config.load_failed"
 )
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
 )
 
 // Event represents a structured message in the event-driven communication system.
@@ -20,6 +21,10 @@ type Event struct {
 
 	// Payload contains event-specific data as key-value pairs.
 	Payload map[string]interface{}
+
+	// Metadata carries cross-cutting values about the event, such as the
+	// trace it belongs to, separately from its payload.
+	Metadata map[string]string
 }
 
 // EventHandler defines the callback function signature for processing events.
@@ -41,6 +46,11 @@ type EventBus interface {
 	Publish(event *Event) error
 	PublishAsync(event *Event) error
 
+	// Publication on behalf of a call, such as an operation: the event joins
+	// the trace of the span ctx carries, unless its metadata already names one
+	PublishContext(ctx context.Context, event *Event) error
+	PublishAsyncContext(ctx context.Context, event *Event) error
+
 	// Subscription
 	Subscribe(eventType string, handler EventHandler) Subscription
 	SubscribeAsync(eventType string, handler EventHandler) Subscription
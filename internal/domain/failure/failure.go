@@ -0,0 +1,131 @@
+// Package failure provides the structured error type of the framework.
+//
+// Every domain package declares its error codes as Code constants, such as
+// storage.ErrKeyNotFound. A Code is itself an error, so codes are the
+// sentinels callers match against:
+//
+//	if errors.Is(err, storage.ErrKeyNotFound) {
+//	    // ...
+//	}
+//
+// Infrastructure packages return *Error values, which carry the code along
+// with a message, the ID of the component that failed, the underlying cause
+// and structured details. errors.Is matches the code of every *Error in a
+// chain, and errors.As extracts them.
+package failure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code identifies a kind of error, in the form "<package>.<snake_case>".
+type Code string
+
+// Error returns the code, so that a bare Code can be returned and matched as
+// an error.
+func (c Code) Error() string {
+	return string(c)
+}
+
+// Error is the error type returned by the framework.
+type Error struct {
+	// Code identifies the kind of error.
+	Code Code
+
+	// Message describes this occurrence of the error.
+	Message string
+
+	// Component is the ID of the component the error relates to, if any.
+	Component string
+
+	// Cause is the error that led to this one, if any.
+	Cause error
+
+	// Details holds structured information about the error.
+	Details map[string]interface{}
+}
+
+// New returns an error with a code and a formatted message.
+func New(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: message(format, args)}
+}
+
+// Wrap returns an error with a code and a formatted message caused by
+// another error.
+func Wrap(cause error, code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: message(format, args), Cause: cause}
+}
+
+func message(format string, args []interface{}) string {
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// WithComponent records the ID of the component the error relates to and
+// returns the error.
+func (e *Error) WithComponent(id string) *Error {
+	e.Component = id
+	return e
+}
+
+// WithDetail adds a detail to the error and returns the error.
+func (e *Error) WithDetail(key string, value interface{}) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]interface{})
+	}
+	e.Details[key] = value
+	return e
+}
+
+// Error returns "code: message: cause", omitting the parts that are empty.
+func (e *Error) Error() string {
+	text := string(e.Code)
+	if e.Message != "" {
+		text += ": " + e.Message
+	}
+	if e.Cause != nil {
+		text += ": " + e.Cause.Error()
+	}
+	return text
+}
+
+// Unwrap returns the cause.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether the error has the code of target, which is a Code or
+// another *Error.
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case Code:
+		return e.Code == t
+	case *Error:
+		return e.Code == t.Code
+	}
+	return false
+}
+
+// CodeOf returns the code of the first *Error or Code in the tree of err,
+// or "" if there is none.
+func CodeOf(err error) Code {
+	switch e := err.(type) {
+	case nil:
+		return ""
+	case *Error:
+		return e.Code
+	case Code:
+		return e
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if code := CodeOf(inner); code != "" {
+				return code
+			}
+		}
+		return ""
+	}
+	return CodeOf(errors.Unwrap(err))
+}
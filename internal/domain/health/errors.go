@@ -0,0 +1,18 @@
+// Package health provides interfaces and types for liveness and readiness checks.
+package health
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard health error codes
+const (
+	// ErrCheckTimeout is reported when a check does not finish within its timeout
+	ErrCheckTimeout failure.Code = "health.check_timeout"
+
+	// ErrCheckPanicked is reported when a check panics
+	ErrCheckPanicked failure.Code = "health.check_panicked"
+
+	// ErrServiceUnhealthy is reported for a service whose status is StatusError
+	ErrServiceUnhealthy failure.Code = "health.service_unhealthy"
+)
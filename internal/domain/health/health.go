@@ -0,0 +1,84 @@
+// Package health provides interfaces and types for liveness and readiness checks.
+package health
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Kind selects liveness or readiness.
+type Kind string
+
+const (
+	// KindLiveness reports whether a component works at all. A component that
+	// is not live should be restarted.
+	KindLiveness Kind = "liveness"
+
+	// KindReadiness reports whether a component can serve requests. A live
+	// component may be temporarily not ready, for example while warming up.
+	KindReadiness Kind = "readiness"
+)
+
+// HealthChecker is implemented by services with a liveness check. A service
+// without it is live unless its status is StatusError.
+type HealthChecker interface {
+	// HealthCheck returns an error if the service is not live.
+	HealthCheck(ctx context.Context) error
+}
+
+// ReadinessChecker is implemented by services with a readiness check. A service
+// without it is ready while it is running.
+type ReadinessChecker interface {
+	// ReadinessCheck returns an error if the service cannot serve requests.
+	ReadinessCheck(ctx context.Context) error
+}
+
+// CheckFunc is a standalone check registered with a Checker.
+type CheckFunc func(ctx context.Context) error
+
+// ComponentReport is the result of checking one component.
+type ComponentReport struct {
+	ID        component.ComponentID `json:"id"`
+	Status    Status                `json:"status"`
+	Error     string                `json:"error,omitempty"`
+	Duration  time.Duration         `json:"duration"`
+	CheckedAt time.Time             `json:"checkedAt"`
+	Cached    bool                  `json:"cached,omitempty"`
+}
+
+// Report aggregates component results. It is up only if every component is up.
+type Report struct {
+	Kind       Kind              `json:"kind"`
+	Status     Status            `json:"status"`
+	Components []ComponentReport `json:"components"`
+	CheckedAt  time.Time         `json:"checkedAt"`
+}
+
+// Checker aggregates the checks of the runtime's services.
+type Checker interface {
+	// Liveness checks whether every component is live.
+	Liveness(ctx context.Context) Report
+
+	// Readiness checks whether every component is ready.
+	Readiness(ctx context.Context) Report
+
+	// AddCheck registers a standalone check of the given kind.
+	AddCheck(id component.ComponentID, kind Kind, check CheckFunc)
+}
+
+// Provider is implemented by systems that check their health, such as the runtime.
+type Provider interface {
+	// Health returns the checker that aggregates service liveness and
+	// readiness. Plugins may add their own checks to it.
+	Health() Checker
+}
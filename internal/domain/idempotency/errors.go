@@ -0,0 +1,24 @@
+// Package idempotency provides interfaces and types for idempotent operation execution.
+package idempotency
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard idempotency error codes
+const (
+	// ErrKeyRequired is returned when an operation requiring an idempotency key is called without one
+	ErrKeyRequired failure.Code = "idempotency.key_required"
+
+	// ErrKeyConflict is returned when an idempotency key is reused with different input
+	ErrKeyConflict failure.Code = "idempotency.key_conflict"
+
+	// ErrRecordNotFound is returned when no result is stored for an idempotency key
+	ErrRecordNotFound failure.Code = "idempotency.record_not_found"
+
+	// ErrInvalidPolicy is returned for a policy with invalid values
+	ErrInvalidPolicy failure.Code = "idempotency.invalid_policy"
+
+	// ErrStoreUnavailable is returned when the result store cannot be read or written
+	ErrStoreUnavailable failure.Code = "idempotency.store_unavailable"
+)
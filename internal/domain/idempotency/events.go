@@ -0,0 +1,7 @@
+package idempotency
+
+// Idempotency event topics
+const (
+	// TopicResultReplayed is triggered when a call is answered with the stored result of an earlier call.
+	TopicResultReplayed = "idempotency.result.replayed"
+)
@@ -0,0 +1,82 @@
+// Package idempotency provides interfaces and types for idempotent operation execution.
+package idempotency
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+)
+
+// MetadataKey is the Input.Metadata entry that carries the idempotency key.
+const MetadataKey = "idempotency-key"
+
+// Policy configures the idempotency of an operation. The zero value runs
+// every call.
+type Policy struct {
+	// TTL is how long the first result for a key is kept. Zero disables
+	// idempotency.
+	TTL time.Duration
+
+	// Required rejects calls without an idempotency key.
+	Required bool
+}
+
+// Record is the stored result of the first call with an idempotency key.
+// Output data is stored as JSON, so replays carry its decoded form, for
+// example map[string]interface{} instead of a struct.
+type Record struct {
+	OperationID component.ComponentID `json:"operationId"`
+	Key         string                `json:"key"`
+
+	// Fingerprint identifies the input of the first call; later calls with
+	// the same key and different input are rejected.
+	Fingerprint string `json:"fingerprint"`
+
+	Output any    `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	// ErrorCode is the code of Error, so that replayed errors match the same
+	// sentinels as the original one.
+	ErrorCode failure.Code `json:"errorCode,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Executor runs an operation; the deduplicator calls it for first calls.
+type Executor func(ctx context.Context, operation component.Operation, input component.Input) (component.Output, error)
+
+// Deduplicator ensures an operation runs at most once per idempotency key.
+type Deduplicator interface {
+	// Execute runs an operation through execute, unless a result is stored for
+	// the idempotency key of the input or a call with the key is in flight,
+	// in which case it returns that result.
+	Execute(ctx context.Context, operation component.Operation, input component.Input, execute Executor) (component.Output, error)
+
+	// SetPolicy sets the policy of an operation, replacing the policy declared
+	// in its properties or in configuration.
+	SetPolicy(operationID component.ComponentID, policy Policy) error
+
+	// SetStore sets the store results are kept in.
+	SetStore(store storage.Store)
+
+	// Lookup returns the stored result for an idempotency key.
+	Lookup(operationID component.ComponentID, key string) (Record, error)
+
+	// Forget deletes the stored result for an idempotency key, so that the
+	// next call with the key runs the operation.
+	Forget(operationID component.ComponentID, key string) error
+
+	// Purge deletes expired results and returns how many were deleted.
+	Purge() (int, error)
+}
+
+// Provider is implemented by systems that deduplicate operations, such as the runtime.
+type Provider interface {
+	// Idempotency returns the deduplicator that runs operations at most once
+	// per idempotency key and stores their first result.
+	Idempotency() Deduplicator
+}
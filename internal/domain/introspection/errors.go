@@ -0,0 +1,13 @@
+// Package introspection provides interfaces and types for inspecting a running
+// system: its components, services, plugins, event subscriptions and stores.
+package introspection
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard introspection error codes
+const (
+	// ErrUnknownFormat is raised when a topology is requested in a format that is not supported
+	ErrUnknownFormat failure.Code = "introspection.unknown_format"
+)
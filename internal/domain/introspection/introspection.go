@@ -0,0 +1,171 @@
+// Package introspection provides interfaces and types for inspecting a running
+// system: its components, services, plugins, event subscriptions, stores and
+// circuit breakers.
+package introspection
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+)
+
+// ComponentInfo describes a component of the system.
+type ComponentInfo struct {
+	ID          component.ComponentID   `json:"id"`
+	Name        string                  `json:"name"`
+	Type        component.ComponentType `json:"type"`
+	Version     string                  `json:"version"`
+	Description string                  `json:"description,omitempty"`
+	Metadata    component.Metadata      `json:"metadata,omitempty"`
+
+	// Plugin is the plugin that registered the component, if any.
+	Plugin component.ComponentID `json:"plugin,omitempty"`
+}
+
+// ServiceInfo describes the state of a service.
+type ServiceInfo struct {
+	ID     component.ComponentID   `json:"id"`
+	Status component.ServiceStatus `json:"status"`
+
+	// StartedAt is when the service last started. It is zero while the
+	// service is not running or when the service does not record it.
+	StartedAt time.Time `json:"startedAt,omitempty"`
+
+	// Uptime is how long the service has been running, or zero.
+	Uptime time.Duration `json:"uptime"`
+
+	Plugin component.ComponentID `json:"plugin,omitempty"`
+}
+
+// PluginInfo describes a plugin and what it brought into the system.
+type PluginInfo struct {
+	ID           component.ComponentID   `json:"id"`
+	Name         string                  `json:"name"`
+	Version      string                  `json:"version"`
+	Author       string                  `json:"author,omitempty"`
+	Type         plugin.PluginType       `json:"type"`
+	Status       component.ServiceStatus `json:"status"`
+	Dependencies []plugin.Dependency     `json:"dependencies,omitempty"`
+
+	// Components are the components the plugin registered.
+	Components []component.ComponentID `json:"components,omitempty"`
+
+	// Topics are the event topics the plugin subscribed to.
+	Topics []string `json:"topics,omitempty"`
+}
+
+// EdgeKind is the relation an edge of the dependency graph stands for.
+type EdgeKind string
+
+const (
+	// EdgeDependsOn links a plugin to a plugin it depends on.
+	EdgeDependsOn EdgeKind = "depends_on"
+
+	// EdgeOwns links a plugin to a component it registered.
+	EdgeOwns EdgeKind = "owns"
+)
+
+// Edge is an edge of the dependency graph.
+type Edge struct {
+	From component.ComponentID `json:"from"`
+	To   component.ComponentID `json:"to"`
+	Kind EdgeKind              `json:"kind"`
+
+	// VersionRange and Optional qualify EdgeDependsOn edges.
+	VersionRange string `json:"versionRange,omitempty"`
+	Optional     bool   `json:"optional,omitempty"`
+}
+
+// TopicInfo describes the subscriptions of an event topic.
+type TopicInfo struct {
+	Topic string `json:"topic"`
+
+	// Subscribers is the number of active subscriptions.
+	Subscribers int `json:"subscribers"`
+
+	// Plugins are the plugins among the subscribers.
+	Plugins []component.ComponentID `json:"plugins,omitempty"`
+}
+
+// StoreInfo describes a store and the engine behind it.
+type StoreInfo struct {
+	Name         string               `json:"name"`
+	Engine       string               `json:"engine"`
+	Capabilities storage.Capabilities `json:"capabilities"`
+
+	// MultiStore is the multi-store service holding the store.
+	MultiStore component.ComponentID `json:"multiStore"`
+}
+
+// BreakerInfo describes the circuit breaker of an operation with a
+// resilience policy.
+type BreakerInfo struct {
+	OperationID component.ComponentID   `json:"operationId"`
+	State       resilience.BreakerState `json:"state"`
+
+	// ConsecutiveFailures counts failed calls since the last success.
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+
+	// OpenedAt is when the breaker last opened, or zero.
+	OpenedAt time.Time `json:"openedAt,omitempty"`
+
+	// Counters since the policy was resolved, as in resilience.State.
+	Calls    int `json:"calls"`
+	Failures int `json:"failures"`
+	Retries  int `json:"retries"`
+	Timeouts int `json:"timeouts"`
+	Rejected int `json:"rejected"`
+
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Topology is a snapshot of the whole system.
+type Topology struct {
+	Components   []ComponentInfo `json:"components"`
+	Services     []ServiceInfo   `json:"services"`
+	Plugins      []PluginInfo    `json:"plugins"`
+	Dependencies []Edge          `json:"dependencies"`
+	Topics       []TopicInfo     `json:"topics"`
+	Stores       []StoreInfo     `json:"stores"`
+	Breakers     []BreakerInfo   `json:"breakers"`
+	TakenAt      time.Time       `json:"takenAt"`
+}
+
+// Inspector reports what a running system is made of. Results are sorted by
+// ID, name or topic so that consecutive snapshots compare cleanly.
+type Inspector interface {
+	// Components describes every component, including the core services.
+	Components() []ComponentInfo
+
+	// Services describes the status and uptime of every service.
+	Services() []ServiceInfo
+
+	// Plugins describes every plugin known to the plugin manager.
+	Plugins() []PluginInfo
+
+	// Dependencies returns the dependency graph between plugins and the
+	// components they own.
+	Dependencies() []Edge
+
+	// Subscriptions describes the event subscriptions per topic.
+	Subscriptions() []TopicInfo
+
+	// Stores describes the stores of every multi-store service.
+	Stores() []StoreInfo
+
+	// Breakers describes the circuit breakers of the operations with a
+	// resilience policy.
+	Breakers() []BreakerInfo
+
+	// Topology returns all of the above at once.
+	Topology() Topology
+}
+
+// Provider is implemented by systems that can be inspected.
+type Provider interface {
+	// Introspection returns the inspector of the system.
+	Introspection() Inspector
+}
@@ -0,0 +1,30 @@
+// Package jobs provides interfaces and types for asynchronous operation jobs.
+package jobs
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard job error codes
+const (
+	// ErrJobNotFound is returned for an unknown job ID
+	ErrJobNotFound failure.Code = "jobs.job_not_found"
+
+	// ErrJobNotFinished is returned when fetching the result of a job that is still pending or running
+	ErrJobNotFinished failure.Code = "jobs.job_not_finished"
+
+	// ErrJobFinished is returned when cancelling a job that already finished
+	ErrJobFinished failure.Code = "jobs.job_finished"
+
+	// ErrJobFailed is returned when fetching the result of a failed job
+	ErrJobFailed failure.Code = "jobs.job_failed"
+
+	// ErrJobCancelled is returned when fetching the result of a cancelled job
+	ErrJobCancelled failure.Code = "jobs.job_cancelled"
+
+	// ErrJobInterrupted is recorded for a job that was running when the manager stopped and was not resumed
+	ErrJobInterrupted failure.Code = "jobs.job_interrupted"
+
+	// ErrStoreUnavailable is returned when the job store cannot be opened or written
+	ErrStoreUnavailable failure.Code = "jobs.store_unavailable"
+)
@@ -0,0 +1,22 @@
+package jobs
+
+// Job event topics
+const (
+	// TopicJobSubmitted is triggered when a job is accepted.
+	TopicJobSubmitted = "job.submitted"
+
+	// TopicJobStarted is triggered when a job starts running, including when it is resumed.
+	TopicJobStarted = "job.started"
+
+	// TopicJobProgress is triggered when a running job reports progress.
+	TopicJobProgress = "job.progress"
+
+	// TopicJobSucceeded is triggered when a job completes successfully.
+	TopicJobSucceeded = "job.succeeded"
+
+	// TopicJobFailed is triggered when a job fails or is interrupted.
+	TopicJobFailed = "job.failed"
+
+	// TopicJobCancelled is triggered when a job is cancelled.
+	TopicJobCancelled = "job.cancelled"
+)
@@ -0,0 +1,89 @@
+// Package jobs provides interfaces and types for asynchronous operation jobs.
+package jobs
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+)
+
+// Status is the state of a job.
+type Status string
+
+const (
+	// StatusPending jobs wait for a free worker.
+	StatusPending Status = "pending"
+
+	// StatusRunning jobs are executing their operation.
+	StatusRunning Status = "running"
+
+	// StatusSucceeded jobs completed; their result is available.
+	StatusSucceeded Status = "succeeded"
+
+	// StatusFailed jobs returned an error or were interrupted.
+	StatusFailed Status = "failed"
+
+	// StatusCancelled jobs were cancelled before they finished.
+	StatusCancelled Status = "cancelled"
+)
+
+// Finished reports whether the status is final.
+func (s Status) Finished() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCancelled
+}
+
+// Progress is the progress a running job reports.
+type Progress struct {
+	// Percent is the completed share of the work, between 0 and 100.
+	Percent float64 `json:"percent"`
+
+	// Message describes the current step.
+	Message string `json:"message,omitempty"`
+}
+
+// Job describes an asynchronous execution of an operation. Input and result
+// data are persisted as JSON, so jobs loaded after a restart carry the
+// decoded form, for example map[string]interface{} instead of a struct.
+type Job struct {
+	ID          string                `json:"id"`
+	OperationID component.ComponentID `json:"operationId"`
+	Input       component.Input       `json:"input"`
+	Status      Status                `json:"status"`
+	Progress    Progress              `json:"progress"`
+	Result      any                   `json:"result,omitempty"`
+	Error       string                `json:"error,omitempty"`
+
+	// Attempts counts the times the job started, including resumptions.
+	Attempts int `json:"attempts"`
+
+	CreatedAt  time.Time `json:"createdAt"`
+	StartedAt  time.Time `json:"startedAt,omitempty"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+}
+
+// Manager runs operations asynchronously as jobs.
+type Manager interface {
+	component.Service
+
+	// Submit queues an operation and returns the ID of its job.
+	Submit(ctx context.Context, operationID component.ComponentID, input component.Input) (string, error)
+
+	// Get returns the current state of a job.
+	Get(jobID string) (Job, error)
+
+	// List returns all known jobs, oldest first.
+	List() []Job
+
+	// Watch streams the state of a job on every change, starting with its
+	// current state. The channel is closed once the job finishes or stop is
+	// called. Updates may be skipped for slow readers, but never the last one.
+	Watch(jobID string) (updates <-chan Job, stop func(), err error)
+
+	// Cancel cancels a pending or running job.
+	Cancel(jobID string) error
+
+	// Result returns the output of a succeeded job, or the reason it did not
+	// succeed.
+	Result(jobID string) (component.Output, error)
+}
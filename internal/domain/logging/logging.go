@@ -3,6 +3,7 @@ package logging
 
 import (
 	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 )
 
 // Logger represents the logging facility for structured application logging.
@@ -30,8 +31,8 @@ type LoggerService interface {
 // Common logging error codes.
 const (
 	// ErrLoggerNotAvailable indicates that the logging system is not available.
-	ErrLoggerNotAvailable = "logging.logger_not_available"
+	ErrLoggerNotAvailable failure.Code = "logging.logger_not_available"
 
 	// ErrInvalidLogLevel indicates that an invalid log level was specified.
-	ErrInvalidLogLevel = "logging.invalid_log_level"
+	ErrInvalidLogLevel failure.Code = "logging.invalid_log_level"
 )
@@ -0,0 +1,15 @@
+// Package metrics provides interfaces and types for recording runtime metrics.
+package metrics
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard metrics error codes
+const (
+	// ErrKindConflict is raised when a metric is created with the name of a metric of another kind
+	ErrKindConflict failure.Code = "metrics.kind_conflict"
+
+	// ErrInvalidName is raised when a metric or label name is not valid
+	ErrInvalidName failure.Code = "metrics.invalid_name"
+)
@@ -0,0 +1,105 @@
+// Package metrics provides interfaces and types for recording runtime metrics.
+package metrics
+
+// Kind is the type of a metric.
+type Kind string
+
+const (
+	// KindCounter is a value that only increases, such as a number of calls.
+	KindCounter Kind = "counter"
+
+	// KindGauge is a value that goes up and down, such as a queue depth.
+	KindGauge Kind = "gauge"
+
+	// KindHistogram counts observations, such as latencies, in buckets.
+	KindHistogram Kind = "histogram"
+)
+
+// Labels identify a series of a metric by label name and value, such as
+// {"operation": "transfer"}. A nil Labels is the series without labels.
+type Labels map[string]string
+
+// Counter is a metric whose series only increase.
+type Counter interface {
+	// Inc adds one to the series with the given labels.
+	Inc(labels Labels)
+
+	// Add adds a non-negative delta to the series with the given labels.
+	Add(labels Labels, delta float64)
+}
+
+// Gauge is a metric whose series can be set to any value.
+type Gauge interface {
+	// Set sets the series with the given labels.
+	Set(labels Labels, value float64)
+
+	// Add adds a delta, which may be negative, to the series with the given labels.
+	Add(labels Labels, delta float64)
+}
+
+// Histogram is a metric that counts observations in cumulative buckets.
+type Histogram interface {
+	// Observe records a value in the series with the given labels.
+	Observe(labels Labels, value float64)
+}
+
+// Registry creates metrics and exposes their current values.
+//
+// Creating a metric with the name of an existing one returns the existing
+// metric; names are in snake_case, such as "skeleton_store_gets_total".
+type Registry interface {
+	// Counter returns the counter with the given name, creating it if needed.
+	Counter(name, help string) Counter
+
+	// Gauge returns the gauge with the given name, creating it if needed.
+	Gauge(name, help string) Gauge
+
+	// Histogram returns the histogram with the given name, creating it with
+	// the bucket upper bounds if needed. Nil buckets select DefaultBuckets.
+	Histogram(name, help string, buckets []float64) Histogram
+
+	// AddCollector registers a function that updates metrics before every
+	// snapshot, for values that are read on demand rather than recorded.
+	AddCollector(collect func())
+
+	// Snapshot returns the current values of every metric, ordered by name.
+	Snapshot() []Family
+}
+
+// DefaultBuckets are the histogram bucket upper bounds used when none are
+// given, suited to latencies in seconds.
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Family is the snapshot of a metric and its series.
+type Family struct {
+	Name   string
+	Help   string
+	Kind   Kind
+	Series []Series
+}
+
+// Series is the snapshot of one series of a metric.
+type Series struct {
+	Labels Labels
+
+	// Value is the value of a counter or gauge series.
+	Value float64
+
+	// Count, Sum and Buckets describe the observations of a histogram series.
+	Count   uint64
+	Sum     float64
+	Buckets []Bucket
+}
+
+// Bucket counts the observations less than or equal to its upper bound.
+type Bucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// Provider is implemented by systems that record metrics, such as the runtime.
+// Components look for it when they are initialized.
+type Provider interface {
+	// Metrics returns the registry of the system.
+	Metrics() Registry
+}
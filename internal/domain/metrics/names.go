@@ -0,0 +1,26 @@
+package metrics
+
+// BuiltinPrefix starts the names of the built-in metrics. Plugins restricted by
+// capabilities may not create metrics under it.
+const BuiltinPrefix = "skeleton_"
+
+// Built-in metric names
+const (
+	// Operations, labelled by "operation"; errors also by "code"
+	OperationExecutions = "skeleton_operation_executions_total"
+	OperationErrors     = "skeleton_operation_errors_total"
+	OperationDuration   = "skeleton_operation_duration_seconds"
+
+	// Events, labelled by "topic"
+	EventPublishes       = "skeleton_event_publishes_total"
+	EventHandlerDuration = "skeleton_event_handler_duration_seconds"
+	EventQueueDepth      = "skeleton_event_queue_depth"
+
+	// Stores, labelled by "store"
+	StoreGets = "skeleton_store_gets_total"
+	StoreSets = "skeleton_store_sets_total"
+
+	// Services, labelled by "service" and "status"; the series of the current
+	// status is 1 and the others are 0
+	ServiceStatus = "skeleton_service_status"
+)
@@ -0,0 +1,30 @@
+package plugin
+
+import "github.com/fintechain/skeleton/internal/domain/component"
+
+// TypePlugin is the component type reported in plugin lifecycle events.
+const TypePlugin component.ComponentType = "plugin"
+
+// Plugin event topics
+const (
+	// TopicPluginDiscovered is triggered when a plugin manifest is found, validated and loaded.
+	TopicPluginDiscovered = "plugin.discovered"
+
+	// TopicPluginDiscoveryFailed is triggered when a plugin directory or manifest cannot be loaded.
+	TopicPluginDiscoveryFailed = "plugin.discovery_failed"
+
+	// TopicPluginLoaded is triggered when a plugin is initialized and its components are registered.
+	TopicPluginLoaded = "plugin.loaded"
+
+	// TopicPluginStarted is triggered when a plugin starts.
+	TopicPluginStarted = "plugin.started"
+
+	// TopicPluginStopped is triggered when a plugin stops.
+	TopicPluginStopped = "plugin.stopped"
+
+	// TopicPluginUnloaded is triggered when a plugin and its components are removed.
+	TopicPluginUnloaded = "plugin.unloaded"
+
+	// TopicPluginFailed is triggered when a plugin fails to initialize, start, stop or unload.
+	TopicPluginFailed = "plugin.failed"
+)
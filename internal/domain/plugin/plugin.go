@@ -31,6 +31,70 @@ type Plugin interface {
 	PluginType() PluginType
 }
 
+// Dependency declares another plugin that a plugin relies on.
+type Dependency struct {
+	// ID is the identifier of the plugin being depended upon.
+	ID component.ComponentID
+
+	// VersionRange is a semantic version constraint the dependency must satisfy,
+	// for example ">=1.2.0 <2.0.0", "^1.4" or "~2.1.0". Empty matches any version.
+	VersionRange string
+
+	// Optional marks a dependency that is only ordered against when present.
+	// A missing optional dependency is ignored; a present but incompatible one is not.
+	Optional bool
+}
+
+// DependentPlugin is implemented by plugins that declare dependencies on other plugins.
+// The plugin manager initializes and starts dependencies before their dependents
+// and stops them in the reverse order.
+type DependentPlugin interface {
+	Plugin
+
+	// Dependencies returns the plugins this plugin requires or optionally uses.
+	Dependencies() []Dependency
+}
+
+// Capabilities declares what a plugin may access through the system it is given.
+//
+// Topic patterns match exactly, or by prefix when they end in ".*" ("orders.*"
+// matches "orders.created"); "*" matches everything. Config prefixes match a key
+// and every key below it ("pricing" matches "pricing" and "pricing.api_url").
+type Capabilities struct {
+	// PublishTopics are the event topics the plugin may publish to.
+	PublishTopics []string
+
+	// SubscribeTopics are the event topics the plugin may subscribe to.
+	SubscribeTopics []string
+
+	// ConfigPrefixes are the configuration keys the plugin may read.
+	ConfigPrefixes []string
+
+	// Stores are the names of the stores the plugin may open, create or delete.
+	Stores []string
+
+	// ManagePlugins allows the plugin to load, start, stop and unload plugins.
+	ManagePlugins bool
+
+	// ManageRuntime allows the plugin to start and stop the runtime, to replace
+	// or purge the stored idempotency results, and to control services and
+	// operations it did not register: start, stop and supervise them, report
+	// their failures, and change their resilience and idempotency policies.
+	// Without it a plugin controls only its own components.
+	ManageRuntime bool
+}
+
+// CapablePlugin is implemented by plugins that declare the capabilities they need.
+// Such a plugin receives a system facade that denies everything it did not
+// declare with component.ErrCapabilityDenied. Plugins that do not implement
+// CapablePlugin receive the unrestricted system.
+type CapablePlugin interface {
+	Plugin
+
+	// Capabilities returns the access the plugin requires.
+	Capabilities() Capabilities
+}
+
 // PluginManager manages plugin lifecycle and discovery.
 // This is the core plugin manager interface without service lifecycle.
 type PluginManager interface {
@@ -40,6 +104,10 @@ type PluginManager interface {
 	Add(pluginID component.ComponentID, plugin Plugin) error
 	Remove(pluginID component.ComponentID) error
 
+	// Unload stops a plugin, removes the components and event subscriptions
+	// it registered, disposes it and removes it from the manager.
+	Unload(ctx context.Context, pluginID component.ComponentID) error
+
 	// Plugin execution
 	StartPlugin(ctx context.Context, pluginID component.ComponentID) error
 	StopPlugin(ctx context.Context, pluginID component.ComponentID) error
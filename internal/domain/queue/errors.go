@@ -0,0 +1,21 @@
+// Package queue provides interfaces and types for the persistent work queue.
+package queue
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard queue error codes
+const (
+	// ErrInvalidJob is returned when enqueueing a job without an operation or with invalid options
+	ErrInvalidJob failure.Code = "queue.invalid_job"
+
+	// ErrJobNotFound is returned for an unknown job ID
+	ErrJobNotFound failure.Code = "queue.job_not_found"
+
+	// ErrLeaseLost is recorded when a delivery finishes after its visibility timeout and the job was delivered again
+	ErrLeaseLost failure.Code = "queue.lease_lost"
+
+	// ErrStoreUnavailable is returned when the queue or dead-letter store cannot be opened, read or written
+	ErrStoreUnavailable failure.Code = "queue.store_unavailable"
+)
@@ -0,0 +1,16 @@
+package queue
+
+// Queue event topics
+const (
+	// TopicJobEnqueued is triggered when a job is added to the queue.
+	TopicJobEnqueued = "queue.job.enqueued"
+
+	// TopicJobCompleted is triggered when a delivery succeeds and the job is removed.
+	TopicJobCompleted = "queue.job.completed"
+
+	// TopicJobRetrying is triggered when a delivery fails and the job is scheduled again.
+	TopicJobRetrying = "queue.job.retrying"
+
+	// TopicJobDeadLettered is triggered when a job runs out of attempts and moves to the dead-letter store.
+	TopicJobDeadLettered = "queue.job.dead_lettered"
+)
@@ -0,0 +1,116 @@
+// Package queue provides interfaces and types for the persistent work queue.
+package queue
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+)
+
+// Metadata keys added to the input of every delivery, so that operations
+// can recognise redeliveries of the same job.
+const (
+	// MetadataJobID carries the ID of the job being delivered.
+	MetadataJobID = "queue-job-id"
+
+	// MetadataAttempt carries the number of the delivery, starting at 1.
+	MetadataAttempt = "queue-attempt"
+)
+
+// Job is an operation execution waiting in the queue, being delivered, or
+// dead-lettered. Input data is persisted as JSON, so jobs read back from the
+// store carry the decoded form, for example map[string]interface{} instead
+// of a struct.
+type Job struct {
+	ID          string                `json:"id"`
+	OperationID component.ComponentID `json:"operationId"`
+	Input       component.Input       `json:"input"`
+
+	// Priority orders ready jobs; higher values are delivered first.
+	Priority int `json:"priority"`
+
+	// Attempts counts the deliveries so far. MaxAttempts bounds them before
+	// the job is dead-lettered.
+	Attempts    int `json:"attempts"`
+	MaxAttempts int `json:"maxAttempts"`
+
+	// VisibilityTimeout is how long a delivery hides the job from other
+	// workers. A job whose delivery has not finished by then is delivered
+	// again, or dead-lettered if that delivery was its last attempt.
+	VisibilityTimeout time.Duration `json:"visibilityTimeout"`
+
+	// LastError is the error of the last failed delivery.
+	LastError string `json:"lastError,omitempty"`
+
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+
+	// AvailableAt is when the job may next be delivered: its enqueue time
+	// plus delay, or the end of its retry backoff.
+	AvailableAt time.Time `json:"availableAt"`
+
+	// LeasedUntil is when the visibility timeout of the current delivery
+	// ends; zero when the job is not being delivered.
+	LeasedUntil time.Time `json:"leasedUntil,omitempty"`
+
+	// DeadAt is when the job was moved to the dead-letter store; zero for
+	// live jobs.
+	DeadAt time.Time `json:"deadAt,omitempty"`
+}
+
+// EnqueueOptions tunes a single job. Zero values select the queue defaults.
+type EnqueueOptions struct {
+	// Priority orders ready jobs; higher values are delivered first.
+	Priority int
+
+	// Delay postpones the first delivery.
+	Delay time.Duration
+
+	// MaxAttempts bounds the deliveries before the job is dead-lettered.
+	MaxAttempts int
+
+	// VisibilityTimeout is how long a delivery hides the job from other
+	// workers. It should exceed the longest expected run of the operation.
+	VisibilityTimeout time.Duration
+}
+
+// Stats counts the jobs of a queue.
+type Stats struct {
+	// Ready jobs wait for a free worker.
+	Ready int
+
+	// Delayed jobs wait for their delay or retry backoff to end.
+	Delayed int
+
+	// InFlight jobs are being delivered.
+	InFlight int
+
+	// Dead jobs are in the dead-letter store.
+	Dead int
+}
+
+// Queue is a durable work queue that delivers jobs to a pool of workers at
+// least once. A job is removed when its operation succeeds; failed
+// deliveries are retried with backoff and, once out of attempts, moved to a
+// dead-letter store.
+type Queue interface {
+	component.Service
+
+	// Enqueue adds a job and returns its ID.
+	Enqueue(operationID component.ComponentID, input component.Input, options EnqueueOptions) (string, error)
+
+	// Get returns a live or dead-lettered job.
+	Get(jobID string) (Job, error)
+
+	// Stats counts the live and dead-lettered jobs.
+	Stats() (Stats, error)
+
+	// DeadLetters returns the dead-lettered jobs, oldest first.
+	DeadLetters() ([]Job, error)
+
+	// Redrive moves a dead-lettered job back to the queue with its attempts
+	// reset.
+	Redrive(jobID string) error
+
+	// Discard deletes a dead-lettered job.
+	Discard(jobID string) error
+}
@@ -0,0 +1,24 @@
+// Package resilience provides interfaces and types for operation resilience policies.
+package resilience
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard resilience error codes
+const (
+	// ErrOperationTimeout is returned when an operation attempt exceeds its timeout
+	ErrOperationTimeout failure.Code = "resilience.operation_timeout"
+
+	// ErrCircuitOpen is returned when a call is rejected by an open circuit breaker
+	ErrCircuitOpen failure.Code = "resilience.circuit_open"
+
+	// ErrRetriesExhausted is returned when every attempt of an operation failed
+	ErrRetriesExhausted failure.Code = "resilience.retries_exhausted"
+
+	// ErrInvalidPolicy is returned for a policy with invalid values
+	ErrInvalidPolicy failure.Code = "resilience.invalid_policy"
+
+	// ErrPolicyNotFound is returned for an operation the guard has no state for
+	ErrPolicyNotFound failure.Code = "resilience.policy_not_found"
+)
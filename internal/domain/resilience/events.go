@@ -0,0 +1,16 @@
+package resilience
+
+// Resilience event topics
+const (
+	// TopicBreakerOpened is triggered when a circuit breaker opens and starts rejecting calls.
+	TopicBreakerOpened = "resilience.breaker.opened"
+
+	// TopicBreakerHalfOpen is triggered when an open breaker lets probe calls through.
+	TopicBreakerHalfOpen = "resilience.breaker.half_open"
+
+	// TopicBreakerClosed is triggered when probe calls succeeded and the breaker closes.
+	TopicBreakerClosed = "resilience.breaker.closed"
+
+	// TopicOperationRetrying is triggered when a failed attempt is scheduled for a retry.
+	TopicOperationRetrying = "resilience.operation.retrying"
+)
@@ -0,0 +1,134 @@
+// Package resilience provides interfaces and types for operation resilience policies.
+package resilience
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+)
+
+// RetryPolicy decides whether and when a failed attempt is retried. Only
+// classified errors are retried: attempt timeouts, errors reporting
+// themselves as transient (see TransientError) and errors carrying one of
+// the RetryOn codes.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one.
+	// Zero or one disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. It doubles with
+	// every retry, up to MaxBackoff. Defaults to 100ms and 10s.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// Jitter shortens each delay by a random fraction of up to Jitter, between
+	// 0 and 1, so that callers retrying together spread out.
+	Jitter float64
+
+	// RetryOn lists error codes, such as "storage.connection_failed", whose
+	// errors are retried in addition to transient errors.
+	RetryOn []string
+
+	// RetryOnTimeout retries attempts that timed out. The timed-out attempt
+	// keeps running until the operation returns, so it may still run while
+	// the retry does; enable it only for operations that tolerate that.
+	RetryOnTimeout bool
+}
+
+// BreakerPolicy configures the circuit breaker of an operation.
+type BreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failed calls that opens
+	// the breaker. Zero disables the breaker.
+	FailureThreshold int
+
+	// OpenTimeout is how long the breaker rejects calls before it lets probe
+	// calls through. Defaults to 30s.
+	OpenTimeout time.Duration
+
+	// HalfOpenProbes is the number of probe calls let through while half-open.
+	// The breaker closes once they all succeed and opens again on the first
+	// failure. Defaults to 1.
+	HalfOpenProbes int
+}
+
+// Policy configures the resilience of an operation. The zero value runs the
+// operation unchanged.
+type Policy struct {
+	// Timeout bounds each attempt. Zero means no timeout.
+	Timeout time.Duration
+
+	Retry   RetryPolicy
+	Breaker BreakerPolicy
+}
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState string
+
+const (
+	// BreakerClosed lets every call through.
+	BreakerClosed BreakerState = "closed"
+
+	// BreakerOpen rejects every call.
+	BreakerOpen BreakerState = "open"
+
+	// BreakerHalfOpen lets a limited number of probe calls through.
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// State describes the resilience of an operation.
+type State struct {
+	OperationID component.ComponentID
+	Policy      Policy
+	Breaker     BreakerState
+
+	// ConsecutiveFailures counts failed calls since the last success.
+	ConsecutiveFailures int
+
+	// OpenedAt is when the breaker last opened.
+	OpenedAt time.Time
+
+	// Counters since the policy was resolved. Calls counts the attempts let
+	// through, including retries; Rejected counts attempts the breaker refused.
+	Calls    int
+	Failures int
+	Retries  int
+	Timeouts int
+	Rejected int
+
+	LastError error
+}
+
+// TransientError is implemented by errors that may succeed when retried.
+type TransientError interface {
+	error
+
+	// Transient reports whether the failure is temporary.
+	Transient() bool
+}
+
+// Guard runs operations under their resilience policies.
+type Guard interface {
+	// Execute runs an operation under its policy.
+	Execute(ctx context.Context, operation component.Operation, input component.Input) (component.Output, error)
+
+	// SetPolicy sets the policy of an operation, replacing the policy declared
+	// in its properties or in configuration, and resets its breaker.
+	SetPolicy(operationID component.ComponentID, policy Policy) error
+
+	// Reset closes the breaker of an operation and clears its failures.
+	Reset(operationID component.ComponentID) error
+
+	// State returns the resilience state of an operation.
+	State(operationID component.ComponentID) (State, error)
+
+	// States returns the resilience state of every operation with a policy.
+	States() []State
+}
+
+// Provider is implemented by systems that guard operations, such as the runtime.
+type Provider interface {
+	// Resilience returns the guard that applies the timeout, retry and circuit
+	// breaker policies of operations, and reports their breaker states.
+	Resilience() Guard
+}
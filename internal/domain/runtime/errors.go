@@ -0,0 +1,14 @@
+package runtime
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Error codes for the runtime lifecycle
+const (
+	ErrHookFailed  failure.Code = "runtime.hook_failed"
+	ErrHookTimeout failure.Code = "runtime.hook_timeout"
+
+	// ErrShuttingDown rejects operations started while the runtime drains.
+	ErrShuttingDown failure.Code = "runtime.shutting_down"
+)
@@ -0,0 +1,23 @@
+package runtime
+
+// Runtime event topics
+const (
+	// TopicRuntimeInit is triggered when the runtime is created, before plugins load.
+	TopicRuntimeInit = "runtime.init"
+
+	// TopicRuntimeStart is triggered when services have started, before the runtime is ready.
+	TopicRuntimeStart = "runtime.start"
+
+	// TopicRuntimeReady is triggered when startup is complete.
+	TopicRuntimeReady = "runtime.ready"
+
+	// TopicRuntimeStop is triggered when shutdown is requested, before services stop.
+	TopicRuntimeStop = "runtime.stop"
+
+	// TopicRuntimeStopped is triggered when shutdown is complete, just before
+	// the event bus stops.
+	TopicRuntimeStopped = "runtime.stopped"
+
+	// TopicHookFailed is triggered when a lifecycle hook fails or times out.
+	TopicHookFailed = "runtime.hook_failed"
+)
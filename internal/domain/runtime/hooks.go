@@ -0,0 +1,19 @@
+package runtime
+
+import "github.com/fintechain/skeleton/internal/domain/context"
+
+// Phase identifies a stage of the runtime lifecycle.
+type Phase string
+
+// Lifecycle phases, in the order they occur
+const (
+	PhaseInit    Phase = "init"    // runtime created, before plugins load
+	PhaseStart   Phase = "start"   // services started, before the runtime is ready
+	PhaseReady   Phase = "ready"   // startup complete
+	PhaseStop    Phase = "stop"    // shutdown requested, before services stop
+	PhaseStopped Phase = "stopped" // shutdown complete
+)
+
+// Hook runs application code at a lifecycle phase. The context carries the
+// hook timeout.
+type Hook func(ctx context.Context, runtime RuntimeEnvironment) error
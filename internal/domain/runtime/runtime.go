@@ -44,3 +44,20 @@ type RuntimeEnvironment interface {
 	// This provides batch plugin loading for efficient startup.
 	LoadPlugins(ctx context.Context, plugins []plugin.Plugin) error
 }
+
+// PluginConfigurationProvider is implemented by runtimes that keep a
+// configuration section per plugin, such as the default runtime. Plugins look
+// for it when they are initialized.
+type PluginConfigurationProvider interface {
+	// PluginConfiguration returns the configuration section of a plugin, rooted
+	// at "plugins.<pluginID>". Plugins register their defaults and validation
+	// rules on it; the rules are checked once the plugins are initialized.
+	PluginConfiguration(pluginID component.ComponentID) config.Section
+}
+
+// StatusProvider is implemented by runtimes that report their lifecycle state.
+type StatusProvider interface {
+	// Status returns the lifecycle state of the runtime.
+	// A runtime whose startup failed and was rolled back reports StatusError.
+	Status() component.ServiceStatus
+}
@@ -0,0 +1,27 @@
+// Package scheduler provides interfaces and types for running operations on a schedule.
+package scheduler
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard scheduler error codes
+const (
+	// ErrInvalidSchedule is returned when a schedule definition is malformed
+	ErrInvalidSchedule failure.Code = "scheduler.invalid_schedule"
+
+	// ErrInvalidCron is returned when a cron expression cannot be parsed
+	ErrInvalidCron failure.Code = "scheduler.invalid_cron"
+
+	// ErrScheduleNotFound is returned for an unknown schedule ID
+	ErrScheduleNotFound failure.Code = "scheduler.schedule_not_found"
+
+	// ErrScheduleExists is returned when adding a schedule whose ID is taken
+	ErrScheduleExists failure.Code = "scheduler.schedule_exists"
+
+	// ErrRunSkipped is returned when a triggered run is skipped because the previous run is still going
+	ErrRunSkipped failure.Code = "scheduler.run_skipped"
+
+	// ErrStoreUnavailable is returned when the schedule store cannot be opened or written
+	ErrStoreUnavailable failure.Code = "scheduler.store_unavailable"
+)
@@ -0,0 +1,16 @@
+package scheduler
+
+// Scheduler event topics
+const (
+	// TopicRunStarted is triggered when a scheduled run starts its operation.
+	TopicRunStarted = "scheduler.run.started"
+
+	// TopicRunSucceeded is triggered when the operation of a scheduled run succeeds.
+	TopicRunSucceeded = "scheduler.run.succeeded"
+
+	// TopicRunFailed is triggered when the operation of a scheduled run fails.
+	TopicRunFailed = "scheduler.run.failed"
+
+	// TopicRunSkipped is triggered when a run is skipped because the previous run is still going.
+	TopicRunSkipped = "scheduler.run.skipped"
+)
@@ -0,0 +1,103 @@
+// Package scheduler provides interfaces and types for running operations on a schedule.
+package scheduler
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+)
+
+// OverlapPolicy decides what happens when a run is due while the previous
+// run of the schedule is still going.
+type OverlapPolicy string
+
+const (
+	// OverlapSkip skips the run. This is the default.
+	OverlapSkip OverlapPolicy = "skip"
+
+	// OverlapAllow starts the run alongside the previous one.
+	OverlapAllow OverlapPolicy = "allow"
+)
+
+// CatchUpPolicy decides what happens to runs that were missed while the
+// scheduler was stopped, based on the persisted last run time.
+type CatchUpPolicy string
+
+const (
+	// CatchUpNone drops missed runs. This is the default.
+	CatchUpNone CatchUpPolicy = "none"
+
+	// CatchUpOnce runs once at start if any run was missed.
+	CatchUpOnce CatchUpPolicy = "once"
+
+	// CatchUpAll runs every missed run at start, oldest first, up to a limit.
+	CatchUpAll CatchUpPolicy = "all"
+)
+
+// Schedule triggers an operation on a cron expression or a fixed interval.
+type Schedule struct {
+	ID        string
+	Operation component.ComponentID
+
+	// Cron is a five-field cron expression ("minute hour day month weekday")
+	// or a descriptor such as "@daily". Interval is used if Cron is empty.
+	Cron     string
+	Interval time.Duration
+
+	// Input and Metadata are passed to the operation on every run.
+	Input    any
+	Metadata map[string]string
+
+	// Jitter delays each run by a random duration below it, so that
+	// instances sharing a schedule spread out.
+	Jitter time.Duration
+
+	Overlap OverlapPolicy
+	CatchUp CatchUpPolicy
+
+	// Disabled schedules are kept but never run.
+	Disabled bool
+}
+
+// State describes a schedule and its runs.
+type State struct {
+	Schedule Schedule
+
+	// LastRun is the time the last run was due; it is persisted.
+	LastRun time.Time
+
+	// NextRun is the time the next run is due, without jitter.
+	NextRun time.Time
+
+	// Running counts the runs in progress.
+	Running int
+
+	// Counters since the scheduler started.
+	Runs     int
+	Failures int
+	Skipped  int
+
+	LastError string
+}
+
+// Scheduler is a service that runs operations on their schedules.
+type Scheduler interface {
+	component.Service
+
+	// Add adds a schedule. It starts running if the scheduler is running.
+	Add(schedule Schedule) error
+
+	// Remove removes a schedule. Runs in progress are not cancelled.
+	Remove(scheduleID string) error
+
+	// Trigger runs a schedule now, following its overlap policy, and returns
+	// the outcome of the operation.
+	Trigger(ctx context.Context, scheduleID string) (component.Output, error)
+
+	// State returns the state of a schedule.
+	State(scheduleID string) (State, error)
+
+	// States returns the state of every schedule, ordered by ID.
+	States() []State
+}
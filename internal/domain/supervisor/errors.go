@@ -0,0 +1,21 @@
+// Package supervisor provides interfaces and types for supervising services.
+package supervisor
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard supervisor error codes
+const (
+	// ErrServiceNotSupervised is returned for a service the supervisor does not watch
+	ErrServiceNotSupervised failure.Code = "supervisor.service_not_supervised"
+
+	// ErrServiceAlreadySupervised is returned when supervising a service twice
+	ErrServiceAlreadySupervised failure.Code = "supervisor.service_already_supervised"
+
+	// ErrInvalidPolicy is returned for an unknown restart policy or escalation
+	ErrInvalidPolicy failure.Code = "supervisor.invalid_policy"
+
+	// ErrRestartBudgetExhausted is reported when a service failed more often than its budget allows
+	ErrRestartBudgetExhausted failure.Code = "supervisor.restart_budget_exhausted"
+)
@@ -0,0 +1,22 @@
+package supervisor
+
+// Supervisor event topics
+const (
+	// TopicServiceFailed is triggered when a supervised service reports a failure.
+	TopicServiceFailed = "supervisor.service.failed"
+
+	// TopicServiceExited is triggered when a supervised service stops without failure.
+	TopicServiceExited = "supervisor.service.exited"
+
+	// TopicServiceRestarting is triggered when a restart is scheduled.
+	TopicServiceRestarting = "supervisor.service.restarting"
+
+	// TopicServiceRestarted is triggered when a service was restarted.
+	TopicServiceRestarted = "supervisor.service.restarted"
+
+	// TopicServiceGaveUp is triggered when a failed service will not be restarted.
+	TopicServiceGaveUp = "supervisor.service.gave_up"
+
+	// TopicEscalated is triggered when a failure is escalated to a plugin or the runtime.
+	TopicEscalated = "supervisor.escalated"
+)
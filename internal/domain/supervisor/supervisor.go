@@ -0,0 +1,131 @@
+// Package supervisor provides interfaces and types for supervising services.
+package supervisor
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+)
+
+// RestartPolicy decides whether a service is restarted when it stops on its own.
+type RestartPolicy string
+
+const (
+	// RestartNever leaves the service stopped.
+	RestartNever RestartPolicy = "never"
+
+	// RestartAlways restarts the service whenever it stops, with or without failure.
+	RestartAlways RestartPolicy = "always"
+
+	// RestartOnFailure restarts the service only when it stops with an error.
+	RestartOnFailure RestartPolicy = "on-failure"
+)
+
+// Escalation decides what happens when a failed service is not restarted,
+// either because its policy forbids it or because its restart budget is spent.
+type Escalation string
+
+const (
+	// EscalateNone leaves the service failed.
+	EscalateNone Escalation = "none"
+
+	// EscalatePlugin stops the plugin that owns the service.
+	EscalatePlugin Escalation = "stop-plugin"
+
+	// EscalateRuntime stops the whole runtime.
+	EscalateRuntime Escalation = "stop-runtime"
+)
+
+// Policy configures how a service is supervised. Zero values select defaults.
+type Policy struct {
+	// Restart defaults to RestartOnFailure.
+	Restart RestartPolicy
+
+	// InitialBackoff is the delay before the first restart. It doubles with
+	// every restart counted in Window, up to MaxBackoff. Defaults to 100ms and 30s.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// MaxRestarts is the restart budget within Window. Defaults to 5;
+	// a negative value means unlimited.
+	MaxRestarts int
+
+	// Window is the period over which restarts are counted. Zero counts every
+	// restart since the service was supervised.
+	Window time.Duration
+
+	// Escalation defaults to EscalateNone.
+	Escalation Escalation
+
+	// Plugin is the plugin stopped by EscalatePlugin. Defaults to the plugin
+	// that registered the service.
+	Plugin component.ComponentID
+}
+
+// ServiceState is the supervision state of a service.
+type ServiceState string
+
+const (
+	StateRunning    ServiceState = "running"
+	StateRestarting ServiceState = "restarting"
+	StateStopped    ServiceState = "stopped"
+	StateFailed     ServiceState = "failed"
+)
+
+// State describes a supervised service.
+type State struct {
+	ServiceID   component.ComponentID
+	Policy      Policy
+	State       ServiceState
+	Restarts    int
+	LastError   error
+	LastFailure time.Time
+}
+
+// FailureReporter is called by a service that stops on its own. A nil error
+// reports that the service exited without failure.
+type FailureReporter = func(err error)
+
+// ReportingService is a service that reports its failures through a callback.
+type ReportingService interface {
+	component.Service
+
+	// SetFailureReporter installs the callback, or removes it when nil.
+	SetFailureReporter(report FailureReporter)
+}
+
+// FailingService is a service that reports its failures on a channel.
+// A nil error reports that the service exited without failure.
+type FailingService interface {
+	component.Service
+
+	// Failures returns the channel failures are reported on.
+	Failures() <-chan error
+}
+
+// Supervisor watches services and restarts them according to their policy.
+type Supervisor interface {
+	component.Service
+
+	// Supervise starts watching a registered service.
+	Supervise(serviceID component.ComponentID, policy Policy) error
+
+	// Unsupervise stops watching a service and cancels a pending restart.
+	Unsupervise(serviceID component.ComponentID) error
+
+	// ReportFailure reports that a supervised service stopped on its own.
+	ReportFailure(serviceID component.ComponentID, err error)
+
+	// State returns the supervision state of a service.
+	State(serviceID component.ComponentID) (State, error)
+
+	// States returns the supervision state of every supervised service.
+	States() []State
+}
+
+// Provider is implemented by systems that supervise services, such as the runtime.
+type Provider interface {
+	// Supervisor returns the service supervisor, which restarts failed
+	// services according to their restart policy.
+	Supervisor() Supervisor
+}
@@ -0,0 +1,11 @@
+package tracing
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard tracing error codes
+const (
+	// ErrExportFailed is returned when spans cannot be shipped to a backend
+	ErrExportFailed failure.Code = "tracing.export_failed"
+)
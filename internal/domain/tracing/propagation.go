@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"strings"
+
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+)
+
+// TraceParentKey is the event metadata key carrying the span context, in the
+// W3C traceparent format.
+const TraceParentKey = "traceparent"
+
+// Context keys of the current span and of a span context received from
+// elsewhere, such as an event.
+type (
+	spanKey        struct{}
+	spanContextKey struct{}
+)
+
+// ContextWithSpan returns a copy of ctx carrying span. The copy is cancelled
+// with ctx.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return &valueContext{Context: ctx, key: spanKey{}, value: span}
+}
+
+// ContextWithSpanContext returns a copy of ctx carrying a span context
+// received from elsewhere, so that spans started from it join its trace. The
+// copy is cancelled with ctx.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return &valueContext{Context: ctx, key: spanContextKey{}, value: sc}
+}
+
+// valueContext adds a value to a context and leaves its deadline and
+// cancellation to the context, unlike a context's own WithValue, which only
+// copies the cancellation state at the time of the call.
+type valueContext struct {
+	context.Context
+	key, value interface{}
+}
+
+func (c *valueContext) Value(key interface{}) interface{} {
+	if key == c.key {
+		return c.value
+	}
+	return c.Context.Value(key)
+}
+
+func (c *valueContext) WithValue(key, value interface{}) context.Context {
+	return &valueContext{Context: c, key: key, value: value}
+}
+
+// SpanFromContext returns the span carried by ctx, or nil.
+func SpanFromContext(ctx context.Context) Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}
+
+// SpanContextFromContext returns the span context of the span carried by ctx,
+// falling back to a received span context. It is invalid when ctx carries
+// neither.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.Context()
+	}
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc
+}
+
+// Inject records the span context carried by ctx in the metadata of evt, so
+// that the handlers of the event join the trace. The metadata is copied
+// rather than modified in place. It does nothing when ctx carries no span.
+func Inject(ctx context.Context, evt *event.Event) {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+
+	metadata := make(map[string]string, len(evt.Metadata)+1)
+	for key, value := range evt.Metadata {
+		metadata[key] = value
+	}
+	metadata[TraceParentKey] = FormatTraceParent(sc)
+	evt.Metadata = metadata
+}
+
+// Extract returns a copy of ctx carrying the span context recorded in the
+// metadata of evt, or ctx itself when there is none.
+func Extract(ctx context.Context, evt *event.Event) context.Context {
+	sc, ok := ParseTraceParent(evt.Metadata[TraceParentKey])
+	if !ok {
+		return ctx
+	}
+	return ContextWithSpanContext(ctx, sc)
+}
+
+// FormatTraceParent formats a span context as a W3C traceparent value.
+func FormatTraceParent(sc SpanContext) string {
+	return "00-" + string(sc.TraceID) + "-" + string(sc.SpanID) + "-01"
+}
+
+// ParseTraceParent parses a W3C traceparent value.
+func ParseTraceParent(value string) (SpanContext, bool) {
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}, false
+	}
+	for _, part := range parts {
+		if !isHex(part) {
+			return SpanContext{}, false
+		}
+	}
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return SpanContext{}, false
+	}
+	return SpanContext{TraceID: TraceID(parts[1]), SpanID: SpanID(parts[2])}, true
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
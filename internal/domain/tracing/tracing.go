@@ -0,0 +1,108 @@
+// Package tracing provides interfaces and types for tracing calls across
+// operations, events and stores.
+package tracing
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/context"
+)
+
+// TraceID identifies a trace as 32 lowercase hex digits.
+type TraceID string
+
+// SpanID identifies a span within a trace as 16 lowercase hex digits.
+type SpanID string
+
+// SpanContext identifies a span and the trace it belongs to. It is what is
+// carried in contexts and event metadata to tie spans together.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid returns whether both identifiers are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != "" && sc.SpanID != ""
+}
+
+// Kind describes the role of a span in a trace.
+type Kind string
+
+const (
+	// KindInternal is work within the process, such as an operation.
+	KindInternal Kind = "internal"
+
+	// KindProducer is the sending of a message, such as an event.
+	KindProducer Kind = "producer"
+
+	// KindConsumer is the handling of a message, such as an event handler.
+	KindConsumer Kind = "consumer"
+
+	// KindClient is a call to a backend, such as a store.
+	KindClient Kind = "client"
+)
+
+// Status is the outcome of a span.
+type Status string
+
+const (
+	// StatusUnset is the status of a span that did not record an error.
+	StatusUnset Status = "unset"
+
+	// StatusError is the status of a span that recorded an error.
+	StatusError Status = "error"
+)
+
+// Span is a timed unit of work in a trace.
+type Span interface {
+	// Context returns the span context identifying the span.
+	Context() SpanContext
+
+	// SetAttribute records a key-value pair describing the work.
+	SetAttribute(key string, value interface{})
+
+	// RecordError marks the span as failed with err. A nil err is ignored.
+	RecordError(err error)
+
+	// End completes the span and hands it to the exporters. Calls after the
+	// first are ignored.
+	End()
+}
+
+// Tracer starts spans.
+type Tracer interface {
+	// Start starts a span as a child of the span carried by ctx, or as the
+	// root of a new trace when ctx carries none. The returned context carries
+	// the new span.
+	Start(ctx context.Context, name string, kind Kind) (context.Context, Span)
+}
+
+// SpanData is a completed span, as handed to exporters.
+type SpanData struct {
+	Name          string
+	Kind          Kind
+	Context       SpanContext
+	ParentSpanID  SpanID
+	Start         time.Time
+	End           time.Time
+	Attributes    map[string]interface{}
+	Status        Status
+	StatusMessage string
+}
+
+// Exporter ships completed spans to a backend.
+type Exporter interface {
+	// Export ships a batch of completed spans.
+	Export(spans []SpanData) error
+
+	// Shutdown flushes and releases the exporter.
+	Shutdown() error
+}
+
+// Provider is implemented by systems that trace calls, such as the runtime.
+// Components look for it when they are initialized.
+type Provider interface {
+	// Tracer returns the tracer of the system.
+	Tracer() Tracer
+}
@@ -0,0 +1,21 @@
+// Package workflow provides interfaces and types for composing operations into flows.
+package workflow
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Standard workflow error codes
+const (
+	// ErrInvalidFlow is returned when a flow definition is malformed
+	ErrInvalidFlow failure.Code = "workflow.invalid_flow"
+
+	// ErrStepFailed is returned when a step of a flow fails
+	ErrStepFailed failure.Code = "workflow.step_failed"
+
+	// ErrMappingFailed is returned when the input of a step cannot be built from the flow state
+	ErrMappingFailed failure.Code = "workflow.mapping_failed"
+
+	// ErrCompensationFailed is returned when a compensating operation fails
+	ErrCompensationFailed failure.Code = "workflow.compensation_failed"
+)
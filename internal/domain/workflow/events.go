@@ -0,0 +1,13 @@
+package workflow
+
+// Workflow event topics
+const (
+	// TopicFlowStarted is triggered when a flow starts executing.
+	TopicFlowStarted = "workflow.started"
+
+	// TopicFlowCompleted is triggered when every step of a flow succeeded.
+	TopicFlowCompleted = "workflow.completed"
+
+	// TopicFlowFailed is triggered when a flow failed, after its completed steps were compensated.
+	TopicFlowFailed = "workflow.failed"
+)
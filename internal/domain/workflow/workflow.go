@@ -0,0 +1,88 @@
+// Package workflow provides interfaces and types for composing operations into flows.
+package workflow
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/component"
+)
+
+// Flow is a declarative composition of operations. It is executed as an
+// operation with the flow ID.
+//
+// Steps read and write the flow state, an object with the flow input under
+// "input" and the output of each named step under "steps.<name>". Paths into
+// the state are dotted, for example "input.account" or "steps.reserve.id".
+type Flow struct {
+	ID          component.ComponentID `json:"id"`
+	Name        string                `json:"name,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Steps       []Step                `json:"steps"`
+
+	// Output is the path of the flow output in the state. Defaults to the
+	// output of the last step.
+	Output string `json:"output,omitempty"`
+}
+
+// Step is one step of a flow. Exactly one of Operation, Steps, Parallel and
+// Branches is set.
+type Step struct {
+	// Name stores the step output under "steps.<name>". Defaults to the
+	// operation ID for operation steps.
+	Name string `json:"name,omitempty"`
+
+	// Operation is the operation the step executes.
+	Operation component.ComponentID `json:"operation,omitempty"`
+
+	// Steps run in sequence.
+	Steps []Step `json:"steps,omitempty"`
+
+	// Parallel steps run concurrently and all receive the step input. The
+	// step output is an object with the output of each child by name.
+	Parallel []Step `json:"parallel,omitempty"`
+
+	// Branches are evaluated in order and the first matching one runs. The
+	// step is skipped if none matches.
+	Branches []Branch `json:"branches,omitempty"`
+
+	// When skips the step unless the condition holds.
+	When *Condition `json:"when,omitempty"`
+
+	// Input builds the step input. Without it, a step receives the output of
+	// the previous step in its sequence, or the input of its group for the
+	// first step.
+	Input *Mapping `json:"input,omitempty"`
+
+	// Compensate is the operation that undoes the step. It runs when a later
+	// step fails, in reverse order of completion, with the step output as
+	// input unless CompensateInput is set. The state then also holds the
+	// failure message under "error".
+	Compensate      component.ComponentID `json:"compensate,omitempty"`
+	CompensateInput *Mapping              `json:"compensateInput,omitempty"`
+}
+
+// Branch is a conditional sequence of steps.
+type Branch struct {
+	// When selects the branch. A branch without a condition always matches.
+	When  *Condition `json:"when,omitempty"`
+	Steps []Step     `json:"steps"`
+}
+
+// Condition tests a value in the flow state.
+type Condition struct {
+	Path string `json:"path"`
+
+	// Equals is compared with the value after both are converted to JSON
+	// form. Without it, the condition holds for values other than nil,
+	// false, 0 and "".
+	Equals any `json:"equals,omitempty"`
+
+	// Not negates the condition.
+	Not bool `json:"not,omitempty"`
+}
+
+// Mapping builds a value from the flow state: the value at From, or an
+// object whose fields are the values at the paths in Fields. Fields are set
+// on top of the object at From if both are given.
+type Mapping struct {
+	From   string            `json:"from,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
@@ -0,0 +1,31 @@
+package component
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+)
+
+// Backoff returns the delay before a retry when n retries were already made:
+// initial, doubled with every retry up to max.
+func Backoff(initial, max time.Duration, n int) time.Duration {
+	backoff := initial
+	for i := 0; i < n && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return backoff
+}
+
+// SameComponent reports whether two values are the same component, such as
+// the component a policy was resolved for and the one now registered under
+// its ID. Values of types that cannot be compared are assumed to be the same.
+func SameComponent(a, b component.Component) bool {
+	if a == nil || b == nil || reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	return !reflect.TypeOf(a).Comparable() || a == b
+}
@@ -17,6 +17,7 @@ type BaseComponent struct {
 	metadata    component.Metadata
 	systemRef   component.System
 	initialized bool
+	listener    component.LifecycleListener
 	mu          sync.RWMutex
 }
 
@@ -79,27 +80,49 @@ func (c *BaseComponent) Metadata() component.Metadata {
 // Initialize prepares the component for use within the system.
 func (c *BaseComponent) Initialize(ctx context.Context, system component.System) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	if c.initialized {
+		c.mu.Unlock()
 		return nil
 	}
 
 	c.systemRef = system
 	c.initialized = true
+	c.mu.Unlock()
+
+	c.notify(component.TopicComponentInitialized, nil)
 	return nil
 }
 
 // Dispose cleans up component resources and prepares for shutdown.
 func (c *BaseComponent) Dispose() error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	c.systemRef = nil
 	c.initialized = false
+	c.mu.Unlock()
+
+	c.notify(component.TopicComponentDisposed, nil)
 	return nil
 }
 
+// SetLifecycleListener installs the listener notified of lifecycle
+// transitions, such as the registry's. A nil listener removes it.
+func (c *BaseComponent) SetLifecycleListener(listener component.LifecycleListener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listener = listener
+}
+
+// notify reports a lifecycle transition to the listener, if any.
+func (c *BaseComponent) notify(topic string, err error) {
+	c.mu.RLock()
+	listener := c.listener
+	c.mu.RUnlock()
+
+	if listener != nil {
+		listener(topic, err)
+	}
+}
+
 // system returns the system reference (protected access).
 func (c *BaseComponent) system() component.System {
 	c.mu.RLock()
@@ -9,6 +9,8 @@ import (
 // in concrete operation implementations.
 type BaseOperation struct {
 	*BaseComponent
+	inputSchema  *component.Schema
+	outputSchema *component.Schema
 }
 
 // NewBaseOperation creates a new base operation with the provided configuration.
@@ -34,3 +36,35 @@ func (o *BaseOperation) Initialize(ctx context.Context, system component.System)
 func (o *BaseOperation) Execute(ctx context.Context, input component.Input) (component.Output, error) {
 	return component.Output{Data: input.Data}, nil
 }
+
+// SetInputSchema declares the schema of the operation input. The runtime
+// rejects input that does not match it before Execute runs.
+func (o *BaseOperation) SetInputSchema(schema *component.Schema) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.inputSchema = schema
+}
+
+// SetOutputSchema declares the schema of the operation output. The runtime
+// rejects output that does not match it after Execute returns.
+func (o *BaseOperation) SetOutputSchema(schema *component.Schema) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.outputSchema = schema
+}
+
+// InputSchema returns the schema of the operation input, or nil if none is
+// declared.
+func (o *BaseOperation) InputSchema() *component.Schema {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inputSchema
+}
+
+// OutputSchema returns the schema of the operation output, or nil if none is
+// declared.
+func (o *BaseOperation) OutputSchema() *component.Schema {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.outputSchema
+}
@@ -3,6 +3,7 @@ package component
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
@@ -12,9 +13,11 @@ import (
 // in concrete service implementations.
 type BaseService struct {
 	*BaseComponent
-	status  component.ServiceStatus
-	running atomic.Bool
-	mu      sync.RWMutex
+	status   component.ServiceStatus
+	running  atomic.Bool
+	started  time.Time
+	reporter func(err error)
+	mu       sync.RWMutex
 }
 
 // NewBaseService creates a new base service with the provided configuration.
@@ -40,15 +43,18 @@ func (s *BaseService) Initialize(ctx context.Context, system component.System) e
 // Base implementation just sets status - override in concrete implementations.
 func (s *BaseService) Start(ctx context.Context) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.running.Load() {
+		s.mu.Unlock()
 		return nil // Already running
 	}
 
 	s.status = component.StatusStarting
 	s.running.Store(true)
+	s.started = time.Now()
 	s.status = component.StatusRunning
+	s.mu.Unlock()
+
+	s.notify(component.TopicServiceStarted, nil)
 	return nil
 }
 
@@ -56,15 +62,18 @@ func (s *BaseService) Start(ctx context.Context) error {
 // Base implementation just sets status - override in concrete implementations.
 func (s *BaseService) Stop(ctx context.Context) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if !s.running.Load() {
+		s.mu.Unlock()
 		return nil // Already stopped
 	}
 
 	s.status = component.StatusStopping
 	s.running.Store(false)
+	s.started = time.Time{}
 	s.status = component.StatusStopped
+	s.mu.Unlock()
+
+	s.notify(component.TopicServiceStopped, nil)
 	return nil
 }
 
@@ -80,6 +89,14 @@ func (s *BaseService) Status() component.ServiceStatus {
 	return s.status
 }
 
+// StartedAt returns when the service last started, or the zero time while it
+// is not running.
+func (s *BaseService) StartedAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.started
+}
+
 // SetStatus updates the service status (protected method for subclasses).
 func (s *BaseService) SetStatus(status component.ServiceStatus) {
 	s.mu.Lock()
@@ -89,5 +106,44 @@ func (s *BaseService) SetStatus(status component.ServiceStatus) {
 
 // SetRunning updates the running state (protected method for subclasses).
 func (s *BaseService) SetRunning(running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if running && !s.running.Load() {
+		s.started = time.Now()
+	} else if !running {
+		s.started = time.Time{}
+	}
 	s.running.Store(running)
 }
+
+// SetFailureReporter installs the callback ReportFailure calls, such as a
+// supervisor's. A nil reporter removes it.
+func (s *BaseService) SetFailureReporter(report func(err error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reporter = report
+}
+
+// ReportFailure marks a service that stopped on its own and notifies the
+// failure reporter. A nil error reports an exit without failure; the service
+// ends in StatusStopped instead of StatusError.
+func (s *BaseService) ReportFailure(err error) {
+	s.mu.Lock()
+	s.running.Store(false)
+	s.started = time.Time{}
+	s.status = component.StatusStopped
+	if err != nil {
+		s.status = component.StatusError
+	}
+	report := s.reporter
+	s.mu.Unlock()
+
+	if err != nil {
+		s.notify(component.TopicServiceFailed, err)
+	} else {
+		s.notify(component.TopicServiceStopped, nil)
+	}
+	if report != nil {
+		report(err)
+	}
+}
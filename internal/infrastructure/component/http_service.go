@@ -0,0 +1,109 @@
+package component
+
+import (
+	stdcontext "context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Timeouts of an HTTPService
+const (
+	httpReadHeaderTimeout = 5 * time.Second
+	httpShutdownTimeout   = 5 * time.Second // bounds the graceful shutdown
+)
+
+// HTTPService is a service that serves a handler over HTTP on a local address,
+// such as the health, metrics and admin endpoints. Errors name the endpoint
+// after the name it was created with.
+type HTTPService struct {
+	*BaseService
+	name     string
+	addr     string
+	handler  http.Handler
+	server   *http.Server
+	listener net.Listener
+	mu       sync.Mutex
+}
+
+// NewHTTPService creates a service serving handler on addr, such as
+// "127.0.0.1:8081". The name, such as "health endpoint", appears in errors.
+func NewHTTPService(config component.ComponentConfig, name, addr string, handler http.Handler) *HTTPService {
+	return &HTTPService{
+		BaseService: NewBaseService(config),
+		name:        name,
+		addr:        addr,
+		handler:     handler,
+	}
+}
+
+// Start listens on the address and serves requests in the background.
+func (s *HTTPService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.IsRunning() {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return failure.Wrap(err, component.ErrServiceStartFailed, "%s on '%s'", s.name, s.addr)
+	}
+	s.listener = listener
+	s.server = &http.Server{Handler: s.handler, ReadHeaderTimeout: httpReadHeaderTimeout}
+
+	server := s.server
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.ReportFailure(err)
+		}
+	}()
+	if err := s.BaseService.Start(ctx); err != nil {
+		// Closing the server also closes the listener
+		server.Close()
+		s.server, s.listener = nil, nil
+		return err
+	}
+	return nil
+}
+
+// Stop shuts the server down, waiting briefly for requests in progress.
+func (s *HTTPService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.IsRunning() {
+		return nil
+	}
+
+	shutdownCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), httpShutdownTimeout)
+	defer cancel()
+	err := s.server.Shutdown(shutdownCtx)
+
+	if stopErr := s.BaseService.Stop(ctx); stopErr != nil {
+		return stopErr
+	}
+	if err != nil {
+		return failure.Wrap(err, component.ErrServiceStopFailed, "%s", s.name)
+	}
+	return nil
+}
+
+// Addr returns the address the server listens on, which differs from the
+// configured address when that used port 0.
+func (s *HTTPService) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return s.addr
+	}
+	return s.listener.Addr().String()
+}
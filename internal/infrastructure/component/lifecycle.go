@@ -0,0 +1,45 @@
+package component
+
+import (
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/event"
+)
+
+// LifecycleEvents publishes component lifecycle transitions on an event bus.
+type LifecycleEvents struct {
+	bus event.EventBus
+}
+
+// NewLifecycleEvents creates a publisher for the given event bus.
+func NewLifecycleEvents(bus event.EventBus) *LifecycleEvents {
+	return &LifecycleEvents{bus: bus}
+}
+
+// Observe publishes the transitions a component reports, if it implements
+// component.ObservableComponent.
+func (l *LifecycleEvents) Observe(comp component.Component) {
+	if observable, ok := comp.(component.ObservableComponent); ok {
+		observable.SetLifecycleListener(func(topic string, err error) {
+			l.Publish(topic, comp, err, nil)
+		})
+	}
+}
+
+// Forget stops publishing the transitions of a component.
+func (l *LifecycleEvents) Forget(comp component.Component) {
+	if observable, ok := comp.(component.ObservableComponent); ok {
+		observable.SetLifecycleListener(nil)
+	}
+}
+
+// Publish publishes a lifecycle event for a component.
+func (l *LifecycleEvents) Publish(topic string, comp component.Component, err error, additionalData map[string]interface{}) {
+	l.bus.Publish(&event.Event{
+		Topic:   topic,
+		Source:  string(comp.ID()),
+		Time:    time.Now(),
+		Payload: component.CreateComponentEventPayload(comp.ID(), comp.Type(), err, additionalData),
+	})
+}
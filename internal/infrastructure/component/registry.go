@@ -1,15 +1,18 @@
 package component
 
 import (
-	"errors"
+	"sort"
 	"sync"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 )
 
 // Registry implements the component.Registry interface.
 type Registry struct {
 	components map[component.ComponentID]component.Component
+	events     *LifecycleEvents
 	mu         sync.RWMutex
 }
 
@@ -20,32 +23,64 @@ func NewRegistry() *Registry {
 	}
 }
 
+// SetEventBus publishes lifecycle events on the bus: registrations and
+// unregistrations, and the transitions of registered components that
+// implement component.ObservableComponent. A nil bus stops publishing.
+func (r *Registry) SetEventBus(bus event.EventBus) {
+	r.mu.Lock()
+	previous := r.events
+	r.events = nil
+	if bus != nil {
+		r.events = NewLifecycleEvents(bus)
+	}
+	events := r.events
+	components := make([]component.Component, 0, len(r.components))
+	for _, comp := range r.components {
+		components = append(components, comp)
+	}
+	r.mu.Unlock()
+
+	for _, comp := range components {
+		if events != nil {
+			events.Observe(comp)
+		} else if previous != nil {
+			previous.Forget(comp)
+		}
+	}
+}
+
 // Register adds a component to the registry.
 func (r *Registry) Register(comp component.Component) error {
 	if comp == nil {
-		return errors.New(component.ErrInvalidItem)
+		return failure.New(component.ErrInvalidItem, "nil component")
 	}
 
 	id := comp.ID()
 	if id == "" {
-		return errors.New(component.ErrInvalidItem)
+		return failure.New(component.ErrInvalidItem, "component without ID")
 	}
 
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
 	if _, exists := r.components[id]; exists {
-		return errors.New(component.ErrItemAlreadyExists)
+		r.mu.Unlock()
+		return failure.New(component.ErrItemAlreadyExists, "'%s'", id).WithComponent(string(id))
 	}
 
 	r.components[id] = comp
+	events := r.events
+	r.mu.Unlock()
+
+	if events != nil {
+		events.Observe(comp)
+		events.Publish(component.TopicComponentRegistered, comp, nil, nil)
+	}
 	return nil
 }
 
 // Get retrieves a component by ID.
 func (r *Registry) Get(id component.ComponentID) (component.Component, error) {
 	if id == "" {
-		return nil, errors.New(component.ErrInvalidItem)
+		return nil, failure.New(component.ErrInvalidItem, "empty ID")
 	}
 
 	r.mu.RLock()
@@ -53,7 +88,7 @@ func (r *Registry) Get(id component.ComponentID) (component.Component, error) {
 
 	comp, exists := r.components[id]
 	if !exists {
-		return nil, errors.New(component.ErrItemNotFound)
+		return nil, failure.New(component.ErrItemNotFound, "'%s'", id).WithComponent(string(id))
 	}
 
 	return comp, nil
@@ -77,7 +112,7 @@ func (r *Registry) GetByType(typ component.ComponentType) ([]component.Component
 // Find returns all components that match the given predicate function.
 func (r *Registry) Find(predicate func(component.Component) bool) ([]component.Component, error) {
 	if predicate == nil {
-		return nil, errors.New(component.ErrInvalidItem)
+		return nil, failure.New(component.ErrInvalidItem, "nil predicate")
 	}
 
 	r.mu.RLock()
@@ -122,17 +157,24 @@ func (r *Registry) List() []component.ComponentID {
 // Unregister removes a component from the registry.
 func (r *Registry) Unregister(id component.ComponentID) error {
 	if id == "" {
-		return errors.New(component.ErrInvalidItem)
+		return failure.New(component.ErrInvalidItem, "empty ID")
 	}
 
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if _, exists := r.components[id]; !exists {
-		return errors.New(component.ErrItemNotFound)
+	comp, exists := r.components[id]
+	if !exists {
+		r.mu.Unlock()
+		return failure.New(component.ErrItemNotFound, "'%s'", id).WithComponent(string(id))
 	}
 
 	delete(r.components, id)
+	events := r.events
+	r.mu.Unlock()
+
+	if events != nil {
+		events.Forget(comp)
+		events.Publish(component.TopicComponentUnregistered, comp, nil, nil)
+	}
 	return nil
 }
 
@@ -147,8 +189,63 @@ func (r *Registry) Count() int {
 // Clear removes all components from the registry.
 func (r *Registry) Clear() error {
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
+	removed := r.components
 	r.components = make(map[component.ComponentID]component.Component)
+	events := r.events
+	r.mu.Unlock()
+
+	if events != nil {
+		for _, comp := range removed {
+			events.Forget(comp)
+			events.Publish(component.TopicComponentUnregistered, comp, nil, nil)
+		}
+	}
 	return nil
 }
+
+// OperationSchema returns the schemas of a registered operation. Operations
+// that do not implement component.SchemaProvider have no schemas.
+func (r *Registry) OperationSchema(id component.ComponentID) (component.OperationSchema, error) {
+	comp, err := r.Get(id)
+	if err != nil {
+		return component.OperationSchema{}, err
+	}
+
+	operation, ok := comp.(component.Operation)
+	if !ok {
+		return component.OperationSchema{}, failure.New(component.ErrInvalidComponentType, "expected operation, got %s '%s'", comp.Type(), id)
+	}
+	return operationSchema(operation), nil
+}
+
+// OperationSchemas returns the schemas of all registered operations, ordered
+// by operation ID.
+func (r *Registry) OperationSchemas() []component.OperationSchema {
+	r.mu.RLock()
+	schemas := make([]component.OperationSchema, 0, len(r.components))
+	for _, comp := range r.components {
+		if operation, ok := comp.(component.Operation); ok {
+			schemas = append(schemas, operationSchema(operation))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].OperationID < schemas[j].OperationID
+	})
+	return schemas
+}
+
+// operationSchema describes an operation.
+func operationSchema(operation component.Operation) component.OperationSchema {
+	schema := component.OperationSchema{
+		OperationID: operation.ID(),
+		Name:        operation.Name(),
+		Description: operation.Description(),
+	}
+	if provider, ok := operation.(component.SchemaProvider); ok {
+		schema.Input = provider.InputSchema()
+		schema.Output = provider.OutputSchema()
+	}
+	return schema
+}
@@ -0,0 +1,240 @@
+package component
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// executionsKey holds the execution counter of a context.
+type executionsKey struct{}
+
+// CountExecutions returns a context in which ExecuteValidated counts the calls
+// it makes to Execute, and the counter. Callers learn from it whether an
+// operation ran at all, whatever the error they got back.
+func CountExecutions(ctx context.Context) (context.Context, *atomic.Int32) {
+	executions := &atomic.Int32{}
+	return ctx.WithValue(executionsKey{}, executions), executions
+}
+
+// ExecuteValidated runs an operation, validating its input before Execute and
+// its output after it against the schemas the operation declares through
+// component.SchemaProvider. Operations without schemas run unchanged.
+func ExecuteValidated(ctx context.Context, operation component.Operation, input component.Input) (component.Output, error) {
+	provider, ok := operation.(component.SchemaProvider)
+	if !ok {
+		countExecution(ctx)
+		return operation.Execute(ctx, input)
+	}
+
+	if err := ValidateSchema(provider.InputSchema(), input.Data); err != nil {
+		return component.Output{}, failure.Wrap(err, component.ErrInputValidationFailed, "operation '%s'", operation.ID())
+	}
+
+	countExecution(ctx)
+	output, err := operation.Execute(ctx, input)
+	if err != nil {
+		return output, err
+	}
+
+	if err := ValidateSchema(provider.OutputSchema(), output.Data); err != nil {
+		return component.Output{}, failure.Wrap(err, component.ErrOutputValidationFailed, "operation '%s'", operation.ID())
+	}
+	return output, nil
+}
+
+// countExecution counts a call to Execute if ctx has a counter.
+func countExecution(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+	if executions, ok := ctx.Value(executionsKey{}).(*atomic.Int32); ok {
+		executions.Add(1)
+	}
+}
+
+// ValidateSchema validates a value against a schema. Go values are compared
+// through their JSON representation, so structs are checked by their json
+// tags. All violations are reported, each prefixed with its path ("$" is the
+// value itself). A nil schema accepts any value.
+//
+// The error carries no code, since only the caller knows what was
+// validated: ExecuteValidated wraps it with ErrInputValidationFailed or
+// ErrOutputValidationFailed.
+func ValidateSchema(schema *component.Schema, value any) error {
+	if schema == nil {
+		return nil
+	}
+
+	normalized, err := normalize(value)
+	if err != nil {
+		return err
+	}
+
+	var violations []string
+	validate(schema, normalized, "$", &violations)
+	if len(violations) > 0 {
+		return errors.New(strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// normalize converts a value to the types produced by decoding JSON.
+func normalize(value any) (any, error) {
+	switch value.(type) {
+	case nil, bool, string, float64, map[string]interface{}, []interface{}:
+		return value, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("value of type %T cannot be encoded as JSON: %w", value, err)
+	}
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// validate appends the violations of value against schema to violations.
+func validate(schema *component.Schema, value any, path string, violations *[]string) {
+	fail := func(format string, args ...interface{}) {
+		*violations = append(*violations, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		fail("expected %s, got %s", schema.Type, jsonType(value))
+		return
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		fail("must be one of %s", encode(schema.Enum))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				fail("missing required property '%s'", name)
+			}
+		}
+		for _, name := range sortedKeys(v) {
+			child, ok := schema.Properties[name]
+			switch {
+			case ok && child != nil:
+				validate(child, v[name], path+"."+name, violations)
+			case !ok && schema.AdditionalProperties != nil && !*schema.AdditionalProperties:
+				fail("unexpected property '%s'", name)
+			}
+		}
+
+	case []interface{}:
+		if schema.MinItems != nil && len(v) < *schema.MinItems {
+			fail("must have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(v) > *schema.MaxItems {
+			fail("must have at most %d items", *schema.MaxItems)
+		}
+		if schema.Items != nil {
+			for i, item := range v {
+				validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+
+	case string:
+		length := utf8.RuneCountInString(v)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			fail("must be at least %d characters", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			fail("must be at most %d characters", *schema.MaxLength)
+		}
+		if schema.Pattern != "" {
+			re, err := regexp.Compile(schema.Pattern)
+			if err != nil {
+				fail("%s: pattern '%s': %v", component.ErrInvalidSchema, schema.Pattern, err)
+			} else if !re.MatchString(v) {
+				fail("must match pattern '%s'", schema.Pattern)
+			}
+		}
+
+	case float64:
+		if schema.Minimum != nil && v < *schema.Minimum {
+			fail("must be >= %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && v > *schema.Maximum {
+			fail("must be <= %v", *schema.Maximum)
+		}
+	}
+}
+
+// hasType reports whether a normalized value has the given JSON Schema type.
+func hasType(value any, typ string) bool {
+	if typ == "integer" {
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	}
+	return jsonType(value) == typ
+}
+
+// jsonType returns the JSON Schema type of a normalized value.
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// inEnum reports whether value equals one of the enum values.
+func inEnum(enum []any, value any) bool {
+	encoded := encode(value)
+	for _, candidate := range enum {
+		if encode(candidate) == encoded {
+			return true
+		}
+	}
+	return false
+}
+
+// encode returns the JSON representation of a value for comparisons and
+// messages.
+func encode(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// sortedKeys returns the keys of an object in order, for stable messages.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,12 +1,12 @@
 package component
 
 import (
-	"errors"
 	"sync"
 	"sync/atomic"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 )
 
 // System implements the component.System interface.
@@ -28,7 +28,8 @@ func (s *System) Registry() component.Registry {
 	return s.registry
 }
 
-// ExecuteOperation executes a registered operation component with the given input.
+// ExecuteOperation executes a registered operation component with the given input,
+// validating input and output against the schemas the operation declares.
 func (s *System) ExecuteOperation(ctx context.Context, operationID component.ComponentID, input component.Input) (component.Output, error) {
 	comp, err := s.registry.Get(operationID)
 	if err != nil {
@@ -37,10 +38,10 @@ func (s *System) ExecuteOperation(ctx context.Context, operationID component.Com
 
 	operation, ok := comp.(component.Operation)
 	if !ok {
-		return component.Output{}, errors.New(component.ErrInvalidComponentType)
+		return component.Output{}, failure.New(component.ErrInvalidComponentType, "'%s' is not an operation", operationID).WithComponent(string(operationID))
 	}
 
-	return operation.Execute(ctx, input)
+	return ExecuteValidated(ctx, operation, input)
 }
 
 // StartService starts a registered service component.
@@ -52,7 +53,7 @@ func (s *System) StartService(ctx context.Context, serviceID component.Component
 
 	service, ok := comp.(component.Service)
 	if !ok {
-		return errors.New(component.ErrInvalidComponentType)
+		return failure.New(component.ErrInvalidComponentType, "'%s' is not a service", serviceID).WithComponent(string(serviceID))
 	}
 
 	return service.Start(ctx)
@@ -67,7 +68,7 @@ func (s *System) StopService(ctx context.Context, serviceID component.ComponentI
 
 	service, ok := comp.(component.Service)
 	if !ok {
-		return errors.New(component.ErrInvalidComponentType)
+		return failure.New(component.ErrInvalidComponentType, "'%s' is not a service", serviceID).WithComponent(string(serviceID))
 	}
 
 	return service.Stop(ctx)
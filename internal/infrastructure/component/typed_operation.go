@@ -0,0 +1,99 @@
+package component
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// OperationFunc is the typed body of a TypedOperation.
+type OperationFunc[In, Out any] func(ctx context.Context, input In) (Out, error)
+
+// TypedOperation adapts a typed function to the component.Operation
+// interface. Input data is decoded into In before the function runs, so the
+// operation works with the values callers pass in-process as well as with
+// the maps produced by JSON transports such as out-of-process plugins.
+type TypedOperation[In, Out any] struct {
+	*BaseOperation
+	execute OperationFunc[In, Out]
+}
+
+// NewTypedOperation creates an operation that runs execute with decoded input.
+func NewTypedOperation[In, Out any](config component.ComponentConfig, execute OperationFunc[In, Out]) *TypedOperation[In, Out] {
+	return &TypedOperation[In, Out]{
+		BaseOperation: NewBaseOperation(config),
+		execute:       execute,
+	}
+}
+
+// Execute decodes the input data into In and runs the typed function.
+func (o *TypedOperation[In, Out]) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	in, err := Decode[In](input.Data)
+	if err != nil {
+		return component.Output{}, failure.Wrap(err, component.ErrInvalidInputType, "operation '%s'", o.ID())
+	}
+
+	out, err := o.execute(ctx, in)
+	if err != nil {
+		return component.Output{}, err
+	}
+	return component.Output{Data: out}, nil
+}
+
+// Execute runs a registered operation with typed input and decodes its output
+// into Out.
+//
+// Example:
+//
+//	quote, err := component.Execute[QuoteRequest, Quote](ctx, system, "quote", QuoteRequest{Symbol: "EURUSD"})
+func Execute[In, Out any](ctx context.Context, system component.System, operationID component.ComponentID, input In) (Out, error) {
+	var zero Out
+
+	output, err := system.ExecuteOperation(ctx, operationID, component.Input{Data: input})
+	if err != nil {
+		return zero, err
+	}
+
+	out, err := Decode[Out](output.Data)
+	if err != nil {
+		return zero, failure.Wrap(err, component.ErrInvalidOutputType, "operation '%s'", operationID)
+	}
+	return out, nil
+}
+
+// Decode converts operation data into T. Values that already are a T are
+// returned as is, pointers to a T are dereferenced and nil yields the zero
+// value. Anything else, typically a map[string]interface{}, is converted
+// through its JSON representation, so map keys follow the json tags of T.
+func Decode[T any](data any) (T, error) {
+	var out T
+
+	switch value := data.(type) {
+	case nil:
+		return out, nil
+	case T:
+		return value, nil
+	case *T:
+		if value != nil {
+			return *value, nil
+		}
+		return out, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err == nil {
+		err = json.Unmarshal(encoded, &out)
+	}
+	if err != nil {
+		return out, failure.Wrap(err, component.ErrInvalidComponentType, "expected %s, got %T", typeName[T](), data)
+	}
+	return out, nil
+}
+
+// typeName returns the name of T, including interface types.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
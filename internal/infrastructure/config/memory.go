@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 )
 
 // MemorySource implements the ConfigurationSource interface using in-memory storage.
@@ -192,7 +193,7 @@ func (c *MemoryConfiguration) GetStringDefault(key, defaultValue string) string
 func (c *MemoryConfiguration) GetInt(key string) (int, error) {
 	value, exists := c.source.GetValue(key)
 	if !exists {
-		return 0, fmt.Errorf(config.ErrConfigKeyNotFound)
+		return 0, failure.New(config.ErrConfigKeyNotFound, "key '%s'", key)
 	}
 
 	switch v := value.(type) {
@@ -206,9 +207,9 @@ func (c *MemoryConfiguration) GetInt(key string) (int, error) {
 		if parsed, err := strconv.Atoi(v); err == nil {
 			return parsed, nil
 		}
-		return 0, fmt.Errorf(config.ErrInvalidConfigType)
+		return 0, failure.New(config.ErrInvalidConfigType, "key '%s' is not an integer", key)
 	default:
-		return 0, fmt.Errorf(config.ErrInvalidConfigType)
+		return 0, failure.New(config.ErrInvalidConfigType, "key '%s' is not an integer", key)
 	}
 }
 
@@ -225,7 +226,7 @@ func (c *MemoryConfiguration) GetIntDefault(key string, defaultValue int) int {
 func (c *MemoryConfiguration) GetBool(key string) (bool, error) {
 	value, exists := c.source.GetValue(key)
 	if !exists {
-		return false, fmt.Errorf(config.ErrConfigKeyNotFound)
+		return false, failure.New(config.ErrConfigKeyNotFound, "key '%s'", key)
 	}
 
 	switch v := value.(type) {
@@ -238,10 +239,10 @@ func (c *MemoryConfiguration) GetBool(key string) (bool, error) {
 		case "false", "0", "no", "off":
 			return false, nil
 		default:
-			return false, fmt.Errorf(config.ErrInvalidConfigType)
+			return false, failure.New(config.ErrInvalidConfigType, "key '%s' is not a boolean", key)
 		}
 	default:
-		return false, fmt.Errorf(config.ErrInvalidConfigType)
+		return false, failure.New(config.ErrInvalidConfigType, "key '%s' is not a boolean", key)
 	}
 }
 
@@ -258,7 +259,7 @@ func (c *MemoryConfiguration) GetBoolDefault(key string, defaultValue bool) bool
 func (c *MemoryConfiguration) GetDuration(key string) (time.Duration, error) {
 	value, exists := c.source.GetValue(key)
 	if !exists {
-		return 0, fmt.Errorf(config.ErrConfigKeyNotFound)
+		return 0, failure.New(config.ErrConfigKeyNotFound, "key '%s'", key)
 	}
 
 	switch v := value.(type) {
@@ -268,13 +269,13 @@ func (c *MemoryConfiguration) GetDuration(key string) (time.Duration, error) {
 		if parsed, err := time.ParseDuration(v); err == nil {
 			return parsed, nil
 		}
-		return 0, fmt.Errorf(config.ErrInvalidConfigType)
+		return 0, failure.New(config.ErrInvalidConfigType, "key '%s' is not a duration", key)
 	case int64:
 		return time.Duration(v), nil
 	case float64:
 		return time.Duration(v), nil
 	default:
-		return 0, fmt.Errorf(config.ErrInvalidConfigType)
+		return 0, failure.New(config.ErrInvalidConfigType, "key '%s' is not a duration", key)
 	}
 }
 
@@ -290,22 +291,22 @@ func (c *MemoryConfiguration) GetDurationDefault(key string, defaultValue time.D
 // GetObject deserializes a configuration section into a struct.
 func (c *MemoryConfiguration) GetObject(key string, result interface{}) error {
 	if result == nil {
-		return fmt.Errorf(config.ErrInvalidConfigValue)
+		return failure.New(config.ErrInvalidConfigValue, "nil result for key '%s'", key)
 	}
 
 	value, exists := c.source.GetValue(key)
 	if !exists {
-		return fmt.Errorf(config.ErrConfigKeyNotFound)
+		return failure.New(config.ErrConfigKeyNotFound, "key '%s'", key)
 	}
 
 	// Use JSON marshaling/unmarshaling for object conversion
 	jsonData, err := json.Marshal(value)
 	if err != nil {
-		return fmt.Errorf(config.ErrInvalidConfigType)
+		return failure.Wrap(err, config.ErrInvalidConfigType, "key '%s'", key)
 	}
 
 	if err := json.Unmarshal(jsonData, result); err != nil {
-		return fmt.Errorf(config.ErrInvalidConfigType)
+		return failure.Wrap(err, config.ErrInvalidConfigType, "key '%s'", key)
 	}
 
 	return nil
@@ -317,6 +318,11 @@ func (c *MemoryConfiguration) Exists(key string) bool {
 	return exists
 }
 
+// GetAllKeys returns all configuration keys.
+func (c *MemoryConfiguration) GetAllKeys() []string {
+	return c.source.GetAllKeys()
+}
+
 // SetValue sets a configuration value (helper method for testing).
 func (c *MemoryConfiguration) SetValue(key string, value interface{}) {
 	c.source.SetValue(key, value)
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fintechain/skeleton/internal/domain/config"
+)
+
+// Required rejects a missing or empty value.
+func Required() config.Rule {
+	return func(value interface{}, exists bool) error {
+		if !exists || value == nil || fmt.Sprintf("%v", value) == "" {
+			return errors.New("is required")
+		}
+		return nil
+	}
+}
+
+// OneOf accepts only the listed values, compared by their string form.
+// A missing value is accepted; combine with Required to demand one.
+func OneOf(allowed ...interface{}) config.Rule {
+	return func(value interface{}, exists bool) error {
+		if !exists {
+			return nil
+		}
+		got := fmt.Sprintf("%v", value)
+		names := make([]string, 0, len(allowed))
+		for _, a := range allowed {
+			name := fmt.Sprintf("%v", a)
+			if name == got {
+				return nil
+			}
+			names = append(names, name)
+		}
+		return fmt.Errorf("must be one of %s, got '%s'", strings.Join(names, ", "), got)
+	}
+}
+
+// IntRange accepts integers between min and max inclusive.
+// A missing value is accepted; combine with Required to demand one.
+func IntRange(min, max int) config.Rule {
+	return func(value interface{}, exists bool) error {
+		if !exists {
+			return nil
+		}
+		var n int
+		switch v := value.(type) {
+		case int:
+			n = v
+		case int64:
+			n = int(v)
+		case float64:
+			if v != float64(int(v)) {
+				return fmt.Errorf("must be an integer, got %v", v)
+			}
+			n = int(v)
+		case string:
+			parsed, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("must be an integer, got '%s'", v)
+			}
+			n = parsed
+		default:
+			return fmt.Errorf("must be an integer, got %T", value)
+		}
+		if n < min || n > max {
+			return fmt.Errorf("must be between %d and %d, got %d", min, max, n)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// PluginsKey is the configuration key under which plugin sections live.
+const PluginsKey = "plugins"
+
+// PluginPrefix returns the key prefix of a plugin's configuration section.
+func PluginPrefix(pluginID string) string {
+	return PluginsKey + "." + pluginID
+}
+
+// Sections hands out configuration sections over a root configuration and
+// keeps the defaults they register. Defaults never override values set in the
+// root configuration; they fill in keys it does not set.
+type Sections struct {
+	root     config.Configuration
+	defaults *MemoryConfiguration
+	sections map[string]*Section
+	order    []string
+	mu       sync.Mutex
+}
+
+// NewSections creates a section registry over the root configuration.
+func NewSections(root config.Configuration) *Sections {
+	return &Sections{
+		root:     root,
+		defaults: NewMemoryConfiguration(),
+		sections: make(map[string]*Section),
+	}
+}
+
+// Section returns the section rooted at prefix, creating it on first use.
+func (s *Sections) Section(prefix string) *Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if section, exists := s.sections[prefix]; exists {
+		return section
+	}
+	section := &Section{
+		prefix: prefix,
+		owner:  s,
+		rules:  make(map[string][]config.Rule),
+	}
+	s.sections[prefix] = section
+	s.order = append(s.order, prefix)
+	return section
+}
+
+// Plugin returns the section of a plugin, rooted at "plugins.<pluginID>".
+func (s *Sections) Plugin(pluginID string) *Section {
+	return s.Section(PluginPrefix(pluginID))
+}
+
+// Validate validates every section. All failures are reported together.
+func (s *Sections) Validate() error {
+	s.mu.Lock()
+	sections := make([]*Section, 0, len(s.order))
+	for _, prefix := range s.order {
+		sections = append(sections, s.sections[prefix])
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, section := range sections {
+		if err := section.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Effective returns the effective configuration as a flat map of dotted keys:
+// every registered default, overlaid by the values of the root configuration.
+// Root values are only listed when the root configuration can enumerate its
+// keys (as MemoryConfiguration can); registered defaults are always listed.
+func (s *Sections) Effective() map[string]interface{} {
+	effective := make(map[string]interface{})
+	for _, key := range s.defaults.GetAllKeys() {
+		effective[key], _ = s.value(key)
+	}
+	if lister, ok := s.root.(interface{ GetAllKeys() []string }); ok {
+		for _, key := range lister.GetAllKeys() {
+			effective[key], _ = s.value(key)
+		}
+	}
+	return effective
+}
+
+// source returns the configuration that provides key: the root configuration
+// if it sets the key, the registered defaults otherwise.
+func (s *Sections) source(key string) config.Configuration {
+	if s.root.Exists(key) {
+		return s.root
+	}
+	return s.defaults
+}
+
+// value returns the raw effective value of key.
+func (s *Sections) value(key string) (interface{}, bool) {
+	if !s.root.Exists(key) {
+		return s.defaults.GetSource().GetValue(key)
+	}
+	if memory, ok := s.root.(*MemoryConfiguration); ok {
+		return memory.GetSource().GetValue(key)
+	}
+	var value interface{}
+	if err := s.root.GetObject(key, &value); err != nil {
+		return s.root.GetString(key), true
+	}
+	return value, true
+}
+
+// Section implements config.Section over a Sections registry.
+type Section struct {
+	prefix   string
+	owner    *Sections
+	rules    map[string][]config.Rule
+	ruleKeys []string
+	mu       sync.RWMutex
+}
+
+// key returns the absolute key of a relative key. The empty key is the
+// section itself.
+func (c *Section) key(key string) string {
+	if key == "" {
+		return c.prefix
+	}
+	return c.prefix + "." + key
+}
+
+// Prefix returns the absolute key prefix of the section.
+func (c *Section) Prefix() string {
+	return c.prefix
+}
+
+// SetDefault registers the default value of a relative key.
+func (c *Section) SetDefault(key string, value interface{}) {
+	c.owner.defaults.SetValue(c.key(key), value)
+}
+
+// SetDefaults registers several defaults at once.
+func (c *Section) SetDefaults(values map[string]interface{}) {
+	for key, value := range values {
+		c.SetDefault(key, value)
+	}
+}
+
+// AddRule registers a validation rule for a relative key.
+func (c *Section) AddRule(key string, rule config.Rule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.rules[key]; !exists {
+		c.ruleKeys = append(c.ruleKeys, key)
+	}
+	c.rules[key] = append(c.rules[key], rule)
+}
+
+// Validate checks every registered rule against the effective values.
+// All failures are reported together.
+func (c *Section) Validate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var errs []error
+	for _, key := range c.ruleKeys {
+		full := c.key(key)
+		value, exists := c.owner.value(full)
+		for _, rule := range c.rules[key] {
+			if err := rule(value, exists); err != nil {
+				errs = append(errs, failure.New(config.ErrConfigValidationFailed, "'%s' %v", full, err).WithDetail("key", full))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetString retrieves a string configuration value.
+func (c *Section) GetString(key string) string {
+	full := c.key(key)
+	return c.owner.source(full).GetString(full)
+}
+
+// GetStringDefault retrieves a string configuration value with a default fallback.
+func (c *Section) GetStringDefault(key, defaultValue string) string {
+	full := c.key(key)
+	return c.owner.source(full).GetStringDefault(full, defaultValue)
+}
+
+// GetInt retrieves an integer configuration value.
+func (c *Section) GetInt(key string) (int, error) {
+	full := c.key(key)
+	return c.owner.source(full).GetInt(full)
+}
+
+// GetIntDefault retrieves an integer configuration value with a default fallback.
+func (c *Section) GetIntDefault(key string, defaultValue int) int {
+	full := c.key(key)
+	return c.owner.source(full).GetIntDefault(full, defaultValue)
+}
+
+// GetBool retrieves a boolean configuration value.
+func (c *Section) GetBool(key string) (bool, error) {
+	full := c.key(key)
+	return c.owner.source(full).GetBool(full)
+}
+
+// GetBoolDefault retrieves a boolean configuration value with a default fallback.
+func (c *Section) GetBoolDefault(key string, defaultValue bool) bool {
+	full := c.key(key)
+	return c.owner.source(full).GetBoolDefault(full, defaultValue)
+}
+
+// GetDuration retrieves a duration configuration value.
+func (c *Section) GetDuration(key string) (time.Duration, error) {
+	full := c.key(key)
+	return c.owner.source(full).GetDuration(full)
+}
+
+// GetDurationDefault retrieves a duration configuration value with a default fallback.
+func (c *Section) GetDurationDefault(key string, defaultValue time.Duration) time.Duration {
+	full := c.key(key)
+	return c.owner.source(full).GetDurationDefault(full, defaultValue)
+}
+
+// GetObject deserializes a configuration section into a struct. The defaults
+// are decoded first and the configured values on top of them, so fields the
+// configuration does not set keep their default.
+func (c *Section) GetObject(key string, result interface{}) error {
+	full := c.key(key)
+	fromDefaults := c.owner.defaults.Exists(full)
+	if fromDefaults {
+		if err := c.owner.defaults.GetObject(full, result); err != nil {
+			return err
+		}
+	}
+	if c.owner.root.Exists(full) {
+		return c.owner.root.GetObject(full, result)
+	}
+	if !fromDefaults {
+		return failure.New(config.ErrConfigKeyNotFound, "key '%s'", full)
+	}
+	return nil
+}
+
+// Exists checks whether the configuration or a default provides the key.
+func (c *Section) Exists(key string) bool {
+	full := c.key(key)
+	return c.owner.root.Exists(full) || c.owner.defaults.Exists(full)
+}
@@ -2,7 +2,6 @@
 package context
 
 import (
-	"errors"
 	"sync"
 	"time"
 
@@ -13,10 +12,15 @@ import (
 // deadline management, and cancellation support.
 type DomainContext struct {
 	values   map[interface{}]interface{}
+	parent   context.Context
 	deadline time.Time
 	done     chan struct{}
 	err      error
 	mu       sync.RWMutex
+
+	// shared is the context a WithValue copy takes its deadline and
+	// cancellation from.
+	shared *DomainContext
 }
 
 // NewContext creates a new domain context instance.
@@ -53,7 +57,7 @@ func NewContextWithTimeout(timeout time.Duration) *DomainContext {
 		ctx := &DomainContext{
 			values: make(map[interface{}]interface{}),
 			done:   make(chan struct{}),
-			err:    errors.New(context.ErrContextDeadlineExceeded),
+			err:    context.ErrContextDeadlineExceeded,
 		}
 		close(ctx.done)
 		return ctx
@@ -62,21 +66,48 @@ func NewContextWithTimeout(timeout time.Duration) *DomainContext {
 	return NewContextWithDeadline(time.Now().Add(timeout))
 }
 
-// Value retrieves a value from the context by key.
-// Returns nil if the key is not found.
+// WithTimeout creates a child context of parent. The child inherits the values
+// of parent and is cancelled when parent is done, when the timeout elapses or
+// when Cancel is called, whichever comes first. A non-positive timeout only
+// follows parent. Callers should call Cancel once the child is no longer used.
+func WithTimeout(parent context.Context, timeout time.Duration) *DomainContext {
+	ctx := &DomainContext{
+		values: make(map[interface{}]interface{}),
+		parent: parent,
+		done:   make(chan struct{}),
+	}
+	if timeout > 0 {
+		ctx.deadline = time.Now().Add(timeout)
+	}
+	if deadline, ok := parent.Deadline(); ok && (ctx.deadline.IsZero() || deadline.Before(ctx.deadline)) {
+		ctx.deadline = deadline
+	}
+
+	go ctx.monitorParent()
+	return ctx
+}
+
+// Value retrieves a value from the context by key, falling back to the
+// parent context. Returns nil if the key is not found.
 func (c *DomainContext) Value(key interface{}) interface{} {
 	if key == nil {
 		return nil
 	}
 
 	c.mu.RLock()
-	defer c.mu.RUnlock()
+	value, ok := c.values[key]
+	parent := c.parent
+	c.mu.RUnlock()
 
-	return c.values[key]
+	if !ok && parent != nil {
+		return parent.Value(key)
+	}
+	return value
 }
 
 // WithValue creates a new context with an additional key-value pair.
-// The new context inherits all values from the parent context.
+// The new context inherits all values from the parent context, and shares its
+// deadline and cancellation: it is done when c is, and cancelling it cancels c.
 func (c *DomainContext) WithValue(key, value interface{}) context.Context {
 	if key == nil {
 		return c // Return same context if key is nil
@@ -88,37 +119,29 @@ func (c *DomainContext) WithValue(key, value interface{}) context.Context {
 	for k, v := range c.values {
 		newValues[k] = v
 	}
-	deadline := c.deadline
-	err := c.err
+	parent := c.parent
 	c.mu.RUnlock()
 
 	// Add new value
 	newValues[key] = value
 
-	newCtx := &DomainContext{
-		values:   newValues,
-		deadline: deadline,
-		done:     make(chan struct{}),
-		err:      err,
+	shared := c
+	if c.shared != nil {
+		shared = c.shared
 	}
-
-	// If parent context is already cancelled, cancel the new context too
-	select {
-	case <-c.done:
-		close(newCtx.done)
-	default:
-		// Start deadline monitoring if deadline is set and in the future
-		if !deadline.IsZero() && deadline.After(time.Now()) && err == nil {
-			go newCtx.monitorDeadline()
-		}
+	return &DomainContext{
+		values: newValues,
+		parent: parent,
+		shared: shared,
 	}
-
-	return newCtx
 }
 
 // Deadline returns the deadline for this context, if any.
 // Returns zero time and false if no deadline is set.
 func (c *DomainContext) Deadline() (time.Time, bool) {
+	if c.shared != nil {
+		return c.shared.Deadline()
+	}
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -131,12 +154,18 @@ func (c *DomainContext) Deadline() (time.Time, bool) {
 // Done returns a channel that's closed when the context is cancelled or times out.
 // This channel can be used in select statements for cancellation handling.
 func (c *DomainContext) Done() <-chan struct{} {
+	if c.shared != nil {
+		return c.shared.Done()
+	}
 	return c.done
 }
 
 // Err returns the error that caused the context to be cancelled.
 // Returns nil if the context is not cancelled.
 func (c *DomainContext) Err() error {
+	if c.shared != nil {
+		return c.shared.Err()
+	}
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -146,6 +175,10 @@ func (c *DomainContext) Err() error {
 // Cancel manually cancels the context with a cancellation error.
 // This is useful for explicit cancellation scenarios.
 func (c *DomainContext) Cancel() {
+	if c.shared != nil {
+		c.shared.Cancel()
+		return
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -153,7 +186,7 @@ func (c *DomainContext) Cancel() {
 		return // Already cancelled
 	}
 
-	c.err = errors.New(context.ErrContextCanceled)
+	c.err = context.ErrContextCanceled
 	close(c.done)
 }
 
@@ -175,7 +208,7 @@ func (c *DomainContext) monitorDeadline() {
 	case <-timer.C:
 		c.mu.Lock()
 		if c.err == nil { // Only cancel if not already cancelled
-			c.err = errors.New(context.ErrContextDeadlineExceeded)
+			c.err = context.ErrContextDeadlineExceeded
 			close(c.done)
 		}
 		c.mu.Unlock()
@@ -185,11 +218,42 @@ func (c *DomainContext) monitorDeadline() {
 	}
 }
 
+// monitorParent runs in a goroutine to cancel a child context when its parent
+// is done or its deadline is reached.
+func (c *DomainContext) monitorParent() {
+	var expired <-chan time.Time
+	if !c.deadline.IsZero() {
+		timer := time.NewTimer(time.Until(c.deadline))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	var err error
+	select {
+	case <-expired:
+		err = context.ErrContextDeadlineExceeded
+	case <-c.parent.Done():
+		err = c.parent.Err()
+		if err == nil {
+			err = context.ErrContextCanceled
+		}
+	case <-c.done:
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+		close(c.done)
+	}
+}
+
 // IsCancelled returns true if the context has been cancelled.
 // This is a convenience method for checking cancellation status.
 func (c *DomainContext) IsCancelled() bool {
 	select {
-	case <-c.done:
+	case <-c.Done():
 		return true
 	default:
 		return false
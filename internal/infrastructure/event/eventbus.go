@@ -3,22 +3,30 @@ package event
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
 	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
 )
 
 // subscription represents a single event subscription
 type subscription struct {
+	bus       *EventBus
 	handler   event.EventHandler
 	topic     string
 	cancelled atomic.Bool
 }
 
-// Cancel cancels the subscription
+// Cancel cancels the subscription and removes it from the bus
 func (s *subscription) Cancel() {
-	s.cancelled.Store(true)
+	if s.cancelled.CompareAndSwap(false, true) {
+		s.bus.remove(s)
+	}
 }
 
 // Topic returns the topic this subscription is registered for
@@ -26,14 +34,28 @@ func (s *subscription) Topic() string {
 	return s.topic
 }
 
+// Cancelled returns whether the subscription was cancelled
+func (s *subscription) Cancelled() bool {
+	return s.cancelled.Load()
+}
+
 // EventBus implements the EventBusService interface
 type EventBus struct {
 	*infraComponent.BaseService
 	subscribers map[string][]*subscription
+	metrics     atomic.Pointer[busMetrics]
+	tracer      tracing.Tracer
 	mu          sync.RWMutex
 	wg          sync.WaitGroup
 }
 
+// busMetrics are the metrics the bus records, labelled by topic.
+type busMetrics struct {
+	publishes       metrics.Counter
+	handlerDuration metrics.Histogram
+	queueDepth      metrics.Gauge
+}
+
 // NewEventBus creates a new event bus
 func NewEventBus(config component.ComponentConfig) *EventBus {
 	return &EventBus{
@@ -42,12 +64,38 @@ func NewEventBus(config component.ComponentConfig) *EventBus {
 	}
 }
 
+// SetMetrics records publishes, handler latency and the number of
+// asynchronous deliveries in progress per topic in the registry.
+func (eb *EventBus) SetMetrics(registry metrics.Registry) {
+	eb.metrics.Store(&busMetrics{
+		publishes:       registry.Counter(metrics.EventPublishes, "Number of events published."),
+		handlerDuration: registry.Histogram(metrics.EventHandlerDuration, "Duration of event handlers in seconds.", nil),
+		queueDepth:      registry.Gauge(metrics.EventQueueDepth, "Number of asynchronous event deliveries in progress."),
+	})
+}
+
+// SetTracer traces every delivery of an event to a handler as a consumer
+// span, joining the trace recorded in the event metadata (see tracing.Inject).
+// Handlers receive a copy of the event whose metadata carries their span.
+func (eb *EventBus) SetTracer(tracer tracing.Tracer) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	eb.tracer = tracer
+}
+
 // Publish publishes an event synchronously to all subscribers
 func (eb *EventBus) Publish(evt *event.Event) error {
 	eb.mu.RLock()
 	subs := eb.subscribers[evt.Topic]
+	tracer := eb.tracer
 	eb.mu.RUnlock()
 
+	m := eb.metrics.Load()
+	labels := metrics.Labels{"topic": evt.Topic}
+	if m != nil {
+		m.publishes.Inc(labels)
+	}
+
 	for _, sub := range subs {
 		if sub.cancelled.Load() {
 			continue
@@ -59,45 +107,118 @@ func (eb *EventBus) Publish(evt *event.Event) error {
 					// Log panic but continue to next handler
 				}
 			}()
-			sub.handler(evt)
+			if m != nil {
+				defer m.observe(labels, time.Now())
+			}
+			delivered, span := traceDelivery(tracer, evt)
+			if span != nil {
+				defer span.End()
+			}
+			sub.handler(delivered)
 		}()
 	}
 
 	return nil
 }
 
+// PublishContext publishes an event synchronously on behalf of the call ctx
+// belongs to, so that its handlers join the trace of the span ctx carries.
+func (eb *EventBus) PublishContext(ctx context.Context, evt *event.Event) error {
+	return eb.Publish(withTrace(ctx, evt))
+}
+
+// PublishAsyncContext publishes an event asynchronously on behalf of the call
+// ctx belongs to, like PublishContext.
+func (eb *EventBus) PublishAsyncContext(ctx context.Context, evt *event.Event) error {
+	return eb.PublishAsync(withTrace(ctx, evt))
+}
+
+// withTrace returns a copy of evt carrying the span context of ctx, or evt
+// itself when ctx carries none or evt already names a trace.
+func withTrace(ctx context.Context, evt *event.Event) *event.Event {
+	if _, ok := evt.Metadata[tracing.TraceParentKey]; ok || ctx == nil {
+		return evt
+	}
+	traced := *evt
+	tracing.Inject(ctx, &traced)
+	return &traced
+}
+
 // PublishAsync publishes an event asynchronously to all subscribers
 func (eb *EventBus) PublishAsync(evt *event.Event) error {
 	eb.mu.RLock()
 	subs := eb.subscribers[evt.Topic]
+	tracer := eb.tracer
 	eb.mu.RUnlock()
 
+	m := eb.metrics.Load()
+	labels := metrics.Labels{"topic": evt.Topic}
+	if m != nil {
+		m.publishes.Inc(labels)
+	}
+
 	for _, sub := range subs {
 		if sub.cancelled.Load() {
 			continue
 		}
 
 		eb.wg.Add(1)
+		if m != nil {
+			m.queueDepth.Add(labels, 1)
+		}
 		go func(s *subscription) {
 			defer eb.wg.Done()
+			if m != nil {
+				defer m.queueDepth.Add(labels, -1)
+			}
 			defer func() {
 				if r := recover(); r != nil {
 					// Log panic but continue
 				}
 			}()
-			s.handler(evt)
+			if m != nil {
+				defer m.observe(labels, time.Now())
+			}
+			delivered, span := traceDelivery(tracer, evt)
+			if span != nil {
+				defer span.End()
+			}
+			s.handler(delivered)
 		}(sub)
 	}
 
 	return nil
 }
 
+// traceDelivery starts the span of delivering evt to one handler and returns
+// the copy of the event to deliver. Without a tracer the event is delivered
+// as is.
+func traceDelivery(tracer tracing.Tracer, evt *event.Event) (*event.Event, tracing.Span) {
+	if tracer == nil {
+		return evt, nil
+	}
+
+	ctx, span := tracer.Start(tracing.Extract(infraContext.NewContext(), evt), "event "+evt.Topic, tracing.KindConsumer)
+	span.SetAttribute("event.topic", evt.Topic)
+	span.SetAttribute("event.source", evt.Source)
+
+	delivered := *evt
+	tracing.Inject(ctx, &delivered)
+	return &delivered, span
+}
+
+// observe records the duration of a handler that began at start.
+func (m *busMetrics) observe(labels metrics.Labels, start time.Time) {
+	m.handlerDuration.Observe(labels, time.Since(start).Seconds())
+}
+
 // Subscribe subscribes to events of a specific type
 func (eb *EventBus) Subscribe(eventType string, handler event.EventHandler) event.Subscription {
 	eb.mu.Lock()
 	defer eb.mu.Unlock()
 
 	sub := &subscription{
+		bus:     eb,
 		handler: handler,
 		topic:   eventType,
 	}
@@ -106,11 +227,48 @@ func (eb *EventBus) Subscribe(eventType string, handler event.EventHandler) even
 	return sub
 }
 
+// remove drops a cancelled subscription. The topic's slice is replaced rather
+// than modified, since publishers may be iterating over it.
+func (eb *EventBus) remove(sub *subscription) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	subs := eb.subscribers[sub.topic]
+	kept := make([]*subscription, 0, len(subs))
+	for _, s := range subs {
+		if s != sub {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) == 0 {
+		delete(eb.subscribers, sub.topic)
+		return
+	}
+	eb.subscribers[sub.topic] = kept
+}
+
 // SubscribeAsync subscribes to events of a specific type (same as Subscribe)
 func (eb *EventBus) SubscribeAsync(eventType string, handler event.EventHandler) event.Subscription {
 	return eb.Subscribe(eventType, handler)
 }
 
+// Subscriptions returns the number of active subscriptions per topic. Topics
+// without active subscriptions are left out.
+func (eb *EventBus) Subscriptions() map[string]int {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for topic, subs := range eb.subscribers {
+		for _, sub := range subs {
+			if !sub.cancelled.Load() {
+				counts[topic]++
+			}
+		}
+	}
+	return counts
+}
+
 // WaitAsync waits for all async operations to complete
 func (eb *EventBus) WaitAsync() {
 	eb.wg.Wait()
@@ -0,0 +1,263 @@
+// Package health provides the health checker and its HTTP endpoint.
+package health
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+// Checker defaults
+const (
+	DefaultTimeout  = 2 * time.Second
+	DefaultCacheTTL = time.Second
+)
+
+// CheckerOptions configures a Checker. Zero values select defaults; a negative
+// CacheTTL disables caching.
+type CheckerOptions struct {
+	// Timeout bounds each component check.
+	Timeout time.Duration
+
+	// CacheTTL is how long a component result is reused.
+	CacheTTL time.Duration
+}
+
+// Checker implements health.Checker over the services of a registry, extra
+// services such as the runtime's core services, and standalone checks.
+//
+// Components are checked concurrently, each with its own timeout. Results are
+// cached per component and kind for the cache TTL, so frequent probes do not
+// hammer the services.
+type Checker struct {
+	registry component.Registry
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	services []component.Service
+	checks   map[health.Kind]map[component.ComponentID]health.CheckFunc
+	cache    map[cacheKey]health.ComponentReport
+	mu       sync.Mutex
+}
+
+// cacheKey identifies a cached result.
+type cacheKey struct {
+	id   component.ComponentID
+	kind health.Kind
+}
+
+// target is one component to check.
+type target struct {
+	id    component.ComponentID
+	check health.CheckFunc
+}
+
+// NewChecker creates a checker over the services of the registry, which may be nil.
+func NewChecker(registry component.Registry, options CheckerOptions) *Checker {
+	options = normalizeOptions(options)
+	return &Checker{
+		registry: registry,
+		timeout:  options.Timeout,
+		cacheTTL: options.CacheTTL,
+		checks: map[health.Kind]map[component.ComponentID]health.CheckFunc{
+			health.KindLiveness:  {},
+			health.KindReadiness: {},
+		},
+		cache: make(map[cacheKey]health.ComponentReport),
+	}
+}
+
+// SetOptions changes the timeout and cache TTL and drops cached results.
+func (c *Checker) SetOptions(options CheckerOptions) {
+	options = normalizeOptions(options)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timeout = options.Timeout
+	c.cacheTTL = options.CacheTTL
+	c.cache = make(map[cacheKey]health.ComponentReport)
+}
+
+// normalizeOptions fills in defaults.
+func normalizeOptions(options CheckerOptions) CheckerOptions {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.CacheTTL == 0 {
+		options.CacheTTL = DefaultCacheTTL
+	}
+	return options
+}
+
+// AddService checks a service that is not in the registry.
+func (c *Checker) AddService(service component.Service) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.services = append(c.services, service)
+}
+
+// AddCheck registers a standalone check of the given kind.
+func (c *Checker) AddCheck(id component.ComponentID, kind health.Kind, check health.CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[kind][id] = check
+}
+
+// Liveness checks whether every component is live.
+func (c *Checker) Liveness(ctx context.Context) health.Report {
+	return c.report(health.KindLiveness)
+}
+
+// Readiness checks whether every component is ready.
+func (c *Checker) Readiness(ctx context.Context) health.Report {
+	return c.report(health.KindReadiness)
+}
+
+// report checks every component concurrently and aggregates the results.
+func (c *Checker) report(kind health.Kind) health.Report {
+	targets := c.targets(kind)
+	results := make([]health.ComponentReport, len(targets))
+
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t target) {
+			defer wg.Done()
+			results[i] = c.checkCached(t, kind)
+		}(i, t)
+	}
+	wg.Wait()
+
+	report := health.Report{
+		Kind:       kind,
+		Status:     health.StatusUp,
+		Components: results,
+		CheckedAt:  time.Now(),
+	}
+	for _, r := range results {
+		if r.Status != health.StatusUp {
+			report.Status = health.StatusDown
+		}
+	}
+	return report
+}
+
+// targets returns the components to check, ordered by ID.
+func (c *Checker) targets(kind health.Kind) []target {
+	c.mu.Lock()
+	byID := make(map[component.ComponentID]health.CheckFunc)
+	for _, service := range c.services {
+		byID[service.ID()] = serviceCheck(service, kind)
+	}
+	for id, check := range c.checks[kind] {
+		byID[id] = check
+	}
+	c.mu.Unlock()
+
+	if c.registry != nil {
+		services, _ := c.registry.Find(func(comp component.Component) bool {
+			_, ok := comp.(component.Service)
+			return ok
+		})
+		for _, comp := range services {
+			if _, exists := byID[comp.ID()]; !exists {
+				byID[comp.ID()] = serviceCheck(comp.(component.Service), kind)
+			}
+		}
+	}
+
+	targets := make([]target, 0, len(byID))
+	for id, check := range byID {
+		targets = append(targets, target{id: id, check: check})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].id < targets[j].id })
+	return targets
+}
+
+// checkCached returns a cached result if it is fresh, and checks otherwise.
+func (c *Checker) checkCached(t target, kind health.Kind) health.ComponentReport {
+	key := cacheKey{id: t.id, kind: kind}
+	c.mu.Lock()
+	timeout, cacheTTL := c.timeout, c.cacheTTL
+	cached, exists := c.cache[key]
+	c.mu.Unlock()
+
+	if cacheTTL > 0 && exists && time.Since(cached.CheckedAt) < cacheTTL {
+		cached.Cached = true
+		return cached
+	}
+
+	result := c.check(t, timeout)
+	if cacheTTL > 0 {
+		c.mu.Lock()
+		c.cache[key] = result
+		c.mu.Unlock()
+	}
+	return result
+}
+
+// check runs one check with the timeout.
+func (c *Checker) check(t target, timeout time.Duration) health.ComponentReport {
+	started := time.Now()
+	ctx := infraContext.NewContextWithTimeout(timeout)
+	defer ctx.Cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- failure.New(health.ErrCheckPanicked, "%v", r)
+			}
+		}()
+		done <- t.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = failure.New(health.ErrCheckTimeout, "no result after %s", timeout)
+	}
+
+	result := health.ComponentReport{
+		ID:        t.id,
+		Status:    health.StatusUp,
+		Duration:  time.Since(started),
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		result.Status = health.StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// serviceCheck returns the check of a service for the given kind.
+func serviceCheck(service component.Service, kind health.Kind) health.CheckFunc {
+	if kind == health.KindLiveness {
+		return func(ctx context.Context) error {
+			if service.Status() == component.StatusError {
+				return failure.New(health.ErrServiceUnhealthy, "status '%s'", component.StatusError)
+			}
+			if checker, ok := service.(health.HealthChecker); ok {
+				return checker.HealthCheck(ctx)
+			}
+			return nil
+		}
+	}
+	return func(ctx context.Context) error {
+		if !service.IsRunning() {
+			return failure.New(component.ErrServiceNotRunning, "status '%s'", service.Status())
+		}
+		if checker, ok := service.(health.ReadinessChecker); ok {
+			return checker.ReadinessCheck(ctx)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+// Endpoint paths
+const (
+	HealthPath    = "/health"       // liveness and readiness together
+	LivenessPath  = "/health/live"  // liveness report
+	ReadinessPath = "/health/ready" // readiness report
+)
+
+// NewHandler serves the checker's reports as JSON. A report that is down is
+// served with 503 Service Unavailable.
+func NewHandler(checker health.Checker) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LivenessPath, func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Liveness(infraContext.NewContext())
+		writeReport(w, report.Status, report)
+	})
+	mux.HandleFunc(ReadinessPath, func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Readiness(infraContext.NewContext())
+		writeReport(w, report.Status, report)
+	})
+	mux.HandleFunc(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		liveness := checker.Liveness(infraContext.NewContext())
+		readiness := checker.Readiness(infraContext.NewContext())
+		status := health.StatusUp
+		if liveness.Status != health.StatusUp || readiness.Status != health.StatusUp {
+			status = health.StatusDown
+		}
+		writeReport(w, status, map[string]interface{}{
+			"status":    status,
+			"liveness":  liveness,
+			"readiness": readiness,
+		})
+	})
+	return mux
+}
+
+// writeReport writes body as JSON with the status code matching status.
+func writeReport(w http.ResponseWriter, status health.Status, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if status == health.StatusUp {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Server is a service that serves health reports over HTTP on a local address.
+type Server struct {
+	*infraComponent.HTTPService
+}
+
+// NewServer creates a health endpoint listening on addr, such as "127.0.0.1:8081".
+func NewServer(config component.ComponentConfig, addr string, checker health.Checker) *Server {
+	return &Server{HTTPService: infraComponent.NewHTTPService(config, "health endpoint", addr, NewHandler(checker))}
+}
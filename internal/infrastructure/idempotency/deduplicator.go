@@ -0,0 +1,404 @@
+// Package idempotency provides the idempotent operation execution implementation.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	"github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+)
+
+// keyPrefix prefixes the keys of results in the store.
+const keyPrefix = "idempotency/"
+
+// unstoredErrors are the codes of errors that are not stored, so that the
+// call can be repeated with the same key: input the operation rejected before
+// doing anything, and the cancellation or deadline of the caller's context.
+var unstoredErrors = []error{
+	component.ErrInputValidationFailed,
+	component.ErrInvalidInputType,
+	context.ErrContextCanceled,
+	context.ErrContextDeadlineExceeded,
+}
+
+// Deduplicator implements idempotency.Deduplicator.
+//
+// The policy of an operation is resolved on its first call from its
+// properties and the configuration, unless set with SetPolicy. Results are
+// kept in an in-memory store until SetStore selects another one; a shared,
+// persistent store makes stored results replay across restarts and processes.
+// Concurrent duplicates within the process wait for the first call and share
+// its result. A failed call is stored only if the operation ran, and not if
+// the caller gave up on it. Store I/O happens outside the lock, with the
+// call in flight standing in for a lock on its key. Calls in flight are not recorded in the store, so duplicates
+// that reach several processes at once may each run the operation: the
+// at-most-once guarantee holds within one process.
+type Deduplicator struct {
+	config   config.Configuration
+	eventBus event.EventBus
+	logger   logging.Logger
+
+	store    storage.Store
+	entries  map[component.ComponentID]*entry
+	inflight map[string]*call
+	mu       sync.Mutex
+}
+
+// entry is the policy of one operation.
+type entry struct {
+	operation component.Operation // the component the policy was resolved for
+	policy    idempotency.Policy
+	explicit  bool // set with SetPolicy
+}
+
+// call is a first call in flight.
+type call struct {
+	fingerprint string
+	done        chan struct{}
+	output      component.Output
+	err         error
+}
+
+// NewDeduplicator creates a deduplicator. Policies are read from the
+// configuration, which may be nil like the event bus and the logger.
+func NewDeduplicator(cfg config.Configuration, eventBus event.EventBus, logger logging.Logger) *Deduplicator {
+	if logger == nil {
+		logger = infraLogging.NewNoOpLogger()
+	}
+	return &Deduplicator{
+		config:   cfg,
+		eventBus: eventBus,
+		logger:   logger,
+		store:    memory.NewStore("idempotency", ""),
+		entries:  make(map[component.ComponentID]*entry),
+		inflight: make(map[string]*call),
+	}
+}
+
+// Execute runs an operation at most once per idempotency key. Calls without
+// a key run unchanged unless the policy requires one. A key reused with
+// different input data is rejected with ErrKeyConflict.
+func (d *Deduplicator) Execute(ctx context.Context, operation component.Operation, input component.Input, execute idempotency.Executor) (component.Output, error) {
+	id := operation.ID()
+	policy, err := d.policyFor(operation)
+	if err != nil {
+		return component.Output{}, err
+	}
+	if policy.TTL == 0 {
+		return execute(ctx, operation, input)
+	}
+
+	key := input.Metadata[idempotency.MetadataKey]
+	if key == "" {
+		if policy.Required {
+			return component.Output{}, failure.New(idempotency.ErrKeyRequired, "operation '%s' needs the '%s' metadata", id, idempotency.MetadataKey)
+		}
+		return execute(ctx, operation, input)
+	}
+
+	fingerprint, err := fingerprintOf(input.Data)
+	if err != nil {
+		return component.Output{}, failure.Wrap(err, component.ErrInvalidInputType, "operation '%s'", id)
+	}
+	name := storeKey(id, key)
+
+	d.mu.Lock()
+	if c, exists := d.inflight[name]; exists {
+		d.mu.Unlock()
+		if c.fingerprint != fingerprint {
+			return component.Output{}, conflict(id, key)
+		}
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return component.Output{}, ctx.Err()
+		}
+		d.replayed(id, key)
+		return c.output, c.err
+	}
+
+	c := &call{
+		fingerprint: fingerprint,
+		done:        make(chan struct{}),
+		err:         failure.New(component.ErrOperationFailed, "operation '%s' did not complete", id),
+	}
+	d.inflight[name] = c
+	store := d.store
+	d.mu.Unlock()
+
+	defer func() {
+		d.mu.Lock()
+		delete(d.inflight, name)
+		d.mu.Unlock()
+		close(c.done)
+	}()
+
+	record, found, err := load(store, name)
+	if err != nil {
+		c.err = err
+		return c.output, c.err
+	}
+	if found {
+		c.output, c.err = replay(record, fingerprint, id, key)
+		if !errors.Is(c.err, idempotency.ErrKeyConflict) {
+			d.replayed(id, key)
+		}
+		return c.output, c.err
+	}
+
+	runCtx, executions := infraComponent.CountExecutions(ctx)
+	c.output, c.err = execute(runCtx, operation, input)
+	if c.err == nil || (executions.Load() > 0 && ctx.Err() == nil && storable(c.err)) {
+		now := time.Now()
+		record := idempotency.Record{
+			OperationID: id,
+			Key:         key,
+			Fingerprint: fingerprint,
+			Output:      c.output.Data,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(policy.TTL),
+		}
+		if c.err != nil {
+			record.Output = nil
+			record.Error = c.err.Error()
+			record.ErrorCode = failure.CodeOf(c.err)
+		}
+		if err := save(store, name, record); err != nil {
+			// The operation ran; report its result and leave the key unprotected
+			d.logger.Error("Failed to store idempotent result", "operation_id", id, "key", key, "error", err)
+		}
+	}
+	return c.output, c.err
+}
+
+// SetPolicy sets the policy of an operation.
+func (d *Deduplicator) SetPolicy(operationID component.ComponentID, policy idempotency.Policy) error {
+	if policy.TTL < 0 {
+		return failure.New(idempotency.ErrInvalidPolicy, "negative TTL %s", policy.TTL)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	e := &entry{policy: policy, explicit: true}
+	if previous, exists := d.entries[operationID]; exists {
+		e.operation = previous.operation
+	}
+	d.entries[operationID] = e
+	return nil
+}
+
+// SetStore sets the store results are kept in. Results in the previous store
+// are not carried over.
+func (d *Deduplicator) SetStore(store storage.Store) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.store = store
+}
+
+// Lookup returns the stored result for an idempotency key.
+func (d *Deduplicator) Lookup(operationID component.ComponentID, key string) (idempotency.Record, error) {
+	record, found, err := load(d.currentStore(), storeKey(operationID, key))
+	if err != nil {
+		return idempotency.Record{}, err
+	}
+	if !found {
+		return idempotency.Record{}, failure.New(idempotency.ErrRecordNotFound, "operation '%s' key '%s'", operationID, key)
+	}
+	return record, nil
+}
+
+// Forget deletes the stored result for an idempotency key.
+func (d *Deduplicator) Forget(operationID component.ComponentID, key string) error {
+	if err := d.currentStore().Delete([]byte(storeKey(operationID, key))); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return failure.New(idempotency.ErrRecordNotFound, "operation '%s' key '%s'", operationID, key)
+		}
+		return failure.Wrap(err, idempotency.ErrStoreUnavailable, "")
+	}
+	return nil
+}
+
+// Purge deletes expired results and returns how many were deleted.
+func (d *Deduplicator) Purge() (int, error) {
+	store := d.currentStore()
+	now := time.Now()
+	var expired []string
+	err := store.Iterate(func(key, value []byte) bool {
+		if !strings.HasPrefix(string(key), keyPrefix) {
+			return true
+		}
+		var record idempotency.Record
+		if json.Unmarshal(value, &record) == nil && now.After(record.ExpiresAt) {
+			expired = append(expired, string(key))
+		}
+		return true
+	})
+	if err != nil {
+		return 0, failure.Wrap(err, idempotency.ErrStoreUnavailable, "")
+	}
+
+	var errs []error
+	purged := 0
+	for _, key := range expired {
+		if err := store.Delete([]byte(key)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		purged++
+	}
+	if len(errs) > 0 {
+		return purged, failure.Wrap(errors.Join(errs...), idempotency.ErrStoreUnavailable, "")
+	}
+	return purged, nil
+}
+
+// policyFor returns the policy of an operation, resolving it on the first
+// call or when the component registered under its ID changed.
+func (d *Deduplicator) policyFor(operation component.Operation) (idempotency.Policy, error) {
+	id := operation.ID()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	e, exists := d.entries[id]
+	if exists && (e.explicit || infraComponent.SameComponent(e.operation, operation)) {
+		e.operation = operation
+		return e.policy, nil
+	}
+
+	policy, err := PolicyFromProperties(operation.Metadata())
+	if err == nil && d.config != nil {
+		policy, err = PolicyFromConfig(d.config, id, policy)
+	}
+	if err != nil {
+		return idempotency.Policy{}, fmt.Errorf("operation '%s': %w", id, err)
+	}
+
+	d.entries[id] = &entry{operation: operation, policy: policy}
+	return policy, nil
+}
+
+// currentStore returns the store results are kept in.
+func (d *Deduplicator) currentStore() storage.Store {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.store
+}
+
+// load reads a result, deleting it if it expired.
+func load(store storage.Store, name string) (idempotency.Record, bool, error) {
+	data, err := store.Get([]byte(name))
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return idempotency.Record{}, false, nil
+		}
+		return idempotency.Record{}, false, failure.Wrap(err, idempotency.ErrStoreUnavailable, "")
+	}
+
+	var record idempotency.Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return idempotency.Record{}, false, failure.Wrap(err, idempotency.ErrStoreUnavailable, "result '%s'", name)
+	}
+	if time.Now().After(record.ExpiresAt) {
+		_ = store.Delete([]byte(name))
+		return idempotency.Record{}, false, nil
+	}
+	return record, true, nil
+}
+
+// save writes a result.
+func save(store storage.Store, name string, record idempotency.Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return store.Set([]byte(name), data)
+}
+
+// replay returns the result stored in a record, or ErrKeyConflict if the
+// record was stored for different input.
+func replay(record idempotency.Record, fingerprint string, id component.ComponentID, key string) (component.Output, error) {
+	if record.Fingerprint != fingerprint {
+		return component.Output{}, conflict(id, key)
+	}
+	if record.Error != "" {
+		return component.Output{}, replayedError(record)
+	}
+	return component.Output{Data: record.Output}, nil
+}
+
+// replayed logs and publishes a call answered with an earlier result.
+func (d *Deduplicator) replayed(id component.ComponentID, key string) {
+	d.logger.Info("Replaying idempotent result", "operation_id", id, "key", key)
+	if d.eventBus == nil {
+		return
+	}
+	d.eventBus.Publish(&event.Event{
+		Topic:  idempotency.TopicResultReplayed,
+		Source: string(id),
+		Time:   time.Now(),
+		Payload: map[string]interface{}{
+			"operationId": string(id),
+			"key":         key,
+		},
+	})
+}
+
+// storeKey returns the store key of the result for an idempotency key.
+func storeKey(operationID component.ComponentID, key string) string {
+	return keyPrefix + string(operationID) + "/" + key
+}
+
+// fingerprintOf hashes the JSON form of the input data.
+func fingerprintOf(data any) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// storable reports whether the error of a call that ran the operation is
+// stored.
+func storable(err error) bool {
+	for _, code := range unstoredErrors {
+		if errors.Is(err, code) {
+			return false
+		}
+	}
+	return true
+}
+
+// replayedError rebuilds the error stored in a record.
+func replayedError(record idempotency.Record) error {
+	if record.ErrorCode == "" {
+		return errors.New(record.Error)
+	}
+	message := record.Error
+	if rest, ok := strings.CutPrefix(message, string(record.ErrorCode)); ok {
+		message = strings.TrimPrefix(rest, ": ")
+	}
+	return &failure.Error{Code: record.ErrorCode, Message: message}
+}
+
+func conflict(id component.ComponentID, key string) error {
+	return failure.New(idempotency.ErrKeyConflict, "key '%s' of operation '%s' was used with different input", key, id)
+}
@@ -0,0 +1,117 @@
+package idempotency
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+)
+
+// DefaultTTL is the TTL of operations that enable idempotency without one.
+const DefaultTTL = 24 * time.Hour
+
+// Policies are declared in component properties under PropertyPrefix, for
+// example "idempotency.ttl", and in configuration under ConfigPrefix and the
+// operation ID, for example "idempotency.operations.transfer.ttl".
+// Configuration takes precedence over properties.
+const (
+	PropertyPrefix = "idempotency."
+	ConfigPrefix   = "idempotency.operations."
+)
+
+// Policy keys, relative to PropertyPrefix or to the operation configuration.
+const (
+	KeyEnabled  = "enabled"
+	KeyTTL      = "ttl"
+	KeyRequired = "required"
+)
+
+// PolicyFromProperties reads a policy from component properties.
+func PolicyFromProperties(properties component.Metadata) (idempotency.Policy, error) {
+	return parsePolicy(idempotency.Policy{}, func(key string) (interface{}, bool) {
+		value, ok := properties[PropertyPrefix+key]
+		return value, ok
+	})
+}
+
+// PolicyFromConfig overlays the policy configured for an operation on base.
+func PolicyFromConfig(cfg config.Configuration, operationID component.ComponentID, base idempotency.Policy) (idempotency.Policy, error) {
+	prefix := ConfigPrefix + string(operationID) + "."
+	return parsePolicy(base, func(key string) (interface{}, bool) {
+		if !cfg.Exists(prefix + key) {
+			return nil, false
+		}
+		var value interface{}
+		if err := cfg.GetObject(prefix+key, &value); err != nil {
+			return nil, false
+		}
+		return value, true
+	})
+}
+
+// parsePolicy overlays the values found by lookup on policy. Enabling
+// idempotency without a TTL selects DefaultTTL; disabling it clears the TTL.
+func parsePolicy(policy idempotency.Policy, lookup func(key string) (interface{}, bool)) (idempotency.Policy, error) {
+	if value, ok := lookup(KeyTTL); ok {
+		ttl, err := toDuration(value)
+		if err != nil || ttl < 0 {
+			return policy, invalidValue(KeyTTL, value)
+		}
+		policy.TTL = ttl
+	}
+	if value, ok := lookup(KeyEnabled); ok {
+		enabled, err := toBool(value)
+		if err != nil {
+			return policy, invalidValue(KeyEnabled, value)
+		}
+		if !enabled {
+			policy.TTL = 0
+		} else if policy.TTL == 0 {
+			policy.TTL = DefaultTTL
+		}
+	}
+	if value, ok := lookup(KeyRequired); ok {
+		required, err := toBool(value)
+		if err != nil {
+			return policy, invalidValue(KeyRequired, value)
+		}
+		policy.Required = required
+	}
+	return policy, nil
+}
+
+func invalidValue(key string, value interface{}) error {
+	return failure.New(idempotency.ErrInvalidPolicy, "invalid value '%v' for '%s'", value, key)
+}
+
+// toDuration converts a duration, a duration string such as "24h" or a
+// number of nanoseconds.
+func toDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return time.ParseDuration(v)
+	case int:
+		return time.Duration(v), nil
+	case int64:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	}
+	return 0, fmt.Errorf("not a duration: %T", value)
+}
+
+func toBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, fmt.Errorf("not a boolean: %T", value)
+}
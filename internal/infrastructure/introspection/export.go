@@ -0,0 +1,232 @@
+package introspection
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+)
+
+// Format is a topology export format.
+type Format string
+
+// Export formats
+const (
+	FormatJSON    Format = "json"
+	FormatDOT     Format = "dot"     // Graphviz
+	FormatMermaid Format = "mermaid" // Mermaid flowchart
+)
+
+// Edge labels of the topology graph besides the dependency graph's own kinds
+const (
+	edgeSubscribes = "subscribes"
+	edgeHosts      = "hosts"
+)
+
+var (
+	dotEscaper     = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "\n", "<br/>")
+)
+
+// nodeKind selects the shape of a node.
+type nodeKind string
+
+const (
+	nodePlugin    nodeKind = "plugin"
+	nodeService   nodeKind = "service"
+	nodeOperation nodeKind = "operation"
+	nodeComponent nodeKind = "component"
+	nodeTopic     nodeKind = "topic"
+	nodeStore     nodeKind = "store"
+)
+
+// node is a vertex of the topology graph.
+type node struct {
+	key   string
+	label string
+	kind  nodeKind
+}
+
+// link is an edge of the topology graph.
+type link struct {
+	from, to string
+	label    string
+	dashed   bool
+}
+
+// Write writes the topology in the format.
+func Write(w io.Writer, topology introspection.Topology, format Format) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(topology)
+	case FormatDOT:
+		return WriteDOT(w, topology)
+	case FormatMermaid:
+		return WriteMermaid(w, topology)
+	default:
+		return failure.New(introspection.ErrUnknownFormat, "'%s'", format)
+	}
+}
+
+// WriteDOT writes the topology as a Graphviz digraph: plugins, components,
+// topics and stores linked by dependencies, ownership, subscriptions and the
+// multi-stores hosting the stores. Optional dependencies are dashed.
+func WriteDOT(w io.Writer, topology introspection.Topology) error {
+	nodes, links := graph(topology)
+
+	b := bufio.NewWriter(w)
+	b.WriteString("digraph topology {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, n := range nodes {
+		b.WriteString("  " + dotID(n.key) + " [label=" + dotID(n.label) + ", shape=" + dotShape(n.kind) + "];\n")
+	}
+	for _, l := range links {
+		b.WriteString("  " + dotID(l.from) + " -> " + dotID(l.to) + " [label=" + dotID(l.label))
+		if l.dashed {
+			b.WriteString(", style=dashed")
+		}
+		b.WriteString("];\n")
+	}
+	b.WriteString("}\n")
+	return b.Flush()
+}
+
+// WriteMermaid writes the topology as a Mermaid flowchart with the nodes and
+// edges of WriteDOT.
+func WriteMermaid(w io.Writer, topology introspection.Topology) error {
+	nodes, links := graph(topology)
+
+	ids := make(map[string]string, len(nodes))
+	b := bufio.NewWriter(w)
+	b.WriteString("flowchart LR\n")
+	for i, n := range nodes {
+		id := "n" + strconv.Itoa(i)
+		ids[n.key] = id
+		left, right := mermaidShape(n.kind)
+		b.WriteString("  " + id + left + `"` + mermaidEscaper.Replace(n.label) + `"` + right + "\n")
+	}
+	for _, l := range links {
+		arrow := " -->|"
+		if l.dashed {
+			arrow = " -.->|"
+		}
+		b.WriteString("  " + ids[l.from] + arrow + l.label + "| " + ids[l.to] + "\n")
+	}
+	return b.Flush()
+}
+
+// graph lays out the topology as nodes and links. Components and plugins
+// share the component ID space, so a plugin that is also a registered
+// component is a single node. Edges to components missing from the topology,
+// such as absent optional dependencies, add a node for them.
+func graph(topology introspection.Topology) ([]node, []link) {
+	var nodes []node
+	index := make(map[string]int)
+	add := func(n node) {
+		if i, ok := index[n.key]; ok {
+			if n.kind == nodePlugin {
+				nodes[i] = n
+			}
+			return
+		}
+		index[n.key] = len(nodes)
+		nodes = append(nodes, n)
+	}
+
+	for _, p := range topology.Plugins {
+		add(node{key: string(p.ID), label: versioned(p.ID, p.Version), kind: nodePlugin})
+	}
+	for _, c := range topology.Components {
+		add(node{key: string(c.ID), label: versioned(c.ID, c.Version), kind: componentKind(c.Type)})
+	}
+
+	var links []link
+	for _, e := range topology.Dependencies {
+		add(node{key: string(e.To), label: string(e.To), kind: nodeComponent})
+		links = append(links, link{from: string(e.From), to: string(e.To), label: string(e.Kind), dashed: e.Optional})
+	}
+	for _, t := range topology.Topics {
+		key := "topic:" + t.Topic
+		add(node{key: key, label: t.Topic, kind: nodeTopic})
+		for _, id := range t.Plugins {
+			links = append(links, link{from: string(id), to: key, label: edgeSubscribes})
+		}
+	}
+	for _, s := range topology.Stores {
+		key := "store:" + string(s.MultiStore) + "/" + s.Name
+		label := s.Name
+		if s.Engine != "" {
+			label += " (" + s.Engine + ")"
+		}
+		add(node{key: key, label: label, kind: nodeStore})
+		add(node{key: string(s.MultiStore), label: string(s.MultiStore), kind: nodeService})
+		links = append(links, link{from: string(s.MultiStore), to: key, label: edgeHosts})
+	}
+	return nodes, links
+}
+
+// versioned labels a component with its version, if any.
+func versioned(id component.ComponentID, version string) string {
+	if version == "" {
+		return string(id)
+	}
+	return string(id) + "\n" + version
+}
+
+// componentKind returns the node kind of a component type.
+func componentKind(typ component.ComponentType) nodeKind {
+	switch typ {
+	case component.TypeService:
+		return nodeService
+	case component.TypeOperation:
+		return nodeOperation
+	default:
+		return nodeComponent
+	}
+}
+
+// dotID quotes s as a DOT identifier.
+func dotID(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+// dotShape returns the Graphviz shape of a node kind.
+func dotShape(kind nodeKind) string {
+	switch kind {
+	case nodePlugin:
+		return "box"
+	case nodeOperation:
+		return "hexagon"
+	case nodeTopic:
+		return "note"
+	case nodeStore:
+		return "cylinder"
+	default:
+		return "ellipse"
+	}
+}
+
+// mermaidShape returns the brackets of a Mermaid node of the kind.
+func mermaidShape(kind nodeKind) (string, string) {
+	switch kind {
+	case nodePlugin:
+		return "[", "]"
+	case nodeService:
+		return "([", "])"
+	case nodeOperation:
+		return "{{", "}}"
+	case nodeTopic:
+		return ">", "]"
+	case nodeStore:
+		return "[(", ")]"
+	default:
+		return "(", ")"
+	}
+}
@@ -0,0 +1,93 @@
+package introspection
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+)
+
+// Endpoint paths
+const (
+	ComponentsPath    = "/admin/components"    // component descriptions
+	ServicesPath      = "/admin/services"      // service statuses and uptimes
+	PluginsPath       = "/admin/plugins"       // plugin descriptions
+	DependenciesPath  = "/admin/dependencies"  // dependency graph
+	SubscriptionsPath = "/admin/subscriptions" // subscriptions per topic
+	StoresPath        = "/admin/stores"        // stores and engine capabilities
+	BreakersPath      = "/admin/breakers"      // circuit breaker states
+	TopologyPath      = "/admin/topology"      // everything; ?format=json|dot|mermaid
+)
+
+// contentTypes are the media types of the export formats.
+var contentTypes = map[Format]string{
+	FormatJSON:    "application/json",
+	FormatDOT:     "text/vnd.graphviz; charset=utf-8",
+	FormatMermaid: "text/plain; charset=utf-8",
+}
+
+// NewHandler serves the reports of the inspector as JSON, and the topology in
+// the format selected by the format query parameter. An unknown format is
+// answered with 400 Bad Request.
+func NewHandler(inspector introspection.Inspector) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ComponentsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, orEmpty(inspector.Components()))
+	})
+	mux.HandleFunc(ServicesPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, orEmpty(inspector.Services()))
+	})
+	mux.HandleFunc(PluginsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, orEmpty(inspector.Plugins()))
+	})
+	mux.HandleFunc(DependenciesPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, orEmpty(inspector.Dependencies()))
+	})
+	mux.HandleFunc(SubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, orEmpty(inspector.Subscriptions()))
+	})
+	mux.HandleFunc(StoresPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, orEmpty(inspector.Stores()))
+	})
+	mux.HandleFunc(BreakersPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, orEmpty(inspector.Breakers()))
+	})
+	mux.HandleFunc(TopologyPath, func(w http.ResponseWriter, r *http.Request) {
+		format := Format(r.URL.Query().Get("format"))
+		if format == "" {
+			format = FormatJSON
+		}
+		contentType, ok := contentTypes[format]
+		if !ok {
+			err := failure.New(introspection.ErrUnknownFormat, "'%s'", format)
+			writeJSON(w, http.StatusBadRequest, map[string]string{
+				"code":  string(failure.CodeOf(err)),
+				"error": err.Error(),
+			})
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "no-store")
+		_ = Write(w, inspector.Topology(), format)
+	})
+	return mux
+}
+
+// writeJSON writes body as JSON with the status code.
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", contentTypes[FormatJSON])
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// orEmpty returns an empty slice for nil, so that lists encode as [] rather
+// than null.
+func orEmpty[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,390 @@
+// Package introspection provides the system inspector, topology exports and
+// the admin endpoint serving them.
+package introspection
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+)
+
+// owners is implemented by plugin managers that track what their plugins
+// register and subscribe to.
+type owners interface {
+	OwnedComponents(pluginID component.ComponentID) []component.ComponentID
+	SubscribedTopics(pluginID component.ComponentID) []string
+}
+
+// dependencyLister is implemented by plugin managers that know dependencies
+// besides those the plugins declare, such as those of plugin manifests.
+type dependencyLister interface {
+	PluginDependencies(pluginID component.ComponentID) []plugin.Dependency
+}
+
+// subscriptionCounter is implemented by event buses that count their
+// subscriptions.
+type subscriptionCounter interface {
+	Subscriptions() map[string]int
+}
+
+// startTimer is implemented by services that record when they started, such
+// as those embedding the infrastructure BaseService.
+type startTimer interface {
+	StartedAt() time.Time
+}
+
+// storeEngines is implemented by multi-stores that know the engine behind
+// each store.
+type storeEngines interface {
+	StoreEngine(name string) (storage.Engine, error)
+}
+
+// Inspector implements introspection.Inspector over a registry, a plugin
+// manager, an event bus, a resilience guard and extra services such as the
+// runtime's core services. Each of them may be nil.
+//
+// Plugin ownership, subscription counts, uptimes and store engines are
+// reported when the plugin manager, event bus, services and multi-stores
+// expose them, as the infrastructure implementations do.
+type Inspector struct {
+	registry      component.Registry
+	pluginManager plugin.PluginManager
+	eventBus      event.EventBus
+	now           func() time.Time
+
+	services []component.Service
+	guard    resilience.Guard
+	mu       sync.RWMutex
+}
+
+// NewInspector creates an inspector over the registry, the plugin manager and
+// the event bus.
+func NewInspector(registry component.Registry, pluginManager plugin.PluginManager, eventBus event.EventBus) *Inspector {
+	return &Inspector{
+		registry:      registry,
+		pluginManager: pluginManager,
+		eventBus:      eventBus,
+		now:           time.Now,
+	}
+}
+
+// AddService reports a service that is not in the registry, such as a core
+// service of the runtime.
+func (i *Inspector) AddService(service component.Service) {
+	if service == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.services = append(i.services, service)
+}
+
+// SetResilience reports the circuit breakers of the guard.
+func (i *Inspector) SetResilience(guard resilience.Guard) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.guard = guard
+}
+
+// Components describes the added services and the components of the registry.
+func (i *Inspector) Components() []introspection.ComponentInfo {
+	ownedBy := i.ownership()
+
+	var infos []introspection.ComponentInfo
+	for _, comp := range i.components() {
+		infos = append(infos, introspection.ComponentInfo{
+			ID:          comp.ID(),
+			Name:        comp.Name(),
+			Type:        comp.Type(),
+			Version:     comp.Version(),
+			Description: comp.Description(),
+			Metadata:    comp.Metadata(),
+			Plugin:      ownedBy[comp.ID()],
+		})
+	}
+	return infos
+}
+
+// Services describes the status and uptime of the added services and the
+// services of the registry.
+func (i *Inspector) Services() []introspection.ServiceInfo {
+	ownedBy := i.ownership()
+	now := i.now()
+
+	var infos []introspection.ServiceInfo
+	for _, comp := range i.components() {
+		service, ok := comp.(component.Service)
+		if !ok {
+			continue
+		}
+		info := introspection.ServiceInfo{
+			ID:     service.ID(),
+			Status: service.Status(),
+			Plugin: ownedBy[service.ID()],
+		}
+		if timer, ok := service.(startTimer); ok && service.IsRunning() {
+			if started := timer.StartedAt(); !started.IsZero() {
+				info.StartedAt = started
+				info.Uptime = now.Sub(started)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Plugins describes the plugins of the plugin manager.
+func (i *Inspector) Plugins() []introspection.PluginInfo {
+	if i.pluginManager == nil {
+		return nil
+	}
+	tracker, _ := i.pluginManager.(owners)
+	lister, _ := i.pluginManager.(dependencyLister)
+
+	var infos []introspection.PluginInfo
+	for _, id := range sortedIDs(i.pluginManager.ListPlugins()) {
+		p, err := i.pluginManager.GetPlugin(id)
+		if err != nil {
+			continue // unloaded meanwhile
+		}
+		info := introspection.PluginInfo{
+			ID:      id,
+			Name:    p.Name(),
+			Version: p.Version(),
+			Author:  p.Author(),
+			Type:    p.PluginType(),
+			Status:  p.Status(),
+		}
+		if lister != nil {
+			info.Dependencies = lister.PluginDependencies(id)
+		} else if dependent, ok := p.(plugin.DependentPlugin); ok {
+			info.Dependencies = dependent.Dependencies()
+		}
+		if tracker != nil {
+			info.Components = tracker.OwnedComponents(id)
+			info.Topics = uniqueTopics(tracker.SubscribedTopics(id))
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Dependencies returns the dependencies between plugins and the ownership of
+// components by plugins, sorted by source, kind and target.
+func (i *Inspector) Dependencies() []introspection.Edge {
+	return dependencies(i.Plugins())
+}
+
+// dependencies derives the dependency graph from plugin descriptions.
+func dependencies(plugins []introspection.PluginInfo) []introspection.Edge {
+	var edges []introspection.Edge
+	for _, p := range plugins {
+		for _, dep := range p.Dependencies {
+			edges = append(edges, introspection.Edge{
+				From:         p.ID,
+				To:           dep.ID,
+				Kind:         introspection.EdgeDependsOn,
+				VersionRange: dep.VersionRange,
+				Optional:     dep.Optional,
+			})
+		}
+		for _, id := range p.Components {
+			edges = append(edges, introspection.Edge{From: p.ID, To: id, Kind: introspection.EdgeOwns})
+		}
+	}
+	sort.SliceStable(edges, func(a, b int) bool {
+		if edges[a].From != edges[b].From {
+			return edges[a].From < edges[b].From
+		}
+		if edges[a].Kind != edges[b].Kind {
+			return edges[a].Kind < edges[b].Kind
+		}
+		return edges[a].To < edges[b].To
+	})
+	return edges
+}
+
+// Subscriptions describes the subscriptions of the event bus per topic and
+// the plugins among the subscribers.
+func (i *Inspector) Subscriptions() []introspection.TopicInfo {
+	return subscriptions(i.eventBus, i.Plugins())
+}
+
+// subscriptions combines the counts of the event bus with the topics of the
+// plugins.
+func subscriptions(bus event.EventBus, plugins []introspection.PluginInfo) []introspection.TopicInfo {
+	subscribers := make(map[string][]component.ComponentID)
+	for _, p := range plugins {
+		for _, topic := range p.Topics {
+			subscribers[topic] = append(subscribers[topic], p.ID)
+		}
+	}
+
+	// Without counts from the bus, only the subscriptions of plugins are known
+	counts := make(map[string]int)
+	if counter, ok := bus.(subscriptionCounter); ok {
+		counts = counter.Subscriptions()
+	} else {
+		for topic, ids := range subscribers {
+			counts[topic] = len(ids)
+		}
+	}
+
+	var infos []introspection.TopicInfo
+	for topic, count := range counts {
+		infos = append(infos, introspection.TopicInfo{
+			Topic:       topic,
+			Subscribers: count,
+			Plugins:     subscribers[topic],
+		})
+	}
+	sort.Slice(infos, func(a, b int) bool { return infos[a].Topic < infos[b].Topic })
+	return infos
+}
+
+// Stores describes the stores of the multi-stores in the registry and in the
+// added services, sorted by multi-store and store name.
+func (i *Inspector) Stores() []introspection.StoreInfo {
+	var infos []introspection.StoreInfo
+	for _, comp := range i.components() {
+		multiStore, ok := comp.(storage.MultiStore)
+		if !ok {
+			continue
+		}
+		engines, _ := multiStore.(storeEngines)
+
+		names := multiStore.ListStores()
+		sort.Strings(names)
+		for _, name := range names {
+			info := introspection.StoreInfo{Name: name, MultiStore: comp.ID()}
+			if engines != nil {
+				if engine, err := engines.StoreEngine(name); err == nil && engine != nil {
+					info.Engine = engine.Name()
+					info.Capabilities = engine.Capabilities()
+				}
+			}
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// Breakers describes the circuit breakers of the guard, sorted by operation ID.
+func (i *Inspector) Breakers() []introspection.BreakerInfo {
+	i.mu.RLock()
+	guard := i.guard
+	i.mu.RUnlock()
+	if guard == nil {
+		return nil
+	}
+
+	var infos []introspection.BreakerInfo
+	for _, state := range guard.States() {
+		info := introspection.BreakerInfo{
+			OperationID:         state.OperationID,
+			State:               state.Breaker,
+			ConsecutiveFailures: state.ConsecutiveFailures,
+			OpenedAt:            state.OpenedAt,
+			Calls:               state.Calls,
+			Failures:            state.Failures,
+			Retries:             state.Retries,
+			Timeouts:            state.Timeouts,
+			Rejected:            state.Rejected,
+		}
+		if state.LastError != nil {
+			info.LastError = state.LastError.Error()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Topology returns a snapshot of the whole system. Plugins are queried once,
+// so the dependency graph and the topics agree with the plugin descriptions.
+func (i *Inspector) Topology() introspection.Topology {
+	plugins := i.Plugins()
+	return introspection.Topology{
+		Components:   i.Components(),
+		Services:     i.Services(),
+		Plugins:      plugins,
+		Dependencies: dependencies(plugins),
+		Topics:       subscriptions(i.eventBus, plugins),
+		Stores:       i.Stores(),
+		Breakers:     i.Breakers(),
+		TakenAt:      i.now(),
+	}
+}
+
+// components returns the added services and the components of the registry,
+// sorted by ID. Registered components shadow added services with the same ID.
+func (i *Inspector) components() []component.Component {
+	byID := make(map[component.ComponentID]component.Component)
+
+	i.mu.RLock()
+	for _, service := range i.services {
+		byID[service.ID()] = service
+	}
+	i.mu.RUnlock()
+
+	if i.registry != nil {
+		for _, id := range i.registry.List() {
+			if comp, err := i.registry.Get(id); err == nil {
+				byID[id] = comp
+			}
+		}
+	}
+
+	ids := make([]component.ComponentID, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	comps := make([]component.Component, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		comps = append(comps, byID[id])
+	}
+	return comps
+}
+
+// ownership maps the components registered by plugins to their plugin.
+func (i *Inspector) ownership() map[component.ComponentID]component.ComponentID {
+	ownedBy := make(map[component.ComponentID]component.ComponentID)
+	if i.pluginManager == nil {
+		return ownedBy
+	}
+	tracker, ok := i.pluginManager.(owners)
+	if !ok {
+		return ownedBy
+	}
+	for _, pluginID := range i.pluginManager.ListPlugins() {
+		for _, id := range tracker.OwnedComponents(pluginID) {
+			ownedBy[id] = pluginID
+		}
+	}
+	return ownedBy
+}
+
+// sortedIDs sorts ids in place and returns them.
+func sortedIDs(ids []component.ComponentID) []component.ComponentID {
+	sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
+	return ids
+}
+
+// uniqueTopics returns the distinct topics in first-seen order.
+func uniqueTopics(topics []string) []string {
+	seen := make(map[string]bool, len(topics))
+	var unique []string
+	for _, topic := range topics {
+		if !seen[topic] {
+			seen[topic] = true
+			unique = append(unique, topic)
+		}
+	}
+	return unique
+}
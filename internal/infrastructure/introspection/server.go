@@ -0,0 +1,19 @@
+package introspection
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+)
+
+// Server is a service that serves the admin API over HTTP on a local address.
+// The API exposes the internals of the system and has no authentication, so
+// it should not listen on a public interface.
+type Server struct {
+	*infraComponent.HTTPService
+}
+
+// NewServer creates an admin endpoint listening on addr, such as "127.0.0.1:8082".
+func NewServer(config component.ComponentConfig, addr string, inspector introspection.Inspector) *Server {
+	return &Server{HTTPService: infraComponent.NewHTTPService(config, "admin endpoint", addr, NewHandler(inspector))}
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/jobs"
+)
+
+// Context keys set by the manager on the context of a running job.
+type jobIDKey struct{}
+type progressKey struct{}
+
+// JobID returns the ID of the job an operation runs in, if any.
+func JobID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(jobIDKey{}).(string)
+	return id, ok
+}
+
+// ReportProgress records the progress of the job an operation runs in. It
+// reports false when the operation does not run in a job.
+func ReportProgress(ctx context.Context, percent float64, message string) bool {
+	report, ok := ctx.Value(progressKey{}).(func(jobs.Progress))
+	if !ok {
+		return false
+	}
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	report(jobs.Progress{Percent: percent, Message: message})
+	return true
+}
@@ -0,0 +1,686 @@
+// Package jobs provides the asynchronous operation job manager implementation.
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/jobs"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	domainRuntime "github.com/fintechain/skeleton/internal/domain/runtime"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+)
+
+// Option defaults
+const (
+	DefaultStore     = "jobs"
+	DefaultEngine    = "memory"
+	DefaultWorkers   = 4
+	DefaultRetention = 24 * time.Hour
+)
+
+// keyPrefix prefixes the keys of jobs in the store.
+const keyPrefix = "job/"
+
+// watchBuffer is the number of updates buffered for each watcher.
+const watchBuffer = 16
+
+// Options configures a job manager. Zero values select defaults.
+type Options struct {
+	// Store is the name of the store jobs are persisted in. It is created
+	// with Engine if it does not exist. Jobs survive a restart only if the
+	// engine persists data. Defaults to "jobs" and "memory".
+	Store  string
+	Engine string
+
+	// Workers is the number of jobs that run at once. Defaults to 4.
+	Workers int
+
+	// Retention is how long finished jobs are kept. Defaults to 24h; a
+	// negative value keeps them forever.
+	Retention time.Duration
+
+	// Resume restarts jobs that were running when the manager stopped.
+	// Otherwise they are marked failed with ErrJobInterrupted. Pending jobs
+	// are always resumed.
+	Resume bool
+}
+
+// Manager implements jobs.Manager.
+//
+// Jobs run their operation through System.ExecuteOperation, so operation
+// schemas and resilience policies apply. Running operations find the job ID
+// and report progress through the context (see JobID and ReportProgress).
+// Every change is persisted before it is published on the event bus.
+//
+// When the runtime publishes its stop phase, or the manager stops, running
+// jobs are cancelled and stay running in the store, to be resumed or marked
+// failed on the next start.
+type Manager struct {
+	*infraComponent.BaseService
+	multiStore storage.MultiStore
+	options    Options
+
+	system   component.System
+	eventBus event.EventBus
+	logger   logging.Logger
+
+	stopSub  event.Subscription
+	store    storage.Store
+	jobs     map[string]*record
+	slots    chan struct{}
+	stop     chan struct{} // closed when the manager stops
+	active   bool
+	stopping bool
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+}
+
+// record is the in-memory state of a job.
+type record struct {
+	job      jobs.Job
+	cancel   func() // cancels the running operation
+	watchers []*watcher
+}
+
+// watcher receives the updates of a job.
+type watcher struct {
+	updates chan jobs.Job
+	closed  bool
+}
+
+// eventBusProvider and loggerProvider are implemented by runtimes.
+type eventBusProvider interface {
+	EventBus() event.EventBusService
+}
+
+type loggerProvider interface {
+	Logger() logging.Logger
+}
+
+// NewManager creates a job manager that persists jobs in a store of the
+// multi-store. The manager executes operations on the system it is
+// initialized with, and uses its event bus and logger if the system is a
+// runtime environment.
+func NewManager(config component.ComponentConfig, multiStore storage.MultiStore, options Options) *Manager {
+	if options.Store == "" {
+		options.Store = DefaultStore
+	}
+	if options.Engine == "" {
+		options.Engine = DefaultEngine
+	}
+	if options.Workers <= 0 {
+		options.Workers = DefaultWorkers
+	}
+	if options.Retention == 0 {
+		options.Retention = DefaultRetention
+	}
+	return &Manager{
+		BaseService: infraComponent.NewBaseService(config),
+		multiStore:  multiStore,
+		options:     options,
+		logger:      infraLogging.NewNoOpLogger(),
+		jobs:        make(map[string]*record),
+	}
+}
+
+// Initialize records the system that executes operations. If the system
+// provides an event bus, running jobs are interrupted when the runtime
+// publishes its stop phase, so that they do not hold up the drain.
+func (m *Manager) Initialize(ctx context.Context, system component.System) error {
+	if err := m.BaseService.Initialize(ctx, system); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.system = system
+	if provider, ok := system.(eventBusProvider); ok {
+		m.eventBus = provider.EventBus()
+		if m.stopSub != nil {
+			m.stopSub.Cancel()
+		}
+		m.stopSub = m.eventBus.Subscribe(domainRuntime.TopicRuntimeStop, func(*event.Event) {
+			m.interrupt()
+		})
+	}
+	if provider, ok := system.(loggerProvider); ok {
+		m.logger = provider.Logger()
+	}
+	return nil
+}
+
+// Start opens the store, loads the persisted jobs and resumes the jobs that
+// did not finish.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.active {
+		return nil
+	}
+	if m.system == nil {
+		return failure.New(component.ErrComponentNotInitialized, "job manager '%s' has no system", m.ID())
+	}
+
+	store, err := m.openStore()
+	if err != nil {
+		return err
+	}
+	loaded, err := m.loadJobs(store)
+	if err != nil {
+		return err
+	}
+
+	m.store = store
+	m.jobs = loaded
+	m.slots = make(chan struct{}, m.options.Workers)
+	m.stop = make(chan struct{})
+	m.active = true
+	m.stopping = false
+	m.prune()
+
+	var interrupted []jobs.Job
+	for _, rec := range m.sorted() {
+		switch rec.job.Status {
+		case jobs.StatusRunning:
+			if !m.options.Resume {
+				rec.job.Status = jobs.StatusFailed
+				rec.job.Error = failure.New(jobs.ErrJobInterrupted, "job '%s' was running when the manager stopped", rec.job.ID).Error()
+				rec.job.FinishedAt = time.Now()
+				m.save(rec)
+				interrupted = append(interrupted, rec.job)
+				continue
+			}
+			rec.job.Status = jobs.StatusPending
+			m.save(rec)
+			m.dispatch(rec.job.ID)
+		case jobs.StatusPending:
+			m.dispatch(rec.job.ID)
+		}
+	}
+
+	if err := m.BaseService.Start(ctx); err != nil {
+		return err
+	}
+
+	// Published without the lock; handlers may query the manager
+	go func() {
+		for _, job := range interrupted {
+			m.publish(jobs.TopicJobFailed, job)
+		}
+	}()
+	return nil
+}
+
+// Stop cancels running jobs, which stay running in the store, and waits for
+// their operations to return or ctx to be done.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.active {
+		m.mu.Unlock()
+		return nil
+	}
+	m.active = false
+	m.interruptLocked()
+	for _, rec := range m.jobs {
+		for _, w := range rec.watchers {
+			w.close()
+		}
+		rec.watchers = nil
+	}
+	close(m.stop)
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return failure.Wrap(ctx.Err(), component.ErrServiceStopFailed, "jobs still running")
+	}
+	return m.BaseService.Stop(ctx)
+}
+
+// Dispose cancels the subscription to the runtime stop phase.
+func (m *Manager) Dispose() error {
+	m.mu.Lock()
+	if m.stopSub != nil {
+		m.stopSub.Cancel()
+		m.stopSub = nil
+	}
+	m.mu.Unlock()
+	return m.BaseService.Dispose()
+}
+
+// Submit persists a pending job for the operation and queues it.
+func (m *Manager) Submit(ctx context.Context, operationID component.ComponentID, input component.Input) (string, error) {
+	m.mu.Lock()
+	if !m.active || m.stopping {
+		m.mu.Unlock()
+		return "", failure.New(component.ErrServiceNotRunning, "job manager '%s'", m.ID())
+	}
+	if !m.system.Registry().Has(operationID) {
+		m.mu.Unlock()
+		return "", failure.New(component.ErrOperationNotFound, "'%s'", operationID)
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		m.mu.Unlock()
+		return "", err
+	}
+	rec := &record{job: jobs.Job{
+		ID:          id,
+		OperationID: operationID,
+		Input:       input,
+		Status:      jobs.StatusPending,
+		CreatedAt:   time.Now(),
+	}}
+	if err := m.save(rec); err != nil {
+		m.mu.Unlock()
+		return "", err
+	}
+	m.jobs[id] = rec
+	m.dispatch(id)
+	job := rec.job
+	m.mu.Unlock()
+
+	m.logger.Info("Job submitted", "job_id", id, "operation_id", operationID)
+	m.publish(jobs.TopicJobSubmitted, job)
+	return id, nil
+}
+
+// Get returns the current state of a job.
+func (m *Manager) Get(jobID string) (jobs.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, exists := m.jobs[jobID]
+	if !exists {
+		return jobs.Job{}, failure.New(jobs.ErrJobNotFound, "'%s'", jobID)
+	}
+	return rec.job, nil
+}
+
+// List returns all known jobs, oldest first.
+func (m *Manager) List() []jobs.Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	records := m.sorted()
+	result := make([]jobs.Job, 0, len(records))
+	for _, rec := range records {
+		result = append(result, rec.job)
+	}
+	return result
+}
+
+// Watch streams the state of a job on every change.
+func (m *Manager) Watch(jobID string) (<-chan jobs.Job, func(), error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec, exists := m.jobs[jobID]
+	if !exists {
+		return nil, nil, failure.New(jobs.ErrJobNotFound, "'%s'", jobID)
+	}
+
+	w := &watcher{updates: make(chan jobs.Job, watchBuffer)}
+	w.updates <- rec.job
+	if rec.job.Status.Finished() || !m.active {
+		w.close()
+		return w.updates, func() {}, nil
+	}
+	rec.watchers = append(rec.watchers, w)
+
+	stop := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i, candidate := range rec.watchers {
+			if candidate == w {
+				rec.watchers = append(rec.watchers[:i], rec.watchers[i+1:]...)
+				break
+			}
+		}
+		w.close()
+	}
+	return w.updates, stop, nil
+}
+
+// Cancel cancels a pending or running job.
+func (m *Manager) Cancel(jobID string) error {
+	m.mu.Lock()
+	rec, exists := m.jobs[jobID]
+	if !exists {
+		m.mu.Unlock()
+		return failure.New(jobs.ErrJobNotFound, "'%s'", jobID)
+	}
+	if rec.job.Status.Finished() {
+		m.mu.Unlock()
+		return failure.New(jobs.ErrJobFinished, "job '%s' is %s", jobID, rec.job.Status)
+	}
+
+	rec.job.Status = jobs.StatusCancelled
+	rec.job.FinishedAt = time.Now()
+	if rec.cancel != nil {
+		rec.cancel()
+	}
+	err := m.save(rec)
+	m.notify(rec)
+	job := rec.job
+	m.mu.Unlock()
+
+	m.logger.Info("Job cancelled", "job_id", jobID)
+	m.publish(jobs.TopicJobCancelled, job)
+	return err
+}
+
+// Result returns the output of a succeeded job.
+func (m *Manager) Result(jobID string) (component.Output, error) {
+	job, err := m.Get(jobID)
+	if err != nil {
+		return component.Output{}, err
+	}
+
+	switch job.Status {
+	case jobs.StatusSucceeded:
+		return component.Output{Data: job.Result}, nil
+	case jobs.StatusFailed:
+		return component.Output{}, failure.New(jobs.ErrJobFailed, "job '%s': %s", jobID, job.Error)
+	case jobs.StatusCancelled:
+		return component.Output{}, failure.New(jobs.ErrJobCancelled, "job '%s'", jobID)
+	default:
+		return component.Output{}, failure.New(jobs.ErrJobNotFinished, "job '%s' is %s", jobID, job.Status)
+	}
+}
+
+// dispatch runs a pending job once a worker is free. Called with the lock held.
+func (m *Manager) dispatch(jobID string) {
+	m.wg.Add(1)
+	go m.run(jobID, m.stop)
+}
+
+// run waits for a worker slot and executes a job.
+func (m *Manager) run(jobID string, stop chan struct{}) {
+	defer m.wg.Done()
+
+	select {
+	case m.slots <- struct{}{}:
+		defer func() { <-m.slots }()
+	case <-stop:
+		return
+	}
+
+	m.mu.Lock()
+	rec, exists := m.jobs[jobID]
+	if !exists || rec.job.Status != jobs.StatusPending || m.stopping {
+		m.mu.Unlock()
+		return
+	}
+
+	base := infraContext.NewContext().
+		WithValue(jobIDKey{}, jobID).
+		WithValue(progressKey{}, func(progress jobs.Progress) { m.progress(rec, progress) })
+	ctx := infraContext.WithTimeout(base, 0)
+	defer ctx.Cancel()
+
+	rec.cancel = ctx.Cancel
+	rec.job.Status = jobs.StatusRunning
+	rec.job.Attempts++
+	rec.job.StartedAt = time.Now()
+	m.save(rec)
+	m.notify(rec)
+	job := rec.job
+	m.mu.Unlock()
+
+	m.logger.Info("Job started", "job_id", jobID, "operation_id", job.OperationID, "attempt", job.Attempts)
+	m.publish(jobs.TopicJobStarted, job)
+
+	output, err := m.system.ExecuteOperation(ctx, job.OperationID, job.Input)
+
+	m.mu.Lock()
+	rec.cancel = nil
+	if m.stopping || rec.job.Status != jobs.StatusRunning {
+		// Interrupted jobs stay running in the store; cancelled jobs are recorded by Cancel
+		m.mu.Unlock()
+		return
+	}
+
+	topic := jobs.TopicJobSucceeded
+	rec.job.FinishedAt = time.Now()
+	if err != nil {
+		topic = jobs.TopicJobFailed
+		rec.job.Status = jobs.StatusFailed
+		rec.job.Error = err.Error()
+	} else {
+		rec.job.Status = jobs.StatusSucceeded
+		rec.job.Result = output.Data
+		rec.job.Progress.Percent = 100
+	}
+	m.save(rec)
+	m.notify(rec)
+	m.prune()
+	job = rec.job
+	m.mu.Unlock()
+
+	if err != nil {
+		m.logger.Warn("Job failed", "job_id", jobID, "operation_id", job.OperationID, "error", err)
+	} else {
+		m.logger.Info("Job succeeded", "job_id", jobID, "operation_id", job.OperationID)
+	}
+	m.publish(topic, job)
+}
+
+// progress records the progress reported by a running job.
+func (m *Manager) progress(rec *record, progress jobs.Progress) {
+	m.mu.Lock()
+	if rec.job.Status != jobs.StatusRunning {
+		m.mu.Unlock()
+		return
+	}
+	rec.job.Progress = progress
+	m.save(rec)
+	m.notify(rec)
+	job := rec.job
+	m.mu.Unlock()
+
+	m.publish(jobs.TopicJobProgress, job)
+}
+
+// interrupt cancels running jobs and stops starting new ones, leaving them
+// to be resumed on the next start.
+func (m *Manager) interrupt() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.interruptLocked()
+}
+
+func (m *Manager) interruptLocked() {
+	if m.stopping {
+		return
+	}
+	m.stopping = true
+	for _, rec := range m.jobs {
+		if rec.cancel != nil {
+			rec.cancel()
+		}
+	}
+}
+
+// notify sends the state of a job to its watchers, closing them once the job
+// finished. Called with the lock held.
+func (m *Manager) notify(rec *record) {
+	finished := rec.job.Status.Finished()
+	for _, w := range rec.watchers {
+		w.send(rec.job, finished)
+	}
+	if finished {
+		rec.watchers = nil
+	}
+}
+
+// save persists a job. Called with the lock held, so that updates reach the
+// store in order.
+func (m *Manager) save(rec *record) error {
+	data, err := json.Marshal(rec.job)
+	if err == nil {
+		err = m.store.Set([]byte(keyPrefix+rec.job.ID), data)
+	}
+	if err != nil {
+		m.logger.Error("Failed to persist job", "job_id", rec.job.ID, "error", err)
+		return failure.Wrap(err, jobs.ErrStoreUnavailable, "job '%s'", rec.job.ID)
+	}
+	return nil
+}
+
+// prune deletes finished jobs older than the retention. Called with the lock held.
+func (m *Manager) prune() {
+	if m.options.Retention < 0 {
+		return
+	}
+	cutoff := time.Now().Add(-m.options.Retention)
+	for id, rec := range m.jobs {
+		if rec.job.Status.Finished() && rec.job.FinishedAt.Before(cutoff) {
+			if err := m.store.Delete([]byte(keyPrefix + id)); err != nil {
+				m.logger.Error("Failed to delete expired job", "job_id", id, "error", err)
+				continue
+			}
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// sorted returns the records oldest first. Called with the lock held.
+func (m *Manager) sorted() []*record {
+	records := make([]*record, 0, len(m.jobs))
+	for _, rec := range m.jobs {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].job.CreatedAt.Equal(records[j].job.CreatedAt) {
+			return records[i].job.ID < records[j].job.ID
+		}
+		return records[i].job.CreatedAt.Before(records[j].job.CreatedAt)
+	})
+	return records
+}
+
+// openStore returns the job store, creating it if needed.
+func (m *Manager) openStore() (storage.Store, error) {
+	store, err := m.multiStore.GetStore(m.options.Store)
+	if err != nil && errors.Is(err, storage.ErrStoreNotFound) {
+		if err = m.multiStore.CreateStore(m.options.Store, m.options.Engine, nil); err == nil {
+			store, err = m.multiStore.GetStore(m.options.Store)
+		}
+	}
+	if err != nil {
+		return nil, failure.Wrap(err, jobs.ErrStoreUnavailable, "store '%s'", m.options.Store)
+	}
+	return store, nil
+}
+
+// loadJobs reads the persisted jobs. Records that cannot be decoded are
+// logged and skipped, and stay in the store.
+func (m *Manager) loadJobs(store storage.Store) (map[string]*record, error) {
+	loaded := make(map[string]*record)
+	err := store.Iterate(func(key, value []byte) bool {
+		if !strings.HasPrefix(string(key), keyPrefix) {
+			return true
+		}
+		var job jobs.Job
+		if err := json.Unmarshal(value, &job); err != nil {
+			m.logger.Error("Skipping undecodable job", "key", string(key), "error", err)
+			return true
+		}
+		loaded[job.ID] = &record{job: job}
+		return true
+	})
+	if err != nil {
+		return nil, failure.Wrap(err, jobs.ErrStoreUnavailable, "")
+	}
+	return loaded, nil
+}
+
+// newJobID returns a random job ID.
+func newJobID() (string, error) {
+	var id [12]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	return hex.EncodeToString(id[:]), nil
+}
+
+// publish sends a job event if an event bus is available.
+func (m *Manager) publish(topic string, job jobs.Job) {
+	if m.eventBus == nil {
+		return
+	}
+	payload := map[string]interface{}{
+		"jobId":       job.ID,
+		"operationId": string(job.OperationID),
+		"status":      string(job.Status),
+		"attempts":    job.Attempts,
+		"progress":    job.Progress.Percent,
+	}
+	if job.Progress.Message != "" {
+		payload["message"] = job.Progress.Message
+	}
+	if job.Error != "" {
+		payload["error"] = job.Error
+	}
+	m.eventBus.Publish(&event.Event{
+		Topic:   topic,
+		Source:  string(m.ID()),
+		Time:    time.Now(),
+		Payload: payload,
+	})
+}
+
+// send delivers an update without blocking. The final update replaces the
+// oldest buffered one if the reader is behind, and closes the channel.
+func (w *watcher) send(job jobs.Job, final bool) {
+	if w.closed {
+		return
+	}
+	select {
+	case w.updates <- job:
+	default:
+		if final {
+			select {
+			case <-w.updates:
+			default:
+			}
+			w.updates <- job
+		}
+	}
+	if final {
+		w.close()
+	}
+}
+
+// close closes the update channel once.
+func (w *watcher) close() {
+	if !w.closed {
+		w.closed = true
+		close(w.updates)
+	}
+}
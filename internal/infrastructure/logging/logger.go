@@ -2,10 +2,9 @@
 package logging
 
 import (
-	"errors"
-
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	"github.com/fintechain/skeleton/internal/domain/logging"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
 )
@@ -20,7 +19,7 @@ type Logger struct {
 // NewLogger creates a new logger service with the provided logger.
 func NewLogger(config component.ComponentConfig, logger logging.Logger) (*Logger, error) {
 	if logger == nil {
-		return nil, errors.New("logger cannot be nil")
+		return nil, failure.New(logging.ErrLoggerNotAvailable, "logger cannot be nil")
 	}
 
 	return &Logger{
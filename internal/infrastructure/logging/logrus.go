@@ -8,6 +8,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	"github.com/fintechain/skeleton/internal/domain/logging"
 )
 
@@ -39,7 +40,7 @@ func NewLogrusLogger(config LogrusConfig) (*LogrusLogger, error) {
 	if config.Level != "" {
 		level, err := logrus.ParseLevel(config.Level)
 		if err != nil {
-			return nil, fmt.Errorf("%s: invalid log level '%s': %w", logging.ErrInvalidLogLevel, config.Level, err)
+			return nil, failure.Wrap(err, logging.ErrInvalidLogLevel, "invalid log level '%s'", config.Level)
 		}
 		logger.SetLevel(level)
 	} else {
@@ -55,7 +56,7 @@ func NewLogrusLogger(config LogrusConfig) (*LogrusLogger, error) {
 			FullTimestamp: true,
 		})
 	default:
-		return nil, fmt.Errorf("%s: unsupported format '%s'", logging.ErrInvalidLogFormat, config.Format)
+		return nil, failure.New(logging.ErrInvalidLogFormat, "unsupported format '%s'", config.Format)
 	}
 
 	// Convert config fields to logrus.Fields
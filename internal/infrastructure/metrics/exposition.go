@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+)
+
+// MetricsPath is the path the metrics are served on.
+const MetricsPath = "/metrics"
+
+// ContentType is the media type of the Prometheus text format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+// NewHandler serves a snapshot of the registry in the Prometheus text format.
+func NewHandler(registry metrics.Registry) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(MetricsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		w.Header().Set("Cache-Control", "no-store")
+		_ = WriteText(w, registry.Snapshot())
+	})
+	return mux
+}
+
+// WriteText writes metric families in the Prometheus text format.
+func WriteText(w io.Writer, families []metrics.Family) error {
+	b := bufio.NewWriter(w)
+	for _, family := range families {
+		if family.Help != "" {
+			b.WriteString("# HELP " + family.Name + " " + helpEscaper.Replace(family.Help) + "\n")
+		}
+		b.WriteString("# TYPE " + family.Name + " " + string(family.Kind) + "\n")
+
+		for _, s := range family.Series {
+			if family.Kind != metrics.KindHistogram {
+				writeSample(b, family.Name, s.Labels, "", "", s.Value)
+				continue
+			}
+			for _, bucket := range s.Buckets {
+				writeSample(b, family.Name+"_bucket", s.Labels, "le", formatFloat(bucket.UpperBound), float64(bucket.Count))
+			}
+			writeSample(b, family.Name+"_bucket", s.Labels, "le", "+Inf", float64(s.Count))
+			writeSample(b, family.Name+"_sum", s.Labels, "", "", s.Sum)
+			writeSample(b, family.Name+"_count", s.Labels, "", "", float64(s.Count))
+		}
+	}
+	return b.Flush()
+}
+
+// writeSample writes one sample line, with an optional extra label such as
+// the "le" bound of a histogram bucket.
+func writeSample(b *bufio.Writer, name string, labels metrics.Labels, extraName, extraValue string, value float64) {
+	b.WriteString(name)
+
+	names := make([]string, 0, len(labels))
+	for label := range labels {
+		names = append(names, label)
+	}
+	sort.Strings(names)
+
+	if len(names) > 0 || extraName != "" {
+		b.WriteByte('{')
+		for i, label := range names {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(label + `="` + labelEscaper.Replace(labels[label]) + `"`)
+		}
+		if extraName != "" {
+			if len(names) > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(extraName + `="` + extraValue + `"`)
+		}
+		b.WriteByte('}')
+	}
+
+	b.WriteByte(' ')
+	b.WriteString(formatFloat(value))
+	b.WriteByte('\n')
+}
+
+// formatFloat formats a value the way the text format spells it.
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
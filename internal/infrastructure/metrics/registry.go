@@ -0,0 +1,258 @@
+// Package metrics provides an in-memory metrics registry and its Prometheus
+// text exposition.
+package metrics
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+)
+
+// validName matches metric names accepted by Prometheus.
+var validName = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// Registry implements metrics.Registry in memory. It is safe for concurrent use.
+type Registry struct {
+	metrics    map[string]*metric
+	collectors []func()
+	mu         sync.RWMutex
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: make(map[string]*metric),
+	}
+}
+
+// Counter returns the counter with the given name, creating it if needed.
+// It panics if the name is invalid or belongs to a metric of another kind.
+func (r *Registry) Counter(name, help string) metrics.Counter {
+	return &counter{r.metric(name, help, metrics.KindCounter, nil)}
+}
+
+// Gauge returns the gauge with the given name, creating it if needed.
+// It panics if the name is invalid or belongs to a metric of another kind.
+func (r *Registry) Gauge(name, help string) metrics.Gauge {
+	return &gauge{r.metric(name, help, metrics.KindGauge, nil)}
+}
+
+// Histogram returns the histogram with the given name, creating it with the
+// bucket upper bounds if needed. It panics if the name is invalid or belongs
+// to a metric of another kind.
+func (r *Registry) Histogram(name, help string, buckets []float64) metrics.Histogram {
+	if buckets == nil {
+		buckets = metrics.DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &histogram{r.metric(name, help, metrics.KindHistogram, buckets)}
+}
+
+// AddCollector registers a function that updates metrics before every
+// snapshot, for values that are read on demand.
+func (r *Registry) AddCollector(collect func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collect)
+}
+
+// Snapshot runs the collectors and returns the current values of every
+// metric, ordered by name. Series are ordered by their labels.
+func (r *Registry) Snapshot() []metrics.Family {
+	r.mu.RLock()
+	collectors := append([]func(){}, r.collectors...)
+	r.mu.RUnlock()
+	for _, collect := range collectors {
+		collect()
+	}
+
+	r.mu.RLock()
+	all := make([]*metric, 0, len(r.metrics))
+	for _, m := range r.metrics {
+		all = append(all, m)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool { return all[i].name < all[j].name })
+	families := make([]metrics.Family, 0, len(all))
+	for _, m := range all {
+		families = append(families, m.snapshot())
+	}
+	return families
+}
+
+// metric returns the metric with the given name, creating it if needed.
+func (r *Registry) metric(name, help string, kind metrics.Kind, buckets []float64) *metric {
+	if !validName.MatchString(name) {
+		panic(failure.New(metrics.ErrInvalidName, "metric name '%s'", name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if m, exists := r.metrics[name]; exists {
+		if m.kind != kind {
+			panic(failure.New(metrics.ErrKindConflict, "metric '%s' is a %s, not a %s", name, m.kind, kind))
+		}
+		return m
+	}
+
+	m := &metric{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.metrics[name] = m
+	return m
+}
+
+// metric holds the series of one metric, keyed by their encoded labels.
+type metric struct {
+	name    string
+	help    string
+	kind    metrics.Kind
+	buckets []float64
+	series  map[string]*series
+	mu      sync.Mutex
+}
+
+// series holds the value of a counter or gauge series, or the observations of
+// a histogram series with one count per bucket.
+type series struct {
+	labels metrics.Labels
+	value  float64
+	count  uint64
+	sum    float64
+	counts []uint64
+}
+
+// with returns the series with the given labels, creating it if needed. The
+// caller holds m.mu.
+func (m *metric) with(labels metrics.Labels) *series {
+	key := encode(labels)
+	s, exists := m.series[key]
+	if !exists {
+		s = &series{labels: copyLabels(labels)}
+		if m.kind == metrics.KindHistogram {
+			s.counts = make([]uint64, len(m.buckets))
+		}
+		m.series[key] = s
+	}
+	return s
+}
+
+// snapshot copies the metric and its series.
+func (m *metric) snapshot() metrics.Family {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	family := metrics.Family{Name: m.name, Help: m.help, Kind: m.kind, Series: make([]metrics.Series, 0, len(keys))}
+	for _, key := range keys {
+		s := m.series[key]
+		snap := metrics.Series{Labels: copyLabels(s.labels), Value: s.value}
+		if m.kind == metrics.KindHistogram {
+			snap.Count = s.count
+			snap.Sum = s.sum
+			snap.Buckets = make([]metrics.Bucket, len(m.buckets))
+			var cumulative uint64
+			for i, bound := range m.buckets {
+				cumulative += s.counts[i]
+				snap.Buckets[i] = metrics.Bucket{UpperBound: bound, Count: cumulative}
+			}
+		}
+		family.Series = append(family.Series, snap)
+	}
+	return family
+}
+
+// encode builds a key identifying a set of labels regardless of their order.
+func encode(labels metrics.Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(0xff)
+		b.WriteString(labels[name])
+		b.WriteByte(0xfe)
+	}
+	return b.String()
+}
+
+func copyLabels(labels metrics.Labels) metrics.Labels {
+	if len(labels) == 0 {
+		return nil
+	}
+	c := make(metrics.Labels, len(labels))
+	for name, value := range labels {
+		c[name] = value
+	}
+	return c
+}
+
+// counter implements metrics.Counter.
+type counter struct{ *metric }
+
+func (c *counter) Inc(labels metrics.Labels) {
+	c.Add(labels, 1)
+}
+
+// Add ignores negative deltas, since counters only increase.
+func (c *counter) Add(labels metrics.Labels, delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.mu.Lock()
+	c.with(labels).value += delta
+	c.mu.Unlock()
+}
+
+// gauge implements metrics.Gauge.
+type gauge struct{ *metric }
+
+func (g *gauge) Set(labels metrics.Labels, value float64) {
+	g.mu.Lock()
+	g.with(labels).value = value
+	g.mu.Unlock()
+}
+
+func (g *gauge) Add(labels metrics.Labels, delta float64) {
+	g.mu.Lock()
+	g.with(labels).value += delta
+	g.mu.Unlock()
+}
+
+// histogram implements metrics.Histogram.
+type histogram struct{ *metric }
+
+func (h *histogram) Observe(labels metrics.Labels, value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.with(labels)
+	s.count++
+	s.sum += value
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		s.counts[i]++
+	}
+}
@@ -0,0 +1,17 @@
+package metrics
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+)
+
+// Server is a service that serves metrics over HTTP on a local address.
+type Server struct {
+	*infraComponent.HTTPService
+}
+
+// NewServer creates a metrics endpoint listening on addr, such as "127.0.0.1:9090".
+func NewServer(config component.ComponentConfig, addr string, registry metrics.Registry) *Server {
+	return &Server{HTTPService: infraComponent.NewHTTPService(config, "metrics endpoint", addr, NewHandler(registry))}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+)
+
+// InstrumentStore wraps a store so that its gets and sets are counted in the
+// registry, labelled by store name. Transactional stores stay transactional,
+// and the gets and sets of their transactions are counted too.
+func InstrumentStore(store storage.Store, registry metrics.Registry) storage.Store {
+	instrumented := &instrumentedStore{
+		Store:  store,
+		labels: metrics.Labels{"store": store.Name()},
+		gets:   registry.Counter(metrics.StoreGets, "Number of store reads."),
+		sets:   registry.Counter(metrics.StoreSets, "Number of store writes."),
+	}
+	if transactional, ok := store.(storage.Transactional); ok {
+		return &instrumentedTransactionalStore{instrumentedStore: instrumented, transactional: transactional}
+	}
+	return instrumented
+}
+
+// instrumentedStore counts the gets and sets of a store.
+type instrumentedStore struct {
+	storage.Store
+	labels metrics.Labels
+	gets   metrics.Counter
+	sets   metrics.Counter
+}
+
+func (s *instrumentedStore) Get(key []byte) ([]byte, error) {
+	s.gets.Inc(s.labels)
+	return s.Store.Get(key)
+}
+
+func (s *instrumentedStore) Set(key, value []byte) error {
+	s.sets.Inc(s.labels)
+	return s.Store.Set(key, value)
+}
+
+// instrumentedTransactionalStore counts the gets and sets of a transactional
+// store and of its transactions.
+type instrumentedTransactionalStore struct {
+	*instrumentedStore
+	transactional storage.Transactional
+}
+
+func (s *instrumentedTransactionalStore) BeginTx() (storage.Transaction, error) {
+	tx, err := s.transactional.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTransaction{Transaction: tx, store: s.instrumentedStore}, nil
+}
+
+func (s *instrumentedTransactionalStore) SupportsTransactions() bool {
+	return s.transactional.SupportsTransactions()
+}
+
+// instrumentedTransaction counts the gets and sets of a transaction against
+// the store that began it.
+type instrumentedTransaction struct {
+	storage.Transaction
+	store *instrumentedStore
+}
+
+func (t *instrumentedTransaction) Get(key []byte) ([]byte, error) {
+	t.store.gets.Inc(t.store.labels)
+	return t.Transaction.Get(key)
+}
+
+func (t *instrumentedTransaction) Set(key, value []byte) error {
+	t.store.sets.Inc(t.store.labels)
+	return t.Transaction.Set(key, value)
+}
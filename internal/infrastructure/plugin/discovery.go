@@ -0,0 +1,245 @@
+package plugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/infrastructure/plugin/remote"
+)
+
+// DiscoverySource is the event source of discovery events.
+const DiscoverySource = "plugin_discovery"
+
+// Discovery finds plugins described by manifests on the filesystem.
+//
+// Every subdirectory of a plugin directory that contains a ManifestFileName is a
+// plugin. Its manifest is validated, its required configuration keys are checked
+// and the plugin is loaded either as a native Go plugin or as an out-of-process
+// plugin. Each success and failure is logged and published on the event bus.
+type Discovery struct {
+	dirs     []string
+	config   config.Configuration
+	logger   logging.Logger
+	eventBus event.EventBus
+}
+
+// DiscoveryResult holds the plugins found by a discovery run.
+type DiscoveryResult struct {
+	// Plugins are the loaded plugins, in directory order.
+	Plugins []plugin.Plugin
+
+	// Manifests are the manifests of Plugins, index for index. The
+	// dependencies a native plugin's manifest lists are not part of the
+	// plugin; AddDependencies declares them to a plugin manager.
+	Manifests []*Manifest
+
+	// Failures describe directories and manifests that could not be loaded.
+	Failures []DiscoveryFailure
+}
+
+// dependencyDeclarer is implemented by plugin managers that accept
+// dependencies besides those the plugins declare, such as Manager.
+type dependencyDeclarer interface {
+	AddDependencies(pluginID component.ComponentID, deps ...plugin.Dependency)
+}
+
+// AddDependencies declares the dependencies listed in the manifests to the
+// plugin manager. It does nothing if the manager does not accept dependencies
+// besides those the plugins declare.
+func (r *DiscoveryResult) AddDependencies(manager plugin.PluginManager) {
+	declarer, ok := manager.(dependencyDeclarer)
+	if !ok {
+		return
+	}
+	for _, m := range r.Manifests {
+		if len(m.Dependencies) > 0 {
+			declarer.AddDependencies(component.ComponentID(m.ID), m.PluginDependencies()...)
+		}
+	}
+}
+
+// DiscoveryFailure describes a plugin that could not be discovered.
+type DiscoveryFailure struct {
+	// Path is the plugin directory or manifest file.
+	Path string
+
+	// PluginID is set when the manifest could be read.
+	PluginID component.ComponentID
+
+	Err error
+}
+
+// Err returns all failures joined, or nil if discovery succeeded everywhere.
+func (r *DiscoveryResult) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(r.Failures))
+	for _, f := range r.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// NewDiscovery creates a discovery over the given directories. The configuration
+// is used to check required keys; the logger and event bus receive discovery
+// results. Any of them may be nil.
+func NewDiscovery(dirs []string, cfg config.Configuration, logger logging.Logger, eventBus event.EventBus) *Discovery {
+	return &Discovery{
+		dirs:     dirs,
+		config:   cfg,
+		logger:   logger,
+		eventBus: eventBus,
+	}
+}
+
+// Discover scans the plugin directories and loads every valid plugin.
+// A failing plugin does not prevent the others from loading.
+func (d *Discovery) Discover() *DiscoveryResult {
+	result := &DiscoveryResult{}
+	seen := make(map[string]string)
+
+	for _, dir := range d.dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			d.fail(result, DiscoveryFailure{
+				Path: dir,
+				Err:  failure.Wrap(err, component.ErrPluginDiscoveryFailed, "cannot read plugin directory '%s'", dir),
+			})
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name(), ManifestFileName)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+
+			manifest, err := LoadManifest(path)
+			if err != nil {
+				d.fail(result, DiscoveryFailure{Path: path, Err: err})
+				continue
+			}
+
+			id := component.ComponentID(manifest.ID)
+			if previous, exists := seen[manifest.ID]; exists {
+				d.fail(result, DiscoveryFailure{Path: path, PluginID: id, Err: failure.New(component.ErrPluginDiscoveryFailed, "plugin '%s' is also declared in '%s'", manifest.ID, previous)})
+				continue
+			}
+			seen[manifest.ID] = path
+
+			p, err := d.Load(manifest)
+			if err != nil {
+				d.fail(result, DiscoveryFailure{Path: path, PluginID: id, Err: err})
+				continue
+			}
+
+			result.Plugins = append(result.Plugins, p)
+			result.Manifests = append(result.Manifests, manifest)
+			d.discovered(path, manifest)
+		}
+	}
+
+	return result
+}
+
+// Load creates the plugin described by a validated manifest.
+func (d *Discovery) Load(m *Manifest) (plugin.Plugin, error) {
+	if missing := d.missingConfig(m.RequiredConfig); len(missing) > 0 {
+		return nil, failure.New(component.ErrPluginDiscoveryFailed, "plugin '%s' requires configuration %s", m.ID, strings.Join(missing, ", "))
+	}
+
+	switch m.Entrypoint.Kind {
+	case EntrypointProcess:
+		return remote.NewProcessPlugin(remote.ProcessConfig{
+			ID:           component.ComponentID(m.ID),
+			Version:      m.Version,
+			Dependencies: m.PluginDependencies(),
+			Path:         m.EntrypointPath(),
+			Args:         m.Entrypoint.Args,
+			Transport:    m.Entrypoint.Transport,
+		}), nil
+
+	case EntrypointNative:
+		symbol := m.Entrypoint.Symbol
+		if symbol == "" {
+			symbol = "Plugin"
+		}
+		p, err := openNativePlugin(m.EntrypointPath(), symbol)
+		if err != nil {
+			return nil, failure.Wrap(err, component.ErrPluginLoadFailed, "plugin '%s'", m.ID)
+		}
+		if string(p.ID()) != m.ID {
+			return nil, failure.New(component.ErrPluginLoadFailed, "plugin '%s' identifies as '%s'", m.ID, p.ID())
+		}
+		return p, nil
+
+	default:
+		return nil, failure.New(component.ErrPluginDiscoveryFailed, "unknown entrypoint kind '%s'", m.Entrypoint.Kind)
+	}
+}
+
+// missingConfig returns the required keys absent from the configuration.
+func (d *Discovery) missingConfig(keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if d.config == nil || !d.config.Exists(key) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// discovered reports a loaded plugin.
+func (d *Discovery) discovered(path string, m *Manifest) {
+	if d.logger != nil {
+		d.logger.Info("Plugin discovered",
+			"plugin_id", m.ID, "version", m.Version, "entrypoint", m.Entrypoint.Kind, "path", path)
+	}
+	d.publish(plugin.TopicPluginDiscovered, map[string]interface{}{
+		"pluginId":   m.ID,
+		"version":    m.Version,
+		"entrypoint": m.Entrypoint.Kind,
+		"path":       path,
+	})
+}
+
+// fail records and reports a discovery failure.
+func (d *Discovery) fail(result *DiscoveryResult, failure DiscoveryFailure) {
+	result.Failures = append(result.Failures, failure)
+
+	if d.logger != nil {
+		d.logger.Error("Plugin discovery failed",
+			"plugin_id", failure.PluginID, "path", failure.Path, "error", failure.Err)
+	}
+	d.publish(plugin.TopicPluginDiscoveryFailed, map[string]interface{}{
+		"pluginId": string(failure.PluginID),
+		"path":     failure.Path,
+		"error":    failure.Err.Error(),
+	})
+}
+
+// publish sends a discovery event if an event bus is available.
+func (d *Discovery) publish(topic string, payload map[string]interface{}) {
+	if d.eventBus == nil {
+		return
+	}
+	d.eventBus.Publish(&event.Event{
+		Topic:   topic,
+		Source:  DiscoverySource,
+		Time:    time.Now(),
+		Payload: payload,
+	})
+}
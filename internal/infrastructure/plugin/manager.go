@@ -2,19 +2,30 @@ package plugin
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	"github.com/fintechain/skeleton/internal/domain/plugin"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
 )
 
 // Manager implements the PluginManager interface.
 type Manager struct {
 	*infraComponent.BaseService
-	plugins map[component.ComponentID]plugin.Plugin
-	mu      sync.RWMutex
+	plugins     map[component.ComponentID]plugin.Plugin
+	resources   map[component.ComponentID]*pluginResources
+	initialized map[component.ComponentID]bool
+	extraDeps   map[component.ComponentID][]plugin.Dependency
+	system      component.System
+	mu          sync.RWMutex
 }
 
 // NewManager creates a new plugin manager.
@@ -22,9 +33,54 @@ func NewManager(config component.ComponentConfig) *Manager {
 	return &Manager{
 		BaseService: infraComponent.NewBaseService(config),
 		plugins:     make(map[component.ComponentID]plugin.Plugin),
+		resources:   make(map[component.ComponentID]*pluginResources),
+		initialized: make(map[component.ComponentID]bool),
+		extraDeps:   make(map[component.ComponentID][]plugin.Dependency),
 	}
 }
 
+// AddDependencies declares dependencies of a plugin in addition to those the
+// plugin declares itself, such as the dependencies listed in the manifest of a
+// native plugin. Dependencies the plugin declares itself take precedence.
+// They may be added before the plugin and are kept when it is unloaded.
+func (m *Manager) AddDependencies(pluginID component.ComponentID, deps ...plugin.Dependency) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.extraDeps[pluginID] = append(m.extraDeps[pluginID], deps...)
+}
+
+// PluginDependencies returns the dependencies of a plugin: those it declares
+// itself followed by those added with AddDependencies.
+func (m *Manager) PluginDependencies(pluginID component.ComponentID) []plugin.Dependency {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.dependencies(pluginID)
+}
+
+// dependencies merges the dependencies of a plugin. The caller must hold m.mu.
+func (m *Manager) dependencies(pluginID component.ComponentID) []plugin.Dependency {
+	own := declaredDependencies(m.plugins[pluginID])
+	extra := m.extraDeps[pluginID]
+	if len(extra) == 0 {
+		return own
+	}
+
+	merged := append([]plugin.Dependency(nil), own...)
+	declared := make(map[component.ComponentID]bool, len(own))
+	for _, dep := range own {
+		declared[dep.ID] = true
+	}
+	for _, dep := range extra {
+		if !declared[dep.ID] {
+			merged = append(merged, dep)
+			declared[dep.ID] = true
+		}
+	}
+	return merged
+}
+
 // Add adds a plugin to the manager.
 func (m *Manager) Add(pluginID component.ComponentID, p plugin.Plugin) error {
 	m.mu.Lock()
@@ -34,19 +90,105 @@ func (m *Manager) Add(pluginID component.ComponentID, p plugin.Plugin) error {
 	return nil
 }
 
-// Remove removes a plugin from the manager.
+// Remove unloads a plugin and removes it from the manager.
+// See Unload for the cleanup performed.
 func (m *Manager) Remove(pluginID component.ComponentID) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	return m.Unload(infraContext.NewContext(), pluginID)
+}
+
+// Unload stops a plugin, removes everything it registered and forgets it.
+//
+// The components the plugin registered through the system registry are stopped
+// (if they are running services), disposed and unregistered in reverse
+// registration order, and the event subscriptions it created are cancelled.
+// The plugin itself is then disposed. A plugin that other loaded plugins
+// require cannot be unloaded.
+func (m *Manager) Unload(ctx context.Context, pluginID component.ComponentID) error {
+	m.mu.RLock()
+	p, exists := m.plugins[pluginID]
+	resources := m.resources[pluginID]
+	system := m.system
+	dependents := m.requiredBy(pluginID)
+	m.mu.RUnlock()
 
-	if _, exists := m.plugins[pluginID]; !exists {
-		return errors.New(component.ErrComponentNotFound)
+	if !exists {
+		return failure.New(component.ErrComponentNotFound, "plugin '%s'", pluginID).WithComponent(string(pluginID))
+	}
+
+	if len(dependents) > 0 {
+		return failure.New(component.ErrPluginUnloadFailed, "plugin '%s' is required by %s", pluginID, strings.Join(dependents, ", "))
 	}
 
+	if p != nil && p.IsRunning() {
+		if err := m.stopPlugin(ctx, pluginID, p); err != nil {
+			return failure.Wrap(err, component.ErrPluginUnloadFailed, "plugin '%s' failed to stop", pluginID)
+		}
+	}
+
+	var errs []error
+	if resources != nil && system != nil {
+		errs = append(errs, releaseResources(ctx, system.Registry(), resources)...)
+	}
+
+	if p != nil {
+		if err := p.Dispose(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to dispose plugin: %w", err))
+		}
+	}
+
+	m.mu.Lock()
 	delete(m.plugins, pluginID)
+	delete(m.resources, pluginID)
+	delete(m.initialized, pluginID)
+	m.mu.Unlock()
+
+	if len(errs) > 0 {
+		err := failure.Wrap(errors.Join(errs...), component.ErrPluginUnloadFailed, "plugin '%s' unloaded with errors", pluginID)
+		m.publish(plugin.TopicPluginFailed, pluginID, err, "unload")
+		return err
+	}
+
+	m.publish(plugin.TopicPluginUnloaded, pluginID, nil, "")
 	return nil
 }
 
+// OwnedComponents returns the IDs of the components a plugin registered
+// in the system registry, in registration order.
+func (m *Manager) OwnedComponents(pluginID component.ComponentID) []component.ComponentID {
+	m.mu.RLock()
+	resources := m.resources[pluginID]
+	m.mu.RUnlock()
+
+	if resources == nil {
+		return nil
+	}
+
+	components, _ := resources.snapshot()
+	return components
+}
+
+// SubscribedTopics returns the topics of the event subscriptions a plugin
+// created and has not cancelled, in subscription order.
+func (m *Manager) SubscribedTopics(pluginID component.ComponentID) []string {
+	m.mu.RLock()
+	resources := m.resources[pluginID]
+	m.mu.RUnlock()
+
+	if resources == nil {
+		return nil
+	}
+
+	_, subscriptions := resources.snapshot()
+	var topics []string
+	for _, sub := range subscriptions {
+		if cancellable, ok := sub.(interface{ Cancelled() bool }); ok && cancellable.Cancelled() {
+			continue
+		}
+		topics = append(topics, sub.Topic())
+	}
+	return topics
+}
+
 // StartPlugin starts a specific plugin.
 func (m *Manager) StartPlugin(ctx context.Context, pluginID component.ComponentID) error {
 	m.mu.RLock()
@@ -54,10 +196,10 @@ func (m *Manager) StartPlugin(ctx context.Context, pluginID component.ComponentI
 	m.mu.RUnlock()
 
 	if !exists {
-		return errors.New(component.ErrComponentNotFound)
+		return failure.New(component.ErrComponentNotFound, "plugin '%s'", pluginID).WithComponent(string(pluginID))
 	}
 
-	return p.Start(ctx)
+	return m.startPlugin(ctx, pluginID, p)
 }
 
 // StopPlugin stops a specific plugin.
@@ -67,10 +209,10 @@ func (m *Manager) StopPlugin(ctx context.Context, pluginID component.ComponentID
 	m.mu.RUnlock()
 
 	if !exists {
-		return errors.New(component.ErrComponentNotFound)
+		return failure.New(component.ErrComponentNotFound, "plugin '%s'", pluginID).WithComponent(string(pluginID))
 	}
 
-	return p.Stop(ctx)
+	return m.stopPlugin(ctx, pluginID, p)
 }
 
 // GetPlugin retrieves a plugin by ID.
@@ -80,7 +222,7 @@ func (m *Manager) GetPlugin(pluginID component.ComponentID) (plugin.Plugin, erro
 
 	p, exists := m.plugins[pluginID]
 	if !exists {
-		return nil, errors.New(component.ErrComponentNotFound)
+		return nil, failure.New(component.ErrComponentNotFound, "plugin '%s'", pluginID).WithComponent(string(pluginID))
 	}
 
 	return p, nil
@@ -100,66 +242,291 @@ func (m *Manager) ListPlugins() []component.ComponentID {
 }
 
 // Start starts the plugin manager and all registered plugins.
+// Plugins are started in dependency order; the manager refuses to start
+// when plugin dependencies cannot be resolved.
+//
+// Plugins that are already running are skipped. Startup is all-or-nothing:
+// if a plugin fails to start, the plugins started by this call are stopped in
+// reverse order and the manager ends in StatusError. The returned error joins
+// the start failure with any rollback failures.
 func (m *Manager) Start(ctx context.Context) error {
-	if err := m.BaseService.Start(ctx); err != nil {
+	plugins, err := m.orderedPlugins()
+	if err != nil {
 		return err
 	}
 
-	m.mu.RLock()
-	plugins := make([]plugin.Plugin, 0, len(m.plugins))
-	for _, p := range m.plugins {
-		plugins = append(plugins, p)
+	if err := m.BaseService.Start(ctx); err != nil {
+		return err
 	}
-	m.mu.RUnlock()
 
 	// Start all plugins
+	started := make([]registeredPlugin, 0, len(plugins))
 	for _, p := range plugins {
-		if err := p.Start(ctx); err != nil {
-			return err
+		if p.plugin.IsRunning() {
+			continue
 		}
+		if err := m.startPlugin(ctx, p.id, p.plugin); err != nil {
+			startErr := failure.Wrap(err, component.ErrServiceStartFailed, "plugin '%s' failed to start", p.id)
+			rollbackErr := m.stopPlugins(ctx, started)
+
+			m.BaseService.Stop(ctx)
+			m.SetStatus(component.StatusError)
+			return errors.Join(startErr, rollbackErr)
+		}
+		started = append(started, p)
 	}
 
 	return nil
 }
 
 // Stop stops all plugins and the plugin manager.
+// Plugins are stopped in reverse dependency order. Every plugin is stopped
+// even when some fail; the returned error joins all the failures.
 func (m *Manager) Stop(ctx context.Context) error {
-	m.mu.RLock()
-	plugins := make([]plugin.Plugin, 0, len(m.plugins))
-	for _, p := range m.plugins {
-		plugins = append(plugins, p)
+	plugins, err := m.orderedPlugins()
+	if err != nil {
+		// Dependencies may have become unresolvable after start; stop everything anyway.
+		plugins = m.sortedPlugins()
 	}
-	m.mu.RUnlock()
 
 	// Stop all plugins
-	for _, p := range plugins {
-		if err := p.Stop(ctx); err != nil {
-			return err
+	var errs []error
+	for i := len(plugins) - 1; i >= 0; i-- {
+		if err := m.stopPlugin(ctx, plugins[i].id, plugins[i].plugin); err != nil {
+			errs = append(errs, failure.Wrap(err, component.ErrServiceStopFailed, "plugin '%s' failed to stop", plugins[i].id))
 		}
 	}
 
-	return m.BaseService.Stop(ctx)
+	if err := m.BaseService.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
 }
 
 // Initialize initializes the plugin manager and all registered plugins.
+// Plugins are initialized in dependency order. Plugins that were already
+// initialized are skipped, so plugins added later can be initialized by
+// calling Initialize again.
+//
+// Each plugin receives a view of the system that records the components it
+// registers and the event subscriptions it creates, so Unload can remove them.
+// Plugins that implement plugin.CapablePlugin are further restricted to the
+// capabilities they declare. If a plugin fails to initialize, whatever it
+// registered or subscribed before failing is released.
 func (m *Manager) Initialize(ctx context.Context, system component.System) error {
-	if err := m.BaseService.Initialize(ctx, system); err != nil {
+	plugins, err := m.orderedPlugins()
+	if err != nil {
 		return err
 	}
 
-	m.mu.RLock()
-	plugins := make([]plugin.Plugin, 0, len(m.plugins))
-	for _, p := range m.plugins {
-		plugins = append(plugins, p)
+	if err := m.BaseService.Initialize(ctx, system); err != nil {
+		return err
 	}
-	m.mu.RUnlock()
+
+	m.mu.Lock()
+	m.system = system
+	m.mu.Unlock()
 
 	// Initialize all plugins
 	for _, p := range plugins {
-		if err := p.Initialize(ctx, system); err != nil {
-			return err
+		m.mu.Lock()
+		if m.initialized[p.id] {
+			m.mu.Unlock()
+			continue
+		}
+		resources := &pluginResources{}
+		m.resources[p.id] = resources
+		m.mu.Unlock()
+
+		if err := p.plugin.Initialize(ctx, pluginSystem(system, p, resources)); err != nil {
+			releaseErrs := releaseResources(ctx, system.Registry(), resources)
+
+			m.mu.Lock()
+			delete(m.resources, p.id)
+			m.mu.Unlock()
+
+			m.publish(plugin.TopicPluginFailed, p.id, err, "initialize")
+			return errors.Join(append([]error{err}, releaseErrs...)...)
 		}
+
+		m.mu.Lock()
+		m.initialized[p.id] = true
+		m.mu.Unlock()
+		m.publish(plugin.TopicPluginLoaded, p.id, nil, "")
 	}
 
 	return nil
 }
+
+// pluginSystem returns the view of the system handed to a plugin.
+func pluginSystem(system component.System, p registeredPlugin, resources *pluginResources) component.System {
+	tracked := trackSystem(system, resources)
+	if capable, ok := p.plugin.(plugin.CapablePlugin); ok {
+		return scopeSystem(tracked, p.id, capable.Capabilities(), resources)
+	}
+	return tracked
+}
+
+// registeredPlugin pairs a plugin with the ID it was added under.
+type registeredPlugin struct {
+	id     component.ComponentID
+	plugin plugin.Plugin
+}
+
+// orderedPlugins returns the registered plugins sorted so that dependencies
+// come before the plugins that depend on them.
+func (m *Manager) orderedPlugins() ([]registeredPlugin, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	order, err := resolveDependencies(m.plugins, m.dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := make([]registeredPlugin, 0, len(order))
+	for _, id := range order {
+		plugins = append(plugins, registeredPlugin{id: id, plugin: m.plugins[id]})
+	}
+
+	return plugins, nil
+}
+
+// sortedPlugins returns the registered plugins ordered by ID, ignoring dependencies.
+func (m *Manager) sortedPlugins() []registeredPlugin {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]component.ComponentID, 0, len(m.plugins))
+	for id := range m.plugins {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	plugins := make([]registeredPlugin, 0, len(ids))
+	for _, id := range ids {
+		plugins = append(plugins, registeredPlugin{id: id, plugin: m.plugins[id]})
+	}
+
+	return plugins
+}
+
+// stopPlugins stops the given plugins in reverse order, attempting every plugin
+// even when some fail. It returns the joined stop failures, or nil.
+func (m *Manager) stopPlugins(ctx context.Context, plugins []registeredPlugin) error {
+	var errs []error
+	for i := len(plugins) - 1; i >= 0; i-- {
+		if err := m.stopPlugin(ctx, plugins[i].id, plugins[i].plugin); err != nil {
+			errs = append(errs, failure.Wrap(err, component.ErrServiceStopFailed, "rollback of plugin '%s' failed", plugins[i].id))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// startPlugin starts a plugin and publishes the outcome.
+func (m *Manager) startPlugin(ctx context.Context, id component.ComponentID, p plugin.Plugin) error {
+	if err := p.Start(ctx); err != nil {
+		m.publish(plugin.TopicPluginFailed, id, err, "start")
+		return err
+	}
+	m.publish(plugin.TopicPluginStarted, id, nil, "")
+	return nil
+}
+
+// stopPlugin stops a plugin and publishes the outcome.
+func (m *Manager) stopPlugin(ctx context.Context, id component.ComponentID, p plugin.Plugin) error {
+	if err := p.Stop(ctx); err != nil {
+		m.publish(plugin.TopicPluginFailed, id, err, "stop")
+		return err
+	}
+	m.publish(plugin.TopicPluginStopped, id, nil, "")
+	return nil
+}
+
+// eventBusProvider is implemented by systems with an event bus, such as the runtime.
+type eventBusProvider interface {
+	EventBus() event.EventBusService
+}
+
+// publish publishes a plugin lifecycle event on the event bus of the system
+// the plugins were initialized with, if it has one. action names the step
+// that failed for TopicPluginFailed.
+func (m *Manager) publish(topic string, id component.ComponentID, err error, action string) {
+	m.mu.RLock()
+	system := m.system
+	m.mu.RUnlock()
+
+	provider, ok := system.(eventBusProvider)
+	if !ok {
+		return
+	}
+	bus := provider.EventBus()
+	if bus == nil {
+		return
+	}
+
+	var data map[string]interface{}
+	if action != "" {
+		data = map[string]interface{}{"action": action}
+	}
+	bus.Publish(&event.Event{
+		Topic:   topic,
+		Source:  string(m.ID()),
+		Time:    time.Now(),
+		Payload: component.CreateComponentEventPayload(id, plugin.TypePlugin, err, data),
+	})
+}
+
+// requiredBy returns the IDs of loaded plugins that require pluginID.
+// The caller must hold m.mu.
+func (m *Manager) requiredBy(pluginID component.ComponentID) []string {
+	var dependents []string
+	for id := range m.plugins {
+		if id == pluginID {
+			continue
+		}
+		for _, dep := range m.dependencies(id) {
+			if dep.ID == pluginID && !dep.Optional {
+				dependents = append(dependents, string(id))
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// releaseResources cancels the tracked subscriptions and removes the tracked
+// components from the registry, newest first. It returns every failure encountered.
+func releaseResources(ctx context.Context, registry component.Registry, resources *pluginResources) []error {
+	components, subscriptions := resources.snapshot()
+
+	for _, sub := range subscriptions {
+		sub.Cancel()
+	}
+
+	var errs []error
+	for i := len(components) - 1; i >= 0; i-- {
+		id := components[i]
+		comp, err := registry.Get(id)
+		if err != nil {
+			continue // Already removed by someone else
+		}
+
+		if service, ok := comp.(component.Service); ok && service.IsRunning() {
+			if err := service.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to stop component '%s': %w", id, err))
+			}
+		}
+
+		if err := comp.Dispose(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to dispose component '%s': %w", id, err))
+		}
+
+		if err := registry.Unregister(id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to unregister component '%s': %w", id, err))
+		}
+	}
+
+	return errs
+}
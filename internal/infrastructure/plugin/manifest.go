@@ -0,0 +1,186 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/infrastructure/plugin/remote"
+)
+
+// ManifestFileName is the name of the manifest file discovery looks for in
+// every subdirectory of a plugin directory.
+const ManifestFileName = "plugin.json"
+
+// Entrypoint kinds
+const (
+	EntrypointNative  = "native"  // a Go plugin (.so) opened with the plugin package
+	EntrypointProcess = "process" // an executable speaking the remote plugin protocol
+)
+
+// Manifest describes a plugin found on the filesystem.
+//
+// Example plugin.json:
+//
+//	{
+//	  "id": "pricing-plugin",
+//	  "name": "Pricing",
+//	  "version": "1.2.0",
+//	  "type": "processor",
+//	  "author": "Payments Team",
+//	  "entrypoint": {"kind": "process", "path": "bin/pricing"},
+//	  "dependencies": [{"id": "database-plugin", "version": "^1.0.0"}],
+//	  "required_config": ["pricing.api_url"]
+//	}
+type Manifest struct {
+	ID             string               `json:"id"`
+	Name           string               `json:"name"`
+	Description    string               `json:"description,omitempty"`
+	Version        string               `json:"version"`
+	Type           plugin.PluginType    `json:"type,omitempty"`
+	Author         string               `json:"author,omitempty"`
+	Entrypoint     Entrypoint           `json:"entrypoint"`
+	Dependencies   []ManifestDependency `json:"dependencies,omitempty"`
+	RequiredConfig []string             `json:"required_config,omitempty"`
+
+	// Dir is the directory the manifest was read from. Relative entrypoint
+	// paths are resolved against it.
+	Dir string `json:"-"`
+}
+
+// Entrypoint tells discovery how to load a plugin.
+type Entrypoint struct {
+	// Kind is EntrypointNative or EntrypointProcess.
+	Kind string `json:"kind"`
+
+	// Path is the shared object or executable, relative to the manifest directory.
+	Path string `json:"path"`
+
+	// Symbol is the exported symbol of a native plugin. It must be a plugin.Plugin
+	// value or a func() plugin.Plugin. Defaults to "Plugin".
+	Symbol string `json:"symbol,omitempty"`
+
+	// Args and Transport configure a process plugin.
+	Args      []string         `json:"args,omitempty"`
+	Transport remote.Transport `json:"transport,omitempty"`
+}
+
+// ManifestDependency declares a plugin dependency in a manifest.
+type ManifestDependency struct {
+	ID       string `json:"id"`
+	Version  string `json:"version,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// LoadManifest reads and validates a manifest file.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, failure.Wrap(err, component.ErrPluginDiscoveryFailed, "cannot read manifest '%s'", path)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, failure.Wrap(err, component.ErrPluginDiscoveryFailed, "invalid manifest '%s'", path)
+	}
+	m.Dir = filepath.Dir(path)
+
+	if err := m.Validate(); err != nil {
+		return nil, failure.Wrap(err, component.ErrPluginDiscoveryFailed, "invalid manifest '%s'", path)
+	}
+	return &m, nil
+}
+
+// Validate checks that the manifest is complete and consistent.
+// All problems are reported together.
+func (m *Manifest) Validate() error {
+	var problems []string
+
+	if strings.TrimSpace(m.ID) == "" {
+		problems = append(problems, "id is required")
+	}
+	if m.Version == "" {
+		problems = append(problems, "version is required")
+	} else if _, err := ParseVersion(m.Version); err != nil {
+		problems = append(problems, fmt.Sprintf("version '%s' is not a semantic version", m.Version))
+	}
+	if m.Type != "" && !knownPluginType(m.Type) {
+		problems = append(problems, fmt.Sprintf("unknown plugin type '%s'", m.Type))
+	}
+
+	switch m.Entrypoint.Kind {
+	case EntrypointNative, EntrypointProcess:
+	case "":
+		problems = append(problems, "entrypoint.kind is required")
+	default:
+		problems = append(problems, fmt.Sprintf("unknown entrypoint kind '%s'", m.Entrypoint.Kind))
+	}
+	if m.Entrypoint.Path == "" {
+		problems = append(problems, "entrypoint.path is required")
+	}
+	switch m.Entrypoint.Transport {
+	case "", remote.TransportStdio, remote.TransportUnix:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown entrypoint transport '%s'", m.Entrypoint.Transport))
+	}
+
+	for i, dep := range m.Dependencies {
+		if dep.ID == "" {
+			problems = append(problems, fmt.Sprintf("dependencies[%d].id is required", i))
+			continue
+		}
+		if dep.ID == m.ID {
+			problems = append(problems, "plugin depends on itself")
+		}
+		if _, err := ParseConstraint(dep.Version); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid version range '%s' for dependency '%s'", dep.Version, dep.ID))
+		}
+	}
+
+	for i, key := range m.RequiredConfig {
+		if strings.TrimSpace(key) == "" {
+			problems = append(problems, fmt.Sprintf("required_config[%d] is empty", i))
+		}
+	}
+
+	if len(problems) > 0 {
+		return failure.New(component.ErrInvalidItem, "%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// PluginDependencies converts the declared dependencies.
+func (m *Manifest) PluginDependencies() []plugin.Dependency {
+	deps := make([]plugin.Dependency, 0, len(m.Dependencies))
+	for _, dep := range m.Dependencies {
+		deps = append(deps, plugin.Dependency{
+			ID:           component.ComponentID(dep.ID),
+			VersionRange: dep.Version,
+			Optional:     dep.Optional,
+		})
+	}
+	return deps
+}
+
+// EntrypointPath returns the entrypoint path resolved against the manifest directory.
+func (m *Manifest) EntrypointPath() string {
+	if filepath.IsAbs(m.Entrypoint.Path) {
+		return m.Entrypoint.Path
+	}
+	return filepath.Join(m.Dir, m.Entrypoint.Path)
+}
+
+// knownPluginType reports whether t is one of the plugin type constants.
+func knownPluginType(t plugin.PluginType) bool {
+	switch t {
+	case plugin.TypeExtension, plugin.TypeIntegration, plugin.TypeMiddleware,
+		plugin.TypeConnector, plugin.TypeProcessor, plugin.TypeAdapter:
+		return true
+	}
+	return false
+}
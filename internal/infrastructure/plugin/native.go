@@ -0,0 +1,39 @@
+//go:build linux && cgo
+
+package plugin
+
+import (
+	goplugin "plugin"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+)
+
+// openNativePlugin opens a Go plugin built with -buildmode=plugin and resolves
+// symbol to a plugin.Plugin. The symbol may be a plugin.Plugin variable, a
+// value implementing plugin.Plugin or a func() plugin.Plugin.
+func openNativePlugin(path, symbol string) (plugin.Plugin, error) {
+	lib, err := goplugin.Open(path)
+	if err != nil {
+		return nil, failure.Wrap(err, component.ErrPluginLoadFailed, "cannot open native plugin '%s'", path)
+	}
+
+	sym, err := lib.Lookup(symbol)
+	if err != nil {
+		return nil, failure.Wrap(err, component.ErrPluginLoadFailed, "native plugin '%s' has no symbol '%s'", path, symbol)
+	}
+
+	switch v := sym.(type) {
+	case func() plugin.Plugin:
+		return v(), nil
+	case *plugin.Plugin:
+		if *v != nil {
+			return *v, nil
+		}
+	case plugin.Plugin:
+		return v, nil
+	}
+
+	return nil, failure.New(component.ErrPluginLoadFailed, "symbol '%s' in native plugin '%s' is %T, not a plugin.Plugin", symbol, path, sym)
+}
@@ -0,0 +1,14 @@
+//go:build !linux || !cgo
+
+package plugin
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+)
+
+// openNativePlugin reports that native plugins are not supported on this platform.
+func openNativePlugin(path, symbol string) (plugin.Plugin, error) {
+	return nil, failure.New(component.ErrPluginLoadFailed, "cannot open native plugin '%s': native plugins require linux and cgo", path)
+}
@@ -0,0 +1,98 @@
+package plugin
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/runtime"
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+)
+
+// providers forwards the optional providers of a runtime, such as
+// supervisor.Provider, through the wrappers handed to plugins, which would
+// otherwise hide them. Each accessor returns nil, or an empty section, when the
+// wrapped runtime does not implement the provider.
+type providers struct {
+	env runtime.RuntimeEnvironment
+}
+
+// PluginConfiguration returns the configuration section of a plugin.
+func (p providers) PluginConfiguration(pluginID component.ComponentID) config.Section {
+	if provider, ok := p.env.(runtime.PluginConfigurationProvider); ok {
+		return provider.PluginConfiguration(pluginID)
+	}
+	return infraConfig.NewSections(infraConfig.NewMemoryConfiguration()).Plugin(string(pluginID))
+}
+
+// Status returns the lifecycle state of the runtime.
+func (p providers) Status() component.ServiceStatus {
+	if provider, ok := p.env.(runtime.StatusProvider); ok {
+		return provider.Status()
+	}
+	if p.env.IsRunning() {
+		return component.StatusRunning
+	}
+	return component.StatusStopped
+}
+
+// Supervisor returns the service supervisor.
+func (p providers) Supervisor() supervisor.Supervisor {
+	if provider, ok := p.env.(supervisor.Provider); ok {
+		return provider.Supervisor()
+	}
+	return nil
+}
+
+// Resilience returns the operation guard.
+func (p providers) Resilience() resilience.Guard {
+	if provider, ok := p.env.(resilience.Provider); ok {
+		return provider.Resilience()
+	}
+	return nil
+}
+
+// Idempotency returns the deduplicator.
+func (p providers) Idempotency() idempotency.Deduplicator {
+	if provider, ok := p.env.(idempotency.Provider); ok {
+		return provider.Idempotency()
+	}
+	return nil
+}
+
+// Health returns the health checker.
+func (p providers) Health() health.Checker {
+	if provider, ok := p.env.(health.Provider); ok {
+		return provider.Health()
+	}
+	return nil
+}
+
+// Metrics returns the metrics registry.
+func (p providers) Metrics() metrics.Registry {
+	if provider, ok := p.env.(metrics.Provider); ok {
+		return provider.Metrics()
+	}
+	return nil
+}
+
+// Tracer returns the tracer.
+func (p providers) Tracer() tracing.Tracer {
+	if provider, ok := p.env.(tracing.Provider); ok {
+		return provider.Tracer()
+	}
+	return nil
+}
+
+// Introspection returns the inspector.
+func (p providers) Introspection() introspection.Inspector {
+	if provider, ok := p.env.(introspection.Provider); ok {
+		return provider.Introspection()
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package remote
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// client sends requests over a connection to a plugin process and matches
+// responses to pending calls. It is safe for concurrent use.
+type client struct {
+	rwc     io.ReadWriteCloser
+	enc     *json.Encoder
+	encMu   sync.Mutex
+	nextID  atomic.Uint64
+	pending map[uint64]chan response
+	closed  chan struct{}
+	err     error
+	mu      sync.Mutex
+}
+
+// newClient starts reading responses from rwc.
+func newClient(rwc io.ReadWriteCloser) *client {
+	c := &client{
+		rwc:     rwc,
+		enc:     json.NewEncoder(rwc),
+		pending: make(map[uint64]chan response),
+		closed:  make(chan struct{}),
+	}
+	go c.readLoop()
+	return c
+}
+
+// call sends a request and waits for its response, the context to be done or the
+// connection to close. When result is non-nil the response result is decoded into it.
+func (c *client) call(ctx context.Context, method string, params, result any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return failure.Wrap(err, ErrInvalidParams, "cannot encode %s parameters", method)
+	}
+
+	id := c.nextID.Add(1)
+	ch := make(chan response, 1)
+
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		return err
+	}
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	c.encMu.Lock()
+	err = c.enc.Encode(request{ID: id, Method: method, Params: raw})
+	c.encMu.Unlock()
+	if err != nil {
+		c.forget(id)
+		c.close(failure.Wrap(err, ErrPluginUnavailable, "write failed"))
+		return c.closeErr()
+	}
+
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result != nil && len(resp.Result) > 0 {
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return failure.Wrap(err, ErrInvalidParams, "cannot decode %s result", method)
+			}
+		}
+		return nil
+	case <-c.closed:
+		return c.closeErr()
+	case <-done:
+		c.forget(id)
+		return ctx.Err()
+	}
+}
+
+// readLoop dispatches responses until the connection fails.
+func (c *client) readLoop() {
+	dec := json.NewDecoder(c.rwc)
+	for {
+		var resp response
+		if err := dec.Decode(&resp); err != nil {
+			if err == io.EOF {
+				c.close(failure.New(ErrPluginUnavailable, "connection closed"))
+			} else {
+				c.close(failure.Wrap(err, ErrPluginUnavailable, "read failed"))
+			}
+			return
+		}
+
+		c.mu.Lock()
+		ch, ok := c.pending[resp.ID]
+		delete(c.pending, resp.ID)
+		c.mu.Unlock()
+
+		if ok {
+			ch <- resp
+		}
+	}
+}
+
+// forget drops a pending call whose caller gave up waiting.
+func (c *client) forget(id uint64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// close closes the connection and fails all pending and future calls with err.
+func (c *client) close(err error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.err = err
+	c.pending = make(map[uint64]chan response)
+	c.mu.Unlock()
+
+	close(c.closed)
+	c.rwc.Close()
+}
+
+// closeErr returns the error the connection was closed with.
+func (c *client) closeErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
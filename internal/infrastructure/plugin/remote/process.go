@@ -0,0 +1,659 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/runtime"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+)
+
+// Transport selects how the host and a plugin process exchange messages.
+type Transport string
+
+// Transport constants
+const (
+	TransportStdio Transport = "stdio" // the plugin's standard input and output
+	TransportUnix  Transport = "unix"  // a Unix socket created by the host
+)
+
+// ProcessConfig describes a plugin executable and how to supervise it.
+type ProcessConfig struct {
+	// ID is the plugin ID. The manifest returned by the process must match it.
+	ID component.ComponentID
+
+	// Version is reported until the process has advertised its own version,
+	// so that dependency resolution can run before the process is launched.
+	Version string
+
+	// Dependencies are the plugins this plugin requires; see plugin.DependentPlugin.
+	Dependencies []plugin.Dependency
+
+	// Path and Args are the executable and its arguments; Env is appended to
+	// the host's environment.
+	Path string
+	Args []string
+	Env  []string
+
+	// Transport defaults to TransportStdio. For TransportUnix the socket is
+	// created in SocketDir, which defaults to the system temporary directory.
+	Transport Transport
+	SocketDir string
+
+	// Stderr receives the plugin's standard error. Defaults to os.Stderr.
+	Stderr io.Writer
+
+	// HandshakeTimeout bounds launching the process and receiving its manifest.
+	// Defaults to 10 seconds.
+	HandshakeTimeout time.Duration
+
+	// MaxRestarts is how many times in a row a crashed process is restarted
+	// before the plugin is marked failed. Zero uses the default of 5; negative
+	// disables restarts.
+	MaxRestarts int
+
+	// RestartBackoff is the delay before the first restart; it doubles with
+	// every further restart up to 30 seconds. Defaults to 500 milliseconds.
+	RestartBackoff time.Duration
+
+	// StableUptime is how long a process must run before its crash counts as
+	// the first in a row again, resetting the restart count and the backoff.
+	// Defaults to one minute.
+	StableUptime time.Duration
+}
+
+// Defaults applied to a ProcessConfig.
+const (
+	DefaultHandshakeTimeout = 10 * time.Second
+	DefaultMaxRestarts      = 5
+	DefaultRestartBackoff   = 500 * time.Millisecond
+	DefaultStableUptime     = time.Minute
+	maxRestartBackoff       = 30 * time.Second
+)
+
+// ProcessPlugin is a plugin.Plugin backed by an executable speaking the remote
+// plugin protocol. Initializing it launches the process and registers a local
+// proxy for every operation and service the process advertises, so that calls
+// such as ExecuteOperation cross the process boundary transparently.
+//
+// A process that exits unexpectedly is restarted with exponential backoff and
+// its services are started again if the plugin is running. Calls made while the
+// process is down fail with ErrPluginUnavailable.
+type ProcessPlugin struct {
+	*infraComponent.BaseService
+	config         ProcessConfig
+	manifest       *Manifest
+	proc           *process
+	serviceProxies []*serviceProxy
+	logger         logging.Logger
+	restarts       int
+	disposed       bool
+	done           chan struct{} // closed on Dispose
+	mu             sync.RWMutex
+}
+
+// process is one running instance of the plugin executable.
+type process struct {
+	cmd     *exec.Cmd
+	client  *client
+	exited  chan struct{}
+	started time.Time
+}
+
+// NewProcessPlugin creates a plugin for the described executable. The process is
+// not launched until the plugin is initialized.
+func NewProcessPlugin(config ProcessConfig) *ProcessPlugin {
+	if config.Transport == "" {
+		config.Transport = TransportStdio
+	}
+	if config.Stderr == nil {
+		config.Stderr = os.Stderr
+	}
+	if config.HandshakeTimeout <= 0 {
+		config.HandshakeTimeout = DefaultHandshakeTimeout
+	}
+	if config.MaxRestarts == 0 {
+		config.MaxRestarts = DefaultMaxRestarts
+	}
+	if config.RestartBackoff <= 0 {
+		config.RestartBackoff = DefaultRestartBackoff
+	}
+	if config.StableUptime <= 0 {
+		config.StableUptime = DefaultStableUptime
+	}
+
+	return &ProcessPlugin{
+		BaseService: infraComponent.NewBaseService(component.ComponentConfig{
+			ID:      config.ID,
+			Name:    string(config.ID),
+			Version: config.Version,
+		}),
+		config: config,
+		logger: infraLogging.NewNoOpLogger(),
+		done:   make(chan struct{}),
+	}
+}
+
+// Name returns the name advertised by the process, or the plugin ID before launch.
+func (p *ProcessPlugin) Name() string {
+	if m := p.Manifest(); m != nil && m.Name != "" {
+		return m.Name
+	}
+	return p.BaseService.Name()
+}
+
+// Description returns the description advertised by the process.
+func (p *ProcessPlugin) Description() string {
+	if m := p.Manifest(); m != nil {
+		return m.Description
+	}
+	return p.BaseService.Description()
+}
+
+// Version returns the version advertised by the process, or the configured version before launch.
+func (p *ProcessPlugin) Version() string {
+	if m := p.Manifest(); m != nil && m.Version != "" {
+		return m.Version
+	}
+	return p.BaseService.Version()
+}
+
+// Author returns the author advertised by the process.
+func (p *ProcessPlugin) Author() string {
+	if m := p.Manifest(); m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+// PluginType returns the plugin type advertised by the process.
+func (p *ProcessPlugin) PluginType() plugin.PluginType {
+	if m := p.Manifest(); m != nil && m.Type != "" {
+		return plugin.PluginType(m.Type)
+	}
+	return plugin.TypeExtension
+}
+
+// Dependencies returns the dependencies declared in the configuration.
+func (p *ProcessPlugin) Dependencies() []plugin.Dependency {
+	return p.config.Dependencies
+}
+
+// Manifest returns the manifest received in the last handshake, or nil before launch.
+func (p *ProcessPlugin) Manifest() *Manifest {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.manifest
+}
+
+// Initialize launches the process and registers proxies for the operations and
+// services it advertises. If a proxy cannot be registered, the proxies already
+// registered are removed and the process is terminated.
+func (p *ProcessPlugin) Initialize(ctx context.Context, system component.System) error {
+	if err := p.BaseService.Initialize(ctx, system); err != nil {
+		return err
+	}
+
+	if env, ok := system.(runtime.RuntimeEnvironment); ok {
+		p.logger = env.Logger()
+	}
+
+	if err := p.launch(); err != nil {
+		return failure.Wrap(err, component.ErrPluginLoadFailed, "plugin '%s'", p.ID())
+	}
+
+	manifest := p.Manifest()
+	registry := system.Registry()
+	var registered []component.ComponentID
+	for _, desc := range manifest.Operations {
+		proxy := newOperationProxy(p, desc)
+		if err := registry.Register(proxy); err != nil {
+			p.abortInitialize(registry, registered)
+			return failure.Wrap(err, component.ErrPluginLoadFailed, "plugin '%s'", p.ID())
+		}
+		registered = append(registered, proxy.ID())
+	}
+	for _, desc := range manifest.Services {
+		proxy := newServiceProxy(p, desc)
+		if err := registry.Register(proxy); err != nil {
+			p.abortInitialize(registry, registered)
+			return failure.Wrap(err, component.ErrPluginLoadFailed, "plugin '%s'", p.ID())
+		}
+		registered = append(registered, proxy.ID())
+		p.mu.Lock()
+		p.serviceProxies = append(p.serviceProxies, proxy)
+		p.mu.Unlock()
+	}
+
+	return nil
+}
+
+// abortInitialize unregisters the proxies registered so far, in reverse order,
+// and terminates the process without restarting it.
+func (p *ProcessPlugin) abortInitialize(registry component.Registry, registered []component.ComponentID) {
+	for i := len(registered) - 1; i >= 0; i-- {
+		if err := registry.Unregister(registered[i]); err != nil {
+			p.logger.Warn("Failed to unregister plugin proxy", "plugin_id", p.ID(), "component_id", registered[i], "error", err)
+		}
+	}
+
+	// Clearing the current process stops its supervisor from restarting it.
+	p.mu.Lock()
+	proc := p.proc
+	p.proc = nil
+	p.manifest = nil
+	p.serviceProxies = nil
+	p.mu.Unlock()
+
+	if proc != nil {
+		proc.terminate(p.config.HandshakeTimeout)
+	}
+}
+
+// Start starts the services provided by the process. If a service fails to
+// start, the services started by this call are stopped in reverse order and
+// the returned error joins the start failure with any rollback failures.
+func (p *ProcessPlugin) Start(ctx context.Context) error {
+	var started []*serviceProxy
+	for _, proxy := range p.services() {
+		if proxy.IsRunning() {
+			continue
+		}
+		if err := proxy.Start(ctx); err != nil {
+			errs := []error{failure.Wrap(err, component.ErrServiceStartFailed, "service '%s'", proxy.ID())}
+			for i := len(started) - 1; i >= 0; i-- {
+				if err := started[i].Stop(ctx); err != nil {
+					errs = append(errs, failure.Wrap(err, component.ErrServiceStopFailed, "rollback of service '%s' failed", started[i].ID()))
+				}
+			}
+			return errors.Join(errs...)
+		}
+		started = append(started, proxy)
+	}
+	return p.BaseService.Start(ctx)
+}
+
+// Stop stops the services provided by the process. The process itself keeps
+// running so its operations stay available until the plugin is disposed.
+func (p *ProcessPlugin) Stop(ctx context.Context) error {
+	var errs []error
+	services := p.services()
+	for i := len(services) - 1; i >= 0; i-- {
+		if err := services[i].Stop(ctx); err != nil {
+			errs = append(errs, failure.Wrap(err, component.ErrServiceStopFailed, "service '%s'", services[i].ID()))
+		}
+	}
+	if err := p.BaseService.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Dispose asks the process to shut down and stops supervising it. A process
+// that does not exit within the handshake timeout is killed.
+func (p *ProcessPlugin) Dispose() error {
+	p.mu.Lock()
+	if !p.disposed {
+		p.disposed = true
+		close(p.done)
+	}
+	proc := p.proc
+	p.mu.Unlock()
+
+	if proc != nil {
+		proc.terminate(p.config.HandshakeTimeout)
+	}
+	return p.BaseService.Dispose()
+}
+
+// Restarts returns how many times in a row the process has been restarted after
+// crashing. The count is reset once a process has run for StableUptime.
+func (p *ProcessPlugin) Restarts() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.restarts
+}
+
+// execute runs an operation in the plugin process.
+func (p *ProcessPlugin) execute(ctx context.Context, id component.ComponentID, input component.Input) (component.Output, error) {
+	var result ExecuteResult
+	req := ExecuteRequest{OperationID: string(id), Data: input.Data, Metadata: input.Metadata}
+	if err := p.call(ctx, MethodExecute, req, &result); err != nil {
+		return component.Output{}, err
+	}
+	return component.Output{Data: result.Data}, nil
+}
+
+// call sends a request to the current process.
+func (p *ProcessPlugin) call(ctx context.Context, method string, params, result any) error {
+	p.mu.RLock()
+	proc := p.proc
+	p.mu.RUnlock()
+
+	if proc == nil {
+		return failure.New(ErrPluginUnavailable, "plugin '%s' is not running", p.ID())
+	}
+	return proc.client.call(ctx, method, params, result)
+}
+
+// services returns the proxies of the services advertised by the process.
+func (p *ProcessPlugin) services() []*serviceProxy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]*serviceProxy(nil), p.serviceProxies...)
+}
+
+// launch starts the process, performs the handshake and begins supervising it.
+func (p *ProcessPlugin) launch() error {
+	proc, err := p.connect()
+	if err != nil {
+		return err
+	}
+	go p.supervise(proc)
+	return nil
+}
+
+// checkManifest verifies that a manifest belongs to this plugin. On restart the
+// advertised components must not change, because proxies are already registered.
+func (p *ProcessPlugin) checkManifest(m Manifest) error {
+	if m.ProtocolVersion != ProtocolVersion {
+		return failure.New(ErrProtocolMismatch, "plugin speaks protocol version %d, host speaks %d", m.ProtocolVersion, ProtocolVersion)
+	}
+	if component.ComponentID(m.ID) != p.config.ID {
+		return failure.New(ErrHandshakeFailed, "process identifies as '%s', expected '%s'", m.ID, p.config.ID)
+	}
+
+	previous := p.Manifest()
+	if previous != nil && (!sameComponents(previous.Operations, m.Operations) || !sameComponents(previous.Services, m.Services)) {
+		return failure.New(ErrHandshakeFailed, "restarted process advertises different components")
+	}
+	return nil
+}
+
+// supervise waits for the process to exit and restarts it unless it was disposed.
+// A process that ran for StableUptime starts a new series of restarts.
+func (p *ProcessPlugin) supervise(proc *process) {
+	<-proc.exited
+
+	p.mu.Lock()
+	if time.Since(proc.started) >= p.config.StableUptime {
+		p.restarts = 0
+	}
+	p.mu.Unlock()
+
+	for {
+		p.mu.Lock()
+		if p.disposed || p.proc != proc {
+			p.mu.Unlock()
+			return
+		}
+		if p.config.MaxRestarts < 0 || p.restarts >= p.config.MaxRestarts {
+			p.proc = nil
+			p.mu.Unlock()
+			p.SetStatus(component.StatusError)
+			p.logger.Error("Plugin process exited and will not be restarted",
+				"plugin_id", p.ID(), "restarts", p.Restarts(), "error", ErrRestartLimitReached)
+			return
+		}
+		p.restarts++
+		attempt := p.restarts
+		p.mu.Unlock()
+
+		delay := p.config.RestartBackoff << (attempt - 1)
+		if delay > maxRestartBackoff || delay <= 0 {
+			delay = maxRestartBackoff
+		}
+		p.logger.Warn("Plugin process exited, restarting", "plugin_id", p.ID(), "attempt", attempt, "delay", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-p.done:
+			timer.Stop()
+			return
+		}
+
+		next, err := p.connect()
+		if err != nil {
+			p.logger.Error("Plugin process restart failed", "plugin_id", p.ID(), "attempt", attempt, "error", err)
+			continue
+		}
+
+		p.restartServices()
+
+		p.logger.Info("Plugin process restarted", "plugin_id", p.ID(), "attempt", attempt)
+		go p.supervise(next)
+		return
+	}
+}
+
+// restartServices starts the services that were running before the process crashed.
+func (p *ProcessPlugin) restartServices() {
+	for _, proxy := range p.services() {
+		if !proxy.IsRunning() {
+			continue
+		}
+		ctx := infraContext.NewContextWithTimeout(p.config.HandshakeTimeout)
+		err := p.call(ctx, MethodStartService, ServiceRequest{ServiceID: string(proxy.ID())}, nil)
+		ctx.Cancel()
+		if err != nil {
+			proxy.SetRunning(false)
+			proxy.SetStatus(component.StatusError)
+			p.logger.Error("Plugin service failed to start after restart",
+				"plugin_id", p.ID(), "service_id", proxy.ID(), "error", err)
+		}
+	}
+}
+
+// connect starts a process, performs the handshake and makes it the current process.
+func (p *ProcessPlugin) connect() (*process, error) {
+	proc, err := p.spawn()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := infraContext.NewContextWithTimeout(p.config.HandshakeTimeout)
+	defer ctx.Cancel()
+
+	var manifest Manifest
+	if err := proc.client.call(ctx, MethodHandshake, HandshakeRequest{ProtocolVersion: ProtocolVersion}, &manifest); err != nil {
+		proc.terminate(0)
+		return nil, failure.Wrap(err, ErrHandshakeFailed, "")
+	}
+	if err := p.checkManifest(manifest); err != nil {
+		proc.terminate(0)
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.disposed {
+		proc.terminate(0)
+		return nil, failure.New(ErrPluginUnavailable, "plugin '%s' has been disposed", p.ID())
+	}
+	proc.started = time.Now()
+	p.proc = proc
+	p.manifest = &manifest
+	return proc, nil
+}
+
+// spawn starts the executable and connects to it over the configured transport.
+func (p *ProcessPlugin) spawn() (*process, error) {
+	cmd := exec.Command(p.config.Path, p.config.Args...)
+	cmd.Env = append(os.Environ(), p.config.Env...)
+	cmd.Stderr = p.config.Stderr
+
+	switch p.config.Transport {
+	case TransportStdio:
+		return spawnStdio(cmd)
+	case TransportUnix:
+		return spawnUnix(cmd, p.config.SocketDir, p.config.ID, p.config.HandshakeTimeout)
+	default:
+		return nil, failure.New(component.ErrInvalidComponentConfig, "unknown transport '%s'", p.config.Transport)
+	}
+}
+
+// spawnStdio starts cmd with its standard input and output connected to the host.
+// The host keeps its own ends of the pipes, so responses written just before the
+// process exits are still read.
+func spawnStdio(cmd *exec.Cmd) (*process, error) {
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		stdinR.Close()
+		stdinW.Close()
+		return nil, err
+	}
+
+	cmd.Stdin = stdinR
+	cmd.Stdout = stdoutW
+	err = cmd.Start()
+	stdinR.Close()
+	stdoutW.Close()
+	if err != nil {
+		stdinW.Close()
+		stdoutR.Close()
+		return nil, failure.Wrap(err, ErrPluginUnavailable, "cannot start '%s'", cmd.Path)
+	}
+
+	return watch(cmd, newClient(pipeConn{Reader: stdoutR, Writer: stdinW, closers: []io.Closer{stdoutR, stdinW}})), nil
+}
+
+// spawnUnix creates a socket, starts cmd with its path in SocketEnv and waits
+// for the process to connect.
+func spawnUnix(cmd *exec.Cmd, dir string, id component.ComponentID, timeout time.Duration) (*process, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	path := filepath.Join(dir, fmt.Sprintf("skeleton-%s-%d-%d.sock", id, os.Getpid(), time.Now().UnixNano()))
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, failure.Wrap(err, ErrPluginUnavailable, "cannot listen on '%s'", path)
+	}
+	defer listener.Close()
+
+	cmd.Env = append(cmd.Env, SocketEnv+"="+path)
+	if err := cmd.Start(); err != nil {
+		return nil, failure.Wrap(err, ErrPluginUnavailable, "cannot start '%s'", cmd.Path)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	accepted := make(chan net.Conn, 1)
+	acceptErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			acceptErr <- err
+			return
+		}
+		accepted <- conn
+	}()
+
+	select {
+	case conn := <-accepted:
+		proc := &process{cmd: cmd, client: newClient(conn), exited: make(chan struct{})}
+		go func() {
+			<-exited
+			proc.client.close(failure.New(ErrPluginUnavailable, "plugin process exited"))
+			close(proc.exited)
+		}()
+		return proc, nil
+	case err := <-acceptErr:
+		cmd.Process.Kill()
+		return nil, failure.Wrap(err, ErrPluginUnavailable, "accept failed")
+	case err := <-exited:
+		return nil, failure.New(ErrPluginUnavailable, "process exited before connecting: %v", err)
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		return nil, failure.New(ErrPluginUnavailable, "process did not connect within %s", timeout)
+	}
+}
+
+// watch waits for cmd to exit in the background.
+func watch(cmd *exec.Cmd, c *client) *process {
+	proc := &process{cmd: cmd, client: c, exited: make(chan struct{})}
+	go func() {
+		cmd.Wait()
+		// Let the reader drain what the process wrote before it exited.
+		select {
+		case <-c.closed:
+		case <-time.After(time.Second):
+			c.close(failure.New(ErrPluginUnavailable, "plugin process exited"))
+		}
+		close(proc.exited)
+	}()
+	return proc
+}
+
+// terminate asks the process to shut down and kills it if it has not exited
+// within timeout.
+func (proc *process) terminate(timeout time.Duration) {
+	if timeout > 0 {
+		ctx := infraContext.NewContextWithTimeout(timeout)
+		proc.client.call(ctx, MethodShutdown, struct{}{}, nil)
+		ctx.Cancel()
+
+		select {
+		case <-proc.exited:
+			return
+		case <-time.After(timeout):
+		}
+	}
+
+	proc.cmd.Process.Kill()
+	proc.client.close(failure.New(ErrPluginUnavailable, "plugin process terminated"))
+	<-proc.exited
+}
+
+// sameComponents reports whether two descriptor lists advertise the same IDs.
+func sameComponents(a, b []ComponentDescriptor) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := make(map[string]bool, len(a))
+	for _, d := range a {
+		ids[d.ID] = true
+	}
+	for _, d := range b {
+		if !ids[d.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+// pipeConn joins the host's ends of a process's standard streams.
+type pipeConn struct {
+	io.Reader
+	io.Writer
+	closers []io.Closer
+}
+
+// Close closes both pipes.
+func (c pipeConn) Close() error {
+	var errs []error
+	for _, closer := range c.closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
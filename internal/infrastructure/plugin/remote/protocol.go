@@ -0,0 +1,123 @@
+// Package remote runs plugins in separate processes and talks to them over a
+// small, versioned request/response protocol carried on stdio or a Unix socket.
+//
+// Messages are newline-delimited JSON objects. The host sends requests and the
+// plugin process answers each one with a response carrying the same ID; requests
+// may be answered out of order. The first request on every connection is a
+// handshake in which the host states its protocol version and the plugin replies
+// with a manifest advertising the operations and services it provides.
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// ProtocolVersion is the protocol version spoken by this package. Hosts and
+// plugins refuse to talk to a peer that speaks a different version.
+const ProtocolVersion = 1
+
+// SocketEnv names the environment variable through which the host tells a plugin
+// process which Unix socket to connect to. When it is unset the plugin speaks the
+// protocol on its standard input and output.
+const SocketEnv = "SKELETON_PLUGIN_SOCKET"
+
+// Protocol methods.
+const (
+	MethodHandshake    = "plugin.handshake"
+	MethodShutdown     = "plugin.shutdown"
+	MethodExecute      = "operation.execute"
+	MethodStartService = "service.start"
+	MethodStopService  = "service.stop"
+)
+
+// Remote plugin error codes
+const (
+	ErrProtocolMismatch    failure.Code = "remote.protocol_mismatch"
+	ErrHandshakeFailed     failure.Code = "remote.handshake_failed"
+	ErrPluginUnavailable   failure.Code = "remote.plugin_unavailable"
+	ErrUnknownMethod       failure.Code = "remote.unknown_method"
+	ErrInvalidParams       failure.Code = "remote.invalid_params"
+	ErrRestartLimitReached failure.Code = "remote.restart_limit_reached"
+)
+
+// request is a call from the host to the plugin process.
+type request struct {
+	ID     uint64          `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// response answers the request with the same ID.
+type response struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is an error returned by the remote side of a call.
+type RPCError struct {
+	Code    failure.Code `json:"code"`
+	Message string       `json:"message"`
+}
+
+// Error returns the error code followed by the remote error message.
+func (e *RPCError) Error() string {
+	if e.Message == "" {
+		return string(e.Code)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is reports whether the remote error has the code of target, so that errors
+// raised in a plugin process match the same sentinels as local ones.
+func (e *RPCError) Is(target error) bool {
+	return (&failure.Error{Code: e.Code}).Is(target)
+}
+
+// HandshakeRequest opens a connection.
+type HandshakeRequest struct {
+	ProtocolVersion int `json:"protocol_version"`
+}
+
+// Manifest describes a plugin process and the components it provides.
+type Manifest struct {
+	ProtocolVersion int                   `json:"protocol_version"`
+	ID              string                `json:"id"`
+	Name            string                `json:"name"`
+	Description     string                `json:"description,omitempty"`
+	Version         string                `json:"version"`
+	Author          string                `json:"author,omitempty"`
+	Type            string                `json:"type,omitempty"`
+	Operations      []ComponentDescriptor `json:"operations,omitempty"`
+	Services        []ComponentDescriptor `json:"services,omitempty"`
+}
+
+// ComponentDescriptor describes an operation or service provided by a plugin process.
+type ComponentDescriptor struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// ExecuteRequest asks the plugin to run one of its operations.
+// Data must be JSON-serializable; it arrives on the other side in its decoded
+// JSON form (objects become map[string]interface{}, numbers float64).
+type ExecuteRequest struct {
+	OperationID string            `json:"operation_id"`
+	Data        any               `json:"data,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// ExecuteResult carries the output of an operation.
+type ExecuteResult struct {
+	Data any `json:"data,omitempty"`
+}
+
+// ServiceRequest asks the plugin to start or stop one of its services.
+type ServiceRequest struct {
+	ServiceID string `json:"service_id"`
+}
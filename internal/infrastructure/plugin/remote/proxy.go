@@ -0,0 +1,76 @@
+package remote
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+)
+
+// operationProxy is the local stand-in for an operation running in a plugin process.
+type operationProxy struct {
+	*infraComponent.BaseOperation
+	host *ProcessPlugin
+}
+
+// newOperationProxy creates a proxy for an advertised operation.
+func newOperationProxy(host *ProcessPlugin, desc ComponentDescriptor) *operationProxy {
+	return &operationProxy{
+		BaseOperation: infraComponent.NewBaseOperation(proxyConfig(host, desc)),
+		host:          host,
+	}
+}
+
+// Execute runs the operation in the plugin process.
+func (o *operationProxy) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	return o.host.execute(ctx, o.ID(), input)
+}
+
+// serviceProxy is the local stand-in for a service running in a plugin process.
+type serviceProxy struct {
+	*infraComponent.BaseService
+	host *ProcessPlugin
+}
+
+// newServiceProxy creates a proxy for an advertised service.
+func newServiceProxy(host *ProcessPlugin, desc ComponentDescriptor) *serviceProxy {
+	return &serviceProxy{
+		BaseService: infraComponent.NewBaseService(proxyConfig(host, desc)),
+		host:        host,
+	}
+}
+
+// Start starts the service in the plugin process.
+func (s *serviceProxy) Start(ctx context.Context) error {
+	if s.IsRunning() {
+		return nil
+	}
+	if err := s.host.call(ctx, MethodStartService, ServiceRequest{ServiceID: string(s.ID())}, nil); err != nil {
+		return err
+	}
+	return s.BaseService.Start(ctx)
+}
+
+// Stop stops the service in the plugin process.
+func (s *serviceProxy) Stop(ctx context.Context) error {
+	if !s.IsRunning() {
+		return nil
+	}
+	if err := s.host.call(ctx, MethodStopService, ServiceRequest{ServiceID: string(s.ID())}, nil); err != nil {
+		return err
+	}
+	return s.BaseService.Stop(ctx)
+}
+
+// proxyConfig builds the configuration of a proxy from its descriptor.
+func proxyConfig(host *ProcessPlugin, desc ComponentDescriptor) component.ComponentConfig {
+	return component.ComponentConfig{
+		ID:          component.ComponentID(desc.ID),
+		Name:        desc.Name,
+		Description: desc.Description,
+		Version:     desc.Version,
+		Properties: component.Metadata{
+			"plugin": string(host.ID()),
+			"remote": true,
+		},
+	}
+}
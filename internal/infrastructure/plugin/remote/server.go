@@ -0,0 +1,300 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+// PluginInfo describes a plugin served from its own process.
+type PluginInfo struct {
+	ID          component.ComponentID
+	Name        string
+	Description string
+	Version     string
+	Author      string
+	Type        string
+}
+
+// Server exposes operations and services to a host over the remote plugin
+// protocol. It is used inside the plugin executable:
+//
+//	server := remote.NewServer(remote.PluginInfo{ID: "pricing", Name: "Pricing", Version: "1.0.0"})
+//	server.AddOperation(NewQuoteOperation())
+//	if err := server.Serve(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Components added to the server are not initialized by it; they run outside the
+// host and have no access to the host's System.
+type Server struct {
+	info       PluginInfo
+	operations map[component.ComponentID]component.Operation
+	services   map[component.ComponentID]component.Service
+	order      []component.ComponentID
+	shutdown   chan struct{}
+	once       sync.Once
+	mu         sync.RWMutex
+}
+
+// NewServer creates a server for the described plugin.
+func NewServer(info PluginInfo) *Server {
+	if info.Version == "" {
+		info.Version = "1.0.0"
+	}
+	return &Server{
+		info:       info,
+		operations: make(map[component.ComponentID]component.Operation),
+		services:   make(map[component.ComponentID]component.Service),
+		shutdown:   make(chan struct{}),
+	}
+}
+
+// AddOperation exposes an operation to the host.
+func (s *Server) AddOperation(op component.Operation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.operations[op.ID()]; !exists {
+		s.order = append(s.order, op.ID())
+	}
+	s.operations[op.ID()] = op
+}
+
+// AddService exposes a service to the host. The host starts and stops it
+// together with the plugin.
+func (s *Server) AddService(svc component.Service) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.services[svc.ID()]; !exists {
+		s.order = append(s.order, svc.ID())
+	}
+	s.services[svc.ID()] = svc
+}
+
+// Serve speaks the protocol on the Unix socket named by SocketEnv, or on
+// standard input and output when it is unset. Anything the plugin wants to log
+// must then go to standard error. Serve returns when the host disconnects or
+// asks the plugin to shut down.
+func (s *Server) Serve() error {
+	if path := os.Getenv(SocketEnv); path != "" {
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return failure.Wrap(err, ErrPluginUnavailable, "cannot connect to host socket '%s'", path)
+		}
+		return s.ServeConn(conn)
+	}
+	return s.ServeConn(stdio{Reader: os.Stdin, Writer: os.Stdout})
+}
+
+// ServeConn speaks the protocol on rwc until the peer disconnects or asks the
+// plugin to shut down. Running services are stopped before it returns.
+func (s *Server) ServeConn(rwc io.ReadWriteCloser) error {
+	defer rwc.Close()
+	defer s.stopServices()
+
+	enc := json.NewEncoder(rwc)
+	var encMu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	reply := func(resp response) {
+		encMu.Lock()
+		defer encMu.Unlock()
+		enc.Encode(resp)
+	}
+
+	requests := make(chan request)
+	readErr := make(chan error, 1)
+	go func() {
+		dec := json.NewDecoder(rwc)
+		for {
+			var req request
+			if err := dec.Decode(&req); err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-s.shutdown:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case req := <-requests:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				reply(s.handle(req))
+			}()
+		case err := <-readErr:
+			if err == io.EOF {
+				return nil
+			}
+			return failure.Wrap(err, ErrPluginUnavailable, "read failed")
+		case <-s.shutdown:
+			return nil
+		}
+	}
+}
+
+// handle dispatches a single request and builds its response.
+func (s *Server) handle(req request) response {
+	result, err := s.dispatch(req)
+	resp := response{ID: req.ID}
+	if err != nil {
+		rpcErr, ok := err.(*RPCError)
+		if !ok {
+			code := failure.CodeOf(err)
+			if code == "" {
+				code = component.ErrOperationFailed
+			}
+			rpcErr = &RPCError{Code: code, Message: strings.TrimPrefix(err.Error(), string(code)+": ")}
+		}
+		resp.Error = rpcErr
+		return resp
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &RPCError{Code: ErrInvalidParams, Message: fmt.Sprintf("cannot encode result: %v", err)}
+		return resp
+	}
+	resp.Result = raw
+	return resp
+}
+
+// dispatch runs the method named by the request.
+func (s *Server) dispatch(req request) (any, error) {
+	ctx := infraContext.NewContext()
+
+	switch req.Method {
+	case MethodHandshake:
+		var params HandshakeRequest
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		if params.ProtocolVersion != ProtocolVersion {
+			return nil, &RPCError{Code: ErrProtocolMismatch, Message: fmt.Sprintf(
+				"plugin speaks protocol version %d, host speaks %d", ProtocolVersion, params.ProtocolVersion)}
+		}
+		return s.manifest(), nil
+
+	case MethodExecute:
+		var params ExecuteRequest
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		s.mu.RLock()
+		op, ok := s.operations[component.ComponentID(params.OperationID)]
+		s.mu.RUnlock()
+		if !ok {
+			return nil, &RPCError{Code: component.ErrOperationNotFound, Message: params.OperationID}
+		}
+		output, err := op.Execute(ctx, component.Input{Data: params.Data, Metadata: params.Metadata})
+		if err != nil {
+			return nil, err
+		}
+		return ExecuteResult{Data: output.Data}, nil
+
+	case MethodStartService, MethodStopService:
+		var params ServiceRequest
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		s.mu.RLock()
+		svc, ok := s.services[component.ComponentID(params.ServiceID)]
+		s.mu.RUnlock()
+		if !ok {
+			return nil, &RPCError{Code: component.ErrServiceNotFound, Message: params.ServiceID}
+		}
+		if req.Method == MethodStartService {
+			return struct{}{}, svc.Start(ctx)
+		}
+		return struct{}{}, svc.Stop(ctx)
+
+	case MethodShutdown:
+		s.once.Do(func() { close(s.shutdown) })
+		return struct{}{}, nil
+
+	default:
+		return nil, &RPCError{Code: ErrUnknownMethod, Message: req.Method}
+	}
+}
+
+// manifest describes the plugin and the components it serves.
+func (s *Server) manifest() Manifest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m := Manifest{
+		ProtocolVersion: ProtocolVersion,
+		ID:              string(s.info.ID),
+		Name:            s.info.Name,
+		Description:     s.info.Description,
+		Version:         s.info.Version,
+		Author:          s.info.Author,
+		Type:            s.info.Type,
+	}
+	for _, id := range s.order {
+		if op, ok := s.operations[id]; ok {
+			m.Operations = append(m.Operations, describe(op))
+		} else if svc, ok := s.services[id]; ok {
+			m.Services = append(m.Services, describe(svc))
+		}
+	}
+	return m
+}
+
+// stopServices stops every running service when the connection ends.
+func (s *Server) stopServices() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, svc := range s.services {
+		if svc.IsRunning() {
+			svc.Stop(infraContext.NewContext())
+		}
+	}
+}
+
+// describe builds the descriptor advertised for a component.
+func describe(comp component.Component) ComponentDescriptor {
+	return ComponentDescriptor{
+		ID:          string(comp.ID()),
+		Name:        comp.Name(),
+		Description: comp.Description(),
+		Version:     comp.Version(),
+	}
+}
+
+// decodeParams decodes request parameters into v.
+func decodeParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &RPCError{Code: ErrInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// stdio joins standard input and output into a single connection.
+type stdio struct {
+	io.Reader
+	io.Writer
+}
+
+// Close closes nothing; the process's standard streams stay open until it exits.
+func (stdio) Close() error {
+	return nil
+}
@@ -0,0 +1,146 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+)
+
+// ResolveDependencies orders plugins so that every plugin comes after the plugins
+// it depends on. Plugins without an ordering constraint between them are ordered
+// by ID, which makes the result deterministic.
+//
+// Dependencies are declared by implementing plugin.DependentPlugin. Resolution fails
+// with component.ErrPluginLoadFailed when a required dependency is missing, when a
+// present dependency does not satisfy its version range, or when a range is invalid.
+// Dependency cycles fail with component.ErrCircularDependency.
+func ResolveDependencies(plugins map[component.ComponentID]plugin.Plugin) ([]component.ComponentID, error) {
+	return resolveDependencies(plugins, func(id component.ComponentID) []plugin.Dependency {
+		return declaredDependencies(plugins[id])
+	})
+}
+
+// declaredDependencies returns the dependencies p declares itself.
+func declaredDependencies(p plugin.Plugin) []plugin.Dependency {
+	if dependent, ok := p.(plugin.DependentPlugin); ok {
+		return dependent.Dependencies()
+	}
+	return nil
+}
+
+// resolveDependencies orders plugins like ResolveDependencies, taking the
+// dependencies of each plugin from dependencies.
+func resolveDependencies(plugins map[component.ComponentID]plugin.Plugin, dependencies func(component.ComponentID) []plugin.Dependency) ([]component.ComponentID, error) {
+	ids := make([]component.ComponentID, 0, len(plugins))
+	for id := range plugins {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	// edges maps a dependency to the plugins that depend on it.
+	edges := make(map[component.ComponentID][]component.ComponentID)
+	inDegree := make(map[component.ComponentID]int, len(ids))
+	var problems []string
+
+	for _, id := range ids {
+		inDegree[id] = 0
+
+		for _, dep := range dependencies(id) {
+			target, exists := plugins[dep.ID]
+			if !exists {
+				if !dep.Optional {
+					problems = append(problems, fmt.Sprintf("plugin '%s' requires missing plugin '%s'", id, dep.ID))
+				}
+				continue
+			}
+
+			if dep.ID == id {
+				problems = append(problems, fmt.Sprintf("plugin '%s' depends on itself", id))
+				continue
+			}
+
+			if problem := checkDependencyVersion(id, dep, target); problem != "" {
+				problems = append(problems, problem)
+				continue
+			}
+
+			edges[dep.ID] = append(edges[dep.ID], id)
+			inDegree[id]++
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, failure.New(component.ErrPluginLoadFailed, "%s", strings.Join(problems, "; "))
+	}
+
+	// Kahn's algorithm, always picking the lowest ready ID.
+	var ready []component.ComponentID
+	for _, id := range ids {
+		if inDegree[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]component.ComponentID, 0, len(ids))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		for _, dependent := range edges[id] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				ready = insertSorted(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) < len(ids) {
+		var cyclic []string
+		for _, id := range ids {
+			if inDegree[id] > 0 {
+				cyclic = append(cyclic, string(id))
+			}
+		}
+		return nil, failure.Wrap(failure.New(component.ErrCircularDependency, "dependency cycle between plugins %s", strings.Join(cyclic, ", ")), component.ErrPluginLoadFailed, "")
+	}
+
+	return order, nil
+}
+
+// checkDependencyVersion validates a present dependency against its version range.
+// It returns a description of the problem, or an empty string if the dependency is satisfied.
+func checkDependencyVersion(id component.ComponentID, dep plugin.Dependency, target plugin.Plugin) string {
+	if dep.VersionRange == "" {
+		return ""
+	}
+
+	constraint, err := ParseConstraint(dep.VersionRange)
+	if err != nil {
+		return fmt.Sprintf("plugin '%s' declares an invalid range for '%s': %v", id, dep.ID, err)
+	}
+
+	version, err := ParseVersion(target.Version())
+	if err != nil {
+		return fmt.Sprintf("plugin '%s' has an invalid version '%s': %v", dep.ID, target.Version(), err)
+	}
+
+	if !constraint.Check(version) {
+		return fmt.Sprintf("plugin '%s' requires '%s' %s, found %s", id, dep.ID, constraint, version)
+	}
+
+	return ""
+}
+
+// insertSorted inserts id into an ascending slice, keeping it sorted.
+func insertSorted(ids []component.ComponentID, id component.ComponentID) []component.ComponentID {
+	i := sort.Search(len(ids), func(i int) bool { return ids[i] >= id })
+	ids = append(ids, "")
+	copy(ids[i+1:], ids[i:])
+	ids[i] = id
+	return ids
+}
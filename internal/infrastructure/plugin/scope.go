@@ -0,0 +1,777 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/runtime"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+)
+
+// capabilityScope checks access against the capabilities a plugin declared.
+type capabilityScope struct {
+	pluginID  component.ComponentID
+	caps      plugin.Capabilities
+	resources *pluginResources
+	logger    logging.Logger
+}
+
+// scopeSystem wraps the system handed to a plugin that declared capabilities so
+// that everything it did not declare is denied with component.ErrCapabilityDenied.
+// Methods that cannot return an error (such as Subscribe or GetString) log the
+// denial and behave as if the resource did not exist.
+func scopeSystem(system component.System, pluginID component.ComponentID, caps plugin.Capabilities, resources *pluginResources) component.System {
+	if system == nil {
+		return nil
+	}
+
+	scope := &capabilityScope{pluginID: pluginID, caps: caps, resources: resources}
+
+	if env, ok := system.(runtime.RuntimeEnvironment); ok {
+		scope.logger = env.Logger()
+		return &scopedRuntime{RuntimeEnvironment: env, providers: providers{env}, scope: scope}
+	}
+
+	return &scopedSystem{System: system, scope: scope}
+}
+
+// deny builds the error returned for an undeclared access.
+func (s *capabilityScope) deny(format string, args ...interface{}) error {
+	return failure.New(component.ErrCapabilityDenied, "plugin '%s' %s", s.pluginID, fmt.Sprintf(format, args...))
+}
+
+// report logs a denial that cannot be returned to the plugin.
+func (s *capabilityScope) report(err error) {
+	if s.logger != nil {
+		s.logger.Warn("Plugin capability denied", "plugin_id", s.pluginID, "error", err)
+	}
+}
+
+// checkPublish reports whether the plugin may publish to topic.
+func (s *capabilityScope) checkPublish(topic string) error {
+	if !matchesTopic(s.caps.PublishTopics, topic) {
+		return s.deny("may not publish to topic '%s': not declared in PublishTopics", topic)
+	}
+	return nil
+}
+
+// checkSubscribe reports whether the plugin may subscribe to topic.
+func (s *capabilityScope) checkSubscribe(topic string) error {
+	if !matchesTopic(s.caps.SubscribeTopics, topic) {
+		return s.deny("may not subscribe to topic '%s': not declared in SubscribeTopics", topic)
+	}
+	return nil
+}
+
+// checkConfig reports whether the plugin may read the configuration key.
+// A plugin may always read its own configuration section.
+func (s *capabilityScope) checkConfig(key string) error {
+	own := infraConfig.PluginPrefix(string(s.pluginID))
+	if key == own || strings.HasPrefix(key, own+".") {
+		return nil
+	}
+	for _, prefix := range s.caps.ConfigPrefixes {
+		if prefix == "*" || key == prefix || strings.HasPrefix(key, prefix+".") {
+			return nil
+		}
+	}
+	return s.deny("may not read configuration key '%s': not covered by ConfigPrefixes", key)
+}
+
+// checkStore reports whether the plugin may access the named store.
+func (s *capabilityScope) checkStore(name string) error {
+	for _, allowed := range s.caps.Stores {
+		if allowed == "*" || allowed == name {
+			return nil
+		}
+	}
+	return s.deny("may not access store '%s': not declared in Stores", name)
+}
+
+// checkManagePlugins reports whether the plugin may manage plugins.
+func (s *capabilityScope) checkManagePlugins(action string) error {
+	if !s.caps.ManagePlugins {
+		return s.deny("may not %s: ManagePlugins not declared", action)
+	}
+	return nil
+}
+
+// checkManageRuntime reports whether the plugin may act on the runtime as a whole.
+func (s *capabilityScope) checkManageRuntime(action string) error {
+	if !s.caps.ManageRuntime {
+		return s.deny("may not %s: ManageRuntime not declared", action)
+	}
+	return nil
+}
+
+// checkControl reports whether the plugin may act on a component: its own
+// components and itself always, others only with ManageRuntime.
+func (s *capabilityScope) checkControl(id component.ComponentID, action string) error {
+	if s.caps.ManageRuntime || id == s.pluginID || s.owns(id) {
+		return nil
+	}
+	return s.deny("may not %s '%s' registered by someone else: ManageRuntime not declared", action, id)
+}
+
+// matchesTopic reports whether topic matches one of the patterns.
+func matchesTopic(patterns []string, topic string) bool {
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*" || pattern == topic:
+			return true
+		case strings.HasSuffix(pattern, ".*") && strings.HasPrefix(topic, strings.TrimSuffix(pattern, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// scopedSystem is a component.System restricted to a plugin's capabilities.
+type scopedSystem struct {
+	component.System
+	scope *capabilityScope
+}
+
+// Registry returns a registry that hides undeclared stores.
+func (s *scopedSystem) Registry() component.Registry {
+	return &scopedRegistry{Registry: s.System.Registry(), scope: s.scope}
+}
+
+// StartService starts a service the plugin controls.
+func (s *scopedSystem) StartService(ctx context.Context, serviceID component.ComponentID) error {
+	if err := s.scope.checkControl(serviceID, "start service"); err != nil {
+		return err
+	}
+	return s.System.StartService(ctx, serviceID)
+}
+
+// StopService stops a service the plugin controls.
+func (s *scopedSystem) StopService(ctx context.Context, serviceID component.ComponentID) error {
+	if err := s.scope.checkControl(serviceID, "stop service"); err != nil {
+		return err
+	}
+	return s.System.StopService(ctx, serviceID)
+}
+
+// Start starts the system if the plugin may manage the runtime.
+func (s *scopedSystem) Start(ctx context.Context) error {
+	if err := s.scope.checkManageRuntime("start the runtime"); err != nil {
+		return err
+	}
+	return s.System.Start(ctx)
+}
+
+// Stop stops the system if the plugin may manage the runtime.
+func (s *scopedSystem) Stop(ctx context.Context) error {
+	if err := s.scope.checkManageRuntime("stop the runtime"); err != nil {
+		return err
+	}
+	return s.System.Stop(ctx)
+}
+
+// scopedRuntime is a runtime.RuntimeEnvironment restricted to a plugin's
+// capabilities. Every accessor that hands out a core service returns a scoped
+// view of it; ExecuteOperation, IsRunning, Status and Logger are passed
+// through unchanged.
+type scopedRuntime struct {
+	runtime.RuntimeEnvironment
+	providers
+	scope *capabilityScope
+}
+
+// StartService starts a service the plugin controls.
+func (r *scopedRuntime) StartService(ctx context.Context, serviceID component.ComponentID) error {
+	if err := r.scope.checkControl(serviceID, "start service"); err != nil {
+		return err
+	}
+	return r.RuntimeEnvironment.StartService(ctx, serviceID)
+}
+
+// StopService stops a service the plugin controls.
+func (r *scopedRuntime) StopService(ctx context.Context, serviceID component.ComponentID) error {
+	if err := r.scope.checkControl(serviceID, "stop service"); err != nil {
+		return err
+	}
+	return r.RuntimeEnvironment.StopService(ctx, serviceID)
+}
+
+// Start starts the runtime if the plugin may manage the runtime.
+func (r *scopedRuntime) Start(ctx context.Context) error {
+	if err := r.scope.checkManageRuntime("start the runtime"); err != nil {
+		return err
+	}
+	return r.RuntimeEnvironment.Start(ctx)
+}
+
+// Stop stops the runtime if the plugin may manage the runtime.
+func (r *scopedRuntime) Stop(ctx context.Context) error {
+	if err := r.scope.checkManageRuntime("stop the runtime"); err != nil {
+		return err
+	}
+	return r.RuntimeEnvironment.Stop(ctx)
+}
+
+// Supervisor returns a supervisor restricted to the services the plugin controls.
+func (r *scopedRuntime) Supervisor() supervisor.Supervisor {
+	if inner := r.providers.Supervisor(); inner != nil {
+		return &scopedSupervisor{Supervisor: inner, scope: r.scope}
+	}
+	return nil
+}
+
+// Resilience returns a guard whose policies can only be changed for the
+// operations the plugin controls.
+func (r *scopedRuntime) Resilience() resilience.Guard {
+	if inner := r.providers.Resilience(); inner != nil {
+		return &scopedGuard{Guard: inner, scope: r.scope}
+	}
+	return nil
+}
+
+// Idempotency returns a deduplicator whose policies and stored results are
+// restricted to the operations the plugin controls.
+func (r *scopedRuntime) Idempotency() idempotency.Deduplicator {
+	if inner := r.providers.Idempotency(); inner != nil {
+		return &scopedDeduplicator{Deduplicator: inner, scope: r.scope}
+	}
+	return nil
+}
+
+// Health returns a checker to which the plugin can only add its own checks.
+func (r *scopedRuntime) Health() health.Checker {
+	if inner := r.providers.Health(); inner != nil {
+		return &scopedChecker{Checker: inner, scope: r.scope}
+	}
+	return nil
+}
+
+// Metrics returns a registry in which the plugin cannot touch the built-in metrics.
+func (r *scopedRuntime) Metrics() metrics.Registry {
+	if inner := r.providers.Metrics(); inner != nil {
+		return &scopedMetrics{Registry: inner, scope: r.scope}
+	}
+	return nil
+}
+
+// Tracer returns a tracer that can only start spans.
+func (r *scopedRuntime) Tracer() tracing.Tracer {
+	if inner := r.providers.Tracer(); inner != nil {
+		return &scopedTracer{Tracer: inner}
+	}
+	return nil
+}
+
+// Introspection returns an inspector that hides undeclared stores.
+func (r *scopedRuntime) Introspection() introspection.Inspector {
+	if inner := r.providers.Introspection(); inner != nil {
+		return &scopedInspector{Inspector: inner, scope: r.scope}
+	}
+	return nil
+}
+
+// Registry returns a registry that hides undeclared stores.
+func (r *scopedRuntime) Registry() component.Registry {
+	return &scopedRegistry{Registry: r.RuntimeEnvironment.Registry(), scope: r.scope}
+}
+
+// EventBus returns an event bus restricted to the declared topics.
+func (r *scopedRuntime) EventBus() event.EventBusService {
+	return &scopedEventBus{EventBusService: r.RuntimeEnvironment.EventBus(), scope: r.scope}
+}
+
+// Configuration returns a configuration restricted to the declared prefixes.
+func (r *scopedRuntime) Configuration() config.Configuration {
+	return &scopedConfiguration{Configuration: r.RuntimeEnvironment.Configuration(), scope: r.scope}
+}
+
+// PluginConfiguration returns the configuration section of a plugin. Sections
+// of other plugins must be covered by ConfigPrefixes; an undeclared section is
+// reported and appears empty.
+func (r *scopedRuntime) PluginConfiguration(pluginID component.ComponentID) config.Section {
+	if err := r.scope.checkConfig(infraConfig.PluginPrefix(string(pluginID))); err != nil {
+		r.scope.report(err)
+		return infraConfig.NewSections(infraConfig.NewMemoryConfiguration()).Plugin(string(pluginID))
+	}
+	return r.providers.PluginConfiguration(pluginID)
+}
+
+// PluginManager returns the plugin manager if the plugin may manage plugins,
+// and otherwise a read-only view that lists plugins.
+func (r *scopedRuntime) PluginManager() plugin.PluginManager {
+	if r.scope.caps.ManagePlugins {
+		return r.RuntimeEnvironment.PluginManager()
+	}
+	return &scopedPluginManager{PluginManager: r.RuntimeEnvironment.PluginManager(), scope: r.scope}
+}
+
+// LoadPlugins loads plugins if the plugin may manage plugins.
+func (r *scopedRuntime) LoadPlugins(ctx context.Context, plugins []plugin.Plugin) error {
+	if err := r.scope.checkManagePlugins("load plugins"); err != nil {
+		return err
+	}
+	return r.RuntimeEnvironment.LoadPlugins(ctx, plugins)
+}
+
+// scopedRegistry wraps multistores in a store-scoped view and services the
+// plugin does not control in a view that denies their lifecycle, and only lets
+// the plugin remove components it registered itself.
+type scopedRegistry struct {
+	component.Registry
+	scope *capabilityScope
+}
+
+// Get returns the component, scoping multistores and foreign services.
+func (r *scopedRegistry) Get(id component.ComponentID) (component.Component, error) {
+	comp, err := r.Registry.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.scope.wrap(comp), nil
+}
+
+// GetByType returns the components of a type, scoping multistores and foreign services.
+func (r *scopedRegistry) GetByType(typ component.ComponentType) ([]component.Component, error) {
+	comps, err := r.Registry.GetByType(typ)
+	return r.scope.wrapAll(comps), err
+}
+
+// Find returns the matching components, scoping multistores and foreign services.
+func (r *scopedRegistry) Find(predicate func(component.Component) bool) ([]component.Component, error) {
+	comps, err := r.Registry.Find(predicate)
+	return r.scope.wrapAll(comps), err
+}
+
+// Unregister removes a component the plugin registered.
+func (r *scopedRegistry) Unregister(id component.ComponentID) error {
+	if !r.scope.owns(id) {
+		return r.scope.deny("may not unregister component '%s' registered by someone else", id)
+	}
+	return r.Registry.Unregister(id)
+}
+
+// Clear is denied; a plugin may only remove its own components.
+func (r *scopedRegistry) Clear() error {
+	return r.scope.deny("may not clear the registry")
+}
+
+// owns reports whether the plugin registered the component.
+func (s *capabilityScope) owns(id component.ComponentID) bool {
+	if s.resources == nil {
+		return false
+	}
+	components, _ := s.resources.snapshot()
+	for _, owned := range components {
+		if owned == id {
+			return true
+		}
+	}
+	return false
+}
+
+// wrap returns a store-scoped view of a multistore, a lifecycle-checked view of
+// a service the plugin does not control, and any other component unchanged.
+func (s *capabilityScope) wrap(comp component.Component) component.Component {
+	if ms, ok := comp.(storage.MultiStoreService); ok {
+		return &scopedMultiStore{MultiStoreService: ms, scope: s}
+	}
+	if service, ok := comp.(component.Service); ok && s.checkControl(service.ID(), "control service") != nil {
+		return &scopedService{Service: service, scope: s}
+	}
+	return comp
+}
+
+// wrapAll applies wrap to every component.
+func (s *capabilityScope) wrapAll(comps []component.Component) []component.Component {
+	for i, comp := range comps {
+		comps[i] = s.wrap(comp)
+	}
+	return comps
+}
+
+// scopedService is a service registered by someone else. Its lifecycle methods
+// are checked like StartService and StopService; status remains readable.
+type scopedService struct {
+	component.Service
+	scope *capabilityScope
+}
+
+// Initialize initializes the service if the plugin controls it.
+func (s *scopedService) Initialize(ctx context.Context, system component.System) error {
+	if err := s.scope.checkControl(s.ID(), "initialize service"); err != nil {
+		return err
+	}
+	return s.Service.Initialize(ctx, system)
+}
+
+// Start starts the service if the plugin controls it.
+func (s *scopedService) Start(ctx context.Context) error {
+	if err := s.scope.checkControl(s.ID(), "start service"); err != nil {
+		return err
+	}
+	return s.Service.Start(ctx)
+}
+
+// Stop stops the service if the plugin controls it.
+func (s *scopedService) Stop(ctx context.Context) error {
+	if err := s.scope.checkControl(s.ID(), "stop service"); err != nil {
+		return err
+	}
+	return s.Service.Stop(ctx)
+}
+
+// Dispose disposes the service if the plugin controls it.
+func (s *scopedService) Dispose() error {
+	if err := s.scope.checkControl(s.ID(), "dispose service"); err != nil {
+		return err
+	}
+	return s.Service.Dispose()
+}
+
+// scopedEventBus restricts publishing and subscribing to the declared topics.
+type scopedEventBus struct {
+	event.EventBusService
+	scope *capabilityScope
+}
+
+// Publish publishes the event if the plugin may publish to its topic.
+func (b *scopedEventBus) Publish(evt *event.Event) error {
+	if err := b.scope.checkPublish(evt.Topic); err != nil {
+		return err
+	}
+	return b.EventBusService.Publish(evt)
+}
+
+// PublishAsync publishes the event if the plugin may publish to its topic.
+func (b *scopedEventBus) PublishAsync(evt *event.Event) error {
+	if err := b.scope.checkPublish(evt.Topic); err != nil {
+		return err
+	}
+	return b.EventBusService.PublishAsync(evt)
+}
+
+// PublishContext publishes the event if the plugin may publish to its topic.
+func (b *scopedEventBus) PublishContext(ctx context.Context, evt *event.Event) error {
+	if err := b.scope.checkPublish(evt.Topic); err != nil {
+		return err
+	}
+	return b.EventBusService.PublishContext(ctx, evt)
+}
+
+// PublishAsyncContext publishes the event if the plugin may publish to its
+// topic.
+func (b *scopedEventBus) PublishAsyncContext(ctx context.Context, evt *event.Event) error {
+	if err := b.scope.checkPublish(evt.Topic); err != nil {
+		return err
+	}
+	return b.EventBusService.PublishAsyncContext(ctx, evt)
+}
+
+// Subscribe subscribes if the plugin may subscribe to the topic. Otherwise the
+// denial is logged and the returned subscription never receives events.
+func (b *scopedEventBus) Subscribe(eventType string, handler event.EventHandler) event.Subscription {
+	if err := b.scope.checkSubscribe(eventType); err != nil {
+		b.scope.report(err)
+		return deniedSubscription(eventType)
+	}
+	return b.EventBusService.Subscribe(eventType, handler)
+}
+
+// SubscribeAsync subscribes if the plugin may subscribe to the topic. Otherwise
+// the denial is logged and the returned subscription never receives events.
+func (b *scopedEventBus) SubscribeAsync(eventType string, handler event.EventHandler) event.Subscription {
+	if err := b.scope.checkSubscribe(eventType); err != nil {
+		b.scope.report(err)
+		return deniedSubscription(eventType)
+	}
+	return b.EventBusService.SubscribeAsync(eventType, handler)
+}
+
+// Initialize is denied; the event bus is a core service.
+func (b *scopedEventBus) Initialize(ctx context.Context, system component.System) error {
+	if err := b.scope.checkManageRuntime("initialize the event bus"); err != nil {
+		return err
+	}
+	return b.EventBusService.Initialize(ctx, system)
+}
+
+// Start is denied; the event bus is a core service.
+func (b *scopedEventBus) Start(ctx context.Context) error {
+	if err := b.scope.checkManageRuntime("start the event bus"); err != nil {
+		return err
+	}
+	return b.EventBusService.Start(ctx)
+}
+
+// Stop is denied; the event bus is a core service.
+func (b *scopedEventBus) Stop(ctx context.Context) error {
+	if err := b.scope.checkManageRuntime("stop the event bus"); err != nil {
+		return err
+	}
+	return b.EventBusService.Stop(ctx)
+}
+
+// Dispose is denied; the event bus is a core service.
+func (b *scopedEventBus) Dispose() error {
+	if err := b.scope.checkManageRuntime("dispose the event bus"); err != nil {
+		return err
+	}
+	return b.EventBusService.Dispose()
+}
+
+// deniedSubscription is returned for a subscription that was not allowed.
+type deniedSubscription string
+
+// Cancel does nothing.
+func (s deniedSubscription) Cancel() {}
+
+// Topic returns the requested topic.
+func (s deniedSubscription) Topic() string {
+	return string(s)
+}
+
+// scopedConfiguration restricts reads to the declared prefixes. Undeclared keys
+// behave as missing keys, and the denial is returned or logged.
+type scopedConfiguration struct {
+	config.Configuration
+	scope *capabilityScope
+}
+
+// allowed reports whether key may be read, logging the denial if not.
+func (c *scopedConfiguration) allowed(key string) bool {
+	if err := c.scope.checkConfig(key); err != nil {
+		c.scope.report(err)
+		return false
+	}
+	return true
+}
+
+// GetString reads a declared string value.
+func (c *scopedConfiguration) GetString(key string) string {
+	if !c.allowed(key) {
+		return ""
+	}
+	return c.Configuration.GetString(key)
+}
+
+// GetStringDefault reads a declared string value with a default fallback.
+func (c *scopedConfiguration) GetStringDefault(key, defaultValue string) string {
+	if !c.allowed(key) {
+		return defaultValue
+	}
+	return c.Configuration.GetStringDefault(key, defaultValue)
+}
+
+// GetInt reads a declared integer value.
+func (c *scopedConfiguration) GetInt(key string) (int, error) {
+	if err := c.scope.checkConfig(key); err != nil {
+		return 0, err
+	}
+	return c.Configuration.GetInt(key)
+}
+
+// GetIntDefault reads a declared integer value with a default fallback.
+func (c *scopedConfiguration) GetIntDefault(key string, defaultValue int) int {
+	if !c.allowed(key) {
+		return defaultValue
+	}
+	return c.Configuration.GetIntDefault(key, defaultValue)
+}
+
+// GetBool reads a declared boolean value.
+func (c *scopedConfiguration) GetBool(key string) (bool, error) {
+	if err := c.scope.checkConfig(key); err != nil {
+		return false, err
+	}
+	return c.Configuration.GetBool(key)
+}
+
+// GetBoolDefault reads a declared boolean value with a default fallback.
+func (c *scopedConfiguration) GetBoolDefault(key string, defaultValue bool) bool {
+	if !c.allowed(key) {
+		return defaultValue
+	}
+	return c.Configuration.GetBoolDefault(key, defaultValue)
+}
+
+// GetDuration reads a declared duration value.
+func (c *scopedConfiguration) GetDuration(key string) (time.Duration, error) {
+	if err := c.scope.checkConfig(key); err != nil {
+		return 0, err
+	}
+	return c.Configuration.GetDuration(key)
+}
+
+// GetDurationDefault reads a declared duration value with a default fallback.
+func (c *scopedConfiguration) GetDurationDefault(key string, defaultValue time.Duration) time.Duration {
+	if !c.allowed(key) {
+		return defaultValue
+	}
+	return c.Configuration.GetDurationDefault(key, defaultValue)
+}
+
+// GetObject deserializes a declared configuration section.
+func (c *scopedConfiguration) GetObject(key string, result interface{}) error {
+	if err := c.scope.checkConfig(key); err != nil {
+		return err
+	}
+	return c.Configuration.GetObject(key, result)
+}
+
+// Exists reports whether a declared key exists.
+func (c *scopedConfiguration) Exists(key string) bool {
+	if !c.allowed(key) {
+		return false
+	}
+	return c.Configuration.Exists(key)
+}
+
+// scopedMultiStore restricts store access to the declared stores.
+type scopedMultiStore struct {
+	storage.MultiStoreService
+	scope *capabilityScope
+}
+
+// GetStore returns the store if the plugin may access it.
+func (m *scopedMultiStore) GetStore(name string) (storage.Store, error) {
+	if err := m.scope.checkStore(name); err != nil {
+		return nil, err
+	}
+	return m.MultiStoreService.GetStore(name)
+}
+
+// CreateStore creates the store if the plugin may access it.
+func (m *scopedMultiStore) CreateStore(name, engine string, cfg storage.Config) error {
+	if err := m.scope.checkStore(name); err != nil {
+		return err
+	}
+	return m.MultiStoreService.CreateStore(name, engine, cfg)
+}
+
+// DeleteStore deletes the store if the plugin may access it.
+func (m *scopedMultiStore) DeleteStore(name string) error {
+	if err := m.scope.checkStore(name); err != nil {
+		return err
+	}
+	return m.MultiStoreService.DeleteStore(name)
+}
+
+// ListStores lists the stores the plugin may access.
+func (m *scopedMultiStore) ListStores() []string {
+	var stores []string
+	for _, name := range m.MultiStoreService.ListStores() {
+		if m.scope.checkStore(name) == nil {
+			stores = append(stores, name)
+		}
+	}
+	return stores
+}
+
+// Initialize is denied; the multistore holds the stores of other plugins.
+func (m *scopedMultiStore) Initialize(ctx context.Context, system component.System) error {
+	if err := m.scope.checkManageRuntime("initialize the multistore"); err != nil {
+		return err
+	}
+	return m.MultiStoreService.Initialize(ctx, system)
+}
+
+// Start is denied; the multistore holds the stores of other plugins.
+func (m *scopedMultiStore) Start(ctx context.Context) error {
+	if err := m.scope.checkManageRuntime("start the multistore"); err != nil {
+		return err
+	}
+	return m.MultiStoreService.Start(ctx)
+}
+
+// Stop is denied; the multistore holds the stores of other plugins.
+func (m *scopedMultiStore) Stop(ctx context.Context) error {
+	if err := m.scope.checkManageRuntime("stop the multistore"); err != nil {
+		return err
+	}
+	return m.MultiStoreService.Stop(ctx)
+}
+
+// Dispose is denied; the multistore holds the stores of other plugins.
+func (m *scopedMultiStore) Dispose() error {
+	if err := m.scope.checkManageRuntime("dispose the multistore"); err != nil {
+		return err
+	}
+	return m.MultiStoreService.Dispose()
+}
+
+// CloseAll is denied; it would close stores of other plugins.
+func (m *scopedMultiStore) CloseAll() error {
+	return m.scope.deny("may not close all stores")
+}
+
+// scopedPluginManager is a read-only plugin manager for plugins that did not
+// declare ManagePlugins.
+type scopedPluginManager struct {
+	plugin.PluginManager
+	scope *capabilityScope
+}
+
+// Add is denied.
+func (m *scopedPluginManager) Add(pluginID component.ComponentID, p plugin.Plugin) error {
+	return m.scope.checkManagePlugins("add plugins")
+}
+
+// Remove is denied.
+func (m *scopedPluginManager) Remove(pluginID component.ComponentID) error {
+	return m.scope.checkManagePlugins("remove plugins")
+}
+
+// Unload is denied.
+func (m *scopedPluginManager) Unload(ctx context.Context, pluginID component.ComponentID) error {
+	return m.scope.checkManagePlugins("unload plugins")
+}
+
+// StartPlugin is denied.
+func (m *scopedPluginManager) StartPlugin(ctx context.Context, pluginID component.ComponentID) error {
+	return m.scope.checkManagePlugins("start plugins")
+}
+
+// StopPlugin is denied.
+func (m *scopedPluginManager) StopPlugin(ctx context.Context, pluginID component.ComponentID) error {
+	return m.scope.checkManagePlugins("stop plugins")
+}
+
+// GetPlugin is denied; it would hand out another plugin unrestricted.
+func (m *scopedPluginManager) GetPlugin(pluginID component.ComponentID) (plugin.Plugin, error) {
+	return nil, m.scope.checkManagePlugins("access other plugins")
+}
+
+// Initialize is denied.
+func (m *scopedPluginManager) Initialize(ctx context.Context, system component.System) error {
+	return m.scope.checkManagePlugins("initialize the plugin manager")
+}
+
+// Start is denied.
+func (m *scopedPluginManager) Start(ctx context.Context) error {
+	return m.scope.checkManagePlugins("start the plugin manager")
+}
+
+// Stop is denied.
+func (m *scopedPluginManager) Stop(ctx context.Context) error {
+	return m.scope.checkManagePlugins("stop the plugin manager")
+}
+
+// Dispose is denied.
+func (m *scopedPluginManager) Dispose() error {
+	return m.scope.checkManagePlugins("dispose the plugin manager")
+}
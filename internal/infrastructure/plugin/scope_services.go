@@ -0,0 +1,239 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+)
+
+// scopedSupervisor restricts supervision to the services the plugin controls.
+// States remain readable.
+type scopedSupervisor struct {
+	supervisor.Supervisor
+	scope *capabilityScope
+}
+
+// Supervise watches a service the plugin controls.
+func (s *scopedSupervisor) Supervise(serviceID component.ComponentID, policy supervisor.Policy) error {
+	if err := s.scope.checkControl(serviceID, "supervise service"); err != nil {
+		return err
+	}
+	return s.Supervisor.Supervise(serviceID, policy)
+}
+
+// Unsupervise stops watching a service the plugin controls.
+func (s *scopedSupervisor) Unsupervise(serviceID component.ComponentID) error {
+	if err := s.scope.checkControl(serviceID, "unsupervise service"); err != nil {
+		return err
+	}
+	return s.Supervisor.Unsupervise(serviceID)
+}
+
+// ReportFailure reports the failure of a service the plugin controls. Reports
+// for other services are logged and dropped.
+func (s *scopedSupervisor) ReportFailure(serviceID component.ComponentID, err error) {
+	if denied := s.scope.checkControl(serviceID, "report a failure of service"); denied != nil {
+		s.scope.report(denied)
+		return
+	}
+	s.Supervisor.ReportFailure(serviceID, err)
+}
+
+// Initialize is denied; the supervisor is a core service.
+func (s *scopedSupervisor) Initialize(ctx context.Context, system component.System) error {
+	if err := s.scope.checkManageRuntime("initialize the supervisor"); err != nil {
+		return err
+	}
+	return s.Supervisor.Initialize(ctx, system)
+}
+
+// Start is denied; the supervisor is a core service.
+func (s *scopedSupervisor) Start(ctx context.Context) error {
+	if err := s.scope.checkManageRuntime("start the supervisor"); err != nil {
+		return err
+	}
+	return s.Supervisor.Start(ctx)
+}
+
+// Stop is denied; the supervisor is a core service.
+func (s *scopedSupervisor) Stop(ctx context.Context) error {
+	if err := s.scope.checkManageRuntime("stop the supervisor"); err != nil {
+		return err
+	}
+	return s.Supervisor.Stop(ctx)
+}
+
+// Dispose is denied; the supervisor is a core service.
+func (s *scopedSupervisor) Dispose() error {
+	if err := s.scope.checkManageRuntime("dispose the supervisor"); err != nil {
+		return err
+	}
+	return s.Supervisor.Dispose()
+}
+
+// scopedGuard restricts policy changes to the operations the plugin controls.
+type scopedGuard struct {
+	resilience.Guard
+	scope *capabilityScope
+}
+
+// SetPolicy sets the policy of an operation the plugin controls.
+func (g *scopedGuard) SetPolicy(operationID component.ComponentID, policy resilience.Policy) error {
+	if err := g.scope.checkControl(operationID, "set the resilience policy of operation"); err != nil {
+		return err
+	}
+	return g.Guard.SetPolicy(operationID, policy)
+}
+
+// Reset closes the breaker of an operation the plugin controls.
+func (g *scopedGuard) Reset(operationID component.ComponentID) error {
+	if err := g.scope.checkControl(operationID, "reset the breaker of operation"); err != nil {
+		return err
+	}
+	return g.Guard.Reset(operationID)
+}
+
+// scopedDeduplicator restricts policies and stored results to the operations
+// the plugin controls, and the store to plugins that may manage the runtime.
+type scopedDeduplicator struct {
+	idempotency.Deduplicator
+	scope *capabilityScope
+}
+
+// SetPolicy sets the policy of an operation the plugin controls.
+func (d *scopedDeduplicator) SetPolicy(operationID component.ComponentID, policy idempotency.Policy) error {
+	if err := d.scope.checkControl(operationID, "set the idempotency policy of operation"); err != nil {
+		return err
+	}
+	return d.Deduplicator.SetPolicy(operationID, policy)
+}
+
+// SetStore replaces the result store if the plugin may manage the runtime.
+// Otherwise the denial is logged and the store is kept.
+func (d *scopedDeduplicator) SetStore(store storage.Store) {
+	if err := d.scope.checkManageRuntime("replace the idempotency store"); err != nil {
+		d.scope.report(err)
+		return
+	}
+	d.Deduplicator.SetStore(store)
+}
+
+// Lookup returns a stored result of an operation the plugin controls.
+func (d *scopedDeduplicator) Lookup(operationID component.ComponentID, key string) (idempotency.Record, error) {
+	if err := d.scope.checkControl(operationID, "read the stored results of operation"); err != nil {
+		return idempotency.Record{}, err
+	}
+	return d.Deduplicator.Lookup(operationID, key)
+}
+
+// Forget deletes a stored result of an operation the plugin controls.
+func (d *scopedDeduplicator) Forget(operationID component.ComponentID, key string) error {
+	if err := d.scope.checkControl(operationID, "delete the stored results of operation"); err != nil {
+		return err
+	}
+	return d.Deduplicator.Forget(operationID, key)
+}
+
+// Purge deletes expired results if the plugin may manage the runtime.
+func (d *scopedDeduplicator) Purge() (int, error) {
+	if err := d.scope.checkManageRuntime("purge stored idempotency results"); err != nil {
+		return 0, err
+	}
+	return d.Deduplicator.Purge()
+}
+
+// scopedChecker only accepts checks under the plugin's own ID, the IDs of its
+// components, or IDs prefixed with "<pluginID>.", so that a plugin cannot
+// replace the checks of the runtime or of other components.
+type scopedChecker struct {
+	health.Checker
+	scope *capabilityScope
+}
+
+// AddCheck registers a check under an ID the plugin controls. Otherwise the
+// denial is logged and the check is dropped.
+func (c *scopedChecker) AddCheck(id component.ComponentID, kind health.Kind, check health.CheckFunc) {
+	if !strings.HasPrefix(string(id), string(c.scope.pluginID)+".") {
+		if err := c.scope.checkControl(id, "add a health check for"); err != nil {
+			c.scope.report(err)
+			return
+		}
+	}
+	c.Checker.AddCheck(id, kind, check)
+}
+
+// scopedMetrics keeps plugins out of the built-in metrics. Like the registry
+// rejecting an invalid name, it panics with a failure error.
+type scopedMetrics struct {
+	metrics.Registry
+	scope *capabilityScope
+}
+
+// check panics if name is reserved for the built-in metrics.
+func (m *scopedMetrics) check(name string) {
+	if strings.HasPrefix(name, metrics.BuiltinPrefix) && !m.scope.caps.ManageRuntime {
+		panic(m.scope.deny("may not record built-in metric '%s': ManageRuntime not declared", name))
+	}
+}
+
+// Counter returns a counter outside the built-in metrics.
+func (m *scopedMetrics) Counter(name, help string) metrics.Counter {
+	m.check(name)
+	return m.Registry.Counter(name, help)
+}
+
+// Gauge returns a gauge outside the built-in metrics.
+func (m *scopedMetrics) Gauge(name, help string) metrics.Gauge {
+	m.check(name)
+	return m.Registry.Gauge(name, help)
+}
+
+// Histogram returns a histogram outside the built-in metrics.
+func (m *scopedMetrics) Histogram(name, help string, buckets []float64) metrics.Histogram {
+	m.check(name)
+	return m.Registry.Histogram(name, help, buckets)
+}
+
+// scopedTracer exposes only the tracing.Tracer methods, so that a plugin cannot
+// reach the exporters of the runtime's tracer by type assertion.
+type scopedTracer struct {
+	tracing.Tracer
+}
+
+// scopedInspector hides the stores the plugin did not declare.
+type scopedInspector struct {
+	introspection.Inspector
+	scope *capabilityScope
+}
+
+// Stores describes the declared stores.
+func (i *scopedInspector) Stores() []introspection.StoreInfo {
+	return i.filterStores(i.Inspector.Stores())
+}
+
+// Topology returns the topology with the declared stores only.
+func (i *scopedInspector) Topology() introspection.Topology {
+	topology := i.Inspector.Topology()
+	topology.Stores = i.filterStores(topology.Stores)
+	return topology
+}
+
+// filterStores keeps the stores the plugin may access.
+func (i *scopedInspector) filterStores(stores []introspection.StoreInfo) []introspection.StoreInfo {
+	var allowed []introspection.StoreInfo
+	for _, store := range stores {
+		if i.scope.checkStore(store.Name) == nil {
+			allowed = append(allowed, store)
+		}
+	}
+	return allowed
+}
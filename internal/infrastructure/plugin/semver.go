@@ -0,0 +1,376 @@
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Version represents a parsed semantic version (MAJOR.MINOR.PATCH[-PRERELEASE]).
+// Build metadata is accepted when parsing but ignored for comparison.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+}
+
+// ParseVersion parses a semantic version string. A leading "v" is accepted and
+// missing minor or patch components default to zero ("1.2" is "1.2.0").
+func ParseVersion(s string) (Version, error) {
+	p, err := parsePartial(s)
+	if err != nil {
+		return Version{}, err
+	}
+	if p.wildcard {
+		return Version{}, failure.New(component.ErrInvalidItem, "version '%s' must not contain wildcards", s)
+	}
+	return p.version(), nil
+}
+
+// String returns the canonical string form of the version.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is lower than, equal to
+// or greater than other. Prerelease versions sort before their release.
+func (v Version) Compare(other Version) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// Constraint is a parsed semantic version range.
+//
+// Supported syntax:
+//   - comparators: "=1.2.3", "!=1.2.3", ">1.2", ">=1.2.0", "<2", "<=2.1.0"
+//   - caret ranges: "^1.2.3" (>=1.2.3 <2.0.0), "^0.2.3" (>=0.2.3 <0.3.0)
+//   - tilde ranges: "~1.2.3" (>=1.2.3 <1.3.0), "~1" (>=1.0.0 <2.0.0)
+//   - wildcards: "*", "1.x", "1.2.*"; a bare partial version such as "1.2" means "1.2.x"
+//   - intersections separated by spaces or commas: ">=1.2.0 <2.0.0"
+//   - unions separated by "||": "^1.0 || ^2.0"
+type Constraint struct {
+	raw    string
+	groups [][]comparator
+}
+
+// comparator is a single version comparison.
+type comparator struct {
+	op      string
+	version Version
+}
+
+// ParseConstraint parses a semantic version range. An empty range matches every version.
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: strings.TrimSpace(s)}
+	if c.raw == "" {
+		return c, nil
+	}
+
+	for _, alt := range strings.Split(c.raw, "||") {
+		fields := strings.FieldsFunc(alt, func(r rune) bool {
+			return r == ' ' || r == ',' || r == '\t'
+		})
+		if len(fields) == 0 {
+			return nil, failure.New(component.ErrInvalidItem, "empty alternative in version range '%s'", s)
+		}
+
+		var group []comparator
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			// Allow a space between an operator and its version (">= 1.2.0").
+			if isOperator(field) && i+1 < len(fields) {
+				field += fields[i+1]
+				i++
+			}
+
+			comps, err := parseComparator(field)
+			if err != nil {
+				return nil, failure.New(component.ErrInvalidItem, "invalid version range '%s': %v", s, err)
+			}
+			group = append(group, comps...)
+		}
+		c.groups = append(c.groups, group)
+	}
+
+	return c, nil
+}
+
+// Check reports whether the version satisfies the constraint.
+func (c *Constraint) Check(v Version) bool {
+	if len(c.groups) == 0 {
+		return true
+	}
+
+	for _, group := range c.groups {
+		matched := true
+		for _, comp := range group {
+			if !comp.matches(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the constraint as originally written.
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// matches reports whether v satisfies the comparator.
+func (c comparator) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+// isOperator reports whether s consists only of a range operator.
+func isOperator(s string) bool {
+	switch s {
+	case "=", "==", "!=", ">", ">=", "<", "<=", "^", "~":
+		return true
+	}
+	return false
+}
+
+// parseComparator expands a single range term into one or two comparators.
+func parseComparator(term string) ([]comparator, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", "!=", "==", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, candidate) {
+			op = candidate
+			break
+		}
+	}
+	rest := strings.TrimPrefix(term, op)
+	if op == "==" {
+		op = "="
+	}
+
+	p, err := parsePartial(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "^":
+		return caretRange(p), nil
+	case "~":
+		return tildeRange(p), nil
+	case "", "=":
+		if p.parts == 3 && !p.wildcard {
+			return []comparator{{op: "=", version: p.version()}}, nil
+		}
+		return wildcardRange(p), nil
+	case "!=":
+		if p.parts < 3 || p.wildcard {
+			return nil, fmt.Errorf("'!=' requires a full version, got '%s'", rest)
+		}
+		return []comparator{{op: "!=", version: p.version()}}, nil
+	case ">":
+		if p.parts < 3 || p.wildcard {
+			// ">1.2" means greater than any 1.2.x version.
+			return []comparator{{op: ">=", version: p.next()}}, nil
+		}
+		return []comparator{{op: ">", version: p.version()}}, nil
+	case "<=":
+		if p.parts < 3 || p.wildcard {
+			// "<=1.2" includes every 1.2.x version.
+			return []comparator{{op: "<", version: p.next()}}, nil
+		}
+		return []comparator{{op: "<=", version: p.version()}}, nil
+	default: // ">=" and "<" compare against the lowest version of a partial
+		return []comparator{{op: op, version: p.version()}}, nil
+	}
+}
+
+// caretRange allows changes that do not modify the left-most non-zero component.
+func caretRange(p partialVersion) []comparator {
+	if p.parts == 0 {
+		return wildcardRange(p)
+	}
+	lower := comparator{op: ">=", version: p.version()}
+	var upper Version
+	switch {
+	case p.major > 0 || p.parts <= 1:
+		upper = Version{Major: p.major + 1}
+	case p.minor > 0 || p.parts == 2:
+		upper = Version{Minor: p.minor + 1}
+	default:
+		upper = Version{Patch: p.patch + 1}
+	}
+	return []comparator{lower, {op: "<", version: upper}}
+}
+
+// tildeRange allows patch-level changes, or minor-level changes when only a major version is given.
+func tildeRange(p partialVersion) []comparator {
+	if p.parts == 0 {
+		return wildcardRange(p)
+	}
+	lower := comparator{op: ">=", version: p.version()}
+	upper := Version{Major: p.major, Minor: p.minor + 1}
+	if p.parts <= 1 {
+		upper = Version{Major: p.major + 1}
+	}
+	return []comparator{lower, {op: "<", version: upper}}
+}
+
+// wildcardRange matches every version sharing the specified components.
+func wildcardRange(p partialVersion) []comparator {
+	if p.parts == 0 {
+		return nil
+	}
+	return []comparator{
+		{op: ">=", version: p.version()},
+		{op: "<", version: p.next()},
+	}
+}
+
+// partialVersion is a version whose trailing components may be omitted or wildcards.
+type partialVersion struct {
+	major, minor, patch uint64
+	prerelease          string
+	parts               int // number of explicitly specified numeric components
+	wildcard            bool
+}
+
+// version returns the lowest version matched by the partial version.
+func (p partialVersion) version() Version {
+	return Version{Major: p.major, Minor: p.minor, Patch: p.patch, Prerelease: p.prerelease}
+}
+
+// next returns the lowest version above every version matched by the partial version.
+func (p partialVersion) next() Version {
+	switch p.parts {
+	case 0:
+		return Version{Major: ^uint64(0)}
+	case 1:
+		return Version{Major: p.major + 1}
+	case 2:
+		return Version{Major: p.major, Minor: p.minor + 1}
+	default:
+		return Version{Major: p.major, Minor: p.minor, Patch: p.patch + 1}
+	}
+}
+
+// parsePartial parses a possibly incomplete version such as "1", "1.2", "1.x" or "*".
+func parsePartial(s string) (partialVersion, error) {
+	var p partialVersion
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return p, failure.New(component.ErrInvalidItem, "empty version")
+	}
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		p.prerelease = s[i+1:]
+		s = s[:i]
+		if p.prerelease == "" {
+			return p, failure.New(component.ErrInvalidItem, "empty prerelease in version '%s'", s)
+		}
+	}
+
+	segments := strings.Split(s, ".")
+	if len(segments) > 3 {
+		return p, failure.New(component.ErrInvalidItem, "too many components in version '%s'", s)
+	}
+
+	values := [3]*uint64{&p.major, &p.minor, &p.patch}
+	for i, seg := range segments {
+		if seg == "x" || seg == "X" || seg == "*" {
+			p.wildcard = true
+			break
+		}
+		n, err := strconv.ParseUint(seg, 10, 64)
+		if err != nil {
+			return p, failure.New(component.ErrInvalidItem, "invalid component '%s' in version '%s'", seg, s)
+		}
+		*values[i] = n
+		p.parts++
+	}
+
+	return p, nil
+}
+
+// compareUint compares two unsigned integers.
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares prerelease identifiers following semver precedence rules.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1 // numeric identifiers sort before alphanumeric ones
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareUint(uint64(len(as)), uint64(len(bs)))
+}
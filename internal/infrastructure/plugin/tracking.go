@@ -0,0 +1,142 @@
+package plugin
+
+import (
+	"sync"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/runtime"
+)
+
+// pluginResources records what a plugin added to the system so it can be
+// removed again when the plugin is unloaded.
+type pluginResources struct {
+	components    []component.ComponentID
+	subscriptions []event.Subscription
+	mu            sync.Mutex
+}
+
+// addComponent records a component registered by the plugin.
+func (r *pluginResources) addComponent(id component.ComponentID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.components = append(r.components, id)
+}
+
+// removeComponent forgets a component the plugin unregistered itself.
+func (r *pluginResources) removeComponent(id component.ComponentID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.components {
+		if existing == id {
+			r.components = append(r.components[:i], r.components[i+1:]...)
+			return
+		}
+	}
+}
+
+// addSubscription records an event subscription created by the plugin.
+func (r *pluginResources) addSubscription(sub event.Subscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions = append(r.subscriptions, sub)
+}
+
+// snapshot returns copies of the tracked components and subscriptions.
+func (r *pluginResources) snapshot() ([]component.ComponentID, []event.Subscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	components := append([]component.ComponentID(nil), r.components...)
+	subscriptions := append([]event.Subscription(nil), r.subscriptions...)
+	return components, subscriptions
+}
+
+// trackSystem wraps the system handed to a plugin so that registry entries and
+// event subscriptions the plugin creates are recorded in resources.
+// When the system is a RuntimeEnvironment the wrapper is one too, so plugins
+// can keep type-asserting to reach framework services.
+func trackSystem(system component.System, resources *pluginResources) component.System {
+	if system == nil {
+		return nil
+	}
+
+	if env, ok := system.(runtime.RuntimeEnvironment); ok {
+		return &trackingRuntime{RuntimeEnvironment: env, providers: providers{env}, resources: resources}
+	}
+
+	return &trackingSystem{System: system, resources: resources}
+}
+
+// trackingSystem is a component.System whose registry records registrations.
+type trackingSystem struct {
+	component.System
+	resources *pluginResources
+}
+
+// Registry returns a registry that records registrations.
+func (s *trackingSystem) Registry() component.Registry {
+	return &trackingRegistry{Registry: s.System.Registry(), resources: s.resources}
+}
+
+// trackingRuntime is a runtime.RuntimeEnvironment whose registry and event bus
+// record what the plugin creates. It offers the optional providers of the
+// runtime it wraps.
+type trackingRuntime struct {
+	runtime.RuntimeEnvironment
+	providers
+	resources *pluginResources
+}
+
+// Registry returns a registry that records registrations.
+func (r *trackingRuntime) Registry() component.Registry {
+	return &trackingRegistry{Registry: r.RuntimeEnvironment.Registry(), resources: r.resources}
+}
+
+// EventBus returns an event bus that records subscriptions.
+func (r *trackingRuntime) EventBus() event.EventBusService {
+	return &trackingEventBus{EventBusService: r.RuntimeEnvironment.EventBus(), resources: r.resources}
+}
+
+// trackingRegistry records components registered through it.
+type trackingRegistry struct {
+	component.Registry
+	resources *pluginResources
+}
+
+// Register adds a component to the underlying registry and records it.
+func (r *trackingRegistry) Register(comp component.Component) error {
+	if err := r.Registry.Register(comp); err != nil {
+		return err
+	}
+	r.resources.addComponent(comp.ID())
+	return nil
+}
+
+// Unregister removes a component from the underlying registry and stops tracking it.
+func (r *trackingRegistry) Unregister(id component.ComponentID) error {
+	if err := r.Registry.Unregister(id); err != nil {
+		return err
+	}
+	r.resources.removeComponent(id)
+	return nil
+}
+
+// trackingEventBus records subscriptions created through it.
+type trackingEventBus struct {
+	event.EventBusService
+	resources *pluginResources
+}
+
+// Subscribe subscribes on the underlying bus and records the subscription.
+func (b *trackingEventBus) Subscribe(eventType string, handler event.EventHandler) event.Subscription {
+	sub := b.EventBusService.Subscribe(eventType, handler)
+	b.resources.addSubscription(sub)
+	return sub
+}
+
+// SubscribeAsync subscribes on the underlying bus and records the subscription.
+func (b *trackingEventBus) SubscribeAsync(eventType string, handler event.EventHandler) event.Subscription {
+	sub := b.EventBusService.SubscribeAsync(eventType, handler)
+	b.resources.addSubscription(sub)
+	return sub
+}
@@ -0,0 +1,1127 @@
+// Package queue provides the persistent work queue implementation.
+package queue
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/queue"
+	domainRuntime "github.com/fintechain/skeleton/internal/domain/runtime"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+)
+
+// Option defaults
+const (
+	DefaultStore             = "queue"
+	DefaultDeadLetterStore   = "queue-dead-letters"
+	DefaultEngine            = "memory"
+	DefaultWorkers           = 4
+	DefaultMaxAttempts       = 5
+	DefaultVisibilityTimeout = 30 * time.Second
+	DefaultInitialBackoff    = time.Second
+	DefaultMaxBackoff        = 5 * time.Minute
+	DefaultPollInterval      = time.Second
+)
+
+// keyPrefix prefixes the keys of jobs in both stores.
+const keyPrefix = "job/"
+
+// duePrefix prefixes the due index of the live store: one key per job, made
+// of the time it is next available and its ID, holding its priority. Keys
+// sort by due time, so a store that supports range queries yields the ready
+// jobs without reading the others. dueEnd bounds the index.
+const (
+	duePrefix = "due/"
+	dueEnd    = "due0"
+)
+
+// Options configures a queue. Zero values select defaults.
+type Options struct {
+	// Store and DeadLetterStore are the names of the stores live and
+	// dead-lettered jobs are persisted in. They are created with Engine if
+	// they do not exist. Defaults to "queue", "queue-dead-letters" and
+	// "memory".
+	Store           string
+	DeadLetterStore string
+	Engine          string
+
+	// Workers is the number of jobs delivered at once. Defaults to 4.
+	Workers int
+
+	// MaxAttempts and VisibilityTimeout apply to jobs enqueued without
+	// their own. Defaults to 5 and 30s.
+	MaxAttempts       int
+	VisibilityTimeout time.Duration
+
+	// InitialBackoff is the delay before the first retry of a failed job.
+	// It doubles with every retry, up to MaxBackoff. Defaults to 1s and 5m.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// PollInterval bounds how long the queue waits before looking for jobs
+	// again, which picks up jobs written by other processes sharing the
+	// store. Defaults to 1s.
+	PollInterval time.Duration
+}
+
+// Queue implements queue.Queue on storage.Store.
+//
+// Every job is a JSON record in the store, indexed by the time it is next
+// available. A dispatcher claims the highest-priority ready job whenever a
+// worker is free, by recording a lease token and the end of its visibility
+// timeout, and the worker executes the operation through
+// System.ExecuteOperation. A claim reads the index entries of the ready jobs
+// only when the store is storage.RangeQueryable, and the keys of the store
+// otherwise. A delivery that succeeds deletes the job; one that fails makes it
+// available again after a backoff, or moves it to the dead-letter store once
+// it is out of attempts. The dead record is written before the live one is
+// deleted: if the delete fails, the job is dead-lettered again once its lease
+// expires, keeping the dead record already written. A delivery only
+// settles the job if it still holds the lease: after the visibility timeout
+// the job is delivered again, whether the first worker is still running or
+// its process died, unless that delivery was its last attempt: then the job
+// is dead-lettered with queue.ErrLeaseLost.
+//
+// Records that cannot be decoded are skipped and logged, so they do not hold
+// up the other jobs.
+//
+// When the runtime publishes its stop phase, or rejects a delivery because it
+// is shutting down, the queue stops claiming jobs and releases the deliveries
+// in progress without counting their attempts, until it is restarted.
+//
+// Changes to a record are applied in a transaction when the store is
+// storage.Transactional, so several processes can share a store whose engine
+// isolates transactions. Otherwise they are serialized within the process.
+type Queue struct {
+	*infraComponent.BaseService
+	multiStore storage.MultiStore
+	options    Options
+
+	system   component.System
+	eventBus event.EventBus
+	logger   logging.Logger
+
+	store     storage.Store
+	deadStore storage.Store
+	slots     chan struct{}
+	wake      chan struct{}
+	stop      chan struct{} // closed when the queue stops
+	active    bool
+	stopping  bool // the runtime is shutting down
+	stopSub   event.Subscription
+	runCtx    context.Context
+	cancel    func()
+	wg        sync.WaitGroup
+	corrupt   map[string]bool // undecodable records already logged, by store and key
+	mu        sync.Mutex      // guards the fields above
+	storeMu   sync.Mutex      // serializes record changes
+}
+
+// record is the persisted form of a job.
+type record struct {
+	queue.Job
+
+	// Lease identifies the current delivery.
+	Lease string `json:"lease,omitempty"`
+}
+
+// change is the outcome of a record update.
+type change int
+
+const (
+	keep change = iota
+	save
+	remove
+)
+
+// eventBusProvider and loggerProvider are implemented by runtimes.
+type eventBusProvider interface {
+	EventBus() event.EventBusService
+}
+
+type loggerProvider interface {
+	Logger() logging.Logger
+}
+
+// NewQueue creates a queue that persists jobs in stores of the multi-store.
+// The queue executes operations on the system it is initialized with, and
+// uses its event bus and logger if the system is a runtime environment.
+func NewQueue(config component.ComponentConfig, multiStore storage.MultiStore, options Options) *Queue {
+	if options.Store == "" {
+		options.Store = DefaultStore
+	}
+	if options.DeadLetterStore == "" {
+		options.DeadLetterStore = DefaultDeadLetterStore
+	}
+	if options.Engine == "" {
+		options.Engine = DefaultEngine
+	}
+	if options.Workers <= 0 {
+		options.Workers = DefaultWorkers
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = DefaultMaxAttempts
+	}
+	if options.VisibilityTimeout <= 0 {
+		options.VisibilityTimeout = DefaultVisibilityTimeout
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = DefaultInitialBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = DefaultMaxBackoff
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+	return &Queue{
+		BaseService: infraComponent.NewBaseService(config),
+		multiStore:  multiStore,
+		options:     options,
+		logger:      infraLogging.NewNoOpLogger(),
+		wake:        make(chan struct{}, 1),
+		corrupt:     make(map[string]bool),
+	}
+}
+
+// Initialize records the system that executes operations, and subscribes to
+// the runtime stop phase to stop claiming jobs once it is published.
+func (q *Queue) Initialize(ctx context.Context, system component.System) error {
+	if err := q.BaseService.Initialize(ctx, system); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.system = system
+	if provider, ok := system.(eventBusProvider); ok {
+		q.eventBus = provider.EventBus()
+		if q.stopSub != nil {
+			q.stopSub.Cancel()
+		}
+		q.stopSub = q.eventBus.Subscribe(domainRuntime.TopicRuntimeStop, func(*event.Event) {
+			q.interrupt()
+		})
+	}
+	if provider, ok := system.(loggerProvider); ok {
+		q.logger = provider.Logger()
+	}
+	return nil
+}
+
+// Start opens the stores and starts delivering jobs. Jobs leased by a
+// previous run are delivered again once their visibility timeout ends.
+func (q *Queue) Start(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.active {
+		return nil
+	}
+	if q.system == nil {
+		return failure.New(component.ErrComponentNotInitialized, "queue '%s' has no system", q.ID())
+	}
+	if err := q.open(); err != nil {
+		return err
+	}
+
+	runCtx := infraContext.WithTimeout(infraContext.NewContext(), 0)
+	q.runCtx, q.cancel = runCtx, runCtx.Cancel
+	q.slots = make(chan struct{}, q.options.Workers)
+	q.stop = make(chan struct{})
+	q.active = true
+	q.stopping = false
+
+	q.wg.Add(1)
+	go q.dispatch(q.stop)
+	return q.BaseService.Start(ctx)
+}
+
+// Stop stops delivering jobs, cancels deliveries in progress and waits for
+// them to return or ctx to be done. Cancelled deliveries make their job
+// available again without counting the attempt.
+func (q *Queue) Stop(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.active {
+		q.mu.Unlock()
+		return nil
+	}
+	q.active = false
+	close(q.stop)
+	q.cancel()
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return failure.Wrap(ctx.Err(), component.ErrServiceStopFailed, "queue deliveries still running")
+	}
+	return q.BaseService.Stop(ctx)
+}
+
+// Dispose cancels the subscription to the runtime stop phase.
+func (q *Queue) Dispose() error {
+	q.mu.Lock()
+	if q.stopSub != nil {
+		q.stopSub.Cancel()
+		q.stopSub = nil
+	}
+	q.mu.Unlock()
+	return q.BaseService.Dispose()
+}
+
+// Enqueue adds a job. Jobs may be enqueued once the queue is initialized;
+// they are delivered while it runs.
+func (q *Queue) Enqueue(operationID component.ComponentID, input component.Input, options queue.EnqueueOptions) (string, error) {
+	switch {
+	case operationID == "":
+		return "", failure.New(queue.ErrInvalidJob, "missing operation")
+	case options.Delay < 0:
+		return "", failure.New(queue.ErrInvalidJob, "negative delay")
+	case options.MaxAttempts < 0:
+		return "", failure.New(queue.ErrInvalidJob, "negative max attempts")
+	case options.VisibilityTimeout < 0:
+		return "", failure.New(queue.ErrInvalidJob, "negative visibility timeout")
+	}
+	if err := q.openStores(); err != nil {
+		return "", err
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	rec := &record{Job: queue.Job{
+		ID:                id,
+		OperationID:       operationID,
+		Input:             input,
+		Priority:          options.Priority,
+		MaxAttempts:       options.MaxAttempts,
+		VisibilityTimeout: options.VisibilityTimeout,
+		EnqueuedAt:        now,
+		AvailableAt:       now.Add(options.Delay),
+	}}
+	if rec.MaxAttempts == 0 {
+		rec.MaxAttempts = q.options.MaxAttempts
+	}
+	if rec.VisibilityTimeout == 0 {
+		rec.VisibilityTimeout = q.options.VisibilityTimeout
+	}
+
+	if _, err := json.Marshal(rec); err != nil {
+		return "", failure.Wrap(err, queue.ErrInvalidJob, "input of operation '%s' cannot be persisted", operationID)
+	}
+	if err := q.transact(q.store, func(target storage.Store) error { return put(target, rec) }); err != nil {
+		return "", err
+	}
+
+	q.notify()
+	q.publish(queue.TopicJobEnqueued, rec.Job, map[string]interface{}{
+		"priority":    rec.Priority,
+		"availableAt": rec.AvailableAt.Format(time.RFC3339Nano),
+	})
+	return id, nil
+}
+
+// Get returns a live or dead-lettered job.
+func (q *Queue) Get(jobID string) (queue.Job, error) {
+	if err := q.openStores(); err != nil {
+		return queue.Job{}, err
+	}
+	for _, store := range []storage.Store{q.store, q.deadStore} {
+		rec, err := read(store, jobID)
+		if err != nil {
+			return queue.Job{}, err
+		}
+		if rec != nil {
+			return rec.Job, nil
+		}
+	}
+	return queue.Job{}, failure.New(queue.ErrJobNotFound, "'%s'", jobID)
+}
+
+// Stats counts the live and dead-lettered jobs.
+func (q *Queue) Stats() (queue.Stats, error) {
+	if err := q.openStores(); err != nil {
+		return queue.Stats{}, err
+	}
+	live, err := q.records(q.store)
+	if err != nil {
+		return queue.Stats{}, err
+	}
+	dead, err := q.records(q.deadStore)
+	if err != nil {
+		return queue.Stats{}, err
+	}
+
+	stats := queue.Stats{Dead: len(dead)}
+	now := time.Now()
+	for _, rec := range live {
+		switch {
+		case rec.LeasedUntil.After(now):
+			stats.InFlight++
+		case rec.LeasedUntil.IsZero() && rec.AvailableAt.After(now):
+			stats.Delayed++
+		default:
+			stats.Ready++
+		}
+	}
+	return stats, nil
+}
+
+// DeadLetters returns the dead-lettered jobs, oldest first.
+func (q *Queue) DeadLetters() ([]queue.Job, error) {
+	if err := q.openStores(); err != nil {
+		return nil, err
+	}
+	dead, err := q.records(q.deadStore)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(dead, func(i, j int) bool {
+		if !dead[i].DeadAt.Equal(dead[j].DeadAt) {
+			return dead[i].DeadAt.Before(dead[j].DeadAt)
+		}
+		return dead[i].ID < dead[j].ID
+	})
+	jobs := make([]queue.Job, len(dead))
+	for i, rec := range dead {
+		jobs[i] = rec.Job
+	}
+	return jobs, nil
+}
+
+// Redrive moves a dead-lettered job back to the queue with its attempts
+// reset. It is available immediately.
+func (q *Queue) Redrive(jobID string) error {
+	if err := q.openStores(); err != nil {
+		return err
+	}
+
+	rec, err := read(q.deadStore, jobID)
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		return failure.New(queue.ErrJobNotFound, "'%s'", jobID)
+	}
+	rec.Attempts = 0
+	rec.DeadAt = time.Time{}
+	rec.LeasedUntil = time.Time{}
+	rec.Lease = ""
+	rec.AvailableAt = time.Now()
+	revived := rec.Job
+
+	// The live record is written first, so that a failure leaves the job in
+	// both stores rather than in neither; redriving it again overwrites it
+	if err := q.transact(q.store, func(target storage.Store) error { return put(target, rec) }); err != nil {
+		return err
+	}
+	err = q.update(q.deadStore, jobID, func(*record) (change, error) {
+		return remove, nil
+	})
+	if err != nil && !errors.Is(err, queue.ErrJobNotFound) {
+		return err
+	}
+
+	q.logger.Info("Redriving dead-lettered job", "job_id", jobID, "operation_id", revived.OperationID)
+	q.notify()
+	q.publish(queue.TopicJobEnqueued, revived, map[string]interface{}{
+		"priority":    revived.Priority,
+		"availableAt": revived.AvailableAt.Format(time.RFC3339Nano),
+		"redriven":    true,
+	})
+	return nil
+}
+
+// Discard deletes a dead-lettered job.
+func (q *Queue) Discard(jobID string) error {
+	if err := q.openStores(); err != nil {
+		return err
+	}
+	return q.update(q.deadStore, jobID, func(*record) (change, error) {
+		return remove, nil
+	})
+}
+
+// dispatch claims ready jobs while workers are free, and otherwise waits for
+// the next job to become available, an enqueue, the poll interval or stop.
+// Once the runtime is shutting down, it only waits for stop.
+func (q *Queue) dispatch(stop chan struct{}) {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case q.slots <- struct{}{}:
+		case <-stop:
+			return
+		}
+		if q.isStopping() {
+			<-q.slots
+			<-stop
+			return
+		}
+
+		rec, wait, err := q.claim(time.Now())
+		if err != nil {
+			q.logger.Error("Failed to claim job", "error", err)
+		}
+		if rec != nil {
+			q.wg.Add(1)
+			go q.deliver(rec)
+			continue
+		}
+		<-q.slots
+
+		if wait <= 0 || wait > q.options.PollInterval {
+			wait = q.options.PollInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-q.wake:
+			timer.Stop()
+		case <-stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// claim leases the next ready job. Without one, it returns how long until a
+// job becomes available.
+func (q *Queue) claim(now time.Time) (*record, time.Duration, error) {
+	ready, wait, err := q.due(now)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, candidate := range ready {
+		lease, err := newJobID()
+		if err != nil {
+			return nil, 0, err
+		}
+		var claimed, expired *record
+		err = q.update(q.store, candidate.id, func(rec *record) (change, error) {
+			// Another worker may have claimed or settled the job since it was read
+			if availableAt(rec).After(now) {
+				return keep, nil
+			}
+			if !rec.LeasedUntil.IsZero() {
+				// The last delivery timed out, and it was the last attempt
+				if rec.Attempts >= rec.MaxAttempts {
+					expired = rec
+					return keep, nil
+				}
+				q.logger.Warn("Visibility timeout expired, delivering job again", "job_id", rec.ID, "attempt", rec.Attempts)
+			}
+			rec.Attempts++
+			rec.Lease = lease
+			rec.LeasedUntil = now.Add(rec.VisibilityTimeout)
+			claimed = rec
+			return save, nil
+		})
+		if errors.Is(err, queue.ErrJobNotFound) {
+			// The index entry outlived its job
+			if err := q.transact(q.store, func(target storage.Store) error { return unindex(target, candidate.key) }); err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if expired != nil {
+			expired.LastError = failure.New(queue.ErrLeaseLost, "visibility timeout of %s expired", expired.VisibilityTimeout).Error()
+			dead, err := q.deadLetter(expired, expired.Lease, now)
+			if err != nil {
+				q.logger.Error("Failed to dead-letter job", "job_id", expired.ID, "error", err)
+			} else if dead != nil {
+				q.logger.Error("Job out of attempts after its visibility timeout, moved to dead letters", "job_id", dead.ID, "operation_id", dead.OperationID, "attempts", dead.Attempts)
+				q.publish(queue.TopicJobDeadLettered, dead.Job, map[string]interface{}{"error": dead.LastError})
+			}
+		}
+		if claimed != nil {
+			return claimed, 0, nil
+		}
+	}
+	return nil, wait, nil
+}
+
+// entry is a due index entry.
+type entry struct {
+	key      string
+	id       string
+	due      time.Time
+	priority int
+}
+
+// due returns the index entries of the jobs available at now, highest
+// priority first, and how long until the next one becomes available.
+func (q *Queue) due(now time.Time) ([]entry, time.Duration, error) {
+	var ready []entry
+	var next time.Time
+	visit := func(key, value []byte) bool {
+		e, ok := parseEntry(key, value)
+		if !ok {
+			return true
+		}
+		if e.due.After(now) {
+			if next.IsZero() || e.due.Before(next) {
+				next = e.due
+			}
+			return true
+		}
+		ready = append(ready, e)
+		return true
+	}
+
+	var err error
+	if ranged, ok := q.store.(storage.RangeQueryable); ok && ranged.SupportsRangeQueries() {
+		// Entries are visited in due order: the first one not yet due ends the range
+		err = ranged.IterateRange([]byte(duePrefix), []byte(dueEnd), true, func(key, value []byte) bool {
+			visit(key, value)
+			return next.IsZero()
+		})
+	} else {
+		err = q.store.Iterate(func(key, value []byte) bool {
+			if strings.HasPrefix(string(key), duePrefix) {
+				visit(key, value)
+			}
+			return true
+		})
+	}
+	if err != nil {
+		return nil, 0, failure.Wrap(err, queue.ErrStoreUnavailable, "store '%s'", q.store.Name())
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		a, b := ready[i], ready[j]
+		if a.priority != b.priority {
+			return a.priority > b.priority
+		}
+		if !a.due.Equal(b.due) {
+			return a.due.Before(b.due)
+		}
+		return a.id < b.id
+	})
+	var wait time.Duration
+	if !next.IsZero() {
+		wait = next.Sub(now)
+	}
+	return ready, wait, nil
+}
+
+// deliver executes the operation of a claimed job and settles the job.
+func (q *Queue) deliver(rec *record) {
+	defer q.wg.Done()
+	defer func() { <-q.slots }()
+
+	metadata := make(map[string]string, len(rec.Input.Metadata)+2)
+	for key, value := range rec.Input.Metadata {
+		metadata[key] = value
+	}
+	metadata[queue.MetadataJobID] = rec.ID
+	metadata[queue.MetadataAttempt] = strconv.Itoa(rec.Attempts)
+
+	ctx := q.runContext()
+	_, err := q.system.ExecuteOperation(ctx, rec.OperationID, component.Input{
+		Data:     rec.Input.Data,
+		Metadata: metadata,
+	})
+
+	switch {
+	case err == nil:
+		q.complete(rec)
+	case errors.Is(err, domainRuntime.ErrShuttingDown):
+		q.interrupt()
+		q.release(rec)
+	case ctx.Err() != nil:
+		q.release(rec)
+	default:
+		q.fail(rec, err)
+	}
+}
+
+// complete deletes a job whose delivery succeeded.
+func (q *Queue) complete(rec *record) {
+	settled, err := q.settle(rec, func(current *record) (change, error) {
+		return remove, nil
+	})
+	if err != nil || !settled {
+		return
+	}
+	q.publish(queue.TopicJobCompleted, rec.Job, nil)
+}
+
+// release makes a job whose delivery was cancelled by Stop or rejected by a
+// runtime shutting down available again, without counting the attempt.
+func (q *Queue) release(rec *record) {
+	q.settle(rec, func(current *record) (change, error) {
+		current.Attempts--
+		current.Lease = ""
+		current.LeasedUntil = time.Time{}
+		current.AvailableAt = time.Now()
+		return save, nil
+	})
+}
+
+// fail schedules a retry of a job whose delivery failed, or dead-letters it
+// once it is out of attempts.
+func (q *Queue) fail(rec *record, cause error) {
+	var topic string
+	var details map[string]interface{}
+	var exhausted *record
+	settled, err := q.settle(rec, func(current *record) (change, error) {
+		current.LastError = cause.Error()
+		if current.Attempts >= current.MaxAttempts {
+			exhausted = current
+			return keep, nil
+		}
+
+		current.Lease = ""
+		current.LeasedUntil = time.Time{}
+		backoff := infraComponent.Backoff(q.options.InitialBackoff, q.options.MaxBackoff, current.Attempts-1)
+		current.AvailableAt = time.Now().Add(backoff)
+		topic = queue.TopicJobRetrying
+		details = map[string]interface{}{
+			"error":       current.LastError,
+			"backoff":     backoff.String(),
+			"availableAt": current.AvailableAt.Format(time.RFC3339Nano),
+		}
+		return save, nil
+	})
+	if err != nil || !settled {
+		return
+	}
+	if exhausted != nil {
+		dead, err := q.deadLetter(exhausted, rec.Lease, time.Now())
+		if err != nil {
+			q.logger.Error("Failed to dead-letter job", "job_id", rec.ID, "error", err)
+			return
+		}
+		if dead == nil {
+			return
+		}
+		topic = queue.TopicJobDeadLettered
+		details = map[string]interface{}{"error": dead.LastError}
+	}
+
+	if topic == queue.TopicJobDeadLettered {
+		q.logger.Error("Job out of attempts, moved to dead letters", "job_id", rec.ID, "operation_id", rec.OperationID, "attempts", rec.Attempts, "error", cause)
+	} else {
+		q.logger.Warn("Job failed, retrying", "job_id", rec.ID, "operation_id", rec.OperationID, "attempt", rec.Attempts, "backoff", details["backoff"], "error", cause)
+	}
+	q.publish(topic, rec.Job, details)
+}
+
+// deadLetter moves a job out of attempts to the dead-letter store if the
+// delivery holding lease is still the last one, and returns the dead record.
+// The dead record is written before the live one is deleted, so that a
+// failure in between leaves the job in both stores rather than in neither.
+// A dead record left by such a failure is kept: it holds the error of the
+// last delivery.
+func (q *Queue) deadLetter(rec *record, lease string, now time.Time) (*record, error) {
+	dead, err := read(q.deadStore, rec.ID)
+	if err != nil {
+		return nil, err
+	}
+	if dead == nil {
+		copied := *rec
+		dead = &copied
+		dead.Lease = ""
+		dead.LeasedUntil = time.Time{}
+		dead.DeadAt = now
+		if err := write(q.deadStore, dead); err != nil {
+			return nil, err
+		}
+	}
+
+	removed := false
+	err = q.update(q.store, rec.ID, func(current *record) (change, error) {
+		if current.Lease != lease {
+			return keep, nil
+		}
+		removed = true
+		return remove, nil
+	})
+	if err != nil && !errors.Is(err, queue.ErrJobNotFound) {
+		return nil, err
+	}
+	if !removed {
+		return nil, nil
+	}
+	return dead, nil
+}
+
+// settle applies fn to a job if the delivery still holds its lease, and
+// reports whether it did.
+func (q *Queue) settle(rec *record, fn func(current *record) (change, error)) (bool, error) {
+	settled := false
+	err := q.update(q.store, rec.ID, func(current *record) (change, error) {
+		if current.Lease != rec.Lease {
+			return keep, nil
+		}
+		settled = true
+		return fn(current)
+	})
+	if err != nil && !errors.Is(err, queue.ErrJobNotFound) {
+		q.logger.Error("Failed to settle job", "job_id", rec.ID, "error", err)
+		return false, err
+	}
+	if !settled {
+		q.logger.Warn("Delivery finished after its visibility timeout", "job_id", rec.ID, "error", queue.ErrLeaseLost)
+	}
+	return settled, nil
+}
+
+// update applies fn to the record of a job and writes the change, in a
+// transaction if the store supports them. Changes to the live store keep the
+// due index up to date.
+func (q *Queue) update(store storage.Store, jobID string, fn func(rec *record) (change, error)) error {
+	indexed := store == q.store
+	return q.transact(store, func(target storage.Store) error {
+		return apply(target, jobID, indexed, fn)
+	})
+}
+
+// transact runs fn on the store, or on a transaction of the store if it
+// supports them, committing the transaction if fn succeeds.
+func (q *Queue) transact(store storage.Store, fn func(target storage.Store) error) error {
+	q.storeMu.Lock()
+	defer q.storeMu.Unlock()
+
+	target := store
+	var tx storage.Transaction
+	if transactional, ok := store.(storage.Transactional); ok && transactional.SupportsTransactions() {
+		var err error
+		if tx, err = transactional.BeginTx(); err != nil {
+			return failure.Wrap(err, queue.ErrStoreUnavailable, "failed to begin transaction")
+		}
+		target = tx
+	}
+
+	err := fn(target)
+	if tx == nil {
+		return err
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return failure.Wrap(err, queue.ErrStoreUnavailable, "failed to commit transaction")
+	}
+	return nil
+}
+
+// apply reads a record, applies fn and writes the change, along with its due
+// index entry if indexed.
+func apply(store storage.Store, jobID string, indexed bool, fn func(rec *record) (change, error)) error {
+	rec, err := read(store, jobID)
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		return failure.New(queue.ErrJobNotFound, "'%s'", jobID)
+	}
+
+	before := dueKey(rec)
+	outcome, err := fn(rec)
+	if err != nil {
+		return err
+	}
+	switch outcome {
+	case save:
+		if indexed {
+			if err := reindex(store, before, rec); err != nil {
+				return err
+			}
+		}
+		return write(store, rec)
+	case remove:
+		if err := store.Delete([]byte(keyPrefix + jobID)); err != nil {
+			return failure.Wrap(err, queue.ErrStoreUnavailable, "failed to delete job '%s'", jobID)
+		}
+		if indexed {
+			return unindex(store, before)
+		}
+	}
+	return nil
+}
+
+// put writes a live record and its due index entry, replacing the entry of
+// the record it overwrites.
+func put(store storage.Store, rec *record) error {
+	previous, err := read(store, rec.ID)
+	if err != nil {
+		return err
+	}
+	before := ""
+	if previous != nil {
+		before = dueKey(previous)
+	}
+	if err := reindex(store, before, rec); err != nil {
+		return err
+	}
+	return write(store, rec)
+}
+
+// reindex replaces the due index entry before with that of rec. The new entry
+// is written first, so that a failure leaves a stale entry, which claims
+// skip, rather than a job no claim finds.
+func reindex(store storage.Store, before string, rec *record) error {
+	key := dueKey(rec)
+	if err := store.Set([]byte(key), []byte(strconv.Itoa(rec.Priority))); err != nil {
+		return failure.Wrap(err, queue.ErrStoreUnavailable, "failed to index job '%s'", rec.ID)
+	}
+	if before == "" || before == key {
+		return nil
+	}
+	return unindex(store, before)
+}
+
+// unindex deletes a due index entry.
+func unindex(store storage.Store, key string) error {
+	if err := store.Delete([]byte(key)); err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		return failure.Wrap(err, queue.ErrStoreUnavailable, "failed to delete index entry '%s'", key)
+	}
+	return nil
+}
+
+// dueKey returns the due index key of a record.
+func dueKey(rec *record) string {
+	return fmt.Sprintf("%s%020d/%s", duePrefix, availableAt(rec).UnixNano(), rec.ID)
+}
+
+// parseEntry decodes a due index entry.
+func parseEntry(key, value []byte) (entry, bool) {
+	rest := strings.TrimPrefix(string(key), duePrefix)
+	stamp, id, ok := strings.Cut(rest, "/")
+	if !ok || id == "" {
+		return entry{}, false
+	}
+	nanos, err := strconv.ParseInt(stamp, 10, 64)
+	if err != nil {
+		return entry{}, false
+	}
+	priority, err := strconv.Atoi(string(value))
+	if err != nil {
+		return entry{}, false
+	}
+	return entry{key: string(key), id: id, due: time.Unix(0, nanos), priority: priority}, true
+}
+
+// read returns the record of a job, or nil if the store has none.
+func read(store storage.Store, jobID string) (*record, error) {
+	data, err := store.Get([]byte(keyPrefix + jobID))
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, failure.Wrap(err, queue.ErrStoreUnavailable, "failed to read job '%s'", jobID)
+	}
+	rec := &record{}
+	if err := json.Unmarshal(data, rec); err != nil {
+		return nil, failure.Wrap(err, queue.ErrStoreUnavailable, "failed to decode job '%s'", jobID)
+	}
+	return rec, nil
+}
+
+// readAll returns the records of a store. Records that cannot be decoded are
+// skipped; their decoding errors are returned by key.
+func readAll(store storage.Store) ([]*record, map[string]error, error) {
+	var records []*record
+	var corrupt map[string]error
+	err := store.Iterate(func(key, value []byte) bool {
+		if !strings.HasPrefix(string(key), keyPrefix) {
+			return true
+		}
+		rec := &record{}
+		if err := json.Unmarshal(value, rec); err != nil {
+			if corrupt == nil {
+				corrupt = make(map[string]error)
+			}
+			corrupt[string(key)] = err
+			return true
+		}
+		records = append(records, rec)
+		return true
+	})
+	if err != nil {
+		return nil, nil, failure.Wrap(err, queue.ErrStoreUnavailable, "store '%s'", store.Name())
+	}
+	return records, corrupt, nil
+}
+
+// records returns the records of a store, skipping those that cannot be
+// decoded. Each of them is logged once, so that one bad record neither stops
+// the queue nor floods the log on every poll.
+func (q *Queue) records(store storage.Store) ([]*record, error) {
+	records, corrupt, err := readAll(store)
+	if err != nil {
+		return nil, err
+	}
+	for key, decodeErr := range corrupt {
+		id := store.Name() + "/" + key
+		q.mu.Lock()
+		logged := q.corrupt[id]
+		q.corrupt[id] = true
+		q.mu.Unlock()
+		if !logged {
+			q.logger.Error("Skipping job record that cannot be decoded", "store", store.Name(), "key", key, "error", decodeErr)
+		}
+	}
+	return records, nil
+}
+
+// write persists a record.
+func write(store storage.Store, rec *record) error {
+	data, err := json.Marshal(rec)
+	if err == nil {
+		err = store.Set([]byte(keyPrefix+rec.ID), data)
+	}
+	if err != nil {
+		return failure.Wrap(err, queue.ErrStoreUnavailable, "failed to write job '%s'", rec.ID)
+	}
+	return nil
+}
+
+// availableAt returns when a job may next be delivered: the end of its
+// visibility timeout while leased, its available time otherwise.
+func availableAt(rec *record) time.Time {
+	if !rec.LeasedUntil.IsZero() {
+		return rec.LeasedUntil
+	}
+	return rec.AvailableAt
+}
+
+// openStores opens the stores if needed.
+func (q *Queue) openStores() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.open()
+}
+
+// open opens the stores, creating them if needed. Called with the lock held.
+func (q *Queue) open() error {
+	if q.store != nil {
+		return nil
+	}
+	if q.multiStore == nil {
+		return failure.New(queue.ErrStoreUnavailable, "queue '%s' has no multi-store", q.ID())
+	}
+	store, err := q.openStore(q.options.Store)
+	if err != nil {
+		return err
+	}
+	deadStore, err := q.openStore(q.options.DeadLetterStore)
+	if err != nil {
+		return err
+	}
+	q.store, q.deadStore = store, deadStore
+	return nil
+}
+
+// openStore returns a store of the multi-store, creating it if needed.
+func (q *Queue) openStore(name string) (storage.Store, error) {
+	store, err := q.multiStore.GetStore(name)
+	if err != nil && errors.Is(err, storage.ErrStoreNotFound) {
+		if err = q.multiStore.CreateStore(name, q.options.Engine, nil); err == nil {
+			store, err = q.multiStore.GetStore(name)
+		}
+	}
+	if err != nil {
+		return nil, failure.Wrap(err, queue.ErrStoreUnavailable, "store '%s'", name)
+	}
+	return store, nil
+}
+
+// interrupt stops claiming jobs and cancels the deliveries in progress, which
+// release their jobs, until the queue is restarted.
+func (q *Queue) interrupt() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.active || q.stopping {
+		return
+	}
+	q.stopping = true
+	q.cancel()
+	q.notify()
+}
+
+// isStopping returns whether the runtime is shutting down.
+func (q *Queue) isStopping() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.stopping
+}
+
+// runContext returns the context of deliveries, cancelled on Stop.
+func (q *Queue) runContext() context.Context {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.runCtx
+}
+
+// notify wakes the dispatcher.
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// newJobID returns a random job ID.
+func newJobID() (string, error) {
+	var id [12]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	return hex.EncodeToString(id[:]), nil
+}
+
+// publish sends a job event if an event bus is available.
+func (q *Queue) publish(topic string, job queue.Job, details map[string]interface{}) {
+	if q.eventBus == nil {
+		return
+	}
+	payload := map[string]interface{}{
+		"jobId":       job.ID,
+		"operationId": string(job.OperationID),
+		"attempt":     job.Attempts,
+	}
+	for key, value := range details {
+		payload[key] = value
+	}
+	q.eventBus.Publish(&event.Event{
+		Topic:   topic,
+		Source:  string(q.ID()),
+		Time:    time.Now(),
+		Payload: payload,
+	})
+}
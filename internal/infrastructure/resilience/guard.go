@@ -0,0 +1,542 @@
+// Package resilience provides the operation resilience guard implementation.
+package resilience
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+)
+
+// Guard implements resilience.Guard.
+//
+// The policy of an operation is resolved on its first call from its
+// properties and the configuration, unless set with SetPolicy, and resolved
+// again when a different component is registered under the same ID. Every
+// attempt validates input and output against the schemas the operation
+// declares, like System.ExecuteOperation.
+//
+// Failed attempts count against the breaker, except for rejected input and
+// cancellation by the caller. An attempt that panics fails with
+// component.ErrOperationFailed. Breaker transitions and retries are published
+// on the event bus.
+type Guard struct {
+	config   config.Configuration
+	eventBus event.EventBus
+	logger   logging.Logger
+
+	entries map[component.ComponentID]*entry
+	mu      sync.Mutex
+
+	// Attempts run in the background when a timeout is set; those still
+	// running after their timeout are abandoned. idle is closed when the last
+	// background attempt returns while Wait is blocked.
+	background int
+	idle       chan struct{}
+	bgMu       sync.Mutex
+	abandoned  atomic.Int64
+}
+
+// entry is the resilience state of one operation.
+type entry struct {
+	operation component.Operation // the component the policy was resolved for
+	policy    resilience.Policy
+	explicit  bool // set with SetPolicy
+
+	breaker  resilience.BreakerState
+	openedAt time.Time
+	probes   int // probe calls let through while half-open
+	probed   int // successful probe calls
+
+	consecutive int
+	calls       int
+	failures    int
+	retries     int
+	timeouts    int
+	rejected    int
+	lastErr     error
+}
+
+// transition is a breaker state change to publish.
+type transition struct {
+	topic string
+	from  resilience.BreakerState
+	to    resilience.BreakerState
+	err   error
+}
+
+// NewGuard creates a guard. Policies are read from the configuration, which
+// may be nil like the event bus and the logger.
+func NewGuard(cfg config.Configuration, eventBus event.EventBus, logger logging.Logger) *Guard {
+	if logger == nil {
+		logger = infraLogging.NewNoOpLogger()
+	}
+	return &Guard{
+		config:   cfg,
+		eventBus: eventBus,
+		logger:   logger,
+		entries:  make(map[component.ComponentID]*entry),
+	}
+}
+
+// Execute runs an operation under its policy. Each attempt is bounded by the
+// policy timeout; an attempt that times out keeps running in the background
+// with a cancelled context until the operation returns, see Wait. Classified
+// failures are retried with backoff until the attempts are spent, the breaker
+// opens or ctx is done.
+func (g *Guard) Execute(ctx context.Context, operation component.Operation, input component.Input) (component.Output, error) {
+	id := operation.ID()
+	e, err := g.entryFor(operation)
+	if err != nil {
+		return component.Output{}, err
+	}
+
+	g.mu.Lock()
+	policy := e.policy
+	g.mu.Unlock()
+	if isZero(policy) {
+		return infraComponent.ExecuteValidated(ctx, operation, input)
+	}
+
+	attempts := max(policy.Retry.MaxAttempts, 1)
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		probe, err := g.acquire(id, e)
+		if err != nil {
+			// The breaker opened while retrying
+			return component.Output{}, errors.Join(err, lastErr)
+		}
+
+		output, err := g.attempt(ctx, operation, input, policy.Timeout)
+		g.release(id, e, probe, err)
+		if err == nil {
+			return output, nil
+		}
+		lastErr = err
+
+		if attempt >= attempts || ctx.Err() != nil || !retryable(err, policy.Retry) {
+			if attempt > 1 {
+				return component.Output{}, failure.Wrap(err, resilience.ErrRetriesExhausted, "operation '%s' failed after %d attempts", id, attempt)
+			}
+			return component.Output{}, err
+		}
+
+		backoff := backoffFor(policy.Retry, attempt-1)
+		g.mu.Lock()
+		e.retries++
+		g.mu.Unlock()
+		g.logger.Warn("Retrying operation", "operation_id", id, "attempt", attempt, "backoff", backoff, "error", err)
+		g.publish(resilience.TopicOperationRetrying, id, map[string]interface{}{
+			"operationId": string(id),
+			"attempt":     attempt,
+			"backoff":     backoff.String(),
+			"error":       err.Error(),
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return component.Output{}, errors.Join(err, ctx.Err())
+		}
+	}
+}
+
+// Wait blocks until every attempt abandoned after its timeout has returned.
+// Attempts may start while Wait is blocked; it returns once none is running.
+func (g *Guard) Wait() {
+	g.bgMu.Lock()
+	if g.background == 0 {
+		g.bgMu.Unlock()
+		return
+	}
+	if g.idle == nil {
+		g.idle = make(chan struct{})
+	}
+	idle := g.idle
+	g.bgMu.Unlock()
+	<-idle
+}
+
+// Abandoned returns how many attempts are still running after their timeout.
+func (g *Guard) Abandoned() int {
+	return int(g.abandoned.Load())
+}
+
+// SetPolicy sets the policy of an operation and resets its breaker.
+func (g *Guard) SetPolicy(operationID component.ComponentID, policy resilience.Policy) error {
+	policy, err := normalizePolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	e := &entry{policy: policy, explicit: true, breaker: resilience.BreakerClosed}
+	if previous, exists := g.entries[operationID]; exists {
+		e.operation = previous.operation
+	}
+	g.entries[operationID] = e
+	return nil
+}
+
+// Reset closes the breaker of an operation and clears its failures.
+func (g *Guard) Reset(operationID component.ComponentID) error {
+	g.mu.Lock()
+	e, exists := g.entries[operationID]
+	if !exists {
+		g.mu.Unlock()
+		return failure.New(resilience.ErrPolicyNotFound, "'%s'", operationID)
+	}
+	from := e.breaker
+	e.breaker = resilience.BreakerClosed
+	e.consecutive = 0
+	e.probes, e.probed = 0, 0
+	g.mu.Unlock()
+
+	if from != resilience.BreakerClosed {
+		g.publishTransition(operationID, transition{topic: resilience.TopicBreakerClosed, from: from, to: resilience.BreakerClosed})
+	}
+	return nil
+}
+
+// State returns the resilience state of an operation.
+func (g *Guard) State(operationID component.ComponentID) (resilience.State, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	e, exists := g.entries[operationID]
+	if !exists || isZero(e.policy) {
+		return resilience.State{}, failure.New(resilience.ErrPolicyNotFound, "'%s'", operationID)
+	}
+	return e.snapshot(operationID), nil
+}
+
+// States returns the resilience state of every operation with a policy,
+// ordered by operation ID.
+func (g *Guard) States() []resilience.State {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	states := make([]resilience.State, 0, len(g.entries))
+	for id, e := range g.entries {
+		if !isZero(e.policy) {
+			states = append(states, e.snapshot(id))
+		}
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].OperationID < states[j].OperationID })
+	return states
+}
+
+// entryFor returns the entry of an operation, resolving its policy on the
+// first call or when the component registered under its ID changed.
+func (g *Guard) entryFor(operation component.Operation) (*entry, error) {
+	id := operation.ID()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	e, exists := g.entries[id]
+	if exists && (e.explicit || infraComponent.SameComponent(e.operation, operation)) {
+		e.operation = operation
+		return e, nil
+	}
+
+	policy, err := PolicyFromProperties(operation.Metadata())
+	if err == nil && g.config != nil {
+		policy, err = PolicyFromConfig(g.config, id, policy)
+	}
+	if err == nil {
+		policy, err = normalizePolicy(policy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("operation '%s': %w", id, err)
+	}
+
+	e = &entry{operation: operation, policy: policy, breaker: resilience.BreakerClosed}
+	g.entries[id] = e
+	return e, nil
+}
+
+// acquire lets an attempt through the breaker, reporting whether it is a
+// probe of a half-open breaker.
+func (g *Guard) acquire(id component.ComponentID, e *entry) (bool, error) {
+	g.mu.Lock()
+	breaker := e.policy.Breaker
+	if breaker.FailureThreshold == 0 {
+		e.calls++
+		g.mu.Unlock()
+		return false, nil
+	}
+
+	var changed *transition
+	if e.breaker == resilience.BreakerOpen {
+		if retryAt := e.openedAt.Add(breaker.OpenTimeout); time.Now().Before(retryAt) {
+			e.rejected++
+			g.mu.Unlock()
+			return false, failure.New(resilience.ErrCircuitOpen, "operation '%s' rejected until %s", id, retryAt.Format(time.RFC3339))
+		}
+		e.breaker = resilience.BreakerHalfOpen
+		e.probes, e.probed = 0, 0
+		changed = &transition{topic: resilience.TopicBreakerHalfOpen, from: resilience.BreakerOpen, to: resilience.BreakerHalfOpen}
+	}
+
+	probe := e.breaker == resilience.BreakerHalfOpen
+	if probe {
+		if e.probes >= breaker.HalfOpenProbes {
+			e.rejected++
+			g.mu.Unlock()
+			return false, failure.New(resilience.ErrCircuitOpen, "operation '%s' rejected while probing", id)
+		}
+		e.probes++
+	}
+	e.calls++
+	g.mu.Unlock()
+
+	if changed != nil {
+		g.publishTransition(id, *changed)
+	}
+	return probe, nil
+}
+
+// release records the outcome of an attempt and moves the breaker.
+func (g *Guard) release(id component.ComponentID, e *entry, probe bool, err error) {
+	counted := err != nil && countsAsFailure(err)
+
+	g.mu.Lock()
+	if err != nil {
+		e.failures++
+		e.lastErr = err
+		if errors.Is(err, resilience.ErrOperationTimeout) {
+			e.timeouts++
+		}
+	}
+	if err == nil {
+		e.consecutive = 0
+	} else if counted {
+		e.consecutive++
+	}
+
+	var changed *transition
+	breaker := e.policy.Breaker
+	switch {
+	case breaker.FailureThreshold == 0:
+	case probe && e.breaker != resilience.BreakerHalfOpen:
+		// The breaker moved on while the probe ran
+	case probe && counted:
+		e.breaker = resilience.BreakerOpen
+		e.openedAt = time.Now()
+		changed = &transition{topic: resilience.TopicBreakerOpened, from: resilience.BreakerHalfOpen, to: resilience.BreakerOpen, err: err}
+	case probe && err != nil:
+		// Rejected input says nothing about the backend; let another probe through
+		e.probes--
+	case probe:
+		e.probed++
+		if e.probed >= breaker.HalfOpenProbes {
+			e.breaker = resilience.BreakerClosed
+			changed = &transition{topic: resilience.TopicBreakerClosed, from: resilience.BreakerHalfOpen, to: resilience.BreakerClosed}
+		}
+	case counted && e.breaker == resilience.BreakerClosed && e.consecutive >= breaker.FailureThreshold:
+		e.breaker = resilience.BreakerOpen
+		e.openedAt = time.Now()
+		changed = &transition{topic: resilience.TopicBreakerOpened, from: resilience.BreakerClosed, to: resilience.BreakerOpen, err: err}
+	}
+	g.mu.Unlock()
+
+	if changed != nil {
+		g.publishTransition(id, *changed)
+	}
+}
+
+// attempt runs one attempt, bounded by the timeout if one is set. An attempt
+// cut short by the timeout or by ctx is abandoned: it keeps running until the
+// operation returns and is counted until then.
+func (g *Guard) attempt(ctx context.Context, operation component.Operation, input component.Input, timeout time.Duration) (component.Output, error) {
+	if timeout <= 0 {
+		return run(ctx, operation, input)
+	}
+
+	attemptCtx := infraContext.WithTimeout(ctx, timeout)
+	defer attemptCtx.Cancel()
+
+	type result struct {
+		output component.Output
+		err    error
+	}
+	done := make(chan result, 1)
+	var settled atomic.Bool // set by the first of the attempt and the timeout
+	g.beginBackground()
+	go func() {
+		defer g.endBackground()
+		output, err := run(attemptCtx, operation, input)
+		done <- result{output, err}
+		if !settled.CompareAndSwap(false, true) {
+			g.abandoned.Add(-1)
+		}
+	}()
+
+	select {
+	case r := <-done:
+		return r.output, r.err
+	case <-attemptCtx.Done():
+		if settled.CompareAndSwap(false, true) {
+			g.abandoned.Add(1)
+		}
+		if ctx.Err() != nil {
+			return component.Output{}, ctx.Err()
+		}
+		return component.Output{}, failure.New(resilience.ErrOperationTimeout, "operation '%s' not done after %s", operation.ID(), timeout)
+	}
+}
+
+// beginBackground counts an attempt running in the background. Every call
+// must be matched by endBackground.
+func (g *Guard) beginBackground() {
+	g.bgMu.Lock()
+	defer g.bgMu.Unlock()
+	g.background++
+}
+
+// endBackground marks a background attempt as returned.
+func (g *Guard) endBackground() {
+	g.bgMu.Lock()
+	defer g.bgMu.Unlock()
+
+	g.background--
+	if g.background == 0 && g.idle != nil {
+		close(g.idle)
+		g.idle = nil
+	}
+}
+
+// run executes a validated attempt, turning a panic into an error.
+func run(ctx context.Context, operation component.Operation, input component.Input) (output component.Output, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = failure.New(component.ErrOperationFailed, "operation '%s' panicked: %v", operation.ID(), r)
+		}
+	}()
+	return infraComponent.ExecuteValidated(ctx, operation, input)
+}
+
+// publishTransition logs and publishes a breaker state change.
+func (g *Guard) publishTransition(id component.ComponentID, t transition) {
+	payload := map[string]interface{}{
+		"operationId":   string(id),
+		"state":         string(t.to),
+		"previousState": string(t.from),
+	}
+	if t.err != nil {
+		payload["error"] = t.err.Error()
+		g.logger.Warn("Circuit breaker opened", "operation_id", id, "error", t.err)
+	} else {
+		g.logger.Info("Circuit breaker changed state", "operation_id", id, "state", t.to)
+	}
+	g.publish(t.topic, id, payload)
+}
+
+// publish sends a resilience event if an event bus is available.
+func (g *Guard) publish(topic string, id component.ComponentID, payload map[string]interface{}) {
+	if g.eventBus == nil {
+		return
+	}
+	g.eventBus.Publish(&event.Event{
+		Topic:   topic,
+		Source:  string(id),
+		Time:    time.Now(),
+		Payload: payload,
+	})
+}
+
+// snapshot returns the public state of the entry.
+func (e *entry) snapshot(id component.ComponentID) resilience.State {
+	return resilience.State{
+		OperationID:         id,
+		Policy:              e.policy,
+		Breaker:             e.breaker,
+		ConsecutiveFailures: e.consecutive,
+		OpenedAt:            e.openedAt,
+		Calls:               e.calls,
+		Failures:            e.failures,
+		Retries:             e.retries,
+		Timeouts:            e.timeouts,
+		Rejected:            e.rejected,
+		LastError:           e.lastErr,
+	}
+}
+
+// Transient marks an error as transient, so that it is retried.
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &transientError{err: err}
+}
+
+// transientError is an error marked as transient.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string   { return e.err.Error() }
+func (e *transientError) Unwrap() error   { return e.err }
+func (e *transientError) Transient() bool { return true }
+
+// retryable reports whether a failed attempt is retried: transient errors,
+// errors carrying one of the RetryOn codes and, with RetryOnTimeout, timeouts.
+func retryable(err error, policy resilience.RetryPolicy) bool {
+	if errors.Is(err, resilience.ErrOperationTimeout) {
+		return policy.RetryOnTimeout
+	}
+	var transient resilience.TransientError
+	if errors.As(err, &transient) {
+		return transient.Transient()
+	}
+	for _, code := range policy.RetryOn {
+		if errors.Is(err, failure.Code(code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// countsAsFailure reports whether a failed attempt counts against the breaker.
+// Rejected input and cancellation by the caller do not.
+func countsAsFailure(err error) bool {
+	for _, code := range []error{
+		component.ErrInputValidationFailed,
+		component.ErrInvalidInputType,
+		context.ErrContextCanceled,
+	} {
+		if errors.Is(err, code) {
+			return false
+		}
+	}
+	return true
+}
+
+// backoffFor returns the delay before a retry when n retries were made,
+// shortened by a random jitter.
+func backoffFor(p resilience.RetryPolicy, n int) time.Duration {
+	backoff := infraComponent.Backoff(p.InitialBackoff, p.MaxBackoff, n)
+	if p.Jitter > 0 {
+		backoff -= time.Duration(float64(backoff) * p.Jitter * rand.Float64())
+	}
+	return backoff
+}
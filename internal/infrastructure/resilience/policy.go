@@ -0,0 +1,237 @@
+package resilience
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+)
+
+// Policy defaults
+const (
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 10 * time.Second
+	DefaultOpenTimeout    = 30 * time.Second
+	DefaultHalfOpenProbes = 1
+)
+
+// Policies are declared in component properties under PropertyPrefix, for
+// example "resilience.timeout", and in configuration under ConfigPrefix and
+// the operation ID, for example "resilience.operations.quote.timeout".
+// Configuration takes precedence over properties.
+const (
+	PropertyPrefix = "resilience."
+	ConfigPrefix   = "resilience.operations."
+)
+
+// Policy keys, relative to PropertyPrefix or to the operation configuration.
+const (
+	KeyTimeout                 = "timeout"
+	KeyRetryMaxAttempts        = "retry.max_attempts"
+	KeyRetryInitialBackoff     = "retry.initial_backoff"
+	KeyRetryMaxBackoff         = "retry.max_backoff"
+	KeyRetryJitter             = "retry.jitter"
+	KeyRetryOn                 = "retry.on"
+	KeyRetryOnTimeout          = "retry.on_timeout"
+	KeyBreakerFailureThreshold = "breaker.failure_threshold"
+	KeyBreakerOpenTimeout      = "breaker.open_timeout"
+	KeyBreakerHalfOpenProbes   = "breaker.half_open_probes"
+)
+
+// PolicyFromProperties reads a policy from component properties.
+func PolicyFromProperties(properties component.Metadata) (resilience.Policy, error) {
+	return parsePolicy(resilience.Policy{}, func(key string) (interface{}, bool) {
+		value, ok := properties[PropertyPrefix+key]
+		return value, ok
+	})
+}
+
+// PolicyFromConfig overlays the policy configured for an operation on base.
+func PolicyFromConfig(cfg config.Configuration, operationID component.ComponentID, base resilience.Policy) (resilience.Policy, error) {
+	prefix := ConfigPrefix + string(operationID) + "."
+	return parsePolicy(base, func(key string) (interface{}, bool) {
+		if !cfg.Exists(prefix + key) {
+			return nil, false
+		}
+		var value interface{}
+		if err := cfg.GetObject(prefix+key, &value); err != nil {
+			return nil, false
+		}
+		return value, true
+	})
+}
+
+// parsePolicy overlays the values found by lookup on policy.
+func parsePolicy(policy resilience.Policy, lookup func(key string) (interface{}, bool)) (resilience.Policy, error) {
+	var err error
+	durations := map[string]*time.Duration{
+		KeyTimeout:             &policy.Timeout,
+		KeyRetryInitialBackoff: &policy.Retry.InitialBackoff,
+		KeyRetryMaxBackoff:     &policy.Retry.MaxBackoff,
+		KeyBreakerOpenTimeout:  &policy.Breaker.OpenTimeout,
+	}
+	for key, target := range durations {
+		if value, ok := lookup(key); ok {
+			if *target, err = toDuration(value); err != nil {
+				return policy, invalidValue(key, value)
+			}
+		}
+	}
+
+	ints := map[string]*int{
+		KeyRetryMaxAttempts:        &policy.Retry.MaxAttempts,
+		KeyBreakerFailureThreshold: &policy.Breaker.FailureThreshold,
+		KeyBreakerHalfOpenProbes:   &policy.Breaker.HalfOpenProbes,
+	}
+	for key, target := range ints {
+		if value, ok := lookup(key); ok {
+			if *target, err = toInt(value); err != nil {
+				return policy, invalidValue(key, value)
+			}
+		}
+	}
+
+	if value, ok := lookup(KeyRetryJitter); ok {
+		if policy.Retry.Jitter, err = toFloat(value); err != nil {
+			return policy, invalidValue(KeyRetryJitter, value)
+		}
+	}
+	if value, ok := lookup(KeyRetryOn); ok {
+		if policy.Retry.RetryOn, err = toStrings(value); err != nil {
+			return policy, invalidValue(KeyRetryOn, value)
+		}
+	}
+	if value, ok := lookup(KeyRetryOnTimeout); ok {
+		if policy.Retry.RetryOnTimeout, err = toBool(value); err != nil {
+			return policy, invalidValue(KeyRetryOnTimeout, value)
+		}
+	}
+	return policy, nil
+}
+
+// normalizePolicy fills in defaults and rejects invalid values.
+func normalizePolicy(p resilience.Policy) (resilience.Policy, error) {
+	if p.Timeout < 0 || p.Retry.MaxAttempts < 0 || p.Retry.InitialBackoff < 0 || p.Retry.MaxBackoff < 0 ||
+		p.Breaker.FailureThreshold < 0 || p.Breaker.OpenTimeout < 0 || p.Breaker.HalfOpenProbes < 0 {
+		return p, failure.New(resilience.ErrInvalidPolicy, "negative value in %+v", p)
+	}
+	if p.Retry.Jitter < 0 || p.Retry.Jitter > 1 {
+		return p, failure.New(resilience.ErrInvalidPolicy, "jitter %v not between 0 and 1", p.Retry.Jitter)
+	}
+
+	if p.Retry.MaxAttempts > 1 {
+		if p.Retry.InitialBackoff == 0 {
+			p.Retry.InitialBackoff = DefaultInitialBackoff
+		}
+		if p.Retry.MaxBackoff == 0 {
+			p.Retry.MaxBackoff = DefaultMaxBackoff
+		}
+		if p.Retry.MaxBackoff < p.Retry.InitialBackoff {
+			p.Retry.MaxBackoff = p.Retry.InitialBackoff
+		}
+	}
+	if p.Breaker.FailureThreshold > 0 {
+		if p.Breaker.OpenTimeout == 0 {
+			p.Breaker.OpenTimeout = DefaultOpenTimeout
+		}
+		if p.Breaker.HalfOpenProbes == 0 {
+			p.Breaker.HalfOpenProbes = DefaultHalfOpenProbes
+		}
+	}
+	return p, nil
+}
+
+// isZero reports whether a policy leaves the operation unchanged.
+func isZero(p resilience.Policy) bool {
+	return p.Timeout == 0 && p.Retry.MaxAttempts <= 1 && p.Breaker.FailureThreshold == 0
+}
+
+func invalidValue(key string, value interface{}) error {
+	return failure.New(resilience.ErrInvalidPolicy, "invalid value '%v' for '%s'", value, key)
+}
+
+// toDuration converts a duration, a duration string such as "2s" or a number
+// of nanoseconds.
+func toDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return time.ParseDuration(v)
+	case int:
+		return time.Duration(v), nil
+	case int64:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	}
+	return 0, fmt.Errorf("not a duration: %T", value)
+}
+
+func toInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	}
+	return 0, fmt.Errorf("not an integer: %T", value)
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("not a number: %T", value)
+}
+
+func toBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, fmt.Errorf("not a boolean: %T", value)
+}
+
+// toStrings converts a list or a comma-separated string.
+func toStrings(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case string:
+		var result []string
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				result = append(result, s)
+			}
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("not a string: %T", item)
+			}
+			result = append(result, s)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("not a list: %T", value)
+}
@@ -3,24 +3,51 @@ package runtime
 
 import (
 	"errors"
-	"fmt"
+	"slices"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/config"
 	"github.com/fintechain/skeleton/internal/domain/context"
 	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
 	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
 	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	domainRuntime "github.com/fintechain/skeleton/internal/domain/runtime"
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraHealth "github.com/fintechain/skeleton/internal/infrastructure/health"
+	infraIdempotency "github.com/fintechain/skeleton/internal/infrastructure/idempotency"
+	infraIntrospection "github.com/fintechain/skeleton/internal/infrastructure/introspection"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
+	infraResilience "github.com/fintechain/skeleton/internal/infrastructure/resilience"
+	infraSupervisor "github.com/fintechain/skeleton/internal/infrastructure/supervisor"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
 )
 
 // Error constants
 const (
-	ErrNilRegistry      = "runtime.nil_registry"
-	ErrNilConfiguration = "runtime.nil_configuration"
-	ErrNilPluginManager = "runtime.nil_plugin_manager"
-	ErrNilEventBus      = "runtime.nil_event_bus"
-	ErrNilLogger        = "runtime.nil_logger"
+	ErrNilRegistry      failure.Code = "runtime.nil_registry"
+	ErrNilConfiguration failure.Code = "runtime.nil_configuration"
+	ErrNilPluginManager failure.Code = "runtime.nil_plugin_manager"
+	ErrNilEventBus      failure.Code = "runtime.nil_event_bus"
+	ErrNilLogger        failure.Code = "runtime.nil_logger"
+)
+
+// Component IDs of the runtime and its supervisor
+const (
+	RuntimeID    = "runtime"
+	SupervisorID = "supervisor"
 )
 
 // Runtime implements the RuntimeEnvironment interface directly.
@@ -32,8 +59,85 @@ type Runtime struct {
 	eventBus      event.EventBusService
 	logger        logging.LoggerService
 
+	// Plugin configuration sections and their defaults
+	sections *infraConfig.Sections
+
+	// Service supervision; failed receives failures escalated to the runtime
+	supervisor *infraSupervisor.Supervisor
+	failed     chan error
+
+	// Liveness and readiness of the core services and registered services
+	health *infraHealth.Checker
+
+	// Lifecycle events of the core services and registered components
+	events *infraComponent.LifecycleEvents
+
+	// Timeouts, retries and circuit breakers of operations
+	guard *infraResilience.Guard
+
+	// At-most-once execution per idempotency key
+	idempotency *infraIdempotency.Deduplicator
+
+	// Metrics of operations, events, stores and services
+	metrics          metrics.Registry
+	operationMetrics atomic.Pointer[operationMetrics]
+
+	// Spans of operations, event deliveries and store calls
+	tracer tracing.Tracer
+
+	// Components, services, plugins, subscriptions and stores of the system
+	inspector *infraIntrospection.Inspector
+
+	// In-flight operations; draining is set once shutdown begins, and idle is
+	// closed when the last operation in flight during a drain finishes
+	inFlight atomic.Int64
+	draining bool
+	idle     chan struct{}
+	opMu     sync.Mutex
+
 	// State
 	running atomic.Bool
+	status  component.ServiceStatus
+	mu      sync.RWMutex
+}
+
+// eventSource is implemented by registries that publish lifecycle events.
+type eventSource interface {
+	SetEventBus(bus event.EventBus)
+}
+
+// metricsRecorder is implemented by core services that record metrics, such
+// as the event bus.
+type metricsRecorder interface {
+	SetMetrics(registry metrics.Registry)
+}
+
+// tracerUser is implemented by core services that trace their calls, such as
+// the event bus.
+type tracerUser interface {
+	SetTracer(tracer tracing.Tracer)
+}
+
+// operationMetrics are the metrics recorded per operation.
+type operationMetrics struct {
+	executions metrics.Counter
+	errors     metrics.Counter
+	duration   metrics.Histogram
+}
+
+// serviceStatuses are the statuses reported by the service status metric.
+var serviceStatuses = []component.ServiceStatus{
+	component.StatusStopped,
+	component.StatusStarting,
+	component.StatusRunning,
+	component.StatusStopping,
+	component.StatusError,
+}
+
+// coreService pairs a core service with a name used in error messages.
+type coreService struct {
+	name    string
+	service component.Service
 }
 
 // NewRuntime creates a new runtime environment with direct dependency injection.
@@ -45,28 +149,71 @@ func NewRuntime(
 	logger logging.LoggerService,
 ) (*Runtime, error) {
 	if registry == nil {
-		return nil, errors.New(ErrNilRegistry)
+		return nil, ErrNilRegistry
 	}
 	if config == nil {
-		return nil, errors.New(ErrNilConfiguration)
+		return nil, ErrNilConfiguration
 	}
 	if pluginManager == nil {
-		return nil, errors.New(ErrNilPluginManager)
+		return nil, ErrNilPluginManager
 	}
 	if eventBus == nil {
-		return nil, errors.New(ErrNilEventBus)
+		return nil, ErrNilEventBus
 	}
 	if logger == nil {
-		return nil, errors.New(ErrNilLogger)
+		return nil, ErrNilLogger
 	}
 
-	return &Runtime{
+	r := &Runtime{
 		registry:      registry,
 		config:        config,
 		pluginManager: pluginManager,
 		eventBus:      eventBus,
 		logger:        logger,
-	}, nil
+		sections:      infraConfig.NewSections(config),
+		failed:        make(chan error, 1),
+		status:        component.StatusStopped,
+	}
+	r.supervisor = infraSupervisor.NewSupervisor(
+		component.ComponentConfig{ID: SupervisorID, Name: "Supervisor", Description: "Restarts failed services"},
+		registry, pluginManager, eventBus, logger,
+	)
+	r.supervisor.SetRuntimeEscalation(r.escalate)
+	r.guard = infraResilience.NewGuard(config, eventBus, logger)
+	r.idempotency = infraIdempotency.NewDeduplicator(config, eventBus, logger)
+	r.SetMetrics(infraMetrics.NewRegistry())
+	r.SetTracer(infraTracing.NewTracer())
+
+	// Publish lifecycle events of the core services and registered components
+	r.events = infraComponent.NewLifecycleEvents(eventBus)
+	if source, ok := registry.(eventSource); ok {
+		source.SetEventBus(eventBus)
+	}
+	for _, svc := range r.coreServices() {
+		r.events.Observe(svc.service)
+	}
+
+	r.inspector = infraIntrospection.NewInspector(registry, pluginManager, eventBus)
+	r.inspector.SetResilience(r.guard)
+	for _, svc := range r.coreServices() {
+		r.inspector.AddService(svc.service)
+	}
+
+	r.health = infraHealth.NewChecker(registry, infraHealth.CheckerOptions{})
+	for _, svc := range r.coreServices() {
+		r.health.AddService(svc.service)
+	}
+	r.health.AddCheck(RuntimeID, health.KindReadiness, func(ctx context.Context) error {
+		if r.isDraining() {
+			return failure.New(ErrShuttingDown, "runtime status '%s'", r.Status())
+		}
+		if !r.IsRunning() {
+			return failure.New(component.ErrSystemNotStarted, "runtime status '%s'", r.Status())
+		}
+		return nil
+	})
+
+	return r, nil
 }
 
 // Registry returns the component registry.
@@ -74,95 +221,204 @@ func (r *Runtime) Registry() component.Registry {
 	return r.registry
 }
 
-// ExecuteOperation executes a registered operation component with the given input.
-func (r *Runtime) ExecuteOperation(ctx context.Context, operationID component.ComponentID, input component.Input) (component.Output, error) {
+// ExecuteOperation executes a registered operation component with the given input,
+// validating input and output against the schemas the operation declares and
+// applying its resilience policy (see Resilience). Once Shutdown has begun,
+// new operations are rejected with ErrShuttingDown; operations executed with
+// the context of an operation in flight, such as workflow steps, still run.
+func (r *Runtime) ExecuteOperation(ctx context.Context, operationID component.ComponentID, input component.Input) (output component.Output, err error) {
+	if !r.beginOperation(ctx.Value(operationKey{}) != nil) {
+		return component.Output{}, failure.New(ErrShuttingDown, "operation '%s' rejected", operationID)
+	}
+	defer r.endOperation()
+	ctx = ctx.WithValue(operationKey{}, operationID)
+	defer r.recordOperation(operationID, time.Now(), &err)
+
+	ctx, span := r.Tracer().Start(ctx, "operation "+string(operationID), tracing.KindInternal)
+	span.SetAttribute("operation.id", string(operationID))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	comp, err := r.registry.Get(operationID)
 	if err != nil {
-		return component.Output{}, fmt.Errorf("operation not found: %w", err)
+		return component.Output{}, failure.Wrap(err, component.ErrOperationNotFound, "operation '%s'", operationID).WithComponent(string(operationID))
 	}
 
 	operation, ok := comp.(component.Operation)
 	if !ok {
-		return component.Output{}, fmt.Errorf("component %s is not an operation", operationID)
+		return component.Output{}, failure.New(component.ErrInvalidComponentType, "component '%s' is not an operation", operationID).WithComponent(string(operationID))
 	}
 
-	return operation.Execute(ctx, input)
+	return r.idempotency.Execute(ctx, operation, input, r.guard.Execute)
+}
+
+// recordOperation records an execution of the operation that began at start
+// and, if it failed, its error code.
+func (r *Runtime) recordOperation(operationID component.ComponentID, start time.Time, err *error) {
+	m := r.operationMetrics.Load()
+	labels := metrics.Labels{"operation": string(operationID)}
+	m.executions.Inc(labels)
+	m.duration.Observe(labels, time.Since(start).Seconds())
+	if *err != nil {
+		code := failure.CodeOf(*err)
+		if code == "" {
+			code = "unknown"
+		}
+		m.errors.Inc(metrics.Labels{"operation": string(operationID), "code": string(code)})
+	}
 }
 
 // StartService starts a registered service component.
 func (r *Runtime) StartService(ctx context.Context, serviceID component.ComponentID) error {
 	comp, err := r.registry.Get(serviceID)
 	if err != nil {
-		return fmt.Errorf("service not found: %w", err)
+		return failure.Wrap(err, component.ErrServiceNotFound, "service '%s'", serviceID).WithComponent(string(serviceID))
 	}
 
 	service, ok := comp.(component.Service)
 	if !ok {
-		return fmt.Errorf("component %s is not a service", serviceID)
+		return failure.New(component.ErrInvalidComponentType, "component '%s' is not a service", serviceID).WithComponent(string(serviceID))
 	}
 
-	return service.Start(ctx)
+	if err := service.Start(ctx); err != nil {
+		r.events.Publish(component.TopicServiceFailed, service, err, map[string]interface{}{"action": "start"})
+		return err
+	}
+	r.publishUnobserved(component.TopicServiceStarted, service)
+	return nil
 }
 
 // StopService stops a running service component gracefully.
 func (r *Runtime) StopService(ctx context.Context, serviceID component.ComponentID) error {
 	comp, err := r.registry.Get(serviceID)
 	if err != nil {
-		return fmt.Errorf("service not found: %w", err)
+		return failure.Wrap(err, component.ErrServiceNotFound, "service '%s'", serviceID).WithComponent(string(serviceID))
 	}
 
 	service, ok := comp.(component.Service)
 	if !ok {
-		return fmt.Errorf("component %s is not a service", serviceID)
+		return failure.New(component.ErrInvalidComponentType, "component '%s' is not a service", serviceID).WithComponent(string(serviceID))
 	}
 
-	return service.Stop(ctx)
+	if err := service.Stop(ctx); err != nil {
+		r.events.Publish(component.TopicServiceFailed, service, err, map[string]interface{}{"action": "stop"})
+		return err
+	}
+	r.publishUnobserved(component.TopicServiceStopped, service)
+	return nil
+}
+
+// publishUnobserved publishes a transition of a service that does not report
+// its own transitions.
+func (r *Runtime) publishUnobserved(topic string, service component.Service) {
+	if _, ok := service.(component.ObservableComponent); !ok {
+		r.events.Publish(topic, service, nil, nil)
+	}
 }
 
 // Start initializes and starts the entire system.
+//
+// Startup is all-or-nothing: if a core service fails to start, the services
+// already started are stopped in reverse order and the runtime ends in
+// StatusError. The returned error joins the start failure with any rollback failures.
 func (r *Runtime) Start(ctx context.Context) error {
 	if r.running.Load() {
 		return nil // Already running
 	}
 
-	// Start core services
-	if err := r.eventBus.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start event bus: %w", err)
-	}
+	r.setStatus(component.StatusStarting)
 
-	if err := r.pluginManager.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start plugin manager: %w", err)
-	}
+	// Accept operations again after a previous shutdown
+	r.opMu.Lock()
+	r.draining = false
+	r.opMu.Unlock()
 
-	if err := r.logger.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start logger: %w", err)
+	// Start core services
+	started := make([]coreService, 0, 4)
+	for _, svc := range r.coreServices() {
+		if err := svc.service.Start(ctx); err != nil {
+			startErr := failure.Wrap(err, component.ErrServiceStartFailed, "failed to start %s", svc.name)
+			rollbackErr := stopCoreServices(ctx, started)
+
+			r.setStatus(component.StatusError)
+			return errors.Join(startErr, rollbackErr)
+		}
+		started = append(started, svc)
 	}
 
 	r.running.Store(true)
+	r.setStatus(component.StatusRunning)
 	return nil
 }
 
-// Stop gracefully shuts down the entire system.
+// Stop shuts down the entire system without waiting for in-flight work;
+// see Shutdown for a graceful shutdown.
 func (r *Runtime) Stop(ctx context.Context) error {
 	if !r.running.Load() {
 		return nil // Already stopped
 	}
 
-	// Stop core services in reverse order
-	if err := r.logger.Stop(ctx); err != nil {
-		return fmt.Errorf("failed to stop logger: %w", err)
+	r.setStatus(component.StatusStopping)
+
+	// Stop core services in reverse order. The event bus comes first in start
+	// order, so it stops last, once the stopped phase has been published.
+	services := r.coreServices()
+	for i := len(services) - 1; i >= 0; i-- {
+		if i == 0 {
+			r.eventBus.Publish(&event.Event{
+				Topic:   domainRuntime.TopicRuntimeStopped,
+				Source:  RuntimeID,
+				Time:    time.Now(),
+				Payload: map[string]interface{}{"phase": string(domainRuntime.PhaseStopped)},
+			})
+		}
+		if err := services[i].service.Stop(ctx); err != nil {
+			r.setStatus(component.StatusError)
+			return failure.Wrap(err, component.ErrServiceStopFailed, "failed to stop %s", services[i].name)
+		}
 	}
 
-	if err := r.pluginManager.Stop(ctx); err != nil {
-		return fmt.Errorf("failed to stop plugin manager: %w", err)
-	}
+	r.running.Store(false)
+	r.setStatus(component.StatusStopped)
+	return nil
+}
+
+// Status returns the lifecycle state of the runtime.
+func (r *Runtime) Status() component.ServiceStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
 
-	if err := r.eventBus.Stop(ctx); err != nil {
-		return fmt.Errorf("failed to stop event bus: %w", err)
+// setStatus updates the lifecycle state of the runtime.
+func (r *Runtime) setStatus(status component.ServiceStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+// coreServices returns the core services in start order.
+func (r *Runtime) coreServices() []coreService {
+	return []coreService{
+		{name: "event bus", service: r.eventBus},
+		{name: "plugin manager", service: r.pluginManager},
+		{name: "logger", service: r.logger},
+		{name: "supervisor", service: r.supervisor},
 	}
+}
 
-	r.running.Store(false)
-	return nil
+// stopCoreServices stops the given services in reverse order, attempting every
+// service even when some fail. It returns the joined stop failures, or nil.
+func stopCoreServices(ctx context.Context, services []coreService) error {
+	var errs []error
+	for i := len(services) - 1; i >= 0; i-- {
+		if err := services[i].service.Stop(ctx); err != nil {
+			errs = append(errs, failure.Wrap(err, component.ErrServiceStopFailed, "rollback: failed to stop %s", services[i].name))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // IsRunning returns whether the system is currently running.
@@ -191,19 +447,191 @@ func (r *Runtime) LoadPlugins(ctx context.Context, plugins []plugin.Plugin) erro
 	for _, p := range plugins {
 		// Add plugin to manager
 		if err := r.pluginManager.Add(p.ID(), p); err != nil {
-			return fmt.Errorf("failed to add plugin %s: %w", p.ID(), err)
+			return failure.Wrap(err, component.ErrPluginLoadFailed, "failed to add plugin '%s'", p.ID()).WithComponent(string(p.ID()))
 		}
 	}
 
 	// Let the plugin manager initialize all added plugins
 	if err := r.pluginManager.Initialize(ctx, r); err != nil {
-		return fmt.Errorf("failed to initialize plugin manager: %w", err)
+		return failure.Wrap(err, component.ErrPluginLoadFailed, "failed to initialize plugin manager")
+	}
+
+	// Plugins register their configuration defaults and rules while initializing
+	if err := r.sections.Validate(); err != nil {
+		return failure.Wrap(err, component.ErrPluginLoadFailed, "invalid plugin configuration")
 	}
 
 	return nil
 }
 
+// UnloadPlugins unloads every plugin, such as after a failed startup. Plugins
+// are unloaded after the plugins that require them; unloading stops once a
+// pass over the remaining plugins unloads none of them. The returned error
+// joins the unload failures.
+func (r *Runtime) UnloadPlugins(ctx context.Context) error {
+	var errs []error
+	remaining := r.pluginManager.ListPlugins()
+	for len(remaining) > 0 {
+		failed := make(map[component.ComponentID]error)
+		for _, id := range remaining {
+			if err := r.pluginManager.Unload(ctx, id); err != nil {
+				failed[id] = err
+			}
+		}
+
+		// Failures of plugins still loaded may clear once their dependents are gone
+		left := r.pluginManager.ListPlugins()
+		stuck := len(left) == len(remaining)
+		for _, id := range remaining {
+			if err, ok := failed[id]; ok && (stuck || !slices.Contains(left, id)) {
+				errs = append(errs, err)
+			}
+		}
+		if stuck {
+			break
+		}
+		remaining = left
+	}
+	return errors.Join(errs...)
+}
+
 // Configuration returns the runtime environment configuration.
 func (r *Runtime) Configuration() config.Configuration {
 	return r.config
 }
+
+// PluginConfiguration returns the configuration section of a plugin.
+func (r *Runtime) PluginConfiguration(pluginID component.ComponentID) config.Section {
+	return r.sections.Plugin(string(pluginID))
+}
+
+// EffectiveConfiguration returns the effective configuration as a flat map of
+// dotted keys, including the defaults registered by plugins.
+func (r *Runtime) EffectiveConfiguration() map[string]interface{} {
+	return r.sections.Effective()
+}
+
+// Supervisor returns the service supervisor.
+func (r *Runtime) Supervisor() supervisor.Supervisor {
+	return r.supervisor
+}
+
+// Resilience returns the guard that applies operation timeouts, retries and
+// circuit breakers.
+func (r *Runtime) Resilience() resilience.Guard {
+	return r.guard
+}
+
+// Idempotency returns the deduplicator that runs operations at most once per
+// idempotency key.
+func (r *Runtime) Idempotency() idempotency.Deduplicator {
+	return r.idempotency
+}
+
+// Failed returns a channel that receives the failure when a supervised service
+// escalates to the runtime. The runtime has already been stopped by then.
+func (r *Runtime) Failed() <-chan error {
+	return r.failed
+}
+
+// escalate stops the runtime after a supervised service failed for good.
+func (r *Runtime) escalate(err error) {
+	r.logger.Error("Stopping runtime after service failure", "error", err)
+	if stopErr := r.Stop(infraContext.NewContext()); stopErr != nil {
+		err = errors.Join(err, stopErr)
+	}
+	select {
+	case r.failed <- err:
+	default:
+	}
+}
+
+// Metrics returns the registry holding the metrics of operations, events,
+// stores and services.
+func (r *Runtime) Metrics() metrics.Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.metrics
+}
+
+// SetMetrics records the metrics of the runtime and its core services in the
+// registry instead of the default in-memory one. It is meant to be called
+// before the runtime starts.
+func (r *Runtime) SetMetrics(registry metrics.Registry) {
+	r.mu.Lock()
+	r.metrics = registry
+	r.mu.Unlock()
+
+	r.operationMetrics.Store(&operationMetrics{
+		executions: registry.Counter(metrics.OperationExecutions, "Number of operation executions."),
+		errors:     registry.Counter(metrics.OperationErrors, "Number of failed operation executions by error code."),
+		duration:   registry.Histogram(metrics.OperationDuration, "Duration of operation executions in seconds.", nil),
+	})
+	if recorder, ok := r.eventBus.(metricsRecorder); ok {
+		recorder.SetMetrics(registry)
+	}
+
+	status := registry.Gauge(metrics.ServiceStatus, "Status of services; the series of the current status is 1.")
+	registry.AddCollector(func() {
+		for _, service := range r.services() {
+			current := service.Status()
+			for _, s := range serviceStatuses {
+				value := 0.0
+				if s == current {
+					value = 1
+				}
+				status.Set(metrics.Labels{"service": string(service.ID()), "status": string(s)}, value)
+			}
+		}
+	})
+}
+
+// Tracer returns the tracer that records spans of operations, event
+// deliveries and store calls.
+func (r *Runtime) Tracer() tracing.Tracer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.tracer
+}
+
+// SetTracer traces operations and the calls of the core services with the
+// tracer instead of the default one, which exports nothing. It is meant to be
+// called before the runtime starts.
+func (r *Runtime) SetTracer(tracer tracing.Tracer) {
+	r.mu.Lock()
+	r.tracer = tracer
+	r.mu.Unlock()
+
+	if user, ok := r.eventBus.(tracerUser); ok {
+		user.SetTracer(tracer)
+	}
+}
+
+// services returns the core services and the services in the registry.
+func (r *Runtime) services() []component.Service {
+	var services []component.Service
+	for _, svc := range r.coreServices() {
+		services = append(services, svc.service)
+	}
+	registered, _ := r.registry.Find(func(comp component.Component) bool {
+		_, ok := comp.(component.Service)
+		return ok
+	})
+	for _, comp := range registered {
+		services = append(services, comp.(component.Service))
+	}
+	return services
+}
+
+// Health returns the checker that aggregates the liveness and readiness of the
+// core services, the registered services and any added checks.
+func (r *Runtime) Health() health.Checker {
+	return r.health
+}
+
+// Introspection returns the inspector that reports the components, services,
+// plugins, event subscriptions and stores of the system, including the core
+// services.
+func (r *Runtime) Introspection() introspection.Inspector {
+	return r.inspector
+}
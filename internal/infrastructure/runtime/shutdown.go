@@ -0,0 +1,184 @@
+package runtime
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	domainRuntime "github.com/fintechain/skeleton/internal/domain/runtime"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+// Shutdown error constants
+const (
+	ErrShuttingDown                 = domainRuntime.ErrShuttingDown
+	ErrShutdownTimeout failure.Code = "runtime.shutdown_timeout"
+	ErrShutdownAborted failure.Code = "runtime.shutdown_aborted"
+)
+
+// Shutdown phase defaults
+const (
+	DefaultDrainTimeout  = 30 * time.Second
+	DefaultEventsTimeout = 10 * time.Second
+	DefaultStopTimeout   = 30 * time.Second
+)
+
+// ShutdownOptions bounds each phase of a graceful shutdown. Zero values select defaults.
+type ShutdownOptions struct {
+	// DrainTimeout bounds the wait for in-flight operations.
+	DrainTimeout time.Duration
+
+	// EventsTimeout bounds the wait for async event handlers.
+	EventsTimeout time.Duration
+
+	// StopTimeout bounds stopping the plugins and core services.
+	StopTimeout time.Duration
+}
+
+// normalizeShutdownOptions fills in defaults.
+func normalizeShutdownOptions(options ShutdownOptions) ShutdownOptions {
+	if options.DrainTimeout <= 0 {
+		options.DrainTimeout = DefaultDrainTimeout
+	}
+	if options.EventsTimeout <= 0 {
+		options.EventsTimeout = DefaultEventsTimeout
+	}
+	if options.StopTimeout <= 0 {
+		options.StopTimeout = DefaultStopTimeout
+	}
+	return options
+}
+
+// Shutdown stops the runtime gracefully, in phases:
+//
+//  1. New operations are rejected with ErrShuttingDown and readiness goes down.
+//     Operations executed with the context of an operation in flight still run,
+//     so that workflows in progress complete.
+//  2. In-flight operations are drained, for at most DrainTimeout. Attempts
+//     the resilience guard abandoned after their timeout are awaited too.
+//  3. Async event handlers are awaited, for at most EventsTimeout.
+//  4. Plugins are stopped in reverse dependency order, then the core services
+//     in reverse start order, for at most StopTimeout.
+//
+// A phase that times out is reported and shutdown moves on to the next phase.
+// Cancelling ctx aborts the remaining waits. The returned error joins the
+// failures of every phase.
+func (r *Runtime) Shutdown(ctx context.Context, options ShutdownOptions) error {
+	options = normalizeShutdownOptions(options)
+
+	// Stop accepting operations
+	idle := r.beginDrain()
+
+	var errs []error
+
+	// Drain in-flight operations
+	r.logger.Info("Draining operations", "inFlight", r.inFlight.Load(), "abandoned", r.guard.Abandoned(), "timeout", options.DrainTimeout)
+	drain := func() {
+		<-idle
+		r.guard.Wait()
+	}
+	if err := waitPhase(ctx, drain, options.DrainTimeout); err != nil {
+		errs = append(errs, failure.Wrap(err, component.ErrServiceStopFailed, "drain operations: %d still running, %d abandoned after their timeout",
+			r.inFlight.Load(), r.guard.Abandoned()))
+	}
+
+	// Wait for async event handlers
+	r.logger.Info("Waiting for event handlers", "timeout", options.EventsTimeout)
+	if err := waitPhase(ctx, r.eventBus.WaitAsync, options.EventsTimeout); err != nil {
+		errs = append(errs, failure.Wrap(err, component.ErrServiceStopFailed, "wait for event handlers"))
+	}
+
+	// Stop services; they receive the phase deadline through their context
+	r.logger.Info("Stopping services", "timeout", options.StopTimeout)
+	stopCtx := infraContext.NewContextWithTimeout(options.StopTimeout)
+	defer stopCtx.Cancel()
+
+	var stopErr error
+	err := waitPhase(ctx, func() { stopErr = r.Stop(stopCtx) }, options.StopTimeout)
+	if err != nil {
+		r.setStatus(component.StatusError)
+		errs = append(errs, failure.Wrap(err, component.ErrServiceStopFailed, "stop services"))
+	} else if stopErr != nil {
+		errs = append(errs, stopErr)
+	}
+
+	return errors.Join(errs...)
+}
+
+// waitPhase runs wait and returns once it finishes, the timeout passes or ctx
+// is cancelled. A wait that is cut short keeps running in the background.
+func waitPhase(ctx context.Context, wait func(), timeout time.Duration) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return failure.New(ErrShutdownTimeout, "not done after %s", timeout)
+	case <-ctx.Done():
+		return failure.New(ErrShutdownAborted, "%v", ctx.Err())
+	}
+}
+
+// operationKey marks the context of an operation in flight.
+type operationKey struct{}
+
+// beginOperation counts an operation as in flight, unless the runtime is
+// shutting down and the operation is not nested in one already in flight.
+// Every successful call must be matched by endOperation.
+func (r *Runtime) beginOperation(nested bool) bool {
+	r.opMu.Lock()
+	defer r.opMu.Unlock()
+
+	if r.draining && !nested {
+		return false
+	}
+	r.inFlight.Add(1)
+	return true
+}
+
+// endOperation marks an operation as finished.
+func (r *Runtime) endOperation() {
+	r.opMu.Lock()
+	defer r.opMu.Unlock()
+
+	if r.inFlight.Add(-1) == 0 && r.idle != nil {
+		close(r.idle)
+		r.idle = nil
+	}
+}
+
+// beginDrain rejects new operations and returns a channel that is closed once
+// no operation is in flight. A drain that timed out earlier is still pending,
+// and its channel is reused.
+func (r *Runtime) beginDrain() <-chan struct{} {
+	r.opMu.Lock()
+	defer r.opMu.Unlock()
+
+	r.draining = true
+	if r.idle == nil {
+		r.idle = make(chan struct{})
+	}
+	idle := r.idle
+	if r.inFlight.Load() == 0 {
+		close(idle)
+		r.idle = nil
+	}
+	return idle
+}
+
+// isDraining returns whether new operations are rejected.
+func (r *Runtime) isDraining() bool {
+	r.opMu.Lock()
+	defer r.opMu.Unlock()
+	return r.draining
+}
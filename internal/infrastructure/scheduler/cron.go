@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/scheduler"
+)
+
+// Cron is a parsed five-field cron expression. When both the day of the
+// month and the day of the week are restricted, a day matching either runs,
+// as in standard cron.
+type Cron struct {
+	spec    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+// field describes the range and names of one cron field.
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// descriptors are the supported shorthand expressions.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// searchYears bounds the search for the next matching time, so that
+// expressions that never match, such as "0 0 30 2 *", end.
+const searchYears = 5
+
+// ParseCron parses a five-field cron expression ("minute hour day month
+// weekday") or a descriptor such as "@daily". Fields accept "*", values,
+// ranges ("1-5"), steps ("*/15", "0-30/10") and lists ("1,15"); months and
+// weekdays also accept three-letter names, and 7 is Sunday.
+func ParseCron(spec string) (*Cron, error) {
+	expression := strings.TrimSpace(spec)
+	if expanded, ok := descriptors[strings.ToLower(expression)]; ok {
+		expression = expanded
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, failure.New(scheduler.ErrInvalidCron, "'%s' needs 5 fields, got %d", spec, len(fields))
+	}
+
+	c := &Cron{spec: spec}
+	var err error
+	targets := []struct {
+		bits *uint64
+		field
+	}{{&c.minute, minuteField}, {&c.hour, hourField}, {&c.dom, domField}, {&c.month, monthField}, {&c.dow, dowField}}
+	for i, target := range targets {
+		if *target.bits, err = parseField(fields[i], target.field); err != nil {
+			return nil, failure.Wrap(err, scheduler.ErrInvalidCron, "'%s'", spec)
+		}
+	}
+	// Sunday is both 0 and 7
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+// String returns the expression as given.
+func (c *Cron) String() string {
+	return c.spec
+}
+
+// Next returns the first matching minute after t, in the location of t, or
+// the zero time if none matches within five years.
+func (c *Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.Year() + searchYears
+
+	for t.Year() <= limit {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(c.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies the day-of-month and day-of-week fields.
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+	switch {
+	case c.domStar && c.dowStar:
+		return true
+	case c.domStar:
+		return dow
+	case c.dowStar:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+// parseField parses a comma-separated list of items into a bit set.
+func parseField(expression string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(expression, ",") {
+		itemBits, err := parseItem(item, f)
+		if err != nil {
+			return 0, err
+		}
+		bits |= itemBits
+	}
+	return bits, nil
+}
+
+// parseItem parses "*", "a", "a-b" with an optional "/step".
+func parseItem(item string, f field) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(item, "/")
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step '%s' in %s", stepPart, f.name)
+		}
+	}
+
+	low, high := f.min, f.max
+	switch {
+	case rangePart == "*":
+		if f.max == 7 {
+			high = 6
+		}
+	case strings.Contains(rangePart, "-"):
+		from, to, _ := strings.Cut(rangePart, "-")
+		var err error
+		if low, err = f.value(from); err != nil {
+			return 0, err
+		}
+		if high, err = f.value(to); err != nil {
+			return 0, err
+		}
+		if low > high {
+			return 0, fmt.Errorf("empty range '%s' in %s", rangePart, f.name)
+		}
+	default:
+		var err error
+		if low, err = f.value(rangePart); err != nil {
+			return 0, err
+		}
+		high = low
+		if hasStep {
+			high = f.max
+		}
+	}
+
+	var bits uint64
+	for v := low; v <= high; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+// value parses a number or name within the field range.
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value '%s' in %s", s, f.name)
+	}
+	return v, nil
+}
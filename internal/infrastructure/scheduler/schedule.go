@@ -0,0 +1,153 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/scheduler"
+)
+
+// ConfigKey is the configuration key of the list of schedules.
+const ConfigKey = "scheduler.schedules"
+
+// scheduleConfig is the configuration form of a schedule. Durations are
+// strings such as "5m" or numbers of nanoseconds.
+type scheduleConfig struct {
+	ID        string            `json:"id"`
+	Operation string            `json:"operation"`
+	Cron      string            `json:"cron"`
+	Interval  any               `json:"interval"`
+	Input     any               `json:"input"`
+	Metadata  map[string]string `json:"metadata"`
+	Jitter    any               `json:"jitter"`
+	Overlap   string            `json:"overlap"`
+	CatchUp   string            `json:"catch_up"`
+	Disabled  bool              `json:"disabled"`
+}
+
+// SchedulesFromConfig reads and validates the schedules listed under
+// ConfigKey.
+func SchedulesFromConfig(cfg config.Configuration) ([]scheduler.Schedule, error) {
+	if !cfg.Exists(ConfigKey) {
+		return nil, nil
+	}
+
+	var entries []scheduleConfig
+	if err := cfg.GetObject(ConfigKey, &entries); err != nil {
+		return nil, failure.Wrap(err, scheduler.ErrInvalidSchedule, "")
+	}
+
+	schedules := make([]scheduler.Schedule, 0, len(entries))
+	var errs []error
+	for i, entry := range entries {
+		schedule := scheduler.Schedule{
+			ID:        entry.ID,
+			Operation: component.ComponentID(entry.Operation),
+			Cron:      entry.Cron,
+			Input:     entry.Input,
+			Metadata:  entry.Metadata,
+			Overlap:   scheduler.OverlapPolicy(entry.Overlap),
+			CatchUp:   scheduler.CatchUpPolicy(entry.CatchUp),
+			Disabled:  entry.Disabled,
+		}
+		var err error
+		if entry.Interval != nil {
+			if schedule.Interval, err = toDuration(entry.Interval); err != nil {
+				errs = append(errs, failure.New(scheduler.ErrInvalidSchedule, "schedule %d: invalid interval '%v'", i, entry.Interval))
+				continue
+			}
+		}
+		if entry.Jitter != nil {
+			if schedule.Jitter, err = toDuration(entry.Jitter); err != nil {
+				errs = append(errs, failure.New(scheduler.ErrInvalidSchedule, "schedule %d: invalid jitter '%v'", i, entry.Jitter))
+				continue
+			}
+		}
+		if _, err := Validate(schedule); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		schedules = append(schedules, schedule)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return schedules, nil
+}
+
+// Validate checks a schedule and returns it with defaults applied.
+func Validate(schedule scheduler.Schedule) (scheduler.Schedule, error) {
+	invalid := func(format string, args ...interface{}) (scheduler.Schedule, error) {
+		return schedule, failure.New(scheduler.ErrInvalidSchedule, "schedule '%s': %s", schedule.ID, fmt.Sprintf(format, args...))
+	}
+
+	if schedule.ID == "" {
+		return invalid("missing ID")
+	}
+	if schedule.Operation == "" {
+		return invalid("missing operation")
+	}
+	switch {
+	case schedule.Cron != "" && schedule.Interval != 0:
+		return invalid("both cron and interval are set")
+	case schedule.Cron != "":
+		if _, err := ParseCron(schedule.Cron); err != nil {
+			return schedule, failure.Wrap(err, scheduler.ErrInvalidSchedule, "schedule '%s'", schedule.ID)
+		}
+	case schedule.Interval <= 0:
+		return invalid("needs a cron expression or a positive interval")
+	}
+	if schedule.Jitter < 0 {
+		return invalid("negative jitter")
+	}
+
+	switch schedule.Overlap {
+	case "":
+		schedule.Overlap = scheduler.OverlapSkip
+	case scheduler.OverlapSkip, scheduler.OverlapAllow:
+	default:
+		return invalid("unknown overlap policy '%s'", schedule.Overlap)
+	}
+	switch schedule.CatchUp {
+	case "":
+		schedule.CatchUp = scheduler.CatchUpNone
+	case scheduler.CatchUpNone, scheduler.CatchUpOnce, scheduler.CatchUpAll:
+	default:
+		return invalid("unknown catch-up policy '%s'", schedule.CatchUp)
+	}
+	return schedule, nil
+}
+
+// nextFunc returns the function computing the next due time of a valid
+// schedule.
+func nextFunc(schedule scheduler.Schedule) func(time.Time) time.Time {
+	if schedule.Cron != "" {
+		cron, _ := ParseCron(schedule.Cron)
+		return cron.Next
+	}
+	return func(t time.Time) time.Time {
+		return t.Add(schedule.Interval)
+	}
+}
+
+// toDuration converts a duration, a duration string such as "5m" or a
+// number of nanoseconds.
+func toDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return time.ParseDuration(v)
+	case int:
+		return time.Duration(v), nil
+	case int64:
+		return time.Duration(v), nil
+	case float64:
+		return time.Duration(v), nil
+	}
+	return 0, fmt.Errorf("not a duration: %T", value)
+}
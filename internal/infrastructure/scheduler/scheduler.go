@@ -0,0 +1,550 @@
+// Package scheduler provides the operation scheduler implementation.
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/scheduler"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+)
+
+// Option defaults
+const (
+	DefaultStore      = "scheduler"
+	DefaultEngine     = "memory"
+	DefaultMaxCatchUp = 100
+)
+
+// keyPrefix prefixes the keys of schedule records in the store.
+const keyPrefix = "schedule/"
+
+// Options configures a scheduler. Zero values select defaults.
+type Options struct {
+	// Store is the name of the store last run times are persisted in. It is
+	// created with Engine if it does not exist. Defaults to "scheduler" and
+	// "memory".
+	Store  string
+	Engine string
+
+	// MaxCatchUp bounds the missed runs CatchUpAll runs at start. Defaults
+	// to 100.
+	MaxCatchUp int
+}
+
+// Scheduler implements scheduler.Scheduler.
+//
+// Each enabled schedule has a goroutine that waits for its next due time,
+// plus jitter, and starts a run. Runs execute their operation through
+// System.ExecuteOperation and publish their outcome on the event bus. The
+// time each run was due is persisted before it starts, and the missed runs
+// are derived from it on the next start according to the catch-up policy.
+// Runs in progress are cancelled when the scheduler stops.
+type Scheduler struct {
+	*infraComponent.BaseService
+	multiStore storage.MultiStore
+	options    Options
+
+	system   component.System
+	eventBus event.EventBus
+	logger   logging.Logger
+
+	store   storage.Store
+	entries map[string]*entry
+	active  bool
+	runCtx  context.Context
+	cancel  func()
+	runs    *sync.WaitGroup // schedule goroutines and runs, replaced on each start
+	mu      sync.Mutex
+}
+
+// entry is the state of one schedule.
+type entry struct {
+	schedule scheduler.Schedule
+	next     func(time.Time) time.Time
+	stop     chan struct{} // closed to end the schedule goroutine; nil when not started
+
+	lastRun   time.Time
+	nextRun   time.Time
+	running   int
+	runs      int
+	failures  int
+	skipped   int
+	lastError string
+}
+
+// record is the persisted state of a schedule.
+type record struct {
+	LastRun time.Time `json:"lastRun"`
+}
+
+// eventBusProvider, loggerProvider and configurationProvider are
+// implemented by runtimes.
+type eventBusProvider interface {
+	EventBus() event.EventBusService
+}
+
+type loggerProvider interface {
+	Logger() logging.Logger
+}
+
+type configurationProvider interface {
+	Configuration() config.Configuration
+}
+
+// NewScheduler creates a scheduler that persists last run times in a store
+// of the multi-store. Without a multi-store, last run times are kept in
+// memory and missed runs are never caught up.
+func NewScheduler(config component.ComponentConfig, multiStore storage.MultiStore, options Options) *Scheduler {
+	if options.Store == "" {
+		options.Store = DefaultStore
+	}
+	if options.Engine == "" {
+		options.Engine = DefaultEngine
+	}
+	if options.MaxCatchUp <= 0 {
+		options.MaxCatchUp = DefaultMaxCatchUp
+	}
+	return &Scheduler{
+		BaseService: infraComponent.NewBaseService(config),
+		multiStore:  multiStore,
+		options:     options,
+		logger:      infraLogging.NewNoOpLogger(),
+		entries:     make(map[string]*entry),
+	}
+}
+
+// Initialize records the system that executes operations and adds the
+// schedules found in its configuration under ConfigKey.
+func (s *Scheduler) Initialize(ctx context.Context, system component.System) error {
+	if err := s.BaseService.Initialize(ctx, system); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.system = system
+	if provider, ok := system.(eventBusProvider); ok {
+		s.eventBus = provider.EventBus()
+	}
+	if provider, ok := system.(loggerProvider); ok {
+		s.logger = provider.Logger()
+	}
+	s.mu.Unlock()
+
+	provider, ok := system.(configurationProvider)
+	if !ok {
+		return nil
+	}
+	schedules, err := SchedulesFromConfig(provider.Configuration())
+	if err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		if err := s.Add(schedule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start opens the store, catches up missed runs and starts the schedules.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active {
+		return nil
+	}
+	if s.system == nil {
+		return failure.New(component.ErrComponentNotInitialized, "scheduler '%s' has no system", s.ID())
+	}
+	if s.multiStore != nil {
+		store, err := s.openStore()
+		if err != nil {
+			return err
+		}
+		s.store = store
+	}
+
+	runCtx := infraContext.WithTimeout(infraContext.NewContext(), 0)
+	s.runCtx, s.cancel = runCtx, runCtx.Cancel
+	s.runs = new(sync.WaitGroup)
+	s.active = true
+	for _, e := range s.entries {
+		s.launch(e)
+	}
+	return s.BaseService.Start(ctx)
+}
+
+// Stop ends the schedules, cancels runs in progress and waits for them to
+// return or ctx to be done.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.active {
+		s.mu.Unlock()
+		return nil
+	}
+	s.active = false
+	for _, e := range s.entries {
+		if e.stop != nil {
+			close(e.stop)
+			e.stop = nil
+		}
+	}
+	s.cancel()
+	runs := s.runs
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		runs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return failure.Wrap(ctx.Err(), component.ErrServiceStopFailed, "scheduled runs still running")
+	}
+	return s.BaseService.Stop(ctx)
+}
+
+// Add adds a schedule and starts it if the scheduler is running.
+func (s *Scheduler) Add(schedule scheduler.Schedule) error {
+	schedule, err := Validate(schedule)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[schedule.ID]; exists {
+		return failure.New(scheduler.ErrScheduleExists, "'%s'", schedule.ID)
+	}
+	e := &entry{schedule: schedule, next: nextFunc(schedule)}
+	s.entries[schedule.ID] = e
+	if s.active {
+		s.launch(e)
+	}
+	return nil
+}
+
+// Remove removes a schedule. Runs in progress are not cancelled.
+func (s *Scheduler) Remove(scheduleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.entries[scheduleID]
+	if !exists {
+		return failure.New(scheduler.ErrScheduleNotFound, "'%s'", scheduleID)
+	}
+	if e.stop != nil {
+		close(e.stop)
+		e.stop = nil
+	}
+	delete(s.entries, scheduleID)
+	return nil
+}
+
+// Trigger runs a schedule now and returns the outcome of its operation.
+func (s *Scheduler) Trigger(ctx context.Context, scheduleID string) (component.Output, error) {
+	s.mu.Lock()
+	e, exists := s.entries[scheduleID]
+	if !exists {
+		s.mu.Unlock()
+		return component.Output{}, failure.New(scheduler.ErrScheduleNotFound, "'%s'", scheduleID)
+	}
+	if !s.active {
+		s.mu.Unlock()
+		return component.Output{}, failure.New(component.ErrServiceNotRunning, "scheduler '%s'", s.ID())
+	}
+	s.mu.Unlock()
+
+	now := time.Now()
+	runs := s.begin(e, now)
+	if runs == nil {
+		return component.Output{}, failure.New(scheduler.ErrRunSkipped, "schedule '%s' is still running", scheduleID)
+	}
+	defer runs.Done()
+	return s.execute(ctx, e, now)
+}
+
+// State returns the state of a schedule.
+func (s *Scheduler) State(scheduleID string) (scheduler.State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.entries[scheduleID]
+	if !exists {
+		return scheduler.State{}, failure.New(scheduler.ErrScheduleNotFound, "'%s'", scheduleID)
+	}
+	return e.snapshot(), nil
+}
+
+// States returns the state of every schedule, ordered by ID.
+func (s *Scheduler) States() []scheduler.State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]scheduler.State, 0, len(s.entries))
+	for _, e := range s.entries {
+		states = append(states, e.snapshot())
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Schedule.ID < states[j].Schedule.ID })
+	return states
+}
+
+// launch starts the goroutine of an enabled schedule with the runs it
+// missed. Called with the lock held.
+func (s *Scheduler) launch(e *entry) {
+	if e.schedule.Disabled {
+		return
+	}
+	if persisted, found := s.load(e.schedule.ID); found && persisted.After(e.lastRun) {
+		e.lastRun = persisted
+	}
+
+	e.stop = make(chan struct{})
+	s.runs.Add(1)
+	go s.loop(e, e.stop, s.runs, s.missed(e, time.Now()))
+}
+
+// missed returns the due times missed since the last run, according to the
+// catch-up policy. Called with the lock held.
+func (s *Scheduler) missed(e *entry, now time.Time) []time.Time {
+	if e.lastRun.IsZero() || e.schedule.CatchUp == scheduler.CatchUpNone {
+		return nil
+	}
+
+	var missed []time.Time
+	for t := e.next(e.lastRun); !t.IsZero() && !t.After(now); t = e.next(t) {
+		missed = append(missed, t)
+		if e.schedule.CatchUp == scheduler.CatchUpOnce || len(missed) >= s.options.MaxCatchUp {
+			break
+		}
+	}
+	return missed
+}
+
+// loop runs the missed runs one after the other, then starts a run each
+// time the schedule is due until stop is closed. It is counted in group.
+func (s *Scheduler) loop(e *entry, stop chan struct{}, group *sync.WaitGroup, missed []time.Time) {
+	defer group.Done()
+
+	for _, at := range missed {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if runs := s.begin(e, at); runs != nil {
+			s.logger.Info("Catching up missed run", "schedule_id", e.schedule.ID, "due", at)
+			s.execute(s.runContext(), e, at)
+			runs.Done()
+		}
+	}
+
+	after := time.Now()
+	for {
+		due := e.next(after)
+		for now := time.Now(); !due.IsZero() && !due.After(now); {
+			// Runs that fell due while the previous one was waiting are dropped
+			due = e.next(due)
+		}
+		if due.IsZero() {
+			s.logger.Warn("Schedule has no further runs", "schedule_id", e.schedule.ID)
+			return
+		}
+
+		s.mu.Lock()
+		e.nextRun = due
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(due) + jitter(e.schedule.Jitter))
+		select {
+		case <-timer.C:
+		case <-stop:
+			timer.Stop()
+			return
+		}
+
+		if runs := s.begin(e, due); runs != nil {
+			go func(at time.Time) {
+				defer runs.Done()
+				s.execute(s.runContext(), e, at)
+			}(due)
+		}
+		after = due
+	}
+}
+
+// begin records the start of a run, following the overlap policy, and
+// returns the wait group of the current start the run is counted in, or nil
+// if the run may not start.
+func (s *Scheduler) begin(e *entry, at time.Time) *sync.WaitGroup {
+	s.mu.Lock()
+	if !s.active {
+		s.mu.Unlock()
+		return nil
+	}
+	if e.running > 0 && e.schedule.Overlap == scheduler.OverlapSkip {
+		e.skipped++
+		s.mu.Unlock()
+		s.logger.Warn("Skipping run, previous run still going", "schedule_id", e.schedule.ID)
+		s.publish(scheduler.TopicRunSkipped, e.schedule, at, map[string]interface{}{"reason": "overlap"})
+		return nil
+	}
+	e.running++
+	e.lastRun = at
+	s.save(e)
+	runs := s.runs
+	runs.Add(1)
+	s.mu.Unlock()
+	return runs
+}
+
+// execute runs the operation of a schedule and records the outcome.
+func (s *Scheduler) execute(ctx context.Context, e *entry, at time.Time) (component.Output, error) {
+	schedule := e.schedule
+	s.publish(scheduler.TopicRunStarted, schedule, at, nil)
+
+	started := time.Now()
+	output, err := s.system.ExecuteOperation(ctx, schedule.Operation, component.Input{
+		Data:     schedule.Input,
+		Metadata: schedule.Metadata,
+	})
+	duration := time.Since(started)
+
+	s.mu.Lock()
+	e.running--
+	e.runs++
+	if err != nil {
+		e.failures++
+		e.lastError = err.Error()
+	} else {
+		e.lastError = ""
+	}
+	s.mu.Unlock()
+
+	details := map[string]interface{}{"duration": duration.String()}
+	if err != nil {
+		details["error"] = err.Error()
+		s.logger.Warn("Scheduled run failed", "schedule_id", schedule.ID, "operation_id", schedule.Operation, "error", err)
+		s.publish(scheduler.TopicRunFailed, schedule, at, details)
+		return output, err
+	}
+	s.publish(scheduler.TopicRunSucceeded, schedule, at, details)
+	return output, nil
+}
+
+// runContext returns the context of scheduled runs, cancelled on Stop.
+func (s *Scheduler) runContext() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.runCtx
+}
+
+// openStore returns the schedule store, creating it if needed.
+func (s *Scheduler) openStore() (storage.Store, error) {
+	store, err := s.multiStore.GetStore(s.options.Store)
+	if err != nil && errors.Is(err, storage.ErrStoreNotFound) {
+		if err = s.multiStore.CreateStore(s.options.Store, s.options.Engine, nil); err == nil {
+			store, err = s.multiStore.GetStore(s.options.Store)
+		}
+	}
+	if err != nil {
+		return nil, failure.Wrap(err, scheduler.ErrStoreUnavailable, "store '%s'", s.options.Store)
+	}
+	return store, nil
+}
+
+// load reads the persisted last run time of a schedule. Called with the
+// lock held.
+func (s *Scheduler) load(scheduleID string) (time.Time, bool) {
+	if s.store == nil {
+		return time.Time{}, false
+	}
+	data, err := s.store.Get([]byte(keyPrefix + scheduleID))
+	if err != nil {
+		if !errors.Is(err, storage.ErrKeyNotFound) {
+			s.logger.Error("Failed to read schedule", "schedule_id", scheduleID, "error", err)
+		}
+		return time.Time{}, false
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		s.logger.Error("Failed to decode schedule", "schedule_id", scheduleID, "error", err)
+		return time.Time{}, false
+	}
+	return r.LastRun, true
+}
+
+// save persists the last run time of a schedule. Called with the lock held.
+func (s *Scheduler) save(e *entry) {
+	if s.store == nil {
+		return
+	}
+	data, err := json.Marshal(record{LastRun: e.lastRun})
+	if err == nil {
+		err = s.store.Set([]byte(keyPrefix+e.schedule.ID), data)
+	}
+	if err != nil {
+		s.logger.Error("Failed to persist schedule", "schedule_id", e.schedule.ID, "error", err)
+	}
+}
+
+// snapshot returns the state of a schedule. Called with the lock held.
+func (e *entry) snapshot() scheduler.State {
+	return scheduler.State{
+		Schedule:  e.schedule,
+		LastRun:   e.lastRun,
+		NextRun:   e.nextRun,
+		Running:   e.running,
+		Runs:      e.runs,
+		Failures:  e.failures,
+		Skipped:   e.skipped,
+		LastError: e.lastError,
+	}
+}
+
+// jitter returns a random delay below max.
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(max)))
+}
+
+// publish sends a run event if an event bus is available.
+func (s *Scheduler) publish(topic string, schedule scheduler.Schedule, at time.Time, details map[string]interface{}) {
+	if s.eventBus == nil {
+		return
+	}
+	payload := map[string]interface{}{
+		"scheduleId":  schedule.ID,
+		"operationId": string(schedule.Operation),
+		"scheduledAt": at.Format(time.RFC3339Nano),
+	}
+	for key, value := range details {
+		payload[key] = value
+	}
+	s.eventBus.Publish(&event.Event{
+		Topic:   topic,
+		Source:  string(s.ID()),
+		Time:    time.Now(),
+		Payload: payload,
+	})
+}
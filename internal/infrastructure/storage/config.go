@@ -2,8 +2,7 @@
 package storage
 
 import (
-	"fmt"
-
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	"github.com/fintechain/skeleton/internal/domain/storage"
 )
 
@@ -46,11 +45,11 @@ func (c *StoreConfigImpl) GetOptions() storage.Config {
 // Validate validates the store configuration.
 func (c *StoreConfigImpl) Validate() error {
 	if c.engine == "" {
-		return fmt.Errorf("%s: engine cannot be empty", storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "engine cannot be empty")
 	}
 
 	if c.path == "" {
-		return fmt.Errorf("%s: path cannot be empty", storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "path cannot be empty")
 	}
 
 	return nil
@@ -109,11 +108,11 @@ func (c *MultiStoreConfigImpl) SetEngineConfig(engine string, config storage.Con
 // Validate validates the multi-store configuration.
 func (c *MultiStoreConfigImpl) Validate() error {
 	if c.rootPath == "" {
-		return fmt.Errorf("%s: root path cannot be empty", storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "root path cannot be empty")
 	}
 
 	if c.defaultEngine == "" {
-		return fmt.Errorf("%s: default engine cannot be empty", storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "default engine cannot be empty")
 	}
 
 	return nil
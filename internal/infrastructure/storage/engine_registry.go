@@ -2,9 +2,9 @@
 package storage
 
 import (
-	"fmt"
 	"sync"
 
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	"github.com/fintechain/skeleton/internal/domain/storage"
 )
 
@@ -26,12 +26,12 @@ func NewEngineRegistry() *EngineRegistry {
 // Register registers a storage engine with the registry.
 func (r *EngineRegistry) Register(engine storage.Engine) error {
 	if engine == nil {
-		return fmt.Errorf("%s: engine cannot be nil", storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "engine cannot be nil")
 	}
 
 	name := engine.Name()
 	if name == "" {
-		return fmt.Errorf("%s: engine name cannot be empty", storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "engine name cannot be empty")
 	}
 
 	r.mu.Lock()
@@ -39,7 +39,7 @@ func (r *EngineRegistry) Register(engine storage.Engine) error {
 
 	// Check if engine already exists
 	if _, exists := r.engines[name]; exists {
-		return fmt.Errorf("%s: engine '%s' already exists", storage.ErrEngineExists, name)
+		return failure.New(storage.ErrEngineExists, "engine '%s' already exists", name)
 	}
 
 	r.engines[name] = engine
@@ -49,7 +49,7 @@ func (r *EngineRegistry) Register(engine storage.Engine) error {
 // Get retrieves a storage engine by name.
 func (r *EngineRegistry) Get(name string) (storage.Engine, error) {
 	if name == "" {
-		return nil, fmt.Errorf("%s: engine name cannot be empty", storage.ErrInvalidConfig)
+		return nil, failure.New(storage.ErrInvalidConfig, "engine name cannot be empty")
 	}
 
 	r.mu.RLock()
@@ -57,7 +57,7 @@ func (r *EngineRegistry) Get(name string) (storage.Engine, error) {
 
 	engine, exists := r.engines[name]
 	if !exists {
-		return nil, fmt.Errorf("%s: engine '%s' not found", storage.ErrEngineNotFound, name)
+		return nil, failure.New(storage.ErrEngineNotFound, "engine '%s' not found", name)
 	}
 
 	return engine, nil
@@ -66,14 +66,14 @@ func (r *EngineRegistry) Get(name string) (storage.Engine, error) {
 // Unregister removes a storage engine from the registry.
 func (r *EngineRegistry) Unregister(name string) error {
 	if name == "" {
-		return fmt.Errorf("%s: engine name cannot be empty", storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "engine name cannot be empty")
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, exists := r.engines[name]; !exists {
-		return fmt.Errorf("%s: engine '%s' not found", storage.ErrEngineNotFound, name)
+		return failure.New(storage.ErrEngineNotFound, "engine '%s' not found", name)
 	}
 
 	delete(r.engines, name)
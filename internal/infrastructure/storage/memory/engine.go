@@ -2,8 +2,7 @@
 package memory
 
 import (
-	"errors"
-
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	"github.com/fintechain/skeleton/internal/domain/storage"
 )
 
@@ -27,7 +26,7 @@ func (e *Engine) Name() string {
 // Create creates a new in-memory store instance with the specified configuration.
 func (e *Engine) Create(name, path string, config storage.Config) (storage.Store, error) {
 	if name == "" {
-		return nil, errors.New(storage.ErrInvalidConfig)
+		return nil, failure.New(storage.ErrInvalidConfig, "store without name")
 	}
 
 	return NewStore(name, path), nil
@@ -2,9 +2,9 @@
 package memory
 
 import (
-	"errors"
 	"sync"
 
+	"github.com/fintechain/skeleton/internal/domain/failure"
 	"github.com/fintechain/skeleton/internal/domain/storage"
 )
 
@@ -32,7 +32,7 @@ func (s *Store) Get(key []byte) ([]byte, error) {
 
 	value, exists := s.data[string(key)]
 	if !exists {
-		return nil, errors.New(storage.ErrKeyNotFound)
+		return nil, failure.New(storage.ErrKeyNotFound, "key '%s' in store '%s'", key, s.name)
 	}
 
 	// Return a copy to prevent external modification
@@ -61,7 +61,7 @@ func (s *Store) Delete(key []byte) error {
 
 	keyStr := string(key)
 	if _, exists := s.data[keyStr]; !exists {
-		return errors.New(storage.ErrKeyNotFound)
+		return failure.New(storage.ErrKeyNotFound, "key '%s' in store '%s'", key, s.name)
 	}
 
 	delete(s.data, keyStr)
@@ -1,14 +1,18 @@
 package storage
 
 import (
-	"errors"
 	"path/filepath"
 	"sync"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
 	"github.com/fintechain/skeleton/internal/domain/storage"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
 )
 
 // MultiStore implements the MultiStoreService interface.
@@ -16,6 +20,9 @@ type MultiStore struct {
 	*infraComponent.BaseService
 	stores   map[string]storage.Store
 	engines  map[string]storage.Engine
+	created  map[string]string // store name to engine name
+	metrics  metrics.Registry
+	tracer   tracing.Tracer
 	mu       sync.RWMutex
 	rootPath string
 }
@@ -26,10 +33,43 @@ func NewMultiStore(config component.ComponentConfig, rootPath string) *MultiStor
 		BaseService: infraComponent.NewBaseService(config),
 		stores:      make(map[string]storage.Store),
 		engines:     make(map[string]storage.Engine),
+		created:     make(map[string]string),
 		rootPath:    rootPath,
 	}
 }
 
+// Initialize records store gets and sets in the metrics of the system and
+// traces store calls with its tracer, when it provides them.
+func (ms *MultiStore) Initialize(ctx context.Context, system component.System) error {
+	if err := ms.BaseService.Initialize(ctx, system); err != nil {
+		return err
+	}
+
+	if provider, ok := system.(metrics.Provider); ok {
+		ms.SetMetrics(provider.Metrics())
+	}
+	if provider, ok := system.(tracing.Provider); ok {
+		ms.SetTracer(provider.Tracer())
+	}
+	return nil
+}
+
+// SetMetrics records the gets and sets of the stores in the registry.
+func (ms *MultiStore) SetMetrics(registry metrics.Registry) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.metrics = registry
+}
+
+// SetTracer traces the calls of the stores with the tracer. Bind a store to
+// the context of a call with infrastructure/tracing.BindStore to make its
+// spans children of the call.
+func (ms *MultiStore) SetTracer(tracer tracing.Tracer) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.tracer = tracer
+}
+
 // GetStore retrieves a store by name.
 func (ms *MultiStore) GetStore(name string) (storage.Store, error) {
 	ms.mu.RLock()
@@ -37,9 +77,15 @@ func (ms *MultiStore) GetStore(name string) (storage.Store, error) {
 
 	store, exists := ms.stores[name]
 	if !exists {
-		return nil, errors.New(storage.ErrStoreNotFound)
+		return nil, failure.New(storage.ErrStoreNotFound, "'%s'", name)
 	}
 
+	if ms.metrics != nil {
+		store = infraMetrics.InstrumentStore(store, ms.metrics)
+	}
+	if ms.tracer != nil {
+		store = infraTracing.TraceStore(store, ms.tracer)
+	}
 	return store, nil
 }
 
@@ -50,13 +96,13 @@ func (ms *MultiStore) CreateStore(name, engineName string, config storage.Config
 
 	// Check if store already exists
 	if _, exists := ms.stores[name]; exists {
-		return errors.New(storage.ErrStoreExists)
+		return failure.New(storage.ErrStoreExists, "'%s'", name)
 	}
 
 	// Get the engine
 	engine, exists := ms.engines[engineName]
 	if !exists {
-		return errors.New(storage.ErrEngineNotFound)
+		return failure.New(storage.ErrEngineNotFound, "'%s'", engineName)
 	}
 
 	// Create store path
@@ -70,6 +116,7 @@ func (ms *MultiStore) CreateStore(name, engineName string, config storage.Config
 
 	// Register the store
 	ms.stores[name] = store
+	ms.created[name] = engineName
 	return nil
 }
 
@@ -80,7 +127,7 @@ func (ms *MultiStore) DeleteStore(name string) error {
 
 	store, exists := ms.stores[name]
 	if !exists {
-		return errors.New(storage.ErrStoreNotFound)
+		return failure.New(storage.ErrStoreNotFound, "'%s'", name)
 	}
 
 	// Close the store
@@ -90,6 +137,7 @@ func (ms *MultiStore) DeleteStore(name string) error {
 
 	// Remove from registry
 	delete(ms.stores, name)
+	delete(ms.created, name)
 	return nil
 }
 
@@ -106,6 +154,18 @@ func (ms *MultiStore) ListStores() []string {
 	return names
 }
 
+// StoreEngine returns the engine that created a store.
+func (ms *MultiStore) StoreEngine(name string) (storage.Engine, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	engineName, exists := ms.created[name]
+	if !exists {
+		return nil, failure.New(storage.ErrStoreNotFound, "'%s'", name)
+	}
+	return ms.engines[engineName], nil
+}
+
 // CloseAll closes all stores.
 func (ms *MultiStore) CloseAll() error {
 	ms.mu.Lock()
@@ -117,6 +177,7 @@ func (ms *MultiStore) CloseAll() error {
 			lastErr = err // Continue closing others, return last error
 		}
 		delete(ms.stores, name)
+		delete(ms.created, name)
 	}
 
 	return lastErr
@@ -125,7 +186,7 @@ func (ms *MultiStore) CloseAll() error {
 // RegisterEngine registers a storage engine.
 func (ms *MultiStore) RegisterEngine(engine storage.Engine) error {
 	if engine == nil {
-		return errors.New(storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "nil engine")
 	}
 
 	ms.mu.Lock()
@@ -133,12 +194,12 @@ func (ms *MultiStore) RegisterEngine(engine storage.Engine) error {
 
 	name := engine.Name()
 	if name == "" {
-		return errors.New(storage.ErrInvalidConfig)
+		return failure.New(storage.ErrInvalidConfig, "engine without name")
 	}
 
 	// Check if engine already exists
 	if _, exists := ms.engines[name]; exists {
-		return errors.New(storage.ErrEngineExists)
+		return failure.New(storage.ErrEngineExists, "'%s'", name)
 	}
 
 	ms.engines[name] = engine
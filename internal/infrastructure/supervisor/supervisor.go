@@ -0,0 +1,498 @@
+// Package supervisor provides the service supervisor implementation.
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+)
+
+// Policy defaults
+const (
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff     = 30 * time.Second
+	DefaultMaxRestarts    = 5
+)
+
+// Supervisor implements supervisor.Supervisor.
+//
+// Services report that they stopped on their own through ReportFailure, through
+// a callback (supervisor.ReportingService) or on a channel (supervisor.FailingService).
+// Restarts happen only while the supervisor is running; failures reported while it
+// is stopped are recorded. A failed service that is not restarted is escalated
+// according to its policy. Every transition is published on the event bus.
+type Supervisor struct {
+	*infraComponent.BaseService
+	registry      component.Registry
+	pluginManager plugin.PluginManager
+	eventBus      event.EventBus
+	logger        logging.Logger
+
+	// escalateRuntime stops the runtime; set by the runtime that owns the supervisor.
+	escalateRuntime func(err error)
+
+	entries map[component.ComponentID]*entry
+	active  bool          // restarts are enabled
+	stop    chan struct{} // closed when the supervisor stops
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+}
+
+// entry is the supervision record of one service.
+type entry struct {
+	service     component.Service
+	policy      supervisor.Policy
+	state       supervisor.ServiceState
+	restarts    []time.Time // restarts counted against the budget
+	total       int
+	lastErr     error
+	lastFailure time.Time
+	done        chan struct{} // closed when the service is unsupervised
+}
+
+// NewSupervisor creates a supervisor for services in the registry. The plugin
+// manager is used to stop plugins on escalation; the event bus and logger may be nil.
+func NewSupervisor(
+	config component.ComponentConfig,
+	registry component.Registry,
+	pluginManager plugin.PluginManager,
+	eventBus event.EventBus,
+	logger logging.Logger,
+) *Supervisor {
+	if logger == nil {
+		logger = infraLogging.NewNoOpLogger()
+	}
+	return &Supervisor{
+		BaseService:   infraComponent.NewBaseService(config),
+		registry:      registry,
+		pluginManager: pluginManager,
+		eventBus:      eventBus,
+		logger:        logger,
+		entries:       make(map[component.ComponentID]*entry),
+	}
+}
+
+// SetRuntimeEscalation sets the function that stops the runtime when a failure
+// is escalated with supervisor.EscalateRuntime.
+func (s *Supervisor) SetRuntimeEscalation(escalate func(err error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.escalateRuntime = escalate
+}
+
+// Start enables restarts. The lock is released before the service starts,
+// because subscribers to the started event may call back into the supervisor.
+func (s *Supervisor) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.active {
+		s.mu.Unlock()
+		return nil
+	}
+	s.active = true
+	stop := make(chan struct{})
+	s.stop = stop
+	s.mu.Unlock()
+
+	if err := s.BaseService.Start(ctx); err != nil {
+		s.mu.Lock()
+		if s.active && s.stop == stop {
+			s.active = false
+			close(stop)
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Stop cancels pending restarts and waits for restarts in progress.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.active {
+		s.mu.Unlock()
+		return nil
+	}
+	s.active = false
+	close(s.stop)
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return s.BaseService.Stop(ctx)
+}
+
+// Supervise starts watching a registered service.
+func (s *Supervisor) Supervise(serviceID component.ComponentID, policy supervisor.Policy) error {
+	policy, err := normalizePolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	comp, err := s.registry.Get(serviceID)
+	if err != nil {
+		return failure.New(component.ErrServiceNotFound, "'%s'", serviceID)
+	}
+	service, ok := comp.(component.Service)
+	if !ok {
+		return failure.New(component.ErrServiceNotFound, "'%s' is not a service", serviceID)
+	}
+
+	s.mu.Lock()
+	if _, exists := s.entries[serviceID]; exists {
+		s.mu.Unlock()
+		return failure.New(supervisor.ErrServiceAlreadySupervised, "'%s'", serviceID)
+	}
+	e := &entry{
+		service: service,
+		policy:  policy,
+		state:   supervisor.StateStopped,
+		done:    make(chan struct{}),
+	}
+	if service.IsRunning() {
+		e.state = supervisor.StateRunning
+	}
+	s.entries[serviceID] = e
+	s.mu.Unlock()
+
+	if reporting, ok := service.(supervisor.ReportingService); ok {
+		reporting.SetFailureReporter(func(err error) {
+			s.ReportFailure(serviceID, err)
+		})
+	}
+	if failing, ok := service.(supervisor.FailingService); ok {
+		go s.watch(serviceID, failing.Failures(), e.done)
+	}
+	return nil
+}
+
+// Unsupervise stops watching a service and cancels a pending restart.
+func (s *Supervisor) Unsupervise(serviceID component.ComponentID) error {
+	s.mu.Lock()
+	e, exists := s.entries[serviceID]
+	if !exists {
+		s.mu.Unlock()
+		return failure.New(supervisor.ErrServiceNotSupervised, "'%s'", serviceID)
+	}
+	delete(s.entries, serviceID)
+	close(e.done)
+	s.mu.Unlock()
+
+	if reporting, ok := e.service.(supervisor.ReportingService); ok {
+		reporting.SetFailureReporter(nil)
+	}
+	return nil
+}
+
+// State returns the supervision state of a service.
+func (s *Supervisor) State(serviceID component.ComponentID) (supervisor.State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.entries[serviceID]
+	if !exists {
+		return supervisor.State{}, failure.New(supervisor.ErrServiceNotSupervised, "'%s'", serviceID)
+	}
+	return e.snapshot(serviceID), nil
+}
+
+// States returns the supervision state of every supervised service, ordered by ID.
+func (s *Supervisor) States() []supervisor.State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]supervisor.State, 0, len(s.entries))
+	for id, e := range s.entries {
+		states = append(states, e.snapshot(id))
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].ServiceID < states[j].ServiceID })
+	return states
+}
+
+// ReportFailure reports that a supervised service stopped on its own. A nil
+// error reports an exit without failure. Reports for a service that is being
+// restarted, or that is not supervised, are ignored. A service that was removed
+// from the registry, for example by unloading its plugin, is no longer supervised.
+func (s *Supervisor) ReportFailure(serviceID component.ComponentID, err error) {
+	if _, getErr := s.registry.Get(serviceID); getErr != nil {
+		_ = s.Unsupervise(serviceID)
+		return
+	}
+
+	s.mu.Lock()
+	e, exists := s.entries[serviceID]
+	if !exists || e.state == supervisor.StateRestarting {
+		s.mu.Unlock()
+		return
+	}
+
+	failed := err != nil
+	e.lastErr = err
+	if failed {
+		e.lastFailure = time.Now()
+	}
+	policy := e.policy
+	restart := policy.Restart == supervisor.RestartAlways ||
+		(policy.Restart == supervisor.RestartOnFailure && failed)
+	active := s.active
+
+	var backoff time.Duration
+	exhausted := false
+	if restart && active {
+		e.prune(policy.Window)
+		if policy.MaxRestarts >= 0 && len(e.restarts) >= policy.MaxRestarts {
+			exhausted = true
+		} else {
+			backoff = infraComponent.Backoff(policy.InitialBackoff, policy.MaxBackoff, len(e.restarts))
+			e.restarts = append(e.restarts, time.Now())
+			e.total++
+			e.state = supervisor.StateRestarting
+			// Added under the lock so that Stop waits for the restart
+			s.wg.Add(1)
+		}
+	}
+	if e.state != supervisor.StateRestarting {
+		e.state = supervisor.StateStopped
+		if failed || exhausted {
+			e.state = supervisor.StateFailed
+		}
+	}
+	attempt := e.total
+	stop := s.stop
+	s.mu.Unlock()
+
+	payload := map[string]interface{}{
+		"serviceId": string(serviceID),
+		"policy":    string(policy.Restart),
+		"restarts":  attempt,
+	}
+	if failed {
+		payload["error"] = err.Error()
+		s.logger.Warn("Supervised service failed", "service_id", serviceID, "error", err)
+		s.publish(supervisor.TopicServiceFailed, payload)
+	} else {
+		s.logger.Info("Supervised service exited", "service_id", serviceID)
+		s.publish(supervisor.TopicServiceExited, payload)
+	}
+
+	switch {
+	case restart && active && !exhausted:
+		s.publish(supervisor.TopicServiceRestarting, withValues(payload, "backoff", backoff.String()))
+		go s.restart(serviceID, e, backoff, attempt, stop)
+
+	case exhausted:
+		giveUp := failure.New(supervisor.ErrRestartBudgetExhausted, "service '%s' restarted %d times", serviceID, policy.MaxRestarts)
+		s.giveUp(serviceID, policy, payload, errors.Join(giveUp, err))
+
+	case failed && active:
+		s.giveUp(serviceID, policy, payload, err)
+	}
+}
+
+// restart waits for the backoff and restarts the service. A failed restart is
+// reported as a new failure.
+func (s *Supervisor) restart(serviceID component.ComponentID, e *entry, backoff time.Duration, attempt int, stop chan struct{}) {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-stop:
+		s.setState(e, supervisor.StateStopped)
+		return
+	case <-e.done:
+		return
+	}
+
+	ctx := infraContext.NewContext()
+	if e.service.IsRunning() {
+		_ = e.service.Stop(ctx)
+	}
+	err := e.service.Start(ctx)
+
+	payload := map[string]interface{}{
+		"serviceId": string(serviceID),
+		"policy":    string(e.policy.Restart),
+		"restarts":  attempt,
+	}
+	if err != nil {
+		s.setState(e, supervisor.StateFailed)
+		s.ReportFailure(serviceID, failure.Wrap(err, component.ErrServiceStartFailed, "restart of '%s' failed", serviceID))
+		return
+	}
+
+	s.setState(e, supervisor.StateRunning)
+	s.logger.Info("Supervised service restarted", "service_id", serviceID, "restarts", attempt)
+	s.publish(supervisor.TopicServiceRestarted, payload)
+}
+
+// giveUp reports a failed service that will not be restarted and escalates.
+func (s *Supervisor) giveUp(serviceID component.ComponentID, policy supervisor.Policy, payload map[string]interface{}, err error) {
+	s.logger.Error("Supervised service will not be restarted", "service_id", serviceID, "error", err)
+	s.publish(supervisor.TopicServiceGaveUp, withValues(payload, "error", err.Error()))
+
+	switch policy.Escalation {
+	case supervisor.EscalatePlugin:
+		pluginID := policy.Plugin
+		if pluginID == "" {
+			pluginID = s.owner(serviceID)
+		}
+		if pluginID == "" {
+			s.logger.Error("No plugin to stop for failed service", "service_id", serviceID)
+			return
+		}
+		s.publish(supervisor.TopicEscalated, withValues(payload, "escalation", string(policy.Escalation), "pluginId", string(pluginID), "error", err.Error()))
+		go func() {
+			if stopErr := s.pluginManager.StopPlugin(infraContext.NewContext(), pluginID); stopErr != nil {
+				s.logger.Error("Failed to stop plugin on escalation", "plugin_id", pluginID, "error", stopErr)
+			}
+		}()
+
+	case supervisor.EscalateRuntime:
+		s.mu.Lock()
+		escalate := s.escalateRuntime
+		s.mu.Unlock()
+		if escalate == nil {
+			s.logger.Error("No runtime to stop for failed service", "service_id", serviceID)
+			return
+		}
+		s.publish(supervisor.TopicEscalated, withValues(payload, "escalation", string(policy.Escalation), "error", err.Error()))
+		go escalate(fmt.Errorf("service '%s' failed: %w", serviceID, err))
+	}
+}
+
+// watch forwards failures reported on a channel until the channel closes or
+// the service is unsupervised.
+func (s *Supervisor) watch(serviceID component.ComponentID, failures <-chan error, done chan struct{}) {
+	for {
+		select {
+		case err, ok := <-failures:
+			if !ok {
+				return
+			}
+			s.ReportFailure(serviceID, err)
+		case <-done:
+			return
+		}
+	}
+}
+
+// owner returns the plugin that registered the service, if the plugin manager tracks ownership.
+func (s *Supervisor) owner(serviceID component.ComponentID) component.ComponentID {
+	owners, ok := s.pluginManager.(interface {
+		OwnedComponents(pluginID component.ComponentID) []component.ComponentID
+	})
+	if !ok {
+		return ""
+	}
+	for _, pluginID := range s.pluginManager.ListPlugins() {
+		for _, id := range owners.OwnedComponents(pluginID) {
+			if id == serviceID {
+				return pluginID
+			}
+		}
+	}
+	return ""
+}
+
+// setState updates the state of an entry.
+func (s *Supervisor) setState(e *entry, state supervisor.ServiceState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.state = state
+}
+
+// publish sends a supervisor event if an event bus is available.
+func (s *Supervisor) publish(topic string, payload map[string]interface{}) {
+	if s.eventBus == nil {
+		return
+	}
+	s.eventBus.Publish(&event.Event{
+		Topic:   topic,
+		Source:  string(s.ID()),
+		Time:    time.Now(),
+		Payload: payload,
+	})
+}
+
+// prune drops restarts that fall outside the window.
+func (e *entry) prune(window time.Duration) {
+	if window <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-window)
+	kept := e.restarts[:0]
+	for _, t := range e.restarts {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	e.restarts = kept
+}
+
+// snapshot returns the public state of the entry.
+func (e *entry) snapshot(serviceID component.ComponentID) supervisor.State {
+	return supervisor.State{
+		ServiceID:   serviceID,
+		Policy:      e.policy,
+		State:       e.state,
+		Restarts:    e.total,
+		LastError:   e.lastErr,
+		LastFailure: e.lastFailure,
+	}
+}
+
+// normalizePolicy fills in defaults and rejects unknown values.
+func normalizePolicy(p supervisor.Policy) (supervisor.Policy, error) {
+	switch p.Restart {
+	case "":
+		p.Restart = supervisor.RestartOnFailure
+	case supervisor.RestartNever, supervisor.RestartAlways, supervisor.RestartOnFailure:
+	default:
+		return p, failure.New(supervisor.ErrInvalidPolicy, "unknown restart policy '%s'", p.Restart)
+	}
+	switch p.Escalation {
+	case "":
+		p.Escalation = supervisor.EscalateNone
+	case supervisor.EscalateNone, supervisor.EscalatePlugin, supervisor.EscalateRuntime:
+	default:
+		return p, failure.New(supervisor.ErrInvalidPolicy, "unknown escalation '%s'", p.Escalation)
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultMaxBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+	if p.MaxRestarts == 0 {
+		p.MaxRestarts = DefaultMaxRestarts
+	}
+	return p, nil
+}
+
+// withValues returns a copy of payload with the key/value pairs added.
+func withValues(payload map[string]interface{}, keyValues ...string) map[string]interface{} {
+	result := make(map[string]interface{}, len(payload)+len(keyValues)/2)
+	for k, v := range payload {
+		result[k] = v
+	}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		result[keyValues[i]] = keyValues[i+1]
+	}
+	return result
+}
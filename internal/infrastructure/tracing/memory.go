@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"sync"
+
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+)
+
+// MemoryExporter keeps exported spans in memory, for tests.
+type MemoryExporter struct {
+	spans []tracing.SpanData
+	mu    sync.Mutex
+}
+
+// NewMemoryExporter creates an empty in-memory exporter.
+func NewMemoryExporter() *MemoryExporter {
+	return &MemoryExporter{}
+}
+
+// Export appends the spans.
+func (e *MemoryExporter) Export(spans []tracing.SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+// Shutdown does nothing; the spans stay available.
+func (e *MemoryExporter) Shutdown() error {
+	return nil
+}
+
+// Spans returns the exported spans in the order they ended.
+func (e *MemoryExporter) Spans() []tracing.SpanData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]tracing.SpanData(nil), e.spans...)
+}
+
+// Reset discards the exported spans.
+func (e *MemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = nil
+}
@@ -0,0 +1,155 @@
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+)
+
+// ScopeName is the instrumentation scope reported in exported spans.
+const ScopeName = "github.com/fintechain/skeleton"
+
+// otlpKinds maps span kinds onto their OTLP values.
+var otlpKinds = map[tracing.Kind]int{
+	tracing.KindInternal: 1,
+	tracing.KindClient:   3,
+	tracing.KindProducer: 4,
+	tracing.KindConsumer: 5,
+}
+
+// FileExporter appends spans to a file in the OTLP/JSON format, one export
+// request per line, as read by the OpenTelemetry Collector file receiver.
+type FileExporter struct {
+	path    string
+	service string
+	file    *os.File
+	mu      sync.Mutex
+}
+
+// NewFileExporter creates an exporter appending to the file at path. Spans are
+// reported as coming from service. The file is opened on the first export and
+// reopened after Shutdown.
+func NewFileExporter(path, service string) *FileExporter {
+	return &FileExporter{path: path, service: service}
+}
+
+// Export appends the spans as one OTLP/JSON export request.
+func (e *FileExporter) Export(spans []tracing.SpanData) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	line, err := json.Marshal(MarshalOTLP(e.service, spans))
+	if err != nil {
+		return failure.Wrap(err, tracing.ErrExportFailed, "encoding spans")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		file, err := os.OpenFile(e.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return failure.Wrap(err, tracing.ErrExportFailed, "opening '%s'", e.path)
+		}
+		e.file = file
+	}
+	if _, err := e.file.Write(append(line, '\n')); err != nil {
+		return failure.Wrap(err, tracing.ErrExportFailed, "writing '%s'", e.path)
+	}
+	return nil
+}
+
+// Shutdown closes the file.
+func (e *FileExporter) Shutdown() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	if err != nil {
+		return failure.Wrap(err, tracing.ErrExportFailed, "closing '%s'", e.path)
+	}
+	return nil
+}
+
+// MarshalOTLP converts spans to an OTLP/JSON export request.
+func MarshalOTLP(service string, spans []tracing.SpanData) map[string]interface{} {
+	otlpSpans := make([]map[string]interface{}, 0, len(spans))
+	for _, s := range spans {
+		otlpSpan := map[string]interface{}{
+			"traceId":           string(s.Context.TraceID),
+			"spanId":            string(s.Context.SpanID),
+			"name":              s.Name,
+			"kind":              otlpKinds[s.Kind],
+			"startTimeUnixNano": strconv.FormatInt(s.Start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.End.UnixNano(), 10),
+			"attributes":        otlpAttributes(s.Attributes),
+		}
+		if s.ParentSpanID != "" {
+			otlpSpan["parentSpanId"] = string(s.ParentSpanID)
+		}
+		if s.Status == tracing.StatusError {
+			otlpSpan["status"] = map[string]interface{}{"code": 2, "message": s.StatusMessage}
+		}
+		otlpSpans = append(otlpSpans, otlpSpan)
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": otlpAttributes(map[string]interface{}{"service.name": service}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": ScopeName},
+						"spans": otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
+
+// otlpAttributes converts attributes to OTLP key-value pairs, ordered by key.
+func otlpAttributes(attributes map[string]interface{}) []map[string]interface{} {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, map[string]interface{}{"key": key, "value": otlpValue(attributes[key])})
+	}
+	return pairs
+}
+
+// otlpValue converts an attribute value to an OTLP any-value. Integers are
+// strings, as the OTLP/JSON encoding of 64-bit integers requires.
+func otlpValue(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case string:
+		return map[string]interface{}{"stringValue": v}
+	case bool:
+		return map[string]interface{}{"boolValue": v}
+	case int:
+		return map[string]interface{}{"intValue": strconv.Itoa(v)}
+	case int64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+	case float64:
+		return map[string]interface{}{"doubleValue": v}
+	default:
+		return map[string]interface{}{"stringValue": fmt.Sprint(v)}
+	}
+}
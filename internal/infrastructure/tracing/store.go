@@ -0,0 +1,142 @@
+package tracing
+
+import (
+	"errors"
+
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+// TraceStore wraps a store so that its calls are traced as client spans.
+// Store calls carry no context, so the spans start new traces until the
+// store is bound to a context with BindStore. Transactional stores stay
+// transactional, and the calls of their transactions are traced too.
+func TraceStore(store storage.Store, tracer tracing.Tracer) storage.Store {
+	traced := &tracedStore{Store: store, tracer: tracer}
+	if transactional, ok := store.(storage.Transactional); ok {
+		return &tracedTransactionalStore{tracedStore: traced, transactional: transactional}
+	}
+	return traced
+}
+
+// BindStore returns a copy of a store returned by TraceStore whose spans are
+// children of the span carried by ctx. Other stores are returned unchanged.
+//
+// Example:
+//
+//	store, err := multiStore.GetStore("ledger")
+//	...
+//	store = tracing.BindStore(ctx, store)
+func BindStore(ctx context.Context, store storage.Store) storage.Store {
+	switch s := store.(type) {
+	case *tracedTransactionalStore:
+		bound := *s.tracedStore
+		bound.ctx = ctx
+		return &tracedTransactionalStore{tracedStore: &bound, transactional: s.transactional}
+	case *tracedStore:
+		bound := *s
+		bound.ctx = ctx
+		return &bound
+	}
+	return store
+}
+
+// tracedStore traces the calls of a store.
+type tracedStore struct {
+	storage.Store
+	tracer tracing.Tracer
+	ctx    context.Context
+}
+
+// trace runs call in a span named after the store method.
+func (s *tracedStore) trace(method string, call func() error) {
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = infraContext.NewContext()
+	}
+	_, span := s.tracer.Start(ctx, "store."+method, tracing.KindClient)
+	span.SetAttribute("store.name", s.Store.Name())
+	if err := call(); err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+func (s *tracedStore) Get(key []byte) (value []byte, err error) {
+	s.trace("get", func() error {
+		value, err = s.Store.Get(key)
+		return err
+	})
+	return value, err
+}
+
+func (s *tracedStore) Set(key, value []byte) (err error) {
+	s.trace("set", func() error {
+		err = s.Store.Set(key, value)
+		return err
+	})
+	return err
+}
+
+func (s *tracedStore) Delete(key []byte) (err error) {
+	s.trace("delete", func() error {
+		err = s.Store.Delete(key)
+		return err
+	})
+	return err
+}
+
+func (s *tracedStore) Has(key []byte) (found bool, err error) {
+	s.trace("has", func() error {
+		found, err = s.Store.Has(key)
+		return err
+	})
+	return found, err
+}
+
+// tracedTransactionalStore traces the calls of a transactional store and of
+// its transactions.
+type tracedTransactionalStore struct {
+	*tracedStore
+	transactional storage.Transactional
+}
+
+func (s *tracedTransactionalStore) BeginTx() (storage.Transaction, error) {
+	tx, err := s.transactional.BeginTx()
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTransaction{
+		tracedStore: &tracedStore{Store: tx, tracer: s.tracer, ctx: s.ctx},
+		tx:          tx,
+	}, nil
+}
+
+func (s *tracedTransactionalStore) SupportsTransactions() bool {
+	return s.transactional.SupportsTransactions()
+}
+
+// tracedTransaction traces the calls of a transaction.
+type tracedTransaction struct {
+	*tracedStore
+	tx storage.Transaction
+}
+
+func (t *tracedTransaction) Commit() error {
+	var err error
+	t.trace("commit", func() error {
+		err = t.tx.Commit()
+		return err
+	})
+	return err
+}
+
+func (t *tracedTransaction) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func (t *tracedTransaction) IsActive() bool {
+	return t.tx.IsActive()
+}
@@ -0,0 +1,148 @@
+// Package tracing provides a tracer, span exporters and traced stores.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+// Tracer implements tracing.Tracer, handing every ended span to its
+// exporters. A tracer without exporters still propagates span contexts.
+type Tracer struct {
+	exporters []tracing.Exporter
+	mu        sync.RWMutex
+}
+
+// NewTracer creates a tracer exporting to the given exporters.
+func NewTracer(exporters ...tracing.Exporter) *Tracer {
+	return &Tracer{exporters: exporters}
+}
+
+// AddExporter adds an exporter for the spans that end from now on.
+func (t *Tracer) AddExporter(exporter tracing.Exporter) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.exporters = append(t.exporters, exporter)
+}
+
+// Start starts a span as a child of the span carried by ctx, or as the root
+// of a new trace. A nil ctx is replaced by an empty context.
+func (t *Tracer) Start(ctx context.Context, name string, kind tracing.Kind) (context.Context, tracing.Span) {
+	if ctx == nil {
+		ctx = infraContext.NewContext()
+	}
+
+	parent := tracing.SpanContextFromContext(ctx)
+	traceID := parent.TraceID
+	if !parent.IsValid() {
+		traceID = tracing.TraceID(randomHex(16))
+	}
+
+	s := &span{
+		tracer: t,
+		data: tracing.SpanData{
+			Name:         name,
+			Kind:         kind,
+			Context:      tracing.SpanContext{TraceID: traceID, SpanID: tracing.SpanID(randomHex(8))},
+			ParentSpanID: parent.SpanID,
+			Start:        time.Now(),
+			Attributes:   make(map[string]interface{}),
+			Status:       tracing.StatusUnset,
+		},
+	}
+	return tracing.ContextWithSpan(ctx, s), s
+}
+
+// Shutdown shuts every exporter down and returns their joined failures.
+func (t *Tracer) Shutdown() error {
+	t.mu.RLock()
+	exporters := append([]tracing.Exporter(nil), t.exporters...)
+	t.mu.RUnlock()
+
+	var errs []error
+	for _, exporter := range exporters {
+		if err := exporter.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// export hands an ended span to every exporter. Export failures are dropped,
+// since tracing must not fail the traced work.
+func (t *Tracer) export(data tracing.SpanData) {
+	t.mu.RLock()
+	exporters := t.exporters
+	t.mu.RUnlock()
+
+	for _, exporter := range exporters {
+		_ = exporter.Export([]tracing.SpanData{data})
+	}
+}
+
+// span implements tracing.Span.
+type span struct {
+	tracer *Tracer
+	data   tracing.SpanData
+	ended  bool
+	mu     sync.Mutex
+}
+
+func (s *span) Context() tracing.SpanContext {
+	return s.data.Context
+}
+
+func (s *span) SetAttribute(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.data.Attributes[key] = value
+	}
+}
+
+// RecordError marks the span as failed and records the error code, if any.
+func (s *span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.data.Status = tracing.StatusError
+	s.data.StatusMessage = err.Error()
+	if code := failure.CodeOf(err); code != "" {
+		s.data.Attributes["error.code"] = string(code)
+	}
+}
+
+func (s *span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	s.mu.Unlock()
+
+	s.tracer.export(data)
+}
+
+// randomHex returns n random bytes as lowercase hex digits.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,168 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/workflow"
+)
+
+// ConfigKey is the configuration key of the list of flows.
+const ConfigKey = "workflows"
+
+// Validate checks a flow definition. Step names must be unique within the
+// flow, so that every output has its own place in the state.
+func Validate(flow workflow.Flow) error {
+	if flow.ID == "" {
+		return failure.New(workflow.ErrInvalidFlow, "flow without an ID")
+	}
+	if len(flow.Steps) == 0 {
+		return failure.New(workflow.ErrInvalidFlow, "flow '%s' has no steps", flow.ID)
+	}
+
+	v := &validator{names: make(map[string]bool)}
+	v.sequence(flow.Steps, "steps")
+	if len(v.errs) > 0 {
+		return failure.Wrap(errors.Join(v.errs...), workflow.ErrInvalidFlow, "flow '%s'", flow.ID)
+	}
+	return nil
+}
+
+// FlowsFromConfig reads and validates the flows listed under ConfigKey.
+func FlowsFromConfig(cfg config.Configuration) ([]workflow.Flow, error) {
+	if !cfg.Exists(ConfigKey) {
+		return nil, nil
+	}
+
+	var flows []workflow.Flow
+	if err := cfg.GetObject(ConfigKey, &flows); err != nil {
+		return nil, failure.Wrap(err, workflow.ErrInvalidFlow, "")
+	}
+
+	var errs []error
+	for _, flow := range flows {
+		if err := Validate(flow); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return flows, nil
+}
+
+// Operations returns the IDs of the operations a flow references, sorted.
+func Operations(flow workflow.Flow) []component.ComponentID {
+	seen := make(map[component.ComponentID]bool)
+	var collect func(steps []workflow.Step)
+	collect = func(steps []workflow.Step) {
+		for _, step := range steps {
+			for _, id := range []component.ComponentID{step.Operation, step.Compensate} {
+				if id != "" {
+					seen[id] = true
+				}
+			}
+			collect(step.Steps)
+			collect(step.Parallel)
+			for _, branch := range step.Branches {
+				collect(branch.Steps)
+			}
+		}
+	}
+	collect(flow.Steps)
+
+	ids := make([]component.ComponentID, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// validator collects the problems of a flow definition.
+type validator struct {
+	names map[string]bool
+	errs  []error
+}
+
+func (v *validator) fail(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) sequence(steps []workflow.Step, path string) {
+	for i, step := range steps {
+		v.step(step, fmt.Sprintf("%s[%d]", path, i))
+	}
+}
+
+func (v *validator) step(step workflow.Step, path string) {
+	kinds := 0
+	if step.Operation != "" {
+		kinds++
+	}
+	for _, group := range []bool{len(step.Steps) > 0, len(step.Parallel) > 0, len(step.Branches) > 0} {
+		if group {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		v.fail(path, "needs exactly one of operation, steps, parallel and branches")
+		return
+	}
+
+	if name := stepName(step); name != "" {
+		if v.names[name] {
+			v.fail(path, "duplicate step name '%s'", name)
+		}
+		v.names[name] = true
+	}
+	if step.When != nil && step.When.Path == "" {
+		v.fail(path, "condition without a path")
+	}
+	v.mapping(step.Input, path+".input")
+	v.mapping(step.CompensateInput, path+".compensateInput")
+	if step.CompensateInput != nil && step.Compensate == "" {
+		v.fail(path, "compensation input without a compensating operation")
+	}
+
+	switch {
+	case len(step.Steps) > 0:
+		v.sequence(step.Steps, path+".steps")
+	case len(step.Parallel) > 0:
+		for i, child := range step.Parallel {
+			if stepName(child) == "" {
+				v.fail(fmt.Sprintf("%s.parallel[%d]", path, i), "parallel steps need a name")
+			}
+		}
+		v.sequence(step.Parallel, path+".parallel")
+	case len(step.Branches) > 0:
+		for i, branch := range step.Branches {
+			branchPath := fmt.Sprintf("%s.branches[%d]", path, i)
+			if branch.When != nil && branch.When.Path == "" {
+				v.fail(branchPath, "condition without a path")
+			}
+			if len(branch.Steps) == 0 {
+				v.fail(branchPath, "branch has no steps")
+			}
+			v.sequence(branch.Steps, branchPath+".steps")
+		}
+	}
+}
+
+func (v *validator) mapping(mapping *workflow.Mapping, path string) {
+	if mapping != nil && mapping.From == "" && len(mapping.Fields) == 0 {
+		v.fail(path, "mapping needs from or fields")
+	}
+}
+
+// stepName returns the name the output of a step is stored under, if any.
+func stepName(step workflow.Step) string {
+	if step.Name != "" {
+		return step.Name
+	}
+	return string(step.Operation)
+}
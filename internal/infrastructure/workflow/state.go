@@ -0,0 +1,152 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fintechain/skeleton/internal/domain/workflow"
+)
+
+// State keys
+const (
+	stateInput = "input"
+	stateSteps = "steps"
+	stateError = "error"
+)
+
+// state is the flow state steps read and write. Values are kept in JSON
+// form so that paths resolve the same way for structs and maps.
+type state struct {
+	data  map[string]any
+	steps map[string]any
+	mu    sync.RWMutex
+}
+
+func newState(input any) *state {
+	steps := make(map[string]any)
+	return &state{
+		data:  map[string]any{stateInput: input, stateSteps: steps},
+		steps: steps,
+	}
+}
+
+// set records the output of a step.
+func (s *state) set(name string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps[name] = value
+}
+
+// setError records the failure compensations run for.
+func (s *state) setError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[stateError] = err.Error()
+}
+
+// lookup resolves a dotted path. Numeric segments index into lists.
+func (s *state) lookup(path string) (any, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var current any = s.data
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			value, exists := node[segment]
+			if !exists {
+				return nil, false
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// build evaluates a mapping.
+func (s *state) build(mapping workflow.Mapping) (any, error) {
+	var base any
+	if mapping.From != "" {
+		value, found := s.lookup(mapping.From)
+		if !found {
+			return nil, fmt.Errorf("path '%s' not found", mapping.From)
+		}
+		if len(mapping.Fields) == 0 {
+			return value, nil
+		}
+		base = value
+	}
+
+	object := make(map[string]any, len(mapping.Fields))
+	if fields, ok := base.(map[string]any); ok {
+		for key, value := range fields {
+			object[key] = value
+		}
+	} else if base != nil {
+		return nil, fmt.Errorf("path '%s' is not an object", mapping.From)
+	}
+	for field, path := range mapping.Fields {
+		value, found := s.lookup(path)
+		if !found {
+			return nil, fmt.Errorf("path '%s' for field '%s' not found", path, field)
+		}
+		object[field] = value
+	}
+	return object, nil
+}
+
+// holds evaluates a condition.
+func (s *state) holds(condition workflow.Condition) bool {
+	value, _ := s.lookup(condition.Path)
+
+	var result bool
+	if condition.Equals != nil {
+		result = reflect.DeepEqual(value, normalize(condition.Equals))
+	} else {
+		result = truthy(value)
+	}
+	return result != condition.Not
+}
+
+// normalize converts a value to its JSON form, leaving values that cannot be
+// encoded unchanged.
+func normalize(value any) any {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return true
+}
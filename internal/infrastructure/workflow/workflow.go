@@ -0,0 +1,346 @@
+// Package workflow provides the flow execution implementation.
+package workflow
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/workflow"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+)
+
+// Workflow executes a flow as an operation.
+//
+// Steps run through System.ExecuteOperation, so schemas, resilience and
+// idempotency apply to each of them; the metadata of the flow input is
+// passed to every step. An idempotency key in it is made specific to each
+// step, as the key followed by "/" and the step name, and to each
+// compensation, with "/compensate" appended, so that a flow run again with
+// its key replays the outcome of every step instead of running it twice. When a step fails, the steps that completed are
+// compensated in reverse order. Compensations run on a fresh context, so
+// that they also run when the flow was cancelled.
+type Workflow struct {
+	*infraComponent.BaseOperation
+	flow workflow.Flow
+
+	system   component.System
+	eventBus event.EventBus
+	logger   logging.Logger
+	mu       sync.RWMutex
+}
+
+// eventBusProvider and loggerProvider are implemented by runtimes.
+type eventBusProvider interface {
+	EventBus() event.EventBusService
+}
+
+type loggerProvider interface {
+	Logger() logging.Logger
+}
+
+// NewWorkflow creates the operation of a flow. The flow is validated; the
+// operations it references are resolved when it executes.
+func NewWorkflow(flow workflow.Flow) (*Workflow, error) {
+	if err := Validate(flow); err != nil {
+		return nil, err
+	}
+	return &Workflow{
+		BaseOperation: infraComponent.NewBaseOperation(component.ComponentConfig{
+			ID:          flow.ID,
+			Name:        flow.Name,
+			Description: flow.Description,
+		}),
+		flow:   flow,
+		logger: infraLogging.NewNoOpLogger(),
+	}, nil
+}
+
+// Register creates the operation of a flow, initializes it with the system
+// and registers it.
+func Register(ctx context.Context, system component.System, flow workflow.Flow) (*Workflow, error) {
+	w, err := NewWorkflow(flow)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Initialize(ctx, system); err != nil {
+		return nil, err
+	}
+	if err := system.Registry().Register(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Flow returns the flow definition.
+func (w *Workflow) Flow() workflow.Flow {
+	return w.flow
+}
+
+// Initialize records the system that executes the steps.
+func (w *Workflow) Initialize(ctx context.Context, system component.System) error {
+	if err := w.BaseOperation.Initialize(ctx, system); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.system = system
+	if provider, ok := system.(eventBusProvider); ok {
+		w.eventBus = provider.EventBus()
+	}
+	if provider, ok := system.(loggerProvider); ok {
+		w.logger = provider.Logger()
+	}
+	return nil
+}
+
+// Execute runs the flow.
+func (w *Workflow) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	w.mu.RLock()
+	system, logger := w.system, w.logger
+	w.mu.RUnlock()
+	if system == nil {
+		return component.Output{}, failure.New(component.ErrComponentNotInitialized, "workflow '%s'", w.ID())
+	}
+
+	run := &execution{
+		workflow: w,
+		system:   system,
+		logger:   logger,
+		metadata: input.Metadata,
+		state:    newState(normalize(input.Data)),
+	}
+	w.publish(ctx, workflow.TopicFlowStarted, map[string]interface{}{"flowId": string(w.ID())})
+
+	output, err := run.sequence(ctx, w.flow.Steps, run.state.data[stateInput])
+	if err == nil && w.flow.Output != "" {
+		var found bool
+		if output, found = run.state.lookup(w.flow.Output); !found {
+			err = failure.New(workflow.ErrMappingFailed, "flow output '%s' not found", w.flow.Output)
+		}
+	}
+
+	if err != nil {
+		compensated, compensationErr := run.compensate(err)
+		logger.Warn("Workflow failed", "flow_id", w.ID(), "compensated", compensated, "error", err)
+		payload := map[string]interface{}{
+			"flowId":      string(w.ID()),
+			"error":       err.Error(),
+			"compensated": compensated,
+		}
+		if compensationErr != nil {
+			payload["compensationError"] = compensationErr.Error()
+		}
+		w.publish(ctx, workflow.TopicFlowFailed, payload)
+		return component.Output{}, errors.Join(err, compensationErr)
+	}
+
+	w.publish(ctx, workflow.TopicFlowCompleted, map[string]interface{}{"flowId": string(w.ID())})
+	return component.Output{Data: output}, nil
+}
+
+// execution is one run of a flow.
+type execution struct {
+	workflow *Workflow
+	system   component.System
+	logger   logging.Logger
+	metadata map[string]string
+	state    *state
+
+	// Completed steps to compensate, in order of completion
+	completed []compensation
+	mu        sync.Mutex
+}
+
+// compensation undoes a completed step.
+type compensation struct {
+	step   workflow.Step
+	output any
+}
+
+// sequence runs steps one after the other, each receiving the output of
+// the previous one unless it maps its input.
+func (e *execution) sequence(ctx context.Context, steps []workflow.Step, current any) (any, error) {
+	for _, step := range steps {
+		output, err := e.step(ctx, step, current)
+		if err != nil {
+			return nil, err
+		}
+		current = output
+	}
+	return current, nil
+}
+
+// step runs one step. Skipped steps pass their input through.
+func (e *execution) step(ctx context.Context, step workflow.Step, current any) (any, error) {
+	name := stepName(step)
+	if step.When != nil && !e.state.holds(*step.When) {
+		return current, nil
+	}
+
+	input := current
+	if step.Input != nil {
+		var err error
+		if input, err = e.state.build(*step.Input); err != nil {
+			return nil, failure.Wrap(err, workflow.ErrMappingFailed, "step '%s'", name)
+		}
+	}
+
+	var output any
+	var err error
+	switch {
+	case step.Operation != "":
+		output, err = e.operation(ctx, step.Operation, input, e.metadataFor(name, ""))
+		if err != nil {
+			return nil, failure.Wrap(err, workflow.ErrStepFailed, "step '%s'", name)
+		}
+	case len(step.Steps) > 0:
+		output, err = e.sequence(ctx, step.Steps, input)
+	case len(step.Parallel) > 0:
+		output, err = e.parallel(ctx, step.Parallel, input)
+	case len(step.Branches) > 0:
+		output, err = e.branch(ctx, step.Branches, input, current)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "" {
+		e.state.set(name, output)
+	}
+	if step.Compensate != "" {
+		e.mu.Lock()
+		e.completed = append(e.completed, compensation{step: step, output: output})
+		e.mu.Unlock()
+	}
+	return output, nil
+}
+
+// parallel runs steps concurrently. The first failure cancels the others.
+func (e *execution) parallel(ctx context.Context, steps []workflow.Step, input any) (any, error) {
+	groupCtx := infraContext.WithTimeout(ctx, 0)
+	defer groupCtx.Cancel()
+
+	outputs := make([]any, len(steps))
+	errs := make([]error, len(steps))
+	var wg sync.WaitGroup
+	for i, step := range steps {
+		wg.Add(1)
+		go func(i int, step workflow.Step) {
+			defer wg.Done()
+			if outputs[i], errs[i] = e.step(groupCtx, step, input); errs[i] != nil {
+				groupCtx.Cancel()
+			}
+		}(i, step)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	result := make(map[string]any, len(steps))
+	for i, step := range steps {
+		result[stepName(step)] = outputs[i]
+	}
+	return result, nil
+}
+
+// branch runs the first matching branch, or passes current through if none
+// matches.
+func (e *execution) branch(ctx context.Context, branches []workflow.Branch, input, current any) (any, error) {
+	for _, branch := range branches {
+		if branch.When == nil || e.state.holds(*branch.When) {
+			return e.sequence(ctx, branch.Steps, input)
+		}
+	}
+	return current, nil
+}
+
+// operation executes an operation with the metadata of a step.
+func (e *execution) operation(ctx context.Context, operationID component.ComponentID, input any, metadata map[string]string) (any, error) {
+	output, err := e.system.ExecuteOperation(ctx, operationID, component.Input{Data: input, Metadata: metadata})
+	if err != nil {
+		return nil, err
+	}
+	return normalize(output.Data), nil
+}
+
+// metadataFor returns the flow metadata with the idempotency key, if any,
+// made specific to a step. Suffix tells its compensation apart.
+func (e *execution) metadataFor(name, suffix string) map[string]string {
+	key, ok := e.metadata[idempotency.MetadataKey]
+	if !ok || key == "" {
+		return e.metadata
+	}
+	metadata := make(map[string]string, len(e.metadata))
+	for k, v := range e.metadata {
+		metadata[k] = v
+	}
+	metadata[idempotency.MetadataKey] = key + "/" + name + suffix
+	return metadata
+}
+
+// compensate undoes the completed steps in reverse order and returns the
+// names of the steps it undid. Every compensation is attempted.
+func (e *execution) compensate(cause error) ([]string, error) {
+	e.state.setError(cause)
+
+	e.mu.Lock()
+	completed := e.completed
+	e.completed = nil
+	e.mu.Unlock()
+
+	ctx := infraContext.NewContext()
+	compensated := make([]string, 0, len(completed))
+	var errs []error
+	for i := len(completed) - 1; i >= 0; i-- {
+		c := completed[i]
+		name := stepName(c.step)
+		if name == "" {
+			name = string(c.step.Compensate)
+		}
+
+		input := c.output
+		if c.step.CompensateInput != nil {
+			var err error
+			if input, err = e.state.build(*c.step.CompensateInput); err != nil {
+				errs = append(errs, failure.Wrap(err, workflow.ErrCompensationFailed, "step '%s': %s", name, workflow.ErrMappingFailed))
+				continue
+			}
+		}
+		if _, err := e.operation(ctx, c.step.Compensate, input, e.metadataFor(name, "/compensate")); err != nil {
+			e.logger.Error("Compensation failed", "flow_id", e.workflow.ID(), "step", name, "error", err)
+			errs = append(errs, failure.Wrap(err, workflow.ErrCompensationFailed, "step '%s'", name))
+			continue
+		}
+		compensated = append(compensated, name)
+	}
+	return compensated, errors.Join(errs...)
+}
+
+// publish sends a workflow event if an event bus is available. The event
+// joins the trace of the flow.
+func (w *Workflow) publish(ctx context.Context, topic string, payload map[string]interface{}) {
+	w.mu.RLock()
+	bus := w.eventBus
+	w.mu.RUnlock()
+	if bus == nil {
+		return
+	}
+	bus.PublishContext(ctx, &event.Event{
+		Topic:   topic,
+		Source:  string(w.ID()),
+		Time:    time.Now(),
+		Payload: payload,
+	})
+}
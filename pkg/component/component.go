@@ -3,6 +3,7 @@ package component
 
 import (
 	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
 )
 
@@ -22,6 +23,12 @@ type Input = component.Input
 type Output = component.Output
 type Metadata = component.Metadata
 type ServiceStatus = component.ServiceStatus
+type LifecycleListener = component.LifecycleListener
+type ObservableComponent = component.ObservableComponent
+type Schema = component.Schema
+type SchemaProvider = component.SchemaProvider
+type SchemaRegistry = component.SchemaRegistry
+type OperationSchema = component.OperationSchema
 
 // Component types
 const (
@@ -67,18 +74,68 @@ const (
 	ErrSystemAlreadyStarted        = component.ErrSystemAlreadyStarted
 	ErrOperationNotFound           = component.ErrOperationNotFound
 	ErrOperationFailed             = component.ErrOperationFailed
+	ErrInvalidInputType            = component.ErrInvalidInputType
+	ErrInvalidOutputType           = component.ErrInvalidOutputType
+	ErrInvalidSchema               = component.ErrInvalidSchema
+	ErrInputValidationFailed       = component.ErrInputValidationFailed
+	ErrOutputValidationFailed      = component.ErrOutputValidationFailed
+	ErrCapabilityDenied            = component.ErrCapabilityDenied
 )
 
+// Lifecycle event topics
+const (
+	TopicComponentRegistered   = component.TopicComponentRegistered
+	TopicComponentUnregistered = component.TopicComponentUnregistered
+	TopicComponentInitialized  = component.TopicComponentInitialized
+	TopicComponentDisposed     = component.TopicComponentDisposed
+	TopicServiceStarted        = component.TopicServiceStarted
+	TopicServiceStopped        = component.TopicServiceStopped
+	TopicServiceFailed         = component.TopicServiceFailed
+)
+
+// CreateComponentEventPayload creates a lifecycle event payload.
+var CreateComponentEventPayload = component.CreateComponentEventPayload
+
+// Schema functions
+var ParseSchema = component.ParseSchema
+var MustParseSchema = component.MustParseSchema
+var ValidateSchema = infraComponent.ValidateSchema
+var ExecuteValidated = infraComponent.ExecuteValidated
+
 // Base implementations
 type BaseComponent = infraComponent.BaseComponent
 type BaseOperation = infraComponent.BaseOperation
 type BaseService = infraComponent.BaseService
+type HTTPService = infraComponent.HTTPService
+type LifecycleEvents = infraComponent.LifecycleEvents
+
+// Typed operations
+type OperationFunc[In, Out any] = infraComponent.OperationFunc[In, Out]
+type TypedOperation[In, Out any] = infraComponent.TypedOperation[In, Out]
 
 // Factory functions
 var NewRegistry = infraComponent.NewRegistry
+var NewLifecycleEvents = infraComponent.NewLifecycleEvents
 var NewSystem = infraComponent.NewSystem
 var NewBaseComponent = infraComponent.NewBaseComponent
 var NewBaseOperation = infraComponent.NewBaseOperation
 var NewBaseService = infraComponent.NewBaseService
+var NewHTTPService = infraComponent.NewHTTPService
+
+// NewTypedOperation creates an operation that runs execute with decoded input.
+func NewTypedOperation[In, Out any](config ComponentConfig, execute OperationFunc[In, Out]) *TypedOperation[In, Out] {
+	return infraComponent.NewTypedOperation(config, execute)
+}
+
+// Execute runs a registered operation with typed input and decodes its output
+// into Out.
+func Execute[In, Out any](ctx context.Context, system System, operationID ComponentID, input In) (Out, error) {
+	return infraComponent.Execute[In, Out](ctx, system, operationID, input)
+}
+
+// Decode converts operation data into T, decoding maps into structs.
+func Decode[T any](data any) (T, error) {
+	return infraComponent.Decode[T](data)
+}
 
 // Note: ComponentConfig is a struct, not created by a constructor function
@@ -44,3 +44,20 @@ func NewMemorySource() ConfigurationSource {
 func NewMemorySourceWithData(data map[string]interface{}) ConfigurationSource {
 	return infraConfig.NewMemorySourceWithData(data)
 }
+
+// Section is a Configuration view rooted at a key prefix, with defaults and validation rules.
+type Section = config.Section
+
+// Rule validates a configuration value.
+type Rule = config.Rule
+
+// PluginsKey is the configuration key under which plugin sections live.
+const PluginsKey = infraConfig.PluginsKey
+
+// Plugin configuration sections and validation rules
+var (
+	PluginPrefix = infraConfig.PluginPrefix
+	Required     = infraConfig.Required
+	OneOf        = infraConfig.OneOf
+	IntRange     = infraConfig.IntRange
+)
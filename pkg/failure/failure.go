@@ -0,0 +1,17 @@
+// Package failure provides the structured error type of the framework.
+package failure
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/failure"
+)
+
+// Types
+type Code = failure.Code
+type Error = failure.Error
+
+// Factory functions
+var New = failure.New
+var Wrap = failure.Wrap
+
+// Utility functions
+var CodeOf = failure.CodeOf
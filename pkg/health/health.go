@@ -0,0 +1,49 @@
+// Package health provides liveness and readiness check interfaces and implementations.
+package health
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/health"
+	infraHealth "github.com/fintechain/skeleton/internal/infrastructure/health"
+)
+
+// Core interfaces
+type Checker = health.Checker
+type HealthChecker = health.HealthChecker
+type ReadinessChecker = health.ReadinessChecker
+type CheckFunc = health.CheckFunc
+type Provider = health.Provider
+
+// Reports
+type Status = health.Status
+type Kind = health.Kind
+type Report = health.Report
+type ComponentReport = health.ComponentReport
+
+// Implementations
+type CheckerOptions = infraHealth.CheckerOptions
+type Server = infraHealth.Server
+
+// Statuses and kinds
+const (
+	StatusUp      = health.StatusUp
+	StatusDown    = health.StatusDown
+	KindLiveness  = health.KindLiveness
+	KindReadiness = health.KindReadiness
+	HealthPath    = infraHealth.HealthPath
+	LivenessPath  = infraHealth.LivenessPath
+	ReadinessPath = infraHealth.ReadinessPath
+)
+
+// Error constants
+const (
+	ErrCheckTimeout     = health.ErrCheckTimeout
+	ErrCheckPanicked    = health.ErrCheckPanicked
+	ErrServiceUnhealthy = health.ErrServiceUnhealthy
+)
+
+// Factory functions
+var (
+	NewChecker = infraHealth.NewChecker
+	NewHandler = infraHealth.NewHandler
+	NewServer  = infraHealth.NewServer
+)
@@ -0,0 +1,48 @@
+// Package idempotency provides at-most-once operation execution per idempotency key.
+package idempotency
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	infraIdempotency "github.com/fintechain/skeleton/internal/infrastructure/idempotency"
+)
+
+// Core interfaces
+type Deduplicator = idempotency.Deduplicator
+type Provider = idempotency.Provider
+
+// Types
+type Policy = idempotency.Policy
+type Record = idempotency.Record
+type Executor = idempotency.Executor
+
+// MetadataKey is the Input.Metadata entry that carries the idempotency key.
+const MetadataKey = idempotency.MetadataKey
+
+// Event topics
+const (
+	TopicResultReplayed = idempotency.TopicResultReplayed
+)
+
+// Error constants
+const (
+	ErrKeyRequired      = idempotency.ErrKeyRequired
+	ErrKeyConflict      = idempotency.ErrKeyConflict
+	ErrRecordNotFound   = idempotency.ErrRecordNotFound
+	ErrInvalidPolicy    = idempotency.ErrInvalidPolicy
+	ErrStoreUnavailable = idempotency.ErrStoreUnavailable
+)
+
+// Policy declaration prefixes and keys
+const (
+	DefaultTTL     = infraIdempotency.DefaultTTL
+	PropertyPrefix = infraIdempotency.PropertyPrefix
+	ConfigPrefix   = infraIdempotency.ConfigPrefix
+	KeyEnabled     = infraIdempotency.KeyEnabled
+	KeyTTL         = infraIdempotency.KeyTTL
+	KeyRequired    = infraIdempotency.KeyRequired
+)
+
+// Factory functions
+var NewDeduplicator = infraIdempotency.NewDeduplicator
+var PolicyFromProperties = infraIdempotency.PolicyFromProperties
+var PolicyFromConfig = infraIdempotency.PolicyFromConfig
@@ -0,0 +1,68 @@
+// Package introspection reports the components, services, plugins, event
+// subscriptions, stores and circuit breakers of a running system and exports
+// its topology.
+package introspection
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	infraIntrospection "github.com/fintechain/skeleton/internal/infrastructure/introspection"
+)
+
+// Core interfaces
+type Inspector = introspection.Inspector
+type Provider = introspection.Provider
+
+// Reports
+type ComponentInfo = introspection.ComponentInfo
+type ServiceInfo = introspection.ServiceInfo
+type PluginInfo = introspection.PluginInfo
+type Edge = introspection.Edge
+type EdgeKind = introspection.EdgeKind
+type TopicInfo = introspection.TopicInfo
+type StoreInfo = introspection.StoreInfo
+type BreakerInfo = introspection.BreakerInfo
+type Topology = introspection.Topology
+
+// Implementations
+type Server = infraIntrospection.Server
+type Format = infraIntrospection.Format
+
+// Edge kinds
+const (
+	EdgeDependsOn = introspection.EdgeDependsOn
+	EdgeOwns      = introspection.EdgeOwns
+)
+
+// Export formats
+const (
+	FormatJSON    = infraIntrospection.FormatJSON
+	FormatDOT     = infraIntrospection.FormatDOT
+	FormatMermaid = infraIntrospection.FormatMermaid
+)
+
+// Endpoint paths
+const (
+	ComponentsPath    = infraIntrospection.ComponentsPath
+	ServicesPath      = infraIntrospection.ServicesPath
+	PluginsPath       = infraIntrospection.PluginsPath
+	DependenciesPath  = infraIntrospection.DependenciesPath
+	SubscriptionsPath = infraIntrospection.SubscriptionsPath
+	StoresPath        = infraIntrospection.StoresPath
+	BreakersPath      = infraIntrospection.BreakersPath
+	TopologyPath      = infraIntrospection.TopologyPath
+)
+
+// Error constants
+const (
+	ErrUnknownFormat = introspection.ErrUnknownFormat
+)
+
+// Factory functions
+var (
+	NewInspector = infraIntrospection.NewInspector
+	NewHandler   = infraIntrospection.NewHandler
+	NewServer    = infraIntrospection.NewServer
+	Write        = infraIntrospection.Write
+	WriteDOT     = infraIntrospection.WriteDOT
+	WriteMermaid = infraIntrospection.WriteMermaid
+)
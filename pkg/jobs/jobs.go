@@ -0,0 +1,61 @@
+// Package jobs provides asynchronous operation jobs with persisted status tracking.
+package jobs
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/jobs"
+	infraJobs "github.com/fintechain/skeleton/internal/infrastructure/jobs"
+)
+
+// Core interfaces
+type Manager = jobs.Manager
+
+// Types
+type Job = jobs.Job
+type Status = jobs.Status
+type Progress = jobs.Progress
+type Options = infraJobs.Options
+
+// Job statuses
+const (
+	StatusPending   = jobs.StatusPending
+	StatusRunning   = jobs.StatusRunning
+	StatusSucceeded = jobs.StatusSucceeded
+	StatusFailed    = jobs.StatusFailed
+	StatusCancelled = jobs.StatusCancelled
+)
+
+// Event topics
+const (
+	TopicJobSubmitted = jobs.TopicJobSubmitted
+	TopicJobStarted   = jobs.TopicJobStarted
+	TopicJobProgress  = jobs.TopicJobProgress
+	TopicJobSucceeded = jobs.TopicJobSucceeded
+	TopicJobFailed    = jobs.TopicJobFailed
+	TopicJobCancelled = jobs.TopicJobCancelled
+)
+
+// Error constants
+const (
+	ErrJobNotFound      = jobs.ErrJobNotFound
+	ErrJobNotFinished   = jobs.ErrJobNotFinished
+	ErrJobFinished      = jobs.ErrJobFinished
+	ErrJobFailed        = jobs.ErrJobFailed
+	ErrJobCancelled     = jobs.ErrJobCancelled
+	ErrJobInterrupted   = jobs.ErrJobInterrupted
+	ErrStoreUnavailable = jobs.ErrStoreUnavailable
+)
+
+// Option defaults
+const (
+	DefaultStore     = infraJobs.DefaultStore
+	DefaultEngine    = infraJobs.DefaultEngine
+	DefaultWorkers   = infraJobs.DefaultWorkers
+	DefaultRetention = infraJobs.DefaultRetention
+)
+
+// Factory functions
+var NewManager = infraJobs.NewManager
+
+// Operation helpers
+var JobID = infraJobs.JobID
+var ReportProgress = infraJobs.ReportProgress
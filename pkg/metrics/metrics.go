@@ -0,0 +1,65 @@
+// Package metrics provides counters, gauges and histograms and their
+// Prometheus text exposition.
+package metrics
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
+)
+
+// Core interfaces
+type Registry = metrics.Registry
+type Counter = metrics.Counter
+type Gauge = metrics.Gauge
+type Histogram = metrics.Histogram
+type Provider = metrics.Provider
+
+// Snapshots
+type Kind = metrics.Kind
+type Labels = metrics.Labels
+type Family = metrics.Family
+type Series = metrics.Series
+type Bucket = metrics.Bucket
+
+// Implementations
+type Server = infraMetrics.Server
+
+// Kinds and endpoint
+const (
+	KindCounter   = metrics.KindCounter
+	KindGauge     = metrics.KindGauge
+	KindHistogram = metrics.KindHistogram
+	MetricsPath   = infraMetrics.MetricsPath
+	ContentType   = infraMetrics.ContentType
+)
+
+// Built-in metric names
+const (
+	OperationExecutions  = metrics.OperationExecutions
+	OperationErrors      = metrics.OperationErrors
+	OperationDuration    = metrics.OperationDuration
+	EventPublishes       = metrics.EventPublishes
+	EventHandlerDuration = metrics.EventHandlerDuration
+	EventQueueDepth      = metrics.EventQueueDepth
+	StoreGets            = metrics.StoreGets
+	StoreSets            = metrics.StoreSets
+	ServiceStatus        = metrics.ServiceStatus
+)
+
+// Error constants
+const (
+	ErrKindConflict = metrics.ErrKindConflict
+	ErrInvalidName  = metrics.ErrInvalidName
+)
+
+// DefaultBuckets are the histogram bucket upper bounds used when none are given.
+var DefaultBuckets = metrics.DefaultBuckets
+
+// Factory functions
+var (
+	NewRegistry     = infraMetrics.NewRegistry
+	NewHandler      = infraMetrics.NewHandler
+	NewServer       = infraMetrics.NewServer
+	WriteText       = infraMetrics.WriteText
+	InstrumentStore = infraMetrics.InstrumentStore
+)
@@ -4,12 +4,33 @@ package plugin
 import (
 	"github.com/fintechain/skeleton/internal/domain/plugin"
 	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	"github.com/fintechain/skeleton/internal/infrastructure/plugin/remote"
 )
 
 // Core interfaces
 type Plugin = plugin.Plugin
 type PluginManager = plugin.PluginManager
 type PluginType = plugin.PluginType
+type DependentPlugin = plugin.DependentPlugin
+type Dependency = plugin.Dependency
+type CapablePlugin = plugin.CapablePlugin
+type Capabilities = plugin.Capabilities
+
+// Discovery
+type Manifest = infraPlugin.Manifest
+type ManifestDependency = infraPlugin.ManifestDependency
+type Entrypoint = infraPlugin.Entrypoint
+type Discovery = infraPlugin.Discovery
+type DiscoveryResult = infraPlugin.DiscoveryResult
+type DiscoveryFailure = infraPlugin.DiscoveryFailure
+
+// Out-of-process plugins
+type ProcessPlugin = remote.ProcessPlugin
+type ProcessConfig = remote.ProcessConfig
+type RemoteServer = remote.Server
+type RemotePluginInfo = remote.PluginInfo
+type RemoteManifest = remote.Manifest
+type Transport = remote.Transport
 
 // Plugin type constants
 const (
@@ -21,5 +42,40 @@ const (
 	TypeAdapter     = plugin.TypeAdapter
 )
 
+// Plugin event topics
+const (
+	TopicPluginDiscovered      = plugin.TopicPluginDiscovered
+	TopicPluginDiscoveryFailed = plugin.TopicPluginDiscoveryFailed
+	TopicPluginLoaded          = plugin.TopicPluginLoaded
+	TopicPluginStarted         = plugin.TopicPluginStarted
+	TopicPluginStopped         = plugin.TopicPluginStopped
+	TopicPluginUnloaded        = plugin.TopicPluginUnloaded
+	TopicPluginFailed          = plugin.TopicPluginFailed
+)
+
+// TypePlugin is the component type reported in plugin lifecycle events.
+const TypePlugin = plugin.TypePlugin
+
+// Discovery constants
+const (
+	ManifestFileName  = infraPlugin.ManifestFileName
+	EntrypointNative  = infraPlugin.EntrypointNative
+	EntrypointProcess = infraPlugin.EntrypointProcess
+)
+
+// Transport constants
+const (
+	TransportStdio = remote.TransportStdio
+	TransportUnix  = remote.TransportUnix
+)
+
+// Remote plugin protocol version
+const RemoteProtocolVersion = remote.ProtocolVersion
+
 // Factory functions
 var NewManager = infraPlugin.NewManager
+var ResolveDependencies = infraPlugin.ResolveDependencies
+var NewDiscovery = infraPlugin.NewDiscovery
+var LoadManifest = infraPlugin.LoadManifest
+var NewProcessPlugin = remote.NewProcessPlugin
+var NewRemoteServer = remote.NewServer
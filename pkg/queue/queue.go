@@ -0,0 +1,54 @@
+// Package queue provides a persistent work queue with a worker pool.
+package queue
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/queue"
+	infraQueue "github.com/fintechain/skeleton/internal/infrastructure/queue"
+)
+
+// Core interfaces
+type Queue = queue.Queue
+
+// Types
+type Job = queue.Job
+type EnqueueOptions = queue.EnqueueOptions
+type Stats = queue.Stats
+type Options = infraQueue.Options
+
+// Metadata keys
+const (
+	MetadataJobID   = queue.MetadataJobID
+	MetadataAttempt = queue.MetadataAttempt
+)
+
+// Event topics
+const (
+	TopicJobEnqueued     = queue.TopicJobEnqueued
+	TopicJobCompleted    = queue.TopicJobCompleted
+	TopicJobRetrying     = queue.TopicJobRetrying
+	TopicJobDeadLettered = queue.TopicJobDeadLettered
+)
+
+// Error constants
+const (
+	ErrInvalidJob       = queue.ErrInvalidJob
+	ErrJobNotFound      = queue.ErrJobNotFound
+	ErrLeaseLost        = queue.ErrLeaseLost
+	ErrStoreUnavailable = queue.ErrStoreUnavailable
+)
+
+// Option defaults
+const (
+	DefaultStore             = infraQueue.DefaultStore
+	DefaultDeadLetterStore   = infraQueue.DefaultDeadLetterStore
+	DefaultEngine            = infraQueue.DefaultEngine
+	DefaultWorkers           = infraQueue.DefaultWorkers
+	DefaultMaxAttempts       = infraQueue.DefaultMaxAttempts
+	DefaultVisibilityTimeout = infraQueue.DefaultVisibilityTimeout
+	DefaultInitialBackoff    = infraQueue.DefaultInitialBackoff
+	DefaultMaxBackoff        = infraQueue.DefaultMaxBackoff
+	DefaultPollInterval      = infraQueue.DefaultPollInterval
+)
+
+// Factory functions
+var NewQueue = infraQueue.NewQueue
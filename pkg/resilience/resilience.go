@@ -0,0 +1,65 @@
+// Package resilience provides operation timeout, retry and circuit breaker policies.
+package resilience
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	infraResilience "github.com/fintechain/skeleton/internal/infrastructure/resilience"
+)
+
+// Core interfaces
+type Guard = resilience.Guard
+type TransientError = resilience.TransientError
+type Provider = resilience.Provider
+
+// Types
+type Policy = resilience.Policy
+type RetryPolicy = resilience.RetryPolicy
+type BreakerPolicy = resilience.BreakerPolicy
+type BreakerState = resilience.BreakerState
+type State = resilience.State
+
+// Breaker states
+const (
+	BreakerClosed   = resilience.BreakerClosed
+	BreakerOpen     = resilience.BreakerOpen
+	BreakerHalfOpen = resilience.BreakerHalfOpen
+)
+
+// Event topics
+const (
+	TopicBreakerOpened     = resilience.TopicBreakerOpened
+	TopicBreakerHalfOpen   = resilience.TopicBreakerHalfOpen
+	TopicBreakerClosed     = resilience.TopicBreakerClosed
+	TopicOperationRetrying = resilience.TopicOperationRetrying
+)
+
+// Error constants
+const (
+	ErrOperationTimeout = resilience.ErrOperationTimeout
+	ErrCircuitOpen      = resilience.ErrCircuitOpen
+	ErrRetriesExhausted = resilience.ErrRetriesExhausted
+	ErrInvalidPolicy    = resilience.ErrInvalidPolicy
+	ErrPolicyNotFound   = resilience.ErrPolicyNotFound
+)
+
+// Policy declaration prefixes and keys
+const (
+	PropertyPrefix             = infraResilience.PropertyPrefix
+	ConfigPrefix               = infraResilience.ConfigPrefix
+	KeyTimeout                 = infraResilience.KeyTimeout
+	KeyRetryMaxAttempts        = infraResilience.KeyRetryMaxAttempts
+	KeyRetryInitialBackoff     = infraResilience.KeyRetryInitialBackoff
+	KeyRetryMaxBackoff         = infraResilience.KeyRetryMaxBackoff
+	KeyRetryJitter             = infraResilience.KeyRetryJitter
+	KeyRetryOn                 = infraResilience.KeyRetryOn
+	KeyRetryOnTimeout          = infraResilience.KeyRetryOnTimeout
+	KeyBreakerFailureThreshold = infraResilience.KeyBreakerFailureThreshold
+	KeyBreakerOpenTimeout      = infraResilience.KeyBreakerOpenTimeout
+	KeyBreakerHalfOpenProbes   = infraResilience.KeyBreakerHalfOpenProbes
+)
+
+// Factory functions
+var NewGuard = infraResilience.NewGuard
+var Transient = infraResilience.Transient
+var PolicyFromProperties = infraResilience.PolicyFromProperties
+var PolicyFromConfig = infraResilience.PolicyFromConfig
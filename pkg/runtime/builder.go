@@ -13,6 +13,13 @@
 //		WithConfig(myConfig).
 //		BuildDaemon()
 //
+// Embedded Mode (caller manages the lifecycle):
+//
+//	app, err := runtime.NewBuilder().
+//		WithPlugins(myPlugin1, myPlugin2).
+//		Build()
+//	defer app.Stop(ctx)
+//
 // Command Mode (execute and exit):
 //
 //	result, err := runtime.NewBuilder().
@@ -24,34 +31,51 @@
 package runtime
 
 import (
+	"errors"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/context"
 	"github.com/fintechain/skeleton/internal/domain/event"
 	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
 	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
 	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
 	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
 	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraHealth "github.com/fintechain/skeleton/internal/infrastructure/health"
+	infraIntrospection "github.com/fintechain/skeleton/internal/infrastructure/introspection"
 	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
 	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
 	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
 )
 
 // RuntimeBuilder provides a simple builder API for creating and running
 // Fintechain applications without FX dependency injection complexity.
 type RuntimeBuilder struct {
-	plugins   []plugin.Plugin
-	config    config.Configuration
-	logger    logging.LoggerService
-	eventBus  event.EventBusService
-	registry  component.Registry
-	pluginMgr plugin.PluginManager
+	plugins     []plugin.Plugin
+	pluginDirs  []string
+	healthAddr  string
+	metricsAddr string
+	adminAddr   string
+	shutdown    ShutdownOptions
+	console     *bool
+	signals     *bool
+	hooks       map[Phase][]Hook
+	hookTimeout time.Duration
+	config      config.Configuration
+	logger      logging.LoggerService
+	eventBus    event.EventBusService
+	registry    component.Registry
+	pluginMgr   plugin.PluginManager
+	metrics     metrics.Registry
+	exporters   []tracing.Exporter
 }
 
 // NewBuilder creates a new RuntimeBuilder with no dependencies set.
@@ -71,6 +95,98 @@ func (b *RuntimeBuilder) WithPlugins(plugins ...plugin.Plugin) *RuntimeBuilder {
 	return b
 }
 
+// WithPluginDirectories adds directories to scan for plugin manifests.
+// Every subdirectory containing a plugin.json manifest is loaded as a plugin;
+// see infrastructure/plugin.Manifest for the format. Directories can also be
+// listed in the configuration under PluginDirectoriesKey.
+//
+// Example:
+//
+//	builder := runtime.NewBuilder().
+//		WithPluginDirectories("/opt/myapp/plugins")
+func (b *RuntimeBuilder) WithPluginDirectories(dirs ...string) *RuntimeBuilder {
+	b.pluginDirs = append(b.pluginDirs, dirs...)
+	return b
+}
+
+// WithHealthEndpoint serves liveness and readiness reports over HTTP on addr
+// in daemon mode. The address can also be set in the configuration under
+// HealthAddressKey; keep it local, such as "127.0.0.1:8081".
+//
+// Example:
+//
+//	builder := runtime.NewBuilder().
+//		WithHealthEndpoint("127.0.0.1:8081")
+func (b *RuntimeBuilder) WithHealthEndpoint(addr string) *RuntimeBuilder {
+	b.healthAddr = addr
+	return b
+}
+
+// WithMetricsEndpoint serves the metrics in the Prometheus text format over
+// HTTP on addr, under /metrics, in daemon mode. The address can also be set in
+// the configuration under MetricsAddressKey.
+//
+// Example:
+//
+//	builder := runtime.NewBuilder().
+//		WithMetricsEndpoint("127.0.0.1:9090")
+func (b *RuntimeBuilder) WithMetricsEndpoint(addr string) *RuntimeBuilder {
+	b.metricsAddr = addr
+	return b
+}
+
+// WithAdminEndpoint serves the admin API over HTTP on addr in daemon mode. It
+// reports the components, services, plugins, event subscriptions and stores of
+// the runtime as JSON under /admin, and the topology as Graphviz DOT or
+// Mermaid under /admin/topology?format=dot|mermaid. The address can also be
+// set in the configuration under AdminAddressKey. The API is unauthenticated;
+// keep it local, such as "127.0.0.1:8082".
+//
+// Example:
+//
+//	builder := runtime.NewBuilder().
+//		WithAdminEndpoint("127.0.0.1:8082")
+func (b *RuntimeBuilder) WithAdminEndpoint(addr string) *RuntimeBuilder {
+	b.adminAddr = addr
+	return b
+}
+
+// WithShutdownTimeouts bounds the phases of the daemon's graceful shutdown.
+// Zero fields fall back to the configuration (see ShutdownDrainTimeoutKey and
+// friends), then to the defaults.
+//
+// Example:
+//
+//	builder := runtime.NewBuilder().
+//		WithShutdownTimeouts(runtime.ShutdownOptions{DrainTimeout: 10 * time.Second})
+func (b *RuntimeBuilder) WithShutdownTimeouts(options ShutdownOptions) *RuntimeBuilder {
+	b.shutdown = options
+	return b
+}
+
+// WithConsoleOutput enables or disables the progress messages printed to
+// stdout. By default BuildDaemon and BuildCommand print them and Build does not.
+func (b *RuntimeBuilder) WithConsoleOutput(enabled bool) *RuntimeBuilder {
+	b.console = &enabled
+	return b
+}
+
+// WithSignalHandling enables or disables stopping on SIGINT and SIGTERM; a
+// second signal during shutdown forces the process to exit. By default
+// BuildDaemon handles signals and Build does not.
+func (b *RuntimeBuilder) WithSignalHandling(enabled bool) *RuntimeBuilder {
+	b.signals = &enabled
+	return b
+}
+
+// option returns the value of an optional setting, or def if it is unset.
+func option(value *bool, def bool) bool {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
 // WithConfig sets a custom configuration service.
 // If not set, a default memory configuration will be used.
 //
@@ -139,6 +255,32 @@ func (b *RuntimeBuilder) WithPluginManager(pluginMgr plugin.PluginManager) *Runt
 	return b
 }
 
+// WithMetrics sets the registry the runtime records its metrics in.
+// If not set, a default in-memory registry will be used.
+//
+// Example:
+//
+//	registry := myCustomRegistry()
+//	builder := runtime.NewBuilder().
+//		WithMetrics(registry)
+func (b *RuntimeBuilder) WithMetrics(registry metrics.Registry) *RuntimeBuilder {
+	b.metrics = registry
+	return b
+}
+
+// WithTraceExporters ships the spans of operations, event deliveries and
+// store calls to the exporters. Spans can also be appended to a file in the
+// OTLP/JSON format by setting TracingFileKey in the configuration.
+//
+// Example:
+//
+//	builder := runtime.NewBuilder().
+//		WithTraceExporters(tracing.NewFileExporter("traces.jsonl", "ledger"))
+func (b *RuntimeBuilder) WithTraceExporters(exporters ...tracing.Exporter) *RuntimeBuilder {
+	b.exporters = append(b.exporters, exporters...)
+	return b
+}
+
 // createDefaultDependencies creates default implementations for any dependencies
 // that were not explicitly set via WithXxx methods.
 func (b *RuntimeBuilder) createDefaultDependencies() error {
@@ -208,10 +350,236 @@ func (b *RuntimeBuilder) createRuntime() (*infraRuntime.Runtime, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create runtime: %w", err)
 	}
+	if b.metrics != nil {
+		runtime.SetMetrics(b.metrics)
+	}
+	if exporters := b.traceExporters(); len(exporters) > 0 {
+		runtime.SetTracer(infraTracing.NewTracer(exporters...))
+	}
 
 	return runtime, nil
 }
 
+// Tracing configuration keys
+const (
+	TracingFileKey    = "tracing.file"    // file spans are appended to in the OTLP/JSON format
+	TracingServiceKey = "tracing.service" // service name reported with the spans
+)
+
+// traceExporters returns the exporters passed to WithTraceExporters and the
+// file exporter, if a file is configured.
+func (b *RuntimeBuilder) traceExporters() []tracing.Exporter {
+	exporters := append([]tracing.Exporter(nil), b.exporters...)
+	if b.config.Exists(TracingFileKey) {
+		service := b.config.GetStringDefault(TracingServiceKey, "skeleton")
+		exporters = append(exporters, infraTracing.NewFileExporter(b.config.GetString(TracingFileKey), service))
+	}
+	return exporters
+}
+
+// PluginDirectoriesKey is the configuration key listing additional plugin directories.
+const PluginDirectoriesKey = "plugins.directories"
+
+// loadPlugins loads the plugins passed to WithPlugins and those discovered in
+// the plugin directories. Discovery failures are reported through the logger
+// and event bus and do not prevent the remaining plugins from loading.
+func (b *RuntimeBuilder) loadPlugins(ctx context.Context, runtime *infraRuntime.Runtime, out console) error {
+	plugins := append([]plugin.Plugin(nil), b.plugins...)
+
+	dirs := append([]string(nil), b.pluginDirs...)
+	var configured []string
+	if b.config.Exists(PluginDirectoriesKey) && b.config.GetObject(PluginDirectoriesKey, &configured) == nil {
+		dirs = append(dirs, configured...)
+	}
+
+	if len(dirs) > 0 {
+		result := infraPlugin.NewDiscovery(dirs, b.config, b.logger, b.eventBus).Discover()
+		out.printf("Discovered %d plugins (%d failed)", len(result.Plugins), len(result.Failures))
+		result.AddDependencies(runtime.PluginManager())
+		plugins = append(plugins, result.Plugins...)
+	}
+
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	out.printf("Loading %d plugins...", len(plugins))
+	if err := runtime.LoadPlugins(ctx, plugins); err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	}
+	return nil
+}
+
+// Health endpoint configuration keys
+const (
+	HealthAddressKey  = "health.address"   // address of the health endpoint
+	HealthTimeoutKey  = "health.timeout"   // timeout of each component check
+	HealthCacheTTLKey = "health.cache_ttl" // how long check results are reused
+)
+
+// startHealthEndpoint starts the health endpoint if an address is configured.
+// It returns nil when the endpoint is disabled.
+func (b *RuntimeBuilder) startHealthEndpoint(ctx context.Context, runtime *infraRuntime.Runtime, out console) (*infraHealth.Server, error) {
+	addr := b.healthAddr
+	if addr == "" && b.config.Exists(HealthAddressKey) {
+		addr = b.config.GetString(HealthAddressKey)
+	}
+	if addr == "" {
+		return nil, nil
+	}
+
+	if checker, ok := runtime.Health().(*infraHealth.Checker); ok {
+		checker.SetOptions(infraHealth.CheckerOptions{
+			Timeout:  b.config.GetDurationDefault(HealthTimeoutKey, infraHealth.DefaultTimeout),
+			CacheTTL: b.config.GetDurationDefault(HealthCacheTTLKey, infraHealth.DefaultCacheTTL),
+		})
+	}
+
+	server := infraHealth.NewServer(component.ComponentConfig{
+		ID:          "health-endpoint",
+		Name:        "Health Endpoint",
+		Description: "Serves liveness and readiness reports over HTTP",
+	}, addr, runtime.Health())
+	if err := server.Start(ctx); err != nil {
+		return nil, err
+	}
+	out.printf("Health endpoint listening on %s", server.Addr())
+	return server, nil
+}
+
+// MetricsAddressKey is the configuration key of the metrics endpoint address.
+const MetricsAddressKey = "metrics.address"
+
+// startMetricsEndpoint starts the metrics endpoint if an address is configured.
+// It returns nil when the endpoint is disabled.
+func (b *RuntimeBuilder) startMetricsEndpoint(ctx context.Context, runtime *infraRuntime.Runtime, out console) (*infraMetrics.Server, error) {
+	addr := b.metricsAddr
+	if addr == "" && b.config.Exists(MetricsAddressKey) {
+		addr = b.config.GetString(MetricsAddressKey)
+	}
+	if addr == "" {
+		return nil, nil
+	}
+
+	server := infraMetrics.NewServer(component.ComponentConfig{
+		ID:          "metrics-endpoint",
+		Name:        "Metrics Endpoint",
+		Description: "Serves metrics in the Prometheus text format over HTTP",
+	}, addr, runtime.Metrics())
+	if err := server.Start(ctx); err != nil {
+		return nil, err
+	}
+	out.printf("Metrics endpoint listening on %s", server.Addr())
+	return server, nil
+}
+
+// AdminAddressKey is the configuration key of the admin endpoint address.
+const AdminAddressKey = "admin.address"
+
+// startAdminEndpoint starts the admin endpoint if an address is configured.
+// It returns nil when the endpoint is disabled.
+func (b *RuntimeBuilder) startAdminEndpoint(ctx context.Context, runtime *infraRuntime.Runtime, out console) (*infraIntrospection.Server, error) {
+	addr := b.adminAddr
+	if addr == "" && b.config.Exists(AdminAddressKey) {
+		addr = b.config.GetString(AdminAddressKey)
+	}
+	if addr == "" {
+		return nil, nil
+	}
+
+	server := infraIntrospection.NewServer(component.ComponentConfig{
+		ID:          "admin-endpoint",
+		Name:        "Admin Endpoint",
+		Description: "Serves the introspection reports and topology over HTTP",
+	}, addr, runtime.Introspection())
+	if err := server.Start(ctx); err != nil {
+		return nil, err
+	}
+	out.printf("Admin endpoint listening on %s", server.Addr())
+	return server, nil
+}
+
+// ShutdownOptions bounds each phase of a graceful shutdown.
+type ShutdownOptions = infraRuntime.ShutdownOptions
+
+// Shutdown configuration keys
+const (
+	ShutdownDrainTimeoutKey  = "shutdown.drain_timeout"  // wait for in-flight operations
+	ShutdownEventsTimeoutKey = "shutdown.events_timeout" // wait for async event handlers
+	ShutdownStopTimeoutKey   = "shutdown.stop_timeout"   // stop plugins and services
+)
+
+// shutdownOptions returns the shutdown timeouts set on the builder, completed
+// from the configuration.
+func (b *RuntimeBuilder) shutdownOptions() ShutdownOptions {
+	options := b.shutdown
+	if options.DrainTimeout <= 0 {
+		options.DrainTimeout = b.config.GetDurationDefault(ShutdownDrainTimeoutKey, infraRuntime.DefaultDrainTimeout)
+	}
+	if options.EventsTimeout <= 0 {
+		options.EventsTimeout = b.config.GetDurationDefault(ShutdownEventsTimeoutKey, infraRuntime.DefaultEventsTimeout)
+	}
+	if options.StopTimeout <= 0 {
+		options.StopTimeout = b.config.GetDurationDefault(ShutdownStopTimeoutKey, infraRuntime.DefaultStopTimeout)
+	}
+	return options
+}
+
+// Build creates the runtime, loads plugins and starts it, returning a handle
+// to stop it and wait for it. Lifecycle hooks (see OnInit and friends) run
+// around each phase. Unlike BuildDaemon it does not block, and by
+// default it neither prints to stdout nor handles signals (see
+// WithConsoleOutput and WithSignalHandling).
+//
+// Example:
+//
+//	app, err := runtime.NewBuilder().
+//		WithPlugins(myPlugin).
+//		Build()
+//	if err != nil {
+//		return err
+//	}
+//	defer app.Stop(ctx)
+func (b *RuntimeBuilder) Build() (*Handle, error) {
+	return b.build(false, false)
+}
+
+// build creates, loads and starts a runtime, using the given defaults for
+// console output and signal handling when the builder does not set them. If
+// loading or starting fails, the plugins loaded so far are unloaded again.
+func (b *RuntimeBuilder) build(console, signals bool) (*Handle, error) {
+	out := b.output(console)
+
+	// Create runtime
+	runtime, err := b.createRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create context
+	ctx := infraContext.NewContext()
+
+	// Run init hooks, then load provided and discovered plugins
+	if err := b.runHooks(runtime, PhaseInit); err != nil {
+		return nil, err
+	}
+	if err := b.loadPlugins(ctx, runtime, out); err != nil {
+		return nil, errors.Join(err, runtime.UnloadPlugins(ctx))
+	}
+	runtime.Logger().Debug("Effective configuration", "config", runtime.EffectiveConfiguration())
+
+	handle := newHandle(b, runtime, out, option(b.signals, signals))
+	if err := handle.Start(ctx); err != nil {
+		return nil, errors.Join(err, runtime.UnloadPlugins(ctx))
+	}
+	return handle, nil
+}
+
+// output returns the console for progress messages.
+func (b *RuntimeBuilder) output(def bool) console {
+	return console(option(b.console, def))
+}
+
 // BuildDaemon creates and runs a long-running daemon application.
 // This function blocks until the application receives a shutdown signal.
 //
@@ -225,52 +593,27 @@ func (b *RuntimeBuilder) createRuntime() (*infraRuntime.Runtime, error) {
 //  1. Create dependencies (use defaults if not provided)
 //  2. Create runtime using existing constructor
 //  3. Load plugins if provided
-//  4. Start runtime and handle signals
-//  5. Block and wait for shutdown signals (SIGINT, SIGTERM)
-//  6. Gracefully shut down all services
+//  4. Start runtime, the health, metrics and admin endpoints if enabled, and handle signals
+//  5. Block and wait for shutdown signals (SIGINT, SIGTERM), or for a
+//     supervised service failure that escalates to the runtime
+//  6. Gracefully shut down: reject new operations, drain in-flight
+//     operations and async event handlers, then stop plugins and services,
+//     each phase bounded by its timeout (see WithShutdownTimeouts)
+//
+// A second signal during shutdown forces the process to exit immediately.
+// BuildDaemon is Build with signal handling and console output enabled by
+// default, followed by Wait.
 //
 // Returns an error if startup fails.
 func (b *RuntimeBuilder) BuildDaemon() error {
-	// Create runtime
-	runtime, err := b.createRuntime()
+	b.output(true).println("Starting daemon mode...")
+	handle, err := b.build(true, true)
 	if err != nil {
 		return err
 	}
+	handle.out.println("Daemon started successfully")
 
-	// Create context
-	ctx := infraContext.NewContext()
-
-	// Load plugins if provided
-	if len(b.plugins) > 0 {
-		fmt.Printf("[Fintechain] Loading %d plugins...\n", len(b.plugins))
-		if err := runtime.LoadPlugins(ctx, b.plugins); err != nil {
-			return fmt.Errorf("failed to load plugins: %w", err)
-		}
-	}
-
-	// Start runtime
-	fmt.Println("[Fintechain] Starting daemon mode...")
-	if err := runtime.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start runtime: %w", err)
-	}
-	fmt.Println("[Fintechain] Daemon started successfully")
-
-	// Set up signal handling
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	// Block until signal received
-	<-sigChan
-	fmt.Println("[Fintechain] Shutdown signal received")
-
-	// Stop runtime
-	fmt.Println("[Fintechain] Stopping daemon...")
-	if err := runtime.Stop(ctx); err != nil {
-		return fmt.Errorf("failed to stop runtime: %w", err)
-	}
-	fmt.Println("[Fintechain] Daemon stopped successfully")
-
-	return nil
+	return handle.Wait()
 }
 
 // BuildCommand creates and runs a command-mode application.
@@ -289,12 +632,18 @@ func (b *RuntimeBuilder) BuildDaemon() error {
 //  4. Execute the specified operation
 //  5. Clean up and return results
 //
+// Lifecycle hooks do not run in command mode: the runtime and its services are
+// never started, so there is no phase to run them in. If plugins fail to
+// load, those loaded so far are unloaded again.
+//
 // Parameters:
 //   - operationID: The ID of the operation component to execute
 //   - input: Input data for the operation
 //
 // Returns the operation output and any error that occurred.
 func (b *RuntimeBuilder) BuildCommand(operationID string, input map[string]interface{}) (map[string]interface{}, error) {
+	out := b.output(true)
+
 	// Create runtime
 	runtime, err := b.createRuntime()
 	if err != nil {
@@ -304,17 +653,14 @@ func (b *RuntimeBuilder) BuildCommand(operationID string, input map[string]inter
 	// Create context
 	ctx := infraContext.NewContext()
 
-	// Load plugins if provided
-	if len(b.plugins) > 0 {
-		fmt.Printf("[Fintechain] Loading %d plugins...\n", len(b.plugins))
-		if err := runtime.LoadPlugins(ctx, b.plugins); err != nil {
-			return nil, fmt.Errorf("failed to load plugins: %w", err)
-		}
+	// Load provided and discovered plugins
+	if err := b.loadPlugins(ctx, runtime, out); err != nil {
+		return nil, errors.Join(err, runtime.UnloadPlugins(ctx))
 	}
 
 	// Initialize runtime without starting long-running services
 	// (services are not started in command mode to avoid long-running processes)
-	fmt.Printf("[Fintechain] Executing command: %s\n", operationID)
+	out.printf("Executing command: %s", operationID)
 
 	// Execute the operation
 	operationInput := component.Input{
@@ -336,6 +682,6 @@ func (b *RuntimeBuilder) BuildCommand(operationID string, input map[string]inter
 		result = map[string]interface{}{"result": output.Data}
 	}
 
-	fmt.Printf("[Fintechain] Command completed successfully\n")
+	out.println("Command completed successfully")
 	return result, nil
 }
@@ -0,0 +1,265 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/fintechain/skeleton/internal/domain/context"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraHealth "github.com/fintechain/skeleton/internal/infrastructure/health"
+	infraIntrospection "github.com/fintechain/skeleton/internal/infrastructure/introspection"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
+)
+
+// Handle controls a runtime created by RuntimeBuilder.Build. It lets tests,
+// other servers and main functions that manage their own lifecycle embed the
+// runtime instead of handing the process over to BuildDaemon.
+//
+// Example:
+//
+//	app, err := runtime.NewBuilder().WithPlugins(myPlugin).Build()
+//	if err != nil {
+//		return err
+//	}
+//	defer app.Stop(ctx)
+//	output, err := app.Runtime().ExecuteOperation(ctx, "my-operation", input)
+type Handle struct {
+	builder *RuntimeBuilder
+	runtime *infraRuntime.Runtime
+	out     console
+	signals bool
+
+	health  *infraHealth.Server
+	metrics *infraMetrics.Server
+	admin   *infraIntrospection.Server
+	running bool
+	done    chan struct{}
+	err     error
+	mu      sync.Mutex
+}
+
+// newHandle creates a handle for a runtime whose plugins are loaded.
+func newHandle(builder *RuntimeBuilder, runtime *infraRuntime.Runtime, out console, signals bool) *Handle {
+	done := make(chan struct{})
+	close(done)
+	return &Handle{
+		builder: builder,
+		runtime: runtime,
+		out:     out,
+		signals: signals,
+		done:    done,
+	}
+}
+
+// Runtime returns the runtime environment.
+func (h *Handle) Runtime() RuntimeEnvironment {
+	return h.runtime
+}
+
+// Start starts the runtime and, if enabled, the health, metrics and admin
+// endpoints and signal handling, running the start and ready hooks. A failing hook stops the
+// runtime again. Starting a running handle does nothing; a stopped handle
+// can be started again.
+func (h *Handle) Start(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.running {
+		return nil
+	}
+
+	h.out.println("Starting runtime...")
+	if err := h.runtime.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start runtime: %w", err)
+	}
+	if err := h.builder.runHooks(h.runtime, PhaseStart); err != nil {
+		return errors.Join(err, h.runtime.Stop(ctx))
+	}
+
+	// Serve health reports if enabled
+	server, err := h.builder.startHealthEndpoint(ctx, h.runtime, h.out)
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to start health endpoint: %w", err), h.runtime.Stop(ctx))
+	}
+
+	// Serve metrics if enabled
+	metricsServer, err := h.builder.startMetricsEndpoint(ctx, h.runtime, h.out)
+	if err != nil {
+		err = fmt.Errorf("failed to start metrics endpoint: %w", err)
+		if server != nil {
+			err = errors.Join(err, server.Stop(ctx))
+		}
+		return errors.Join(err, h.runtime.Stop(ctx))
+	}
+
+	// Serve the admin API if enabled
+	adminServer, err := h.builder.startAdminEndpoint(ctx, h.runtime, h.out)
+	if err != nil {
+		err = fmt.Errorf("failed to start admin endpoint: %w", err)
+		if server != nil {
+			err = errors.Join(err, server.Stop(ctx))
+		}
+		if metricsServer != nil {
+			err = errors.Join(err, metricsServer.Stop(ctx))
+		}
+		return errors.Join(err, h.runtime.Stop(ctx))
+	}
+
+	if err := h.builder.runHooks(h.runtime, PhaseReady); err != nil {
+		if server != nil {
+			err = errors.Join(err, server.Stop(ctx))
+		}
+		if metricsServer != nil {
+			err = errors.Join(err, metricsServer.Stop(ctx))
+		}
+		if adminServer != nil {
+			err = errors.Join(err, adminServer.Stop(ctx))
+		}
+		return errors.Join(err, h.runtime.Stop(ctx))
+	}
+
+	done := make(chan struct{})
+	h.health = server
+	h.metrics = metricsServer
+	h.admin = adminServer
+	h.running = true
+	h.done = done
+	h.err = nil
+
+	go h.watch(done)
+	return nil
+}
+
+// Stop shuts the runtime down gracefully (see WithShutdownTimeouts) and stops
+// the health, metrics and admin endpoints, running the stop hooks before and the stopped hooks
+// after. Cancelling ctx cuts the shutdown short. Stopping a stopped handle
+// does nothing.
+func (h *Handle) Stop(ctx context.Context) error {
+	return h.finish(func() error {
+		h.out.println("Stopping runtime...")
+		hookErr := h.builder.runHooks(h.runtime, PhaseStop)
+		if err := h.runtime.Shutdown(ctx, h.builder.shutdownOptions()); err != nil {
+			return errors.Join(hookErr, fmt.Errorf("failed to stop runtime: %w", err))
+		}
+		h.out.println("Runtime stopped successfully")
+		return hookErr
+	})
+}
+
+// Wait blocks until the handle stops, through Stop, a shutdown signal or a
+// supervised service failure that escalates to the runtime. It returns the
+// reason the runtime stopped, or nil after a clean shutdown.
+func (h *Handle) Wait() error {
+	<-h.Done()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// Done returns a channel that is closed when the handle stops.
+func (h *Handle) Done() <-chan struct{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.done
+}
+
+// finish stops a running handle with stop, stops the endpoints, shuts the
+// trace exporters down, runs the stopped hooks, records the result for Wait
+// and closes Done. It returns nil if the handle is not running.
+func (h *Handle) finish(stop func() error) error {
+	h.mu.Lock()
+	if !h.running {
+		h.mu.Unlock()
+		return nil
+	}
+	h.running = false
+	server, metricsServer, adminServer, done := h.health, h.metrics, h.admin, h.done
+	h.mu.Unlock()
+
+	err := stop()
+	if server != nil {
+		if stopErr := server.Stop(infraContext.NewContext()); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop health endpoint: %w", stopErr))
+		}
+	}
+	if metricsServer != nil {
+		if stopErr := metricsServer.Stop(infraContext.NewContext()); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop metrics endpoint: %w", stopErr))
+		}
+	}
+	if adminServer != nil {
+		if stopErr := adminServer.Stop(infraContext.NewContext()); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop admin endpoint: %w", stopErr))
+		}
+	}
+	if tracer, ok := h.runtime.Tracer().(*infraTracing.Tracer); ok {
+		if shutdownErr := tracer.Shutdown(); shutdownErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to shut down trace exporters: %w", shutdownErr))
+		}
+	}
+	err = errors.Join(err, h.builder.runHooks(h.runtime, PhaseStopped))
+
+	h.mu.Lock()
+	h.err = err
+	h.mu.Unlock()
+	close(done)
+	return err
+}
+
+// watch stops the handle on a shutdown signal, if signal handling is enabled,
+// or when a supervised service failure stops the runtime. It returns when
+// done is closed.
+func (h *Handle) watch(done chan struct{}) {
+	var sigChan chan os.Signal
+	if h.signals {
+		sigChan = make(chan os.Signal, 2)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(sigChan)
+	}
+
+	select {
+	case <-sigChan:
+		h.out.println("Shutdown signal received")
+
+		// A second signal forces exit
+		go func() {
+			select {
+			case <-sigChan:
+				h.out.println("Second signal received, forcing exit")
+				os.Exit(1)
+			case <-done:
+			}
+		}()
+		h.Stop(infraContext.NewContext())
+	case err := <-h.runtime.Failed():
+		h.out.println("Runtime stopped after service failure")
+		h.finish(func() error {
+			return fmt.Errorf("runtime stopped: %w", err)
+		})
+	case <-done:
+	}
+}
+
+// console prints progress messages when console output is enabled.
+type console bool
+
+// println prints a progress message.
+func (c console) println(msg string) {
+	if c {
+		fmt.Println("[Fintechain] " + msg)
+	}
+}
+
+// printf prints a formatted progress message.
+func (c console) printf(format string, args ...interface{}) {
+	if c {
+		fmt.Printf("[Fintechain] "+format+"\n", args...)
+	}
+}
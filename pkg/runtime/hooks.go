@@ -0,0 +1,193 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	domainRuntime "github.com/fintechain/skeleton/internal/domain/runtime"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+)
+
+// Hook runs application code at a lifecycle phase.
+type Hook = domainRuntime.Hook
+
+// Phase identifies a stage of the runtime lifecycle.
+type Phase = domainRuntime.Phase
+
+// Lifecycle phases
+const (
+	PhaseInit    = domainRuntime.PhaseInit
+	PhaseStart   = domainRuntime.PhaseStart
+	PhaseReady   = domainRuntime.PhaseReady
+	PhaseStop    = domainRuntime.PhaseStop
+	PhaseStopped = domainRuntime.PhaseStopped
+)
+
+// Lifecycle error codes
+const (
+	ErrHookFailed  = domainRuntime.ErrHookFailed
+	ErrHookTimeout = domainRuntime.ErrHookTimeout
+)
+
+// Lifecycle event topics
+const (
+	TopicRuntimeInit    = domainRuntime.TopicRuntimeInit
+	TopicRuntimeStart   = domainRuntime.TopicRuntimeStart
+	TopicRuntimeReady   = domainRuntime.TopicRuntimeReady
+	TopicRuntimeStop    = domainRuntime.TopicRuntimeStop
+	TopicRuntimeStopped = domainRuntime.TopicRuntimeStopped
+	TopicHookFailed     = domainRuntime.TopicHookFailed
+)
+
+// HookTimeoutKey is the configuration key bounding each lifecycle hook.
+const HookTimeoutKey = "hooks.timeout"
+
+// DefaultHookTimeout bounds each lifecycle hook unless configured otherwise.
+const DefaultHookTimeout = 15 * time.Second
+
+// phaseTopics maps each lifecycle phase to its event topic.
+var phaseTopics = map[Phase]string{
+	PhaseInit:    TopicRuntimeInit,
+	PhaseStart:   TopicRuntimeStart,
+	PhaseReady:   TopicRuntimeReady,
+	PhaseStop:    TopicRuntimeStop,
+	PhaseStopped: TopicRuntimeStopped,
+}
+
+// OnInit registers a hook that runs once the runtime is created, before
+// plugins load. A failing hook aborts the build.
+func (b *RuntimeBuilder) OnInit(hook Hook) *RuntimeBuilder {
+	return b.addHook(PhaseInit, hook)
+}
+
+// OnStart registers a hook that runs once the services have started, before
+// the runtime is ready. A failing hook aborts startup and stops the runtime.
+func (b *RuntimeBuilder) OnStart(hook Hook) *RuntimeBuilder {
+	return b.addHook(PhaseStart, hook)
+}
+
+// OnReady registers a hook that runs once startup is complete, including the
+// health endpoint. A failing hook aborts startup and stops the runtime.
+func (b *RuntimeBuilder) OnReady(hook Hook) *RuntimeBuilder {
+	return b.addHook(PhaseReady, hook)
+}
+
+// OnStop registers a hook that runs when shutdown is requested, before
+// operations drain and services stop. Failures are reported by Stop and do
+// not interrupt shutdown.
+func (b *RuntimeBuilder) OnStop(hook Hook) *RuntimeBuilder {
+	return b.addHook(PhaseStop, hook)
+}
+
+// OnStopped registers a hook that runs once shutdown is complete, including
+// after a supervised service failure stopped the runtime. Failures are
+// reported by Stop or Wait.
+func (b *RuntimeBuilder) OnStopped(hook Hook) *RuntimeBuilder {
+	return b.addHook(PhaseStopped, hook)
+}
+
+// WithHookTimeout bounds each lifecycle hook. If not set, HookTimeoutKey is
+// read from the configuration, then DefaultHookTimeout applies.
+func (b *RuntimeBuilder) WithHookTimeout(timeout time.Duration) *RuntimeBuilder {
+	b.hookTimeout = timeout
+	return b
+}
+
+// addHook registers a hook for a phase.
+func (b *RuntimeBuilder) addHook(phase Phase, hook Hook) *RuntimeBuilder {
+	if b.hooks == nil {
+		b.hooks = make(map[Phase][]Hook)
+	}
+	b.hooks[phase] = append(b.hooks[phase], hook)
+	return b
+}
+
+// runHooks publishes the phase event and runs the hooks of the phase. The
+// stopped phase is published by the runtime itself, before its event bus
+// stops, so only its hooks run here.
+//
+// Startup hooks (init, start, ready) run in registration order and stop at
+// the first failure. Shutdown hooks (stop, stopped) run in reverse
+// registration order, like deferred calls, and all run even when some fail.
+func (b *RuntimeBuilder) runHooks(runtime *infraRuntime.Runtime, phase Phase) error {
+	if phase != PhaseStopped {
+		runtime.EventBus().Publish(&event.Event{
+			Topic:   phaseTopics[phase],
+			Source:  infraRuntime.RuntimeID,
+			Time:    time.Now(),
+			Payload: map[string]interface{}{"phase": string(phase)},
+		})
+	}
+
+	hooks := b.hooks[phase]
+	if len(hooks) == 0 {
+		return nil
+	}
+	shutdown := phase == PhaseStop || phase == PhaseStopped
+	timeout := b.hookTimeoutOrDefault()
+
+	var errs []error
+	for n := range hooks {
+		i := n
+		if shutdown {
+			i = len(hooks) - 1 - n
+		}
+		if err := runHook(runtime, phase, i, hooks[i], timeout); err != nil {
+			runtime.Logger().Error("Lifecycle hook failed", "phase", phase, "hook", i, "error", err)
+			runtime.EventBus().Publish(&event.Event{
+				Topic:  TopicHookFailed,
+				Source: infraRuntime.RuntimeID,
+				Time:   time.Now(),
+				Payload: map[string]interface{}{
+					"phase": string(phase),
+					"hook":  i,
+					"error": err.Error(),
+				},
+			})
+			if !shutdown {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// hookTimeoutOrDefault returns the timeout of each lifecycle hook.
+func (b *RuntimeBuilder) hookTimeoutOrDefault() time.Duration {
+	if b.hookTimeout > 0 {
+		return b.hookTimeout
+	}
+	return b.config.GetDurationDefault(HookTimeoutKey, DefaultHookTimeout)
+}
+
+// runHook runs one hook with the timeout, recovering from panics. A hook that
+// times out keeps running in the background.
+func runHook(runtime *infraRuntime.Runtime, phase Phase, index int, hook Hook, timeout time.Duration) error {
+	ctx := infraContext.NewContextWithTimeout(timeout)
+	defer ctx.Cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- hook(ctx, runtime)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return failure.Wrap(err, ErrHookFailed, "%s hook %d", phase, index)
+		}
+		return nil
+	case <-ctx.Done():
+		return failure.New(ErrHookTimeout, "%s hook %d not done after %s", phase, index, timeout)
+	}
+}
@@ -12,6 +12,13 @@
 //		WithConfig(myConfig).
 //		BuildDaemon()
 //
+// Embedded Mode (caller manages the lifecycle):
+//
+//	app, err := runtime.NewBuilder().
+//		WithPlugins(myPlugin1, myPlugin2).
+//		Build()
+//	defer app.Stop(ctx)
+//
 // Command Mode (execute and exit):
 //
 //	result, err := runtime.NewBuilder().
@@ -33,6 +40,12 @@ import (
 // This extends the component.System interface with additional accessors for commonly used core services.
 type RuntimeEnvironment = domainRuntime.RuntimeEnvironment
 
+// PluginConfigurationProvider is implemented by runtimes that keep a configuration section per plugin.
+type PluginConfigurationProvider = domainRuntime.PluginConfigurationProvider
+
+// StatusProvider is implemented by runtimes that report their lifecycle state.
+type StatusProvider = domainRuntime.StatusProvider
+
 // =============================================================================
 // Builder-based API (Recommended)
 // =============================================================================
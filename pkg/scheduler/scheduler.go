@@ -0,0 +1,63 @@
+// Package scheduler provides a service that runs operations on cron expressions or intervals.
+package scheduler
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/scheduler"
+	infraScheduler "github.com/fintechain/skeleton/internal/infrastructure/scheduler"
+)
+
+// Core interfaces
+type Scheduler = scheduler.Scheduler
+
+// Types
+type Schedule = scheduler.Schedule
+type State = scheduler.State
+type OverlapPolicy = scheduler.OverlapPolicy
+type CatchUpPolicy = scheduler.CatchUpPolicy
+type Options = infraScheduler.Options
+type Cron = infraScheduler.Cron
+
+// Overlap policies
+const (
+	OverlapSkip  = scheduler.OverlapSkip
+	OverlapAllow = scheduler.OverlapAllow
+)
+
+// Catch-up policies
+const (
+	CatchUpNone = scheduler.CatchUpNone
+	CatchUpOnce = scheduler.CatchUpOnce
+	CatchUpAll  = scheduler.CatchUpAll
+)
+
+// Event topics
+const (
+	TopicRunStarted   = scheduler.TopicRunStarted
+	TopicRunSucceeded = scheduler.TopicRunSucceeded
+	TopicRunFailed    = scheduler.TopicRunFailed
+	TopicRunSkipped   = scheduler.TopicRunSkipped
+)
+
+// Error constants
+const (
+	ErrInvalidSchedule  = scheduler.ErrInvalidSchedule
+	ErrInvalidCron      = scheduler.ErrInvalidCron
+	ErrScheduleNotFound = scheduler.ErrScheduleNotFound
+	ErrScheduleExists   = scheduler.ErrScheduleExists
+	ErrRunSkipped       = scheduler.ErrRunSkipped
+	ErrStoreUnavailable = scheduler.ErrStoreUnavailable
+)
+
+// Defaults and configuration
+const (
+	ConfigKey         = infraScheduler.ConfigKey
+	DefaultStore      = infraScheduler.DefaultStore
+	DefaultEngine     = infraScheduler.DefaultEngine
+	DefaultMaxCatchUp = infraScheduler.DefaultMaxCatchUp
+)
+
+// Factory functions
+var NewScheduler = infraScheduler.NewScheduler
+var ParseCron = infraScheduler.ParseCron
+var Validate = infraScheduler.Validate
+var SchedulesFromConfig = infraScheduler.SchedulesFromConfig
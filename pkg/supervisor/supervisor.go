@@ -0,0 +1,64 @@
+// Package supervisor provides service supervision interfaces and implementations.
+package supervisor
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	infraSupervisor "github.com/fintechain/skeleton/internal/infrastructure/supervisor"
+)
+
+// Core interfaces
+type Supervisor = supervisor.Supervisor
+type ReportingService = supervisor.ReportingService
+type FailingService = supervisor.FailingService
+type FailureReporter = supervisor.FailureReporter
+type Provider = supervisor.Provider
+
+// Policies and state
+type Policy = supervisor.Policy
+type RestartPolicy = supervisor.RestartPolicy
+type Escalation = supervisor.Escalation
+type State = supervisor.State
+type ServiceState = supervisor.ServiceState
+
+// Restart policies
+const (
+	RestartNever     = supervisor.RestartNever
+	RestartAlways    = supervisor.RestartAlways
+	RestartOnFailure = supervisor.RestartOnFailure
+)
+
+// Escalations
+const (
+	EscalateNone    = supervisor.EscalateNone
+	EscalatePlugin  = supervisor.EscalatePlugin
+	EscalateRuntime = supervisor.EscalateRuntime
+)
+
+// Service states
+const (
+	StateRunning    = supervisor.StateRunning
+	StateRestarting = supervisor.StateRestarting
+	StateStopped    = supervisor.StateStopped
+	StateFailed     = supervisor.StateFailed
+)
+
+// Event topics
+const (
+	TopicServiceFailed     = supervisor.TopicServiceFailed
+	TopicServiceExited     = supervisor.TopicServiceExited
+	TopicServiceRestarting = supervisor.TopicServiceRestarting
+	TopicServiceRestarted  = supervisor.TopicServiceRestarted
+	TopicServiceGaveUp     = supervisor.TopicServiceGaveUp
+	TopicEscalated         = supervisor.TopicEscalated
+)
+
+// Error constants
+const (
+	ErrServiceNotSupervised     = supervisor.ErrServiceNotSupervised
+	ErrServiceAlreadySupervised = supervisor.ErrServiceAlreadySupervised
+	ErrInvalidPolicy            = supervisor.ErrInvalidPolicy
+	ErrRestartBudgetExhausted   = supervisor.ErrRestartBudgetExhausted
+)
+
+// Factory functions
+var NewSupervisor = infraSupervisor.NewSupervisor
@@ -0,0 +1,61 @@
+// Package tracing provides spans that tie operations, event deliveries and
+// store calls together, and exporters that ship them.
+package tracing
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
+)
+
+// Core interfaces
+type Tracer = tracing.Tracer
+type Span = tracing.Span
+type Exporter = tracing.Exporter
+type Provider = tracing.Provider
+
+// Span data
+type TraceID = tracing.TraceID
+type SpanID = tracing.SpanID
+type SpanContext = tracing.SpanContext
+type SpanData = tracing.SpanData
+type Kind = tracing.Kind
+type Status = tracing.Status
+
+// Implementations
+type MemoryExporter = infraTracing.MemoryExporter
+type FileExporter = infraTracing.FileExporter
+
+// Kinds, statuses and metadata key
+const (
+	KindInternal   = tracing.KindInternal
+	KindProducer   = tracing.KindProducer
+	KindConsumer   = tracing.KindConsumer
+	KindClient     = tracing.KindClient
+	StatusUnset    = tracing.StatusUnset
+	StatusError    = tracing.StatusError
+	TraceParentKey = tracing.TraceParentKey
+)
+
+// Error constants
+const (
+	ErrExportFailed = tracing.ErrExportFailed
+)
+
+// Propagation functions
+var (
+	ContextWithSpan        = tracing.ContextWithSpan
+	ContextWithSpanContext = tracing.ContextWithSpanContext
+	SpanFromContext        = tracing.SpanFromContext
+	SpanContextFromContext = tracing.SpanContextFromContext
+	Inject                 = tracing.Inject
+	Extract                = tracing.Extract
+)
+
+// Factory functions
+var (
+	NewTracer         = infraTracing.NewTracer
+	NewMemoryExporter = infraTracing.NewMemoryExporter
+	NewFileExporter   = infraTracing.NewFileExporter
+	TraceStore        = infraTracing.TraceStore
+	BindStore         = infraTracing.BindStore
+)
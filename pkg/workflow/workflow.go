@@ -0,0 +1,40 @@
+// Package workflow provides declarative flows composed of operations, with saga compensation.
+package workflow
+
+import (
+	"github.com/fintechain/skeleton/internal/domain/workflow"
+	infraWorkflow "github.com/fintechain/skeleton/internal/infrastructure/workflow"
+)
+
+// Types
+type Flow = workflow.Flow
+type Step = workflow.Step
+type Branch = workflow.Branch
+type Condition = workflow.Condition
+type Mapping = workflow.Mapping
+type Workflow = infraWorkflow.Workflow
+
+// Event topics
+const (
+	TopicFlowStarted   = workflow.TopicFlowStarted
+	TopicFlowCompleted = workflow.TopicFlowCompleted
+	TopicFlowFailed    = workflow.TopicFlowFailed
+)
+
+// Error constants
+const (
+	ErrInvalidFlow        = workflow.ErrInvalidFlow
+	ErrStepFailed         = workflow.ErrStepFailed
+	ErrMappingFailed      = workflow.ErrMappingFailed
+	ErrCompensationFailed = workflow.ErrCompensationFailed
+)
+
+// ConfigKey is the configuration key of the list of flows.
+const ConfigKey = infraWorkflow.ConfigKey
+
+// Factory functions
+var NewWorkflow = infraWorkflow.NewWorkflow
+var Register = infraWorkflow.Register
+var Validate = infraWorkflow.Validate
+var FlowsFromConfig = infraWorkflow.FlowsFromConfig
+var Operations = infraWorkflow.Operations
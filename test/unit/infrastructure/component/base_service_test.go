@@ -2,6 +2,7 @@ package component
 
 import (
 	"testing"
+	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
@@ -58,16 +59,19 @@ func TestBaseServiceLifecycle(t *testing.T) {
 	ctx := infraContext.NewContext()
 
 	// Test start
+	assert.True(t, service.StartedAt().IsZero())
 	err := service.Start(ctx)
 	assert.NoError(t, err)
 	assert.True(t, service.IsRunning())
 	assert.Equal(t, component.StatusRunning, service.Status())
+	assert.WithinDuration(t, time.Now(), service.StartedAt(), time.Second)
 
 	// Test stop
 	err = service.Stop(ctx)
 	assert.NoError(t, err)
 	assert.False(t, service.IsRunning())
 	assert.Equal(t, component.StatusStopped, service.Status())
+	assert.True(t, service.StartedAt().IsZero())
 }
 
 func TestBaseServiceIdempotentOperations(t *testing.T) {
@@ -0,0 +1,56 @@
+package component
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPService(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "pong")
+	})
+	newService := func(id, addr string) *infraComponent.HTTPService {
+		return infraComponent.NewHTTPService(component.ComponentConfig{ID: component.ComponentID(id)}, "ping endpoint", addr, handler)
+	}
+
+	t.Run("Serves the handler until stopped", func(t *testing.T) {
+		service := newService("ping", "127.0.0.1:0")
+		assert.Equal(t, "127.0.0.1:0", service.Addr())
+		require.NoError(t, service.Start(infraContext.NewContext()))
+		assert.True(t, service.IsRunning())
+
+		resp, err := http.Get("http://" + service.Addr() + "/")
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, "pong", string(body))
+
+		require.NoError(t, service.Stop(infraContext.NewContext()))
+		assert.False(t, service.IsRunning())
+		_, err = http.Get("http://" + service.Addr() + "/")
+		assert.Error(t, err)
+
+		// Stopping again is a no-op
+		assert.NoError(t, service.Stop(infraContext.NewContext()))
+	})
+
+	t.Run("An address in use fails to start", func(t *testing.T) {
+		other := newService("other", "127.0.0.1:0")
+		require.NoError(t, other.Start(infraContext.NewContext()))
+		defer other.Stop(infraContext.NewContext())
+
+		conflict := newService("conflict", other.Addr())
+		err := conflict.Start(infraContext.NewContext())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrServiceStartFailed)
+		assert.Contains(t, err.Error(), "ping endpoint on '"+other.Addr()+"'")
+		assert.False(t, conflict.IsRunning())
+	})
+}
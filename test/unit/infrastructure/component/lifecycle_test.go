@@ -0,0 +1,138 @@
+package component
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+)
+
+// lifecycleTopics lists every component lifecycle topic.
+var lifecycleTopics = []string{
+	component.TopicComponentRegistered,
+	component.TopicComponentUnregistered,
+	component.TopicComponentInitialized,
+	component.TopicComponentDisposed,
+	component.TopicServiceStarted,
+	component.TopicServiceStopped,
+	component.TopicServiceFailed,
+}
+
+// eventRecorder records the lifecycle events published on a bus.
+type eventRecorder struct {
+	events []*event.Event
+	mu     sync.Mutex
+}
+
+func newEventRecorder() (*eventRecorder, *infraEvent.EventBus) {
+	recorder := &eventRecorder{}
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	for _, topic := range lifecycleTopics {
+		bus.Subscribe(topic, func(evt *event.Event) {
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			recorder.events = append(recorder.events, evt)
+		})
+	}
+	return recorder, bus
+}
+
+// topics returns the recorded topics in order.
+func (r *eventRecorder) topics() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	topics := make([]string, 0, len(r.events))
+	for _, evt := range r.events {
+		topics = append(topics, evt.Topic)
+	}
+	return topics
+}
+
+// last returns the most recent event.
+func (r *eventRecorder) last() *event.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events[len(r.events)-1]
+}
+
+func TestRegistryLifecycleEvents(t *testing.T) {
+	t.Run("Registered services publish their transitions", func(t *testing.T) {
+		recorder, bus := newEventRecorder()
+		registry := infraComponent.NewRegistry()
+		registry.SetEventBus(bus)
+		service := infraComponent.NewBaseService(component.ComponentConfig{ID: "worker"})
+		ctx := infraContext.NewContext()
+
+		require.NoError(t, registry.Register(service))
+		require.NoError(t, service.Initialize(ctx, nil))
+		require.NoError(t, service.Start(ctx))
+		require.NoError(t, service.Start(ctx)) // already running: no event
+		service.ReportFailure(errors.New("connection lost"))
+		require.NoError(t, service.Start(ctx))
+		require.NoError(t, service.Stop(ctx))
+		require.NoError(t, service.Dispose())
+		require.NoError(t, registry.Unregister("worker"))
+
+		// Unregistered components are no longer observed
+		require.NoError(t, service.Start(ctx))
+
+		assert.Equal(t, []string{
+			component.TopicComponentRegistered,
+			component.TopicComponentInitialized,
+			component.TopicServiceStarted,
+			component.TopicServiceFailed,
+			component.TopicServiceStarted,
+			component.TopicServiceStopped,
+			component.TopicComponentDisposed,
+			component.TopicComponentUnregistered,
+		}, recorder.topics())
+	})
+
+	t.Run("Payloads identify the component", func(t *testing.T) {
+		recorder, bus := newEventRecorder()
+		registry := infraComponent.NewRegistry()
+		registry.SetEventBus(bus)
+		service := infraComponent.NewBaseService(component.ComponentConfig{ID: "worker"})
+		require.NoError(t, registry.Register(service))
+
+		service.ReportFailure(errors.New("connection lost"))
+
+		evt := recorder.last()
+		assert.Equal(t, component.TopicServiceFailed, evt.Topic)
+		assert.Equal(t, "worker", evt.Source)
+		payload := evt.Payload
+		assert.Equal(t, "worker", payload["componentId"])
+		assert.Equal(t, string(component.TypeService), payload["componentType"])
+		assert.Equal(t, "connection lost", payload["error"])
+		assert.Contains(t, payload, "timestamp")
+	})
+
+	t.Run("Components registered before the bus are observed", func(t *testing.T) {
+		recorder, bus := newEventRecorder()
+		registry := infraComponent.NewRegistry()
+		service := infraComponent.NewBaseService(component.ComponentConfig{ID: "worker"})
+		require.NoError(t, registry.Register(service))
+
+		registry.SetEventBus(bus)
+		require.NoError(t, service.Start(infraContext.NewContext()))
+		require.NoError(t, registry.Clear())
+
+		assert.Equal(t, []string{component.TopicServiceStarted, component.TopicComponentUnregistered}, recorder.topics())
+	})
+
+	t.Run("Registries without a bus publish nothing", func(t *testing.T) {
+		registry := infraComponent.NewRegistry()
+		service := infraComponent.NewBaseService(component.ComponentConfig{ID: "worker"})
+		require.NoError(t, registry.Register(service))
+		assert.NoError(t, service.Start(infraContext.NewContext()))
+	})
+}
@@ -41,7 +41,7 @@ func TestRegistryRegister(t *testing.T) {
 	// Test duplicate registration
 	err = registry.Register(mockComponent)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), component.ErrItemAlreadyExists)
+	assert.ErrorIs(t, err, component.ErrItemAlreadyExists)
 
 	mockComponent.AssertExpectations(t)
 }
@@ -52,7 +52,7 @@ func TestRegistryRegisterNilComponent(t *testing.T) {
 
 	err := registry.Register(nil)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), component.ErrInvalidItem)
+	assert.ErrorIs(t, err, component.ErrInvalidItem)
 }
 
 // TestRegistryGet tests component retrieval
@@ -77,7 +77,7 @@ func TestRegistryGet(t *testing.T) {
 	// Test non-existent component
 	_, err = registry.Get("non-existent")
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), component.ErrItemNotFound)
+	assert.ErrorIs(t, err, component.ErrItemNotFound)
 
 	mockComponent.AssertExpectations(t)
 }
@@ -184,7 +184,7 @@ func TestRegistryUnregister(t *testing.T) {
 	// Test unregistering non-existent component
 	err := registry.Unregister(componentID)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), component.ErrItemNotFound)
+	assert.ErrorIs(t, err, component.ErrItemNotFound)
 
 	// Register component
 	registry.Register(mockComponent)
@@ -252,7 +252,7 @@ func TestRegistryFind(t *testing.T) {
 	// Test find with nil predicate
 	_, err = registry.Find(nil)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), component.ErrInvalidItem)
+	assert.ErrorIs(t, err, component.ErrInvalidItem)
 
 	mockComponent1.AssertExpectations(t)
 	mockComponent2.AssertExpectations(t)
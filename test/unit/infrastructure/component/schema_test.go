@@ -0,0 +1,200 @@
+package component
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+var orderSchema = component.MustParseSchema(`{
+	"type": "object",
+	"required": ["symbol", "quantity"],
+	"additionalProperties": false,
+	"properties": {
+		"symbol":   {"type": "string", "pattern": "^[A-Z]{6}$"},
+		"quantity": {"type": "integer", "minimum": 1, "maximum": 1000},
+		"side":     {"enum": ["buy", "sell"]},
+		"tags":     {"type": "array", "maxItems": 2, "items": {"type": "string", "minLength": 1}}
+	}
+}`)
+
+var receiptSchema = component.MustParseSchema(`{
+	"type": "object",
+	"required": ["orderId"],
+	"properties": {"orderId": {"type": "string"}}
+}`)
+
+// newOrderSystem returns a system with an "order" operation declaring schemas,
+// whose output is produced by respond.
+func newOrderSystem(t *testing.T, respond func(input any) any) (*infraComponent.System, *int) {
+	calls := 0
+	operation := infraComponent.NewTypedOperation(
+		component.ComponentConfig{ID: "order", Name: "Order", Description: "Places an order"},
+		func(ctx context.Context, input any) (any, error) {
+			calls++
+			return respond(input), nil
+		},
+	)
+	operation.SetInputSchema(orderSchema)
+	operation.SetOutputSchema(receiptSchema)
+
+	registry := infraComponent.NewRegistry()
+	require.NoError(t, registry.Register(operation))
+	require.NoError(t, registry.Register(infraComponent.NewBaseOperation(component.ComponentConfig{ID: "echo"})))
+	require.NoError(t, registry.Register(infraComponent.NewBaseService(component.ComponentConfig{ID: "worker"})))
+	return infraComponent.NewSystem(registry), &calls
+}
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      any
+		violations string
+	}{
+		{"valid", map[string]interface{}{"symbol": "EURUSD", "quantity": 10.0, "side": "buy"}, ""},
+		{"wrong type", "EURUSD", "$: expected object, got string"},
+		{"missing required", map[string]interface{}{"symbol": "EURUSD"}, "$: missing required property 'quantity'"},
+		{"not an integer", map[string]interface{}{"symbol": "EURUSD", "quantity": 1.5}, "$.quantity: expected integer, got number"},
+		{"below minimum", map[string]interface{}{"symbol": "EURUSD", "quantity": 0.0}, "$.quantity: must be >= 1"},
+		{"pattern", map[string]interface{}{"symbol": "eur", "quantity": 1.0}, "$.symbol: must match pattern '^[A-Z]{6}$'"},
+		{"enum", map[string]interface{}{"symbol": "EURUSD", "quantity": 1.0, "side": "hold"}, `$.side: must be one of ["buy","sell"]`},
+		{"unexpected property", map[string]interface{}{"symbol": "EURUSD", "quantity": 1.0, "note": "x"}, "$: unexpected property 'note'"},
+		{
+			"array items",
+			map[string]interface{}{"symbol": "EURUSD", "quantity": 1.0, "tags": []interface{}{"a", "", "c"}},
+			"$.tags: must have at most 2 items; $.tags[1]: must be at least 1 characters",
+		},
+		{
+			"all violations",
+			map[string]interface{}{"symbol": 1.0, "quantity": 5000.0},
+			"$.quantity: must be <= 1000; $.symbol: expected string, got number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := infraComponent.ValidateSchema(orderSchema, tt.value)
+			if tt.violations == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.violations)
+		})
+	}
+
+	t.Run("Go values are validated by their JSON form", func(t *testing.T) {
+		type order struct {
+			Symbol   string `json:"symbol"`
+			Quantity int    `json:"quantity"`
+		}
+		assert.NoError(t, infraComponent.ValidateSchema(orderSchema, order{Symbol: "EURUSD", Quantity: 3}))
+		assert.EqualError(t, infraComponent.ValidateSchema(orderSchema, order{Symbol: "EURUSD"}), "$.quantity: must be >= 1")
+	})
+
+	t.Run("Nil schemas accept anything", func(t *testing.T) {
+		assert.NoError(t, infraComponent.ValidateSchema(nil, func() {}))
+	})
+}
+
+func TestExecuteValidated(t *testing.T) {
+	ctx := infraContext.NewContext()
+
+	t.Run("Valid input and output pass through", func(t *testing.T) {
+		system, calls := newOrderSystem(t, func(any) any {
+			return map[string]interface{}{"orderId": "o-1"}
+		})
+
+		output, err := system.ExecuteOperation(ctx, "order", component.Input{
+			Data: map[string]interface{}{"symbol": "EURUSD", "quantity": 5.0},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"orderId": "o-1"}, output.Data)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("Invalid input is rejected before Execute", func(t *testing.T) {
+		system, calls := newOrderSystem(t, func(any) any { return nil })
+
+		_, err := system.ExecuteOperation(ctx, "order", component.Input{
+			Data: map[string]interface{}{"symbol": "EURUSD"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, "component.input_validation_failed: operation 'order': $: missing required property 'quantity'", err.Error())
+		assert.Zero(t, *calls)
+	})
+
+	t.Run("Invalid output is rejected after Execute", func(t *testing.T) {
+		system, calls := newOrderSystem(t, func(any) any { return map[string]interface{}{"orderId": 7.0} })
+
+		_, err := system.ExecuteOperation(ctx, "order", component.Input{
+			Data: map[string]interface{}{"symbol": "EURUSD", "quantity": 5.0},
+		})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrOutputValidationFailed)
+		assert.Contains(t, err.Error(), "$.orderId: expected string, got number")
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("Operations without schemas run unchanged", func(t *testing.T) {
+		system, _ := newOrderSystem(t, func(any) any { return nil })
+
+		output, err := system.ExecuteOperation(ctx, "echo", component.Input{Data: "anything"})
+		require.NoError(t, err)
+		assert.Equal(t, "anything", output.Data)
+	})
+}
+
+func TestRegistryOperationSchemas(t *testing.T) {
+	system, _ := newOrderSystem(t, func(any) any { return nil })
+	registry := system.Registry().(component.SchemaRegistry)
+
+	t.Run("Schemas of one operation", func(t *testing.T) {
+		schema, err := registry.OperationSchema("order")
+		require.NoError(t, err)
+		assert.Equal(t, component.ComponentID("order"), schema.OperationID)
+		assert.Equal(t, "Places an order", schema.Description)
+		assert.Same(t, orderSchema, schema.Input)
+		assert.Same(t, receiptSchema, schema.Output)
+	})
+
+	t.Run("Schemas of all operations", func(t *testing.T) {
+		schemas := registry.OperationSchemas()
+		require.Len(t, schemas, 2)
+		assert.Equal(t, component.ComponentID("echo"), schemas[0].OperationID)
+		assert.Nil(t, schemas[0].Input)
+		assert.Equal(t, component.ComponentID("order"), schemas[1].OperationID)
+	})
+
+	t.Run("Non-operations are rejected", func(t *testing.T) {
+		_, err := registry.OperationSchema("worker")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrInvalidComponentType)
+
+		_, err = registry.OperationSchema("missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("Schemas serialize as JSON Schema", func(t *testing.T) {
+		schema, err := registry.OperationSchema("order")
+		require.NoError(t, err)
+
+		encoded, err := json.Marshal(schema.Output)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type":"object","required":["orderId"],"properties":{"orderId":{"type":"string"}}}`, string(encoded))
+	})
+}
+
+func TestParseSchema(t *testing.T) {
+	_, err := component.ParseSchema([]byte(`{"type": 1}`))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrInvalidSchema)
+
+	assert.Panics(t, func() { component.MustParseSchema("{") })
+}
@@ -125,7 +125,7 @@ func TestSystemExecuteOperationInvalidType(t *testing.T) {
 	// Test operation execution with wrong type
 	_, err := system.ExecuteOperation(nil, operationID, input)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), component.ErrInvalidComponentType)
+	assert.ErrorIs(t, err, component.ErrInvalidComponentType)
 
 	mockRegistry.AssertExpectations(t)
 }
@@ -188,7 +188,7 @@ func TestSystemStartServiceInvalidType(t *testing.T) {
 	// Test service start with wrong type
 	err := system.StartService(nil, serviceID)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), component.ErrInvalidComponentType)
+	assert.ErrorIs(t, err, component.ErrInvalidComponentType)
 
 	mockRegistry.AssertExpectations(t)
 }
@@ -251,7 +251,7 @@ func TestSystemStopServiceInvalidType(t *testing.T) {
 	// Test service stop with wrong type
 	err := system.StopService(nil, serviceID)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), component.ErrInvalidComponentType)
+	assert.ErrorIs(t, err, component.ErrInvalidComponentType)
 
 	mockRegistry.AssertExpectations(t)
 }
@@ -0,0 +1,125 @@
+package component
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+type quoteRequest struct {
+	Symbol string  `json:"symbol"`
+	Amount float64 `json:"amount"`
+}
+
+type quote struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price"`
+}
+
+// newQuoteSystem returns a system with a typed "quote" operation registered.
+func newQuoteSystem(t *testing.T) *infraComponent.System {
+	operation := infraComponent.NewTypedOperation(
+		component.ComponentConfig{ID: "quote", Name: "Quote"},
+		func(ctx context.Context, req quoteRequest) (quote, error) {
+			if req.Symbol == "" {
+				return quote{}, errors.New("symbol required")
+			}
+			return quote{Symbol: req.Symbol, Price: req.Amount * 2}, nil
+		},
+	)
+
+	registry := infraComponent.NewRegistry()
+	require.NoError(t, registry.Register(operation))
+	return infraComponent.NewSystem(registry)
+}
+
+func TestTypedOperation(t *testing.T) {
+	ctx := infraContext.NewContext()
+
+	t.Run("Implements Operation", func(t *testing.T) {
+		operation := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "typed"},
+			func(ctx context.Context, in string) (int, error) { return len(in), nil })
+
+		var _ component.Operation = operation
+		assert.Equal(t, component.TypeOperation, operation.Type())
+	})
+
+	t.Run("Executes with typed values", func(t *testing.T) {
+		system := newQuoteSystem(t)
+
+		result, err := infraComponent.Execute[quoteRequest, quote](ctx, system, "quote", quoteRequest{Symbol: "EURUSD", Amount: 10})
+		require.NoError(t, err)
+		assert.Equal(t, "EURUSD", result.Symbol)
+		assert.Equal(t, 20.0, result.Price)
+	})
+
+	t.Run("Decodes map input into structs", func(t *testing.T) {
+		system := newQuoteSystem(t)
+
+		output, err := system.ExecuteOperation(ctx, "quote", component.Input{
+			Data: map[string]interface{}{"symbol": "GBPUSD", "amount": 20.0},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, quote{Symbol: "GBPUSD", Price: 40}, output.Data)
+	})
+
+	t.Run("Accepts pointers to the input type", func(t *testing.T) {
+		system := newQuoteSystem(t)
+
+		result, err := infraComponent.Execute[*quoteRequest, quote](ctx, system, "quote", &quoteRequest{Symbol: "USDJPY"})
+		require.NoError(t, err)
+		assert.Equal(t, "USDJPY", result.Symbol)
+	})
+
+	t.Run("Reports expected and actual input types", func(t *testing.T) {
+		system := newQuoteSystem(t)
+
+		_, err := system.ExecuteOperation(ctx, "quote", component.Input{Data: "EURUSD"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrInvalidInputType)
+		assert.Contains(t, err.Error(), "expected component.quoteRequest, got string")
+	})
+
+	t.Run("Reports expected and actual output types", func(t *testing.T) {
+		system := newQuoteSystem(t)
+
+		_, err := infraComponent.Execute[quoteRequest, []string](ctx, system, "quote", quoteRequest{Symbol: "EURUSD"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrInvalidOutputType)
+		assert.Contains(t, err.Error(), "expected []string, got component.quote")
+	})
+
+	t.Run("Returns operation errors unchanged", func(t *testing.T) {
+		system := newQuoteSystem(t)
+
+		_, err := infraComponent.Execute[quoteRequest, quote](ctx, system, "quote", quoteRequest{})
+		assert.EqualError(t, err, "symbol required")
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("Nil yields the zero value", func(t *testing.T) {
+		value, err := infraComponent.Decode[quote](nil)
+		require.NoError(t, err)
+		assert.Equal(t, quote{}, value)
+	})
+
+	t.Run("JSON numbers convert to integers", func(t *testing.T) {
+		value, err := infraComponent.Decode[int](float64(42))
+		require.NoError(t, err)
+		assert.Equal(t, 42, value)
+	})
+
+	t.Run("Interface types accept any value", func(t *testing.T) {
+		value, err := infraComponent.Decode[any]("raw")
+		require.NoError(t, err)
+		assert.Equal(t, "raw", value)
+	})
+}
@@ -306,7 +306,7 @@ func TestMemoryConfiguration(t *testing.T) {
 		// Test non-existent key
 		value, err := cfg.GetInt("nonexistent")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrConfigKeyNotFound, err.Error())
+		assert.ErrorIs(t, err, config.ErrConfigKeyNotFound)
 		assert.Equal(t, 0, value)
 
 		// Test int value
@@ -337,14 +337,14 @@ func TestMemoryConfiguration(t *testing.T) {
 		cfg.SetValue("invalid_string_key", "not_a_number")
 		value, err = cfg.GetInt("invalid_string_key")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrInvalidConfigType, err.Error())
+		assert.ErrorIs(t, err, config.ErrInvalidConfigType)
 		assert.Equal(t, 0, value)
 
 		// Test invalid type
 		cfg.SetValue("invalid_type_key", []string{"array"})
 		value, err = cfg.GetInt("invalid_type_key")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrInvalidConfigType, err.Error())
+		assert.ErrorIs(t, err, config.ErrInvalidConfigType)
 		assert.Equal(t, 0, value)
 	})
 
@@ -369,7 +369,7 @@ func TestMemoryConfiguration(t *testing.T) {
 		// Test non-existent key
 		value, err := cfg.GetBool("nonexistent")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrConfigKeyNotFound, err.Error())
+		assert.ErrorIs(t, err, config.ErrConfigKeyNotFound)
 		assert.False(t, value)
 
 		// Test bool value
@@ -408,14 +408,14 @@ func TestMemoryConfiguration(t *testing.T) {
 		cfg.SetValue("invalid_string", "maybe")
 		value, err = cfg.GetBool("invalid_string")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrInvalidConfigType, err.Error())
+		assert.ErrorIs(t, err, config.ErrInvalidConfigType)
 		assert.False(t, value)
 
 		// Test invalid type
 		cfg.SetValue("invalid_type", 123)
 		value, err = cfg.GetBool("invalid_type")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrInvalidConfigType, err.Error())
+		assert.ErrorIs(t, err, config.ErrInvalidConfigType)
 		assert.False(t, value)
 	})
 
@@ -441,7 +441,7 @@ func TestMemoryConfiguration(t *testing.T) {
 		// Test non-existent key
 		value, err := cfg.GetDuration("nonexistent")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrConfigKeyNotFound, err.Error())
+		assert.ErrorIs(t, err, config.ErrConfigKeyNotFound)
 		assert.Equal(t, time.Duration(0), value)
 
 		// Test duration value
@@ -478,14 +478,14 @@ func TestMemoryConfiguration(t *testing.T) {
 		cfg.SetValue("invalid_string", "not_a_duration")
 		value, err = cfg.GetDuration("invalid_string")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrInvalidConfigType, err.Error())
+		assert.ErrorIs(t, err, config.ErrInvalidConfigType)
 		assert.Equal(t, time.Duration(0), value)
 
 		// Test invalid type
 		cfg.SetValue("invalid_type", []string{"array"})
 		value, err = cfg.GetDuration("invalid_type")
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrInvalidConfigType, err.Error())
+		assert.ErrorIs(t, err, config.ErrInvalidConfigType)
 		assert.Equal(t, time.Duration(0), value)
 	})
 
@@ -511,13 +511,13 @@ func TestMemoryConfiguration(t *testing.T) {
 		// Test with nil result
 		err := cfg.GetObject("key", nil)
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrInvalidConfigValue, err.Error())
+		assert.ErrorIs(t, err, config.ErrInvalidConfigValue)
 
 		// Test non-existent key
 		var result map[string]interface{}
 		err = cfg.GetObject("nonexistent", &result)
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrConfigKeyNotFound, err.Error())
+		assert.ErrorIs(t, err, config.ErrConfigKeyNotFound)
 
 		// Test valid object
 		testData := map[string]interface{}{
@@ -557,7 +557,7 @@ func TestMemoryConfiguration(t *testing.T) {
 		var invalidResult map[string]interface{}
 		err = cfg.GetObject("invalid_object", &invalidResult)
 		assert.Error(t, err)
-		assert.Equal(t, config.ErrInvalidConfigType, err.Error())
+		assert.ErrorIs(t, err, config.ErrInvalidConfigType)
 	})
 
 	t.Run("Exists", func(t *testing.T) {
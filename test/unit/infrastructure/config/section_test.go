@@ -0,0 +1,131 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/config"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+)
+
+// TestSection tests reading a configuration section with defaults
+func TestSection(t *testing.T) {
+	root := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+		"plugins.pricing.url":     "https://prices.example.com",
+		"plugins.pricing.timeout": "5s",
+		"plugins.other.url":       "https://other.example.com",
+	})
+
+	t.Run("Keys are relative to the section", func(t *testing.T) {
+		section := infraConfig.NewSections(root).Plugin("pricing")
+
+		assert.Equal(t, "plugins.pricing", section.Prefix())
+		assert.Equal(t, "https://prices.example.com", section.GetString("url"))
+		timeout, err := section.GetDuration("timeout")
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, timeout)
+		assert.False(t, section.Exists("other"))
+	})
+
+	t.Run("Defaults fill in missing keys", func(t *testing.T) {
+		section := infraConfig.NewSections(root).Plugin("pricing")
+		section.SetDefault("retries", 3)
+		section.SetDefault("url", "https://default.example.com")
+
+		retries, err := section.GetInt("retries")
+		require.NoError(t, err)
+		assert.Equal(t, 3, retries)
+		assert.True(t, section.Exists("retries"))
+
+		// Configured values win over defaults
+		assert.Equal(t, "https://prices.example.com", section.GetString("url"))
+		assert.Equal(t, "fallback", section.GetStringDefault("missing", "fallback"))
+		_, err = section.GetInt("missing")
+		assert.ErrorIs(t, err, config.ErrConfigKeyNotFound)
+	})
+
+	t.Run("GetObject merges configured values over defaults", func(t *testing.T) {
+		section := infraConfig.NewSections(root).Plugin("pricing")
+		section.SetDefaults(map[string]interface{}{"retries": 3, "url": "https://default.example.com"})
+
+		var settings struct {
+			URL     string `json:"url"`
+			Retries int    `json:"retries"`
+		}
+		require.NoError(t, section.GetObject("", &settings))
+		assert.Equal(t, "https://prices.example.com", settings.URL)
+		assert.Equal(t, 3, settings.Retries)
+	})
+
+	t.Run("Sections are shared per prefix", func(t *testing.T) {
+		sections := infraConfig.NewSections(root)
+		sections.Plugin("pricing").SetDefault("retries", 3)
+
+		assert.Same(t, sections.Plugin("pricing"), sections.Plugin("pricing"))
+		assert.Equal(t, 3, sections.Section("plugins.pricing").GetIntDefault("retries", 0))
+	})
+}
+
+// TestSectionValidation tests validation rules on a section
+func TestSectionValidation(t *testing.T) {
+	root := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+		"plugins.db.driver": "oracle",
+		"plugins.db.pool":   500,
+	})
+
+	t.Run("Rules report every failure", func(t *testing.T) {
+		sections := infraConfig.NewSections(root)
+		section := sections.Plugin("db")
+		section.AddRule("driver", infraConfig.OneOf("postgres", "mysql"))
+		section.AddRule("pool", infraConfig.IntRange(1, 100))
+		section.AddRule("dsn", infraConfig.Required())
+
+		err := sections.Validate()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, config.ErrConfigValidationFailed)
+		assert.Contains(t, err.Error(), "'plugins.db.driver' must be one of postgres, mysql, got 'oracle'")
+		assert.Contains(t, err.Error(), "'plugins.db.pool' must be between 1 and 100, got 500")
+		assert.Contains(t, err.Error(), "'plugins.db.dsn' is required")
+	})
+
+	t.Run("Defaults satisfy rules", func(t *testing.T) {
+		section := infraConfig.NewSections(infraConfig.NewMemoryConfiguration()).Plugin("db")
+		section.SetDefault("dsn", "memory://")
+		section.SetDefault("pool", 10)
+		section.AddRule("dsn", infraConfig.Required())
+		section.AddRule("pool", infraConfig.IntRange(1, 100))
+		section.AddRule("driver", infraConfig.OneOf("postgres"))
+
+		assert.NoError(t, section.Validate())
+	})
+
+	t.Run("Custom rules", func(t *testing.T) {
+		section := infraConfig.NewSections(root).Plugin("db")
+		section.AddRule("driver", func(value interface{}, exists bool) error {
+			assert.True(t, exists)
+			assert.Equal(t, "oracle", value)
+			return nil
+		})
+
+		assert.NoError(t, section.Validate())
+	})
+}
+
+// TestSectionsEffective tests the effective configuration dump
+func TestSectionsEffective(t *testing.T) {
+	root := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+		"app.name":          "payments",
+		"plugins.db.driver": "mysql",
+	})
+	sections := infraConfig.NewSections(root)
+	sections.Plugin("db").SetDefaults(map[string]interface{}{"driver": "postgres", "pool": 10})
+
+	assert.Equal(t, map[string]interface{}{
+		"app.name":          "payments",
+		"plugins.db.driver": "mysql",
+		"plugins.db.pool":   10,
+	}, sections.Effective())
+}
@@ -375,6 +375,37 @@ func TestContextWithValueFromCancelledParent(t *testing.T) {
 	assert.Equal(t, "value", childCtx.Value("key"))
 }
 
+func TestContextWithValueSharesCancellation(t *testing.T) {
+	t.Run("Copies are done when the original is cancelled", func(t *testing.T) {
+		parent := context.WithTimeout(context.NewContext(), 0)
+		child := parent.WithValue("key", "value").WithValue("other", 1)
+
+		parent.Cancel()
+		select {
+		case <-child.Done():
+			assert.Contains(t, child.Err().Error(), domainContext.ErrContextCanceled)
+		case <-time.After(time.Second):
+			t.Fatal("copy was not cancelled with the original")
+		}
+	})
+
+	t.Run("Copies keep the deadline of the original", func(t *testing.T) {
+		parent := context.NewContextWithTimeout(10 * time.Millisecond)
+		child := parent.WithValue("key", "value")
+
+		deadline, ok := child.Deadline()
+		assert.True(t, ok)
+		parentDeadline, _ := parent.Deadline()
+		assert.Equal(t, parentDeadline, deadline)
+		select {
+		case <-child.Done():
+			assert.Contains(t, child.Err().Error(), domainContext.ErrContextDeadlineExceeded)
+		case <-time.After(time.Second):
+			t.Fatal("copy did not expire with the original")
+		}
+	})
+}
+
 func TestWrapContext(t *testing.T) {
 	// Test wrapping with nil
 	ctx := context.WrapContext(nil)
@@ -457,3 +488,50 @@ func BenchmarkContextDeadline(b *testing.B) {
 		_, _ = ctx.Deadline()
 	}
 }
+
+func TestWithTimeout(t *testing.T) {
+	t.Run("Inherits parent values", func(t *testing.T) {
+		parent := context.NewContext().WithValue("request", "r-1")
+		child := context.WithTimeout(parent, time.Second)
+		defer child.Cancel()
+
+		assert.Equal(t, "r-1", child.Value("request"))
+		assert.Equal(t, "r-1", child.WithValue("attempt", 2).Value("request"))
+	})
+
+	t.Run("Expires after the timeout", func(t *testing.T) {
+		child := context.WithTimeout(context.NewContext(), 10*time.Millisecond)
+
+		select {
+		case <-child.Done():
+			assert.Contains(t, child.Err().Error(), domainContext.ErrContextDeadlineExceeded)
+		case <-time.After(time.Second):
+			t.Fatal("child context did not expire")
+		}
+	})
+
+	t.Run("Follows parent cancellation", func(t *testing.T) {
+		parent := context.NewContext()
+		child := context.WithTimeout(parent, 0)
+		parent.Cancel()
+
+		select {
+		case <-child.Done():
+			assert.Contains(t, child.Err().Error(), domainContext.ErrContextCanceled)
+		case <-time.After(time.Second):
+			t.Fatal("child context was not cancelled")
+		}
+		assert.NoError(t, context.NewContext().Err())
+	})
+
+	t.Run("Keeps the earlier parent deadline", func(t *testing.T) {
+		parent := context.NewContextWithTimeout(50 * time.Millisecond)
+		child := context.WithTimeout(parent, time.Hour)
+		defer child.Cancel()
+
+		parentDeadline, _ := parent.Deadline()
+		childDeadline, ok := child.Deadline()
+		assert.True(t, ok)
+		assert.Equal(t, parentDeadline, childDeadline)
+	})
+}
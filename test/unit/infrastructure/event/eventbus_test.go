@@ -1,11 +1,13 @@
 package event
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
 	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
 	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
 	"github.com/fintechain/skeleton/test/unit/mocks"
@@ -190,6 +192,26 @@ func TestEventBusSubscriptionCancel(t *testing.T) {
 	assert.NoError(t, err)
 	time.Sleep(10 * time.Millisecond)
 	assert.Equal(t, 1, callCount) // Should still be 1
+
+	t.Run("Cancelled subscriptions are removed from the bus", func(t *testing.T) {
+		var kept atomic.Int32
+		first := eventBus.Subscribe("test.remove.topic", func(e *event.Event) {})
+		second := eventBus.Subscribe("test.remove.topic", func(e *event.Event) {
+			kept.Add(1)
+		})
+
+		first.Cancel()
+		first.Cancel()
+		assert.Equal(t, 1, eventBus.Subscriptions()["test.remove.topic"])
+
+		err := eventBus.Publish(&event.Event{Topic: "test.remove.topic", Source: "test", Time: time.Now()})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), kept.Load())
+
+		second.Cancel()
+		_, exists := eventBus.Subscriptions()["test.remove.topic"]
+		assert.False(t, exists)
+	})
 }
 
 func TestEventBusLifecycle(t *testing.T) {
@@ -235,3 +257,42 @@ func TestEventBusInitializeAndDispose(t *testing.T) {
 	err = eventBus.Dispose()
 	assert.NoError(t, err)
 }
+
+func TestEventBusPublishContext(t *testing.T) {
+	eventBus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	sc := tracing.SpanContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"}
+	ctx := tracing.ContextWithSpanContext(infraContext.NewContext(), sc)
+
+	var received []*event.Event
+	eventBus.Subscribe("test.topic", func(evt *event.Event) {
+		received = append(received, evt)
+	})
+
+	t.Run("Events join the trace of the context", func(t *testing.T) {
+		received = nil
+		evt := &event.Event{Topic: "test.topic"}
+		assert.NoError(t, eventBus.PublishContext(ctx, evt))
+
+		assert.Len(t, received, 1)
+		assert.Equal(t, tracing.FormatTraceParent(sc), received[0].Metadata[tracing.TraceParentKey])
+		assert.Empty(t, evt.Metadata, "the published event is not modified")
+	})
+
+	t.Run("Events naming a trace keep it", func(t *testing.T) {
+		received = nil
+		explicit := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		evt := &event.Event{Topic: "test.topic", Metadata: map[string]string{tracing.TraceParentKey: explicit}}
+		assert.NoError(t, eventBus.PublishContext(ctx, evt))
+
+		assert.Len(t, received, 1)
+		assert.Equal(t, explicit, received[0].Metadata[tracing.TraceParentKey])
+	})
+
+	t.Run("Contexts without a span publish unchanged", func(t *testing.T) {
+		received = nil
+		assert.NoError(t, eventBus.PublishContext(infraContext.NewContext(), &event.Event{Topic: "test.topic"}))
+
+		assert.Len(t, received, 1)
+		assert.Empty(t, received[0].Metadata)
+	})
+}
@@ -0,0 +1,204 @@
+package health
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraHealth "github.com/fintechain/skeleton/internal/infrastructure/health"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+)
+
+// checkedService is a service with liveness and readiness hooks.
+type checkedService struct {
+	*infraComponent.BaseService
+	healthErr error
+	readyErr  error
+	delay     time.Duration
+	checks    atomic.Int32
+}
+
+func newCheckedService(id string) *checkedService {
+	return &checkedService{
+		BaseService: infraComponent.NewBaseService(component.ComponentConfig{ID: component.ComponentID(id), Name: id}),
+	}
+}
+
+func (s *checkedService) HealthCheck(ctx context.Context) error {
+	s.checks.Add(1)
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return s.healthErr
+}
+
+func (s *checkedService) ReadinessCheck(ctx context.Context) error {
+	return s.readyErr
+}
+
+// newChecker creates an uncached checker over a registry holding the services.
+func newChecker(t *testing.T, options infraHealth.CheckerOptions, services ...component.Service) *infraHealth.Checker {
+	registry := infraComponent.NewRegistry()
+	for _, s := range services {
+		require.NoError(t, registry.Register(s))
+	}
+	if options.CacheTTL == 0 {
+		options.CacheTTL = -1
+	}
+	return infraHealth.NewChecker(registry, options)
+}
+
+// componentReport returns the report of one component.
+func componentReport(t *testing.T, report health.Report, id component.ComponentID) health.ComponentReport {
+	for _, c := range report.Components {
+		if c.ID == id {
+			return c
+		}
+	}
+	t.Fatalf("no report for %s", id)
+	return health.ComponentReport{}
+}
+
+func TestLiveness(t *testing.T) {
+	t.Run("Services without hooks are live unless failed", func(t *testing.T) {
+		plain := infraComponent.NewBaseService(component.ComponentConfig{ID: "plain"})
+		failed := infraComponent.NewBaseService(component.ComponentConfig{ID: "failed"})
+		failed.ReportFailure(errors.New("crashed"))
+		checker := newChecker(t, infraHealth.CheckerOptions{}, plain, failed)
+
+		report := checker.Liveness(infraContext.NewContext())
+
+		assert.Equal(t, health.KindLiveness, report.Kind)
+		assert.Equal(t, health.StatusDown, report.Status)
+		require.Len(t, report.Components, 2)
+		assert.Equal(t, component.ComponentID("failed"), report.Components[0].ID)
+		assert.Equal(t, health.StatusDown, report.Components[0].Status)
+		assert.Contains(t, report.Components[0].Error, health.ErrServiceUnhealthy)
+		assert.Equal(t, health.StatusUp, componentReport(t, report, "plain").Status)
+	})
+
+	t.Run("Health hooks are called", func(t *testing.T) {
+		good := newCheckedService("good")
+		bad := newCheckedService("bad")
+		bad.healthErr = errors.New("disk full")
+		checker := newChecker(t, infraHealth.CheckerOptions{}, good, bad)
+
+		report := checker.Liveness(infraContext.NewContext())
+
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, health.StatusUp, componentReport(t, report, "good").Status)
+		assert.Equal(t, "disk full", componentReport(t, report, "bad").Error)
+	})
+
+	t.Run("Slow checks time out", func(t *testing.T) {
+		slow := newCheckedService("slow")
+		slow.delay = time.Second
+		checker := newChecker(t, infraHealth.CheckerOptions{Timeout: 20 * time.Millisecond}, slow)
+
+		started := time.Now()
+		report := checker.Liveness(infraContext.NewContext())
+
+		assert.Less(t, time.Since(started), 500*time.Millisecond)
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Contains(t, componentReport(t, report, "slow").Error, health.ErrCheckTimeout)
+	})
+
+	t.Run("Panicking checks are down", func(t *testing.T) {
+		checker := newChecker(t, infraHealth.CheckerOptions{})
+		checker.AddCheck("panicky", health.KindLiveness, func(ctx context.Context) error { panic("oops") })
+
+		report := checker.Liveness(infraContext.NewContext())
+
+		assert.Contains(t, componentReport(t, report, "panicky").Error, health.ErrCheckPanicked)
+	})
+
+	t.Run("Results are cached", func(t *testing.T) {
+		svc := newCheckedService("svc")
+		checker := newChecker(t, infraHealth.CheckerOptions{CacheTTL: time.Hour}, svc)
+
+		first := checker.Liveness(infraContext.NewContext())
+		second := checker.Liveness(infraContext.NewContext())
+
+		assert.Equal(t, int32(1), svc.checks.Load())
+		assert.False(t, componentReport(t, first, "svc").Cached)
+		assert.True(t, componentReport(t, second, "svc").Cached)
+
+		checker.SetOptions(infraHealth.CheckerOptions{CacheTTL: -1})
+		checker.Liveness(infraContext.NewContext())
+		assert.Equal(t, int32(2), svc.checks.Load())
+	})
+}
+
+func TestReadiness(t *testing.T) {
+	running := newCheckedService("running")
+	require.NoError(t, running.Start(infraContext.NewContext()))
+	stopped := newCheckedService("stopped")
+	warming := newCheckedService("warming")
+	require.NoError(t, warming.Start(infraContext.NewContext()))
+	warming.readyErr = errors.New("cache warming")
+	checker := newChecker(t, infraHealth.CheckerOptions{}, running, stopped, warming)
+	checker.AddCheck("queue", health.KindReadiness, func(ctx context.Context) error { return nil })
+
+	report := checker.Readiness(infraContext.NewContext())
+
+	assert.Equal(t, health.KindReadiness, report.Kind)
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusUp, componentReport(t, report, "running").Status)
+	assert.Equal(t, health.StatusUp, componentReport(t, report, "queue").Status)
+	assert.Contains(t, componentReport(t, report, "stopped").Error, component.ErrServiceNotRunning)
+	assert.Equal(t, "cache warming", componentReport(t, report, "warming").Error)
+
+	// Standalone checks only apply to their kind
+	for _, c := range checker.Liveness(infraContext.NewContext()).Components {
+		assert.NotEqual(t, component.ComponentID("queue"), c.ID)
+	}
+}
+
+func TestRuntimeHealth(t *testing.T) {
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	rt, err := infraRuntime.NewRuntime(
+		infraComponent.NewRegistry(),
+		infraConfig.NewMemoryConfiguration(),
+		infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}),
+		infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"}),
+		logger,
+	)
+	require.NoError(t, err)
+	rt.Health().(*infraHealth.Checker).SetOptions(infraHealth.CheckerOptions{CacheTTL: -1})
+
+	report := rt.Health().Readiness(infraContext.NewContext())
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Contains(t, componentReport(t, report, infraRuntime.RuntimeID).Error, component.ErrSystemNotStarted)
+
+	require.NoError(t, rt.Start(infraContext.NewContext()))
+	defer rt.Stop(infraContext.NewContext())
+
+	report = rt.Health().Readiness(infraContext.NewContext())
+	assert.Equal(t, health.StatusUp, report.Status)
+	ids := make([]component.ComponentID, 0, len(report.Components))
+	for _, c := range report.Components {
+		ids = append(ids, c.ID)
+	}
+	assert.ElementsMatch(t, []component.ComponentID{
+		"event-bus", "plugin-manager", "logger", infraRuntime.SupervisorID, infraRuntime.RuntimeID,
+	}, ids)
+	assert.Equal(t, health.StatusUp, rt.Health().Liveness(infraContext.NewContext()).Status)
+}
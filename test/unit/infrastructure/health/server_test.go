@@ -0,0 +1,88 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraHealth "github.com/fintechain/skeleton/internal/infrastructure/health"
+)
+
+// get requests path from the handler and decodes the JSON body.
+func get(t *testing.T, handler http.Handler, path string, body interface{}) int {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), body))
+	return rec.Code
+}
+
+func TestHandler(t *testing.T) {
+	svc := newCheckedService("svc")
+	require.NoError(t, svc.Start(infraContext.NewContext()))
+	checker := newChecker(t, infraHealth.CheckerOptions{}, svc)
+	handler := infraHealth.NewHandler(checker)
+
+	t.Run("Up reports are 200", func(t *testing.T) {
+		var report health.Report
+		assert.Equal(t, http.StatusOK, get(t, handler, infraHealth.LivenessPath, &report))
+		assert.Equal(t, health.StatusUp, report.Status)
+		require.Len(t, report.Components, 1)
+		assert.Equal(t, component.ComponentID("svc"), report.Components[0].ID)
+
+		assert.Equal(t, http.StatusOK, get(t, handler, infraHealth.ReadinessPath, &report))
+		assert.Equal(t, health.KindReadiness, report.Kind)
+	})
+
+	t.Run("Down reports are 503", func(t *testing.T) {
+		svc.readyErr = errors.New("warming up")
+		defer func() { svc.readyErr = nil }()
+
+		var report health.Report
+		assert.Equal(t, http.StatusServiceUnavailable, get(t, handler, infraHealth.ReadinessPath, &report))
+		assert.Equal(t, "warming up", report.Components[0].Error)
+
+		var combined struct {
+			Status    health.Status `json:"status"`
+			Liveness  health.Report `json:"liveness"`
+			Readiness health.Report `json:"readiness"`
+		}
+		assert.Equal(t, http.StatusServiceUnavailable, get(t, handler, infraHealth.HealthPath, &combined))
+		assert.Equal(t, health.StatusDown, combined.Status)
+		assert.Equal(t, health.StatusUp, combined.Liveness.Status)
+		assert.Equal(t, health.StatusDown, combined.Readiness.Status)
+	})
+}
+
+func TestServer(t *testing.T) {
+	checker := newChecker(t, infraHealth.CheckerOptions{})
+	server := infraHealth.NewServer(component.ComponentConfig{ID: "health-endpoint"}, "127.0.0.1:0", checker)
+	require.NoError(t, server.Start(infraContext.NewContext()))
+
+	resp, err := http.Get("http://" + server.Addr() + infraHealth.LivenessPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, server.Stop(infraContext.NewContext()))
+	assert.False(t, server.IsRunning())
+	_, err = http.Get("http://" + server.Addr() + infraHealth.LivenessPath)
+	assert.Error(t, err)
+
+	// The address is in use by another listener
+	other := infraHealth.NewServer(component.ComponentConfig{ID: "other"}, "127.0.0.1:0", checker)
+	require.NoError(t, other.Start(infraContext.NewContext()))
+	defer other.Stop(infraContext.NewContext())
+	conflict := infraHealth.NewServer(component.ComponentConfig{ID: "conflict"}, other.Addr(), checker)
+	err = conflict.Start(infraContext.NewContext())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrServiceStartFailed)
+}
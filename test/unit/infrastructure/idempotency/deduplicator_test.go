@@ -0,0 +1,413 @@
+package idempotency
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraIdempotency "github.com/fintechain/skeleton/internal/infrastructure/idempotency"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	"github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+)
+
+type transfer struct {
+	Amount int `json:"amount"`
+}
+
+// countingOperation returns the number of its call, or fails with err.
+type countingOperation struct {
+	*infraComponent.TypedOperation[transfer, string]
+	calls atomic.Int32
+}
+
+func newCountingOperation(properties component.Metadata, err error) *countingOperation {
+	op := &countingOperation{}
+	op.TypedOperation = infraComponent.NewTypedOperation(
+		component.ComponentConfig{ID: "transfer", Name: "Transfer", Properties: properties},
+		func(ctx context.Context, in transfer) (string, error) {
+			n := op.calls.Add(1)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("transfer-%d", n), nil
+		},
+	)
+	return op
+}
+
+func keyed(key string, amount int) component.Input {
+	return component.Input{
+		Data:     transfer{Amount: amount},
+		Metadata: map[string]string{idempotency.MetadataKey: key},
+	}
+}
+
+func execute(ctx context.Context, operation component.Operation, input component.Input) (component.Output, error) {
+	return infraComponent.ExecuteValidated(ctx, operation, input)
+}
+
+var enabled = component.Metadata{"idempotency.ttl": "1h"}
+
+func TestDeduplicatorReplaysResults(t *testing.T) {
+	ctx := infraContext.NewContext()
+
+	t.Run("Replays skip the operation", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, nil)
+
+		first, err := dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		require.NoError(t, err)
+		second, err := dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		require.NoError(t, err)
+
+		assert.Equal(t, "transfer-1", first.Data)
+		assert.Equal(t, first, second)
+		assert.EqualValues(t, 1, op.calls.Load())
+
+		record, err := dedup.Lookup("transfer", "k1")
+		require.NoError(t, err)
+		assert.Equal(t, "transfer-1", record.Output)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), record.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Other keys and calls without a key run", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, nil)
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		output, err := dedup.Execute(ctx, op, keyed("k2", 10), execute)
+		require.NoError(t, err)
+		assert.Equal(t, "transfer-2", output.Data)
+
+		output, err = dedup.Execute(ctx, op, component.Input{Data: transfer{Amount: 10}}, execute)
+		require.NoError(t, err)
+		assert.Equal(t, "transfer-3", output.Data)
+	})
+
+	t.Run("Operations without a policy always run", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(nil, nil)
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		assert.EqualValues(t, 2, op.calls.Load())
+	})
+
+	t.Run("Reusing a key with different input is rejected", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, nil)
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		_, err := dedup.Execute(ctx, op, keyed("k1", 20), execute)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, idempotency.ErrKeyConflict)
+		assert.EqualValues(t, 1, op.calls.Load())
+	})
+
+	t.Run("Errors are replayed", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, errors.New("insufficient funds"))
+
+		_, err := dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		assert.EqualError(t, err, "insufficient funds")
+		_, err = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		assert.EqualError(t, err, "insufficient funds")
+		assert.EqualValues(t, 1, op.calls.Load())
+	})
+
+	t.Run("Replayed errors keep their code", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, failure.New(component.ErrOperationFailed, "insufficient funds"))
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		_, err := dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		assert.ErrorIs(t, err, component.ErrOperationFailed)
+		assert.EqualError(t, err, "component.operation_failed: insufficient funds")
+		assert.EqualValues(t, 1, op.calls.Load())
+	})
+
+	t.Run("Rejected input is not stored", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, nil)
+		invalid := component.Input{Data: "ten", Metadata: map[string]string{idempotency.MetadataKey: "k1"}}
+
+		_, err := dedup.Execute(ctx, op, invalid, execute)
+		require.Error(t, err)
+		_, err = dedup.Lookup("transfer", "k1")
+		assert.ErrorIs(t, err, idempotency.ErrRecordNotFound)
+	})
+
+	t.Run("Cancelled calls are not stored", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := infraComponent.NewTypedOperation(
+			component.ComponentConfig{ID: "transfer", Properties: enabled},
+			func(ctx context.Context, in transfer) (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			},
+		)
+
+		callCtx := infraContext.WithTimeout(infraContext.NewContext(), 10*time.Millisecond)
+		defer callCtx.Cancel()
+		_, err := dedup.Execute(callCtx, op, keyed("k1", 10), execute)
+		require.Error(t, err)
+		_, err = dedup.Lookup("transfer", "k1")
+		assert.ErrorIs(t, err, idempotency.ErrRecordNotFound)
+	})
+
+	t.Run("Calls the breaker rejected are not stored", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, nil)
+		rejected := func(ctx context.Context, operation component.Operation, input component.Input) (component.Output, error) {
+			return component.Output{}, failure.New(resilience.ErrCircuitOpen, "open")
+		}
+
+		_, err := dedup.Execute(ctx, op, keyed("k1", 10), rejected)
+		assert.ErrorIs(t, err, resilience.ErrCircuitOpen)
+		_, err = dedup.Lookup("transfer", "k1")
+		assert.ErrorIs(t, err, idempotency.ErrRecordNotFound)
+	})
+
+	t.Run("Calls the breaker stopped after an attempt are stored", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, errors.New("insufficient funds"))
+		stopped := func(ctx context.Context, operation component.Operation, input component.Input) (component.Output, error) {
+			_, err := infraComponent.ExecuteValidated(ctx, operation, input)
+			return component.Output{}, errors.Join(failure.New(resilience.ErrCircuitOpen, "open"), err)
+		}
+
+		_, err := dedup.Execute(ctx, op, keyed("k1", 10), stopped)
+		assert.ErrorIs(t, err, resilience.ErrCircuitOpen)
+		_, err = dedup.Execute(ctx, op, keyed("k1", 10), stopped)
+		assert.ErrorIs(t, err, resilience.ErrCircuitOpen)
+		assert.EqualValues(t, 1, op.calls.Load(), "the operation ran, so its result is replayed")
+	})
+
+	t.Run("Replays are published", func(t *testing.T) {
+		bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+		var replayed []*event.Event
+		bus.Subscribe(idempotency.TopicResultReplayed, func(evt *event.Event) {
+			replayed = append(replayed, evt)
+		})
+		dedup := infraIdempotency.NewDeduplicator(nil, bus, nil)
+		op := newCountingOperation(enabled, nil)
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		require.Len(t, replayed, 1)
+		assert.Equal(t, map[string]interface{}{"operationId": "transfer", "key": "k1"}, replayed[0].Payload)
+	})
+}
+
+func TestDeduplicatorConcurrentDuplicates(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	op := infraComponent.NewTypedOperation(
+		component.ComponentConfig{ID: "transfer", Properties: enabled},
+		func(ctx context.Context, in transfer) (string, error) {
+			calls.Add(1)
+			<-release
+			return "done", nil
+		},
+	)
+	dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+
+	var wg sync.WaitGroup
+	outputs := make([]component.Output, 5)
+	errs := make([]error, 5)
+	for i := range outputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i], errs[i] = dedup.Execute(infraContext.NewContext(), op, keyed("k1", 10), execute)
+		}(i)
+	}
+
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load())
+	for i := range outputs {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "done", outputs[i].Data)
+	}
+}
+
+// slowStore is a memory store whose reads of one key wait for release.
+type slowStore struct {
+	*memory.Store
+	key     string
+	reading chan struct{}
+	release chan struct{}
+}
+
+func (s *slowStore) Get(key []byte) ([]byte, error) {
+	if string(key) == s.key {
+		close(s.reading)
+		<-s.release
+	}
+	return s.Store.Get(key)
+}
+
+func TestDeduplicatorStoreAccess(t *testing.T) {
+	store := &slowStore{
+		Store:   memory.NewStore("results", ""),
+		key:     "idempotency/transfer/slow",
+		reading: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+	dedup.SetStore(store)
+	op := newCountingOperation(enabled, nil)
+
+	slow := make(chan error, 1)
+	go func() {
+		_, err := dedup.Execute(infraContext.NewContext(), op, keyed("slow", 10), execute)
+		slow <- err
+	}()
+	<-store.reading
+
+	// Another key runs while the store is read for the first one
+	_, err := dedup.Execute(infraContext.NewContext(), op, keyed("fast", 10), execute)
+	require.NoError(t, err)
+	_, err = dedup.Lookup("transfer", "fast")
+	require.NoError(t, err)
+
+	close(store.release)
+	require.NoError(t, <-slow)
+	assert.EqualValues(t, 2, op.calls.Load())
+}
+
+func TestDeduplicatorPolicies(t *testing.T) {
+	ctx := infraContext.NewContext()
+
+	t.Run("Required keys", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(component.Metadata{"idempotency.enabled": true, "idempotency.required": true}, nil)
+
+		_, err := dedup.Execute(ctx, op, component.Input{Data: transfer{Amount: 10}}, execute)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, idempotency.ErrKeyRequired)
+		assert.EqualValues(t, 0, op.calls.Load())
+	})
+
+	t.Run("Configuration overrides properties", func(t *testing.T) {
+		cfg := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+			"idempotency": map[string]interface{}{
+				"operations": map[string]interface{}{
+					"transfer": map[string]interface{}{"enabled": false},
+				},
+			},
+		})
+		dedup := infraIdempotency.NewDeduplicator(cfg, nil, nil)
+		op := newCountingOperation(enabled, nil)
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		assert.EqualValues(t, 2, op.calls.Load())
+	})
+
+	t.Run("Invalid policies are reported", func(t *testing.T) {
+		_, err := infraIdempotency.PolicyFromProperties(component.Metadata{"idempotency.ttl": "soon"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, idempotency.ErrInvalidPolicy)
+
+		policy, err := infraIdempotency.PolicyFromProperties(component.Metadata{"idempotency.enabled": "true"})
+		require.NoError(t, err)
+		assert.Equal(t, infraIdempotency.DefaultTTL, policy.TTL)
+	})
+
+	t.Run("Results expire", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(nil, nil)
+		require.NoError(t, dedup.SetPolicy("transfer", idempotency.Policy{TTL: 5 * time.Millisecond}))
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		_, _ = dedup.Execute(ctx, op, keyed("k2", 10), execute)
+		time.Sleep(10 * time.Millisecond)
+
+		purged, err := dedup.Purge()
+		require.NoError(t, err)
+		assert.Equal(t, 2, purged)
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		assert.EqualValues(t, 3, op.calls.Load())
+	})
+
+	t.Run("Forgotten keys run again", func(t *testing.T) {
+		dedup := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		op := newCountingOperation(enabled, nil)
+
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		require.NoError(t, dedup.Forget("transfer", "k1"))
+		_, _ = dedup.Execute(ctx, op, keyed("k1", 10), execute)
+		assert.EqualValues(t, 2, op.calls.Load())
+
+		err := dedup.Forget("transfer", "unknown")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, idempotency.ErrRecordNotFound)
+	})
+
+	t.Run("Results are kept in the configured store", func(t *testing.T) {
+		store := memory.NewStore("results", "")
+		first := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		first.SetStore(store)
+		second := infraIdempotency.NewDeduplicator(nil, nil, nil)
+		second.SetStore(store)
+		op := newCountingOperation(enabled, nil)
+
+		_, _ = first.Execute(ctx, op, keyed("k1", 10), execute)
+		output, err := second.Execute(ctx, op, keyed("k1", 10), execute)
+		require.NoError(t, err)
+		assert.Equal(t, "transfer-1", output.Data)
+		assert.EqualValues(t, 1, op.calls.Load())
+	})
+}
+
+func TestRuntimeIdempotency(t *testing.T) {
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	rt, err := infraRuntime.NewRuntime(
+		infraComponent.NewRegistry(),
+		infraConfig.NewMemoryConfiguration(),
+		infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}),
+		infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"}),
+		logger,
+	)
+	require.NoError(t, err)
+
+	op := newCountingOperation(enabled, nil)
+	require.NoError(t, rt.Registry().Register(op))
+
+	ctx := infraContext.NewContext()
+	for i := 0; i < 3; i++ {
+		output, err := rt.ExecuteOperation(ctx, "transfer", keyed("payment-42", 10))
+		require.NoError(t, err)
+		assert.Equal(t, "transfer-1", output.Data)
+	}
+	assert.EqualValues(t, 1, op.calls.Load())
+
+	record, err := rt.Idempotency().Lookup("transfer", "payment-42")
+	require.NoError(t, err)
+	assert.Equal(t, "payment-42", record.Key)
+}
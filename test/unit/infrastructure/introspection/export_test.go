@@ -0,0 +1,102 @@
+package introspection
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	infraIntrospection "github.com/fintechain/skeleton/internal/infrastructure/introspection"
+)
+
+// exportTopology is a small system: a plugin owning a service and an
+// operation, depending on another plugin and optionally on a missing one,
+// subscribing to a topic, with a store on the service.
+func exportTopology() introspection.Topology {
+	return introspection.Topology{
+		Components: []introspection.ComponentInfo{
+			{ID: "ledger-stores", Type: component.TypeService, Version: "1.0.0", Plugin: "ledger"},
+			{ID: "transfer", Type: component.TypeOperation, Version: "1.2.0", Plugin: "ledger"},
+		},
+		Plugins: []introspection.PluginInfo{
+			{ID: "db", Version: "2.0.0"},
+			{ID: "ledger", Version: "1.2.0"},
+		},
+		Dependencies: []introspection.Edge{
+			{From: "ledger", To: "audit", Kind: introspection.EdgeDependsOn, Optional: true},
+			{From: "ledger", To: "db", Kind: introspection.EdgeDependsOn, VersionRange: "^2"},
+			{From: "ledger", To: "ledger-stores", Kind: introspection.EdgeOwns},
+			{From: "ledger", To: "transfer", Kind: introspection.EdgeOwns},
+		},
+		Topics: []introspection.TopicInfo{
+			{Topic: `orders."created"`, Subscribers: 1, Plugins: []component.ComponentID{"ledger"}},
+		},
+		Stores: []introspection.StoreInfo{
+			{Name: "accounts", Engine: "memory", MultiStore: "ledger-stores"},
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	t.Run("DOT", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, infraIntrospection.WriteDOT(&out, exportTopology()))
+
+		assert.Equal(t, `digraph topology {
+  rankdir=LR;
+  "db" [label="db\n2.0.0", shape=box];
+  "ledger" [label="ledger\n1.2.0", shape=box];
+  "ledger-stores" [label="ledger-stores\n1.0.0", shape=ellipse];
+  "transfer" [label="transfer\n1.2.0", shape=hexagon];
+  "audit" [label="audit", shape=ellipse];
+  "topic:orders.\"created\"" [label="orders.\"created\"", shape=note];
+  "store:ledger-stores/accounts" [label="accounts (memory)", shape=cylinder];
+  "ledger" -> "audit" [label="depends_on", style=dashed];
+  "ledger" -> "db" [label="depends_on"];
+  "ledger" -> "ledger-stores" [label="owns"];
+  "ledger" -> "transfer" [label="owns"];
+  "ledger" -> "topic:orders.\"created\"" [label="subscribes"];
+  "ledger-stores" -> "store:ledger-stores/accounts" [label="hosts"];
+}
+`, out.String())
+	})
+
+	t.Run("Mermaid", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, infraIntrospection.WriteMermaid(&out, exportTopology()))
+
+		assert.Equal(t, `flowchart LR
+  n0["db<br/>2.0.0"]
+  n1["ledger<br/>1.2.0"]
+  n2(["ledger-stores<br/>1.0.0"])
+  n3{{"transfer<br/>1.2.0"}}
+  n4("audit")
+  n5>"orders.#quot;created#quot;"]
+  n6[("accounts (memory)")]
+  n1 -.->|depends_on| n4
+  n1 -->|depends_on| n0
+  n1 -->|owns| n2
+  n1 -->|owns| n3
+  n1 -->|subscribes| n5
+  n2 -->|hosts| n6
+`, out.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, infraIntrospection.Write(&out, exportTopology(), infraIntrospection.FormatJSON))
+
+		var topology introspection.Topology
+		require.NoError(t, json.Unmarshal(out.Bytes(), &topology))
+		assert.Equal(t, exportTopology().Dependencies, topology.Dependencies)
+	})
+
+	t.Run("Unknown formats are rejected", func(t *testing.T) {
+		err := infraIntrospection.Write(&bytes.Buffer{}, exportTopology(), "svg")
+		assert.ErrorIs(t, err, introspection.ErrUnknownFormat)
+	})
+}
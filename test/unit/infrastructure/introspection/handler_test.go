@@ -0,0 +1,85 @@
+package introspection
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraIntrospection "github.com/fintechain/skeleton/internal/infrastructure/introspection"
+)
+
+func TestHandler(t *testing.T) {
+	registry := infraComponent.NewRegistry()
+	require.NoError(t, registry.Register(infraComponent.NewBaseOperation(component.ComponentConfig{ID: "transfer"})))
+	inspector := infraIntrospection.NewInspector(registry, nil, nil)
+	handler := infraIntrospection.NewHandler(inspector)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	t.Run("Reports are served as JSON", func(t *testing.T) {
+		rec := get(infraIntrospection.ComponentsPath)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var components []introspection.ComponentInfo
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &components))
+		require.Len(t, components, 1)
+		assert.Equal(t, component.ComponentID("transfer"), components[0].ID)
+	})
+
+	t.Run("Empty reports are empty lists", func(t *testing.T) {
+		for _, path := range []string{infraIntrospection.PluginsPath, infraIntrospection.StoresPath, infraIntrospection.BreakersPath} {
+			rec := get(path)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "[]", strings.TrimSpace(rec.Body.String()), path)
+		}
+	})
+
+	t.Run("The topology is served in the requested format", func(t *testing.T) {
+		rec := get(infraIntrospection.TopologyPath)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"components"`)
+
+		rec = get(infraIntrospection.TopologyPath + "?format=dot")
+		assert.Equal(t, "text/vnd.graphviz; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(rec.Body.String(), "digraph topology {"))
+
+		rec = get(infraIntrospection.TopologyPath + "?format=mermaid")
+		assert.True(t, strings.HasPrefix(rec.Body.String(), "flowchart LR"))
+	})
+
+	t.Run("Unknown formats are bad requests", func(t *testing.T) {
+		rec := get(infraIntrospection.TopologyPath + "?format=svg")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, string(introspection.ErrUnknownFormat), body["code"])
+	})
+}
+
+func TestServer(t *testing.T) {
+	server := infraIntrospection.NewServer(component.ComponentConfig{ID: "admin-endpoint"}, "127.0.0.1:0",
+		infraIntrospection.NewInspector(infraComponent.NewRegistry(), nil, nil))
+	ctx := infraContext.NewContext()
+	require.NoError(t, server.Start(ctx))
+	defer server.Stop(ctx)
+
+	resp, err := http.Get("http://" + server.Addr() + infraIntrospection.ServicesPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,272 @@
+package introspection
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/runtime"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraIntrospection "github.com/fintechain/skeleton/internal/infrastructure/introspection"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraResilience "github.com/fintechain/skeleton/internal/infrastructure/resilience"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraStorage "github.com/fintechain/skeleton/internal/infrastructure/storage"
+	memoryStorage "github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+)
+
+// topologyPlugin registers the given components and subscribes to the given
+// topics while initializing.
+type topologyPlugin struct {
+	*infraComponent.BaseService
+	deps       []plugin.Dependency
+	components []component.Component
+	topics     []string
+}
+
+func newTopologyPlugin(id, version string, deps ...plugin.Dependency) *topologyPlugin {
+	return &topologyPlugin{
+		BaseService: infraComponent.NewBaseService(component.ComponentConfig{
+			ID:      component.ComponentID(id),
+			Name:    id,
+			Version: version,
+		}),
+		deps: deps,
+	}
+}
+
+func (p *topologyPlugin) Author() string                    { return "test" }
+func (p *topologyPlugin) PluginType() plugin.PluginType     { return plugin.TypeExtension }
+func (p *topologyPlugin) Dependencies() []plugin.Dependency { return p.deps }
+
+func (p *topologyPlugin) Initialize(ctx context.Context, system component.System) error {
+	if err := p.BaseService.Initialize(ctx, system); err != nil {
+		return err
+	}
+	for _, comp := range p.components {
+		if err := system.Registry().Register(comp); err != nil {
+			return err
+		}
+	}
+	for _, topic := range p.topics {
+		system.(runtime.RuntimeEnvironment).EventBus().Subscribe(topic, func(*event.Event) {})
+	}
+	return nil
+}
+
+func newInspectedRuntime(t *testing.T) (*infraRuntime.Runtime, *infraEvent.EventBus) {
+	registry := infraComponent.NewRegistry()
+	manager := infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"})
+	eventBus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+
+	rt, err := infraRuntime.NewRuntime(registry, infraConfig.NewMemoryConfiguration(), manager, eventBus, logger)
+	require.NoError(t, err)
+	return rt, eventBus
+}
+
+func TestInspector(t *testing.T) {
+	rt, eventBus := newInspectedRuntime(t)
+	ctx := infraContext.NewContext()
+
+	multiStore := infraStorage.NewMultiStore(component.ComponentConfig{ID: "ledger-stores", Version: "1.0.0"}, t.TempDir())
+	require.NoError(t, multiStore.RegisterEngine(memoryStorage.NewEngine()))
+	require.NoError(t, multiStore.CreateStore("accounts", "memory", nil))
+
+	ledger := newTopologyPlugin("ledger", "1.2.0")
+	ledger.components = []component.Component{
+		multiStore,
+		infraComponent.NewBaseOperation(component.ComponentConfig{
+			ID:         "transfer",
+			Name:       "Transfer",
+			Version:    "1.2.0",
+			Properties: map[string]interface{}{"idempotent": true},
+		}),
+	}
+	ledger.topics = []string{"orders.created", "orders.created"}
+
+	reports := newTopologyPlugin("reports", "0.3.0",
+		plugin.Dependency{ID: "ledger", VersionRange: "^1.0"},
+		plugin.Dependency{ID: "audit", Optional: true},
+	)
+	reports.topics = []string{"orders.created"}
+
+	require.NoError(t, rt.LoadPlugins(ctx, []plugin.Plugin{ledger, reports}))
+	require.NoError(t, rt.Start(ctx))
+	defer rt.Stop(ctx)
+
+	eventBus.Subscribe("orders.created", func(*event.Event) {})
+	eventBus.Subscribe("orders.cancelled", func(*event.Event) {}).Cancel()
+
+	inspector := rt.Introspection()
+
+	t.Run("Components report their owning plugin", func(t *testing.T) {
+		byID := make(map[component.ComponentID]introspection.ComponentInfo)
+		for _, info := range inspector.Components() {
+			byID[info.ID] = info
+		}
+
+		assert.Equal(t, introspection.ComponentInfo{
+			ID:       "transfer",
+			Name:     "Transfer",
+			Type:     component.TypeOperation,
+			Version:  "1.2.0",
+			Metadata: component.Metadata{"idempotent": true},
+			Plugin:   "ledger",
+		}, byID["transfer"])
+		assert.Equal(t, component.TypeService, byID["event-bus"].Type)
+		assert.Empty(t, byID["event-bus"].Plugin)
+		assert.Contains(t, byID, component.ComponentID("supervisor"))
+	})
+
+	t.Run("Running services report their uptime", func(t *testing.T) {
+		byID := make(map[component.ComponentID]introspection.ServiceInfo)
+		for _, info := range inspector.Services() {
+			byID[info.ID] = info
+		}
+
+		bus := byID["event-bus"]
+		assert.Equal(t, component.StatusRunning, bus.Status)
+		assert.WithinDuration(t, time.Now(), bus.StartedAt, time.Minute)
+		assert.GreaterOrEqual(t, bus.Uptime, time.Duration(0))
+
+		stores := byID["ledger-stores"]
+		assert.Equal(t, component.ComponentID("ledger"), stores.Plugin)
+		assert.Equal(t, component.StatusStopped, stores.Status)
+		assert.True(t, stores.StartedAt.IsZero())
+		assert.Zero(t, stores.Uptime)
+		assert.NotContains(t, byID, component.ComponentID("transfer"))
+	})
+
+	t.Run("Plugins report what they brought", func(t *testing.T) {
+		plugins := inspector.Plugins()
+		require.Len(t, plugins, 2)
+
+		assert.Equal(t, component.ComponentID("ledger"), plugins[0].ID)
+		assert.Equal(t, "1.2.0", plugins[0].Version)
+		assert.Equal(t, component.StatusRunning, plugins[0].Status)
+		assert.Equal(t, []component.ComponentID{"ledger-stores", "transfer"}, plugins[0].Components)
+		assert.Equal(t, []string{"orders.created"}, plugins[0].Topics)
+
+		assert.Equal(t, component.ComponentID("reports"), plugins[1].ID)
+		assert.Len(t, plugins[1].Dependencies, 2)
+		assert.Empty(t, plugins[1].Components)
+	})
+
+	t.Run("The dependency graph links plugins and owned components", func(t *testing.T) {
+		assert.Equal(t, []introspection.Edge{
+			{From: "ledger", To: "ledger-stores", Kind: introspection.EdgeOwns},
+			{From: "ledger", To: "transfer", Kind: introspection.EdgeOwns},
+			{From: "reports", To: "audit", Kind: introspection.EdgeDependsOn, Optional: true},
+			{From: "reports", To: "ledger", Kind: introspection.EdgeDependsOn, VersionRange: "^1.0"},
+		}, inspector.Dependencies())
+	})
+
+	t.Run("Subscriptions are counted per topic", func(t *testing.T) {
+		topics := make(map[string]introspection.TopicInfo)
+		for _, info := range inspector.Subscriptions() {
+			topics[info.Topic] = info
+		}
+
+		created := topics["orders.created"]
+		assert.Equal(t, 4, created.Subscribers)
+		assert.Equal(t, []component.ComponentID{"ledger", "reports"}, created.Plugins)
+		assert.NotContains(t, topics, "orders.cancelled", "cancelled subscriptions are not reported")
+	})
+
+	t.Run("Stores report their engine capabilities", func(t *testing.T) {
+		stores := inspector.Stores()
+		require.Len(t, stores, 1)
+		assert.Equal(t, "accounts", stores[0].Name)
+		assert.Equal(t, "memory", stores[0].Engine)
+		assert.Equal(t, memoryStorage.NewEngine().Capabilities(), stores[0].Capabilities)
+		assert.Equal(t, component.ComponentID("ledger-stores"), stores[0].MultiStore)
+	})
+
+	t.Run("Unloaded plugins leave the topology", func(t *testing.T) {
+		require.NoError(t, rt.PluginManager().Unload(ctx, "reports"))
+
+		topology := inspector.Topology()
+		require.Len(t, topology.Plugins, 1)
+		for _, edge := range topology.Dependencies {
+			assert.NotEqual(t, component.ComponentID("reports"), edge.From)
+		}
+		for _, topic := range topology.Topics {
+			if topic.Topic == "orders.created" {
+				assert.Equal(t, 3, topic.Subscribers)
+				assert.Equal(t, []component.ComponentID{"ledger"}, topic.Plugins)
+			}
+		}
+		assert.False(t, topology.TakenAt.IsZero())
+	})
+}
+
+func TestInspectorWithoutSources(t *testing.T) {
+	inspector := infraIntrospection.NewInspector(nil, nil, nil)
+	inspector.AddService(infraComponent.NewBaseService(component.ComponentConfig{ID: "worker"}))
+
+	topology := inspector.Topology()
+	require.Len(t, topology.Components, 1)
+	assert.Equal(t, component.ComponentID("worker"), topology.Components[0].ID)
+	require.Len(t, topology.Services, 1)
+	assert.Equal(t, component.StatusStopped, topology.Services[0].Status)
+	assert.Empty(t, topology.Plugins)
+	assert.Empty(t, topology.Dependencies)
+	assert.Empty(t, topology.Topics)
+	assert.Empty(t, topology.Stores)
+	assert.Empty(t, topology.Breakers)
+}
+
+func TestInspectorBreakers(t *testing.T) {
+	errBackend := errors.New("backend unavailable")
+	failing := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "quote"},
+		func(ctx context.Context, input any) (any, error) { return nil, errBackend })
+
+	guard := infraResilience.NewGuard(nil, nil, nil)
+	require.NoError(t, guard.SetPolicy("quote", resilience.Policy{
+		Breaker: resilience.BreakerPolicy{FailureThreshold: 2, OpenTimeout: time.Hour},
+	}))
+
+	ctx := infraContext.NewContext()
+	for i := 0; i < 3; i++ {
+		_, _ = guard.Execute(ctx, failing, component.Input{})
+	}
+
+	inspector := infraIntrospection.NewInspector(nil, nil, nil)
+	assert.Empty(t, inspector.Breakers(), "without a guard")
+
+	inspector.SetResilience(guard)
+
+	t.Run("Breakers report their state and counters", func(t *testing.T) {
+		breakers := inspector.Breakers()
+		require.Len(t, breakers, 1)
+
+		quote := breakers[0]
+		assert.Equal(t, component.ComponentID("quote"), quote.OperationID)
+		assert.Equal(t, resilience.BreakerOpen, quote.State)
+		assert.Equal(t, 2, quote.ConsecutiveFailures)
+		assert.False(t, quote.OpenedAt.IsZero())
+		assert.Equal(t, 2, quote.Calls)
+		assert.Equal(t, 2, quote.Failures)
+		assert.Equal(t, 1, quote.Rejected)
+		assert.Equal(t, errBackend.Error(), quote.LastError)
+	})
+
+	t.Run("The topology includes the breakers", func(t *testing.T) {
+		assert.Equal(t, inspector.Breakers(), inspector.Topology().Breakers)
+	})
+}
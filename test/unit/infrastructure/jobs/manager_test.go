@@ -0,0 +1,347 @@
+package jobs
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/jobs"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraJobs "github.com/fintechain/skeleton/internal/infrastructure/jobs"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraStorage "github.com/fintechain/skeleton/internal/infrastructure/storage"
+	"github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+)
+
+type reportInput struct {
+	Steps int `json:"steps"`
+}
+
+type reportOutput struct {
+	JobID string `json:"jobId"`
+	Steps int    `json:"steps"`
+}
+
+// newEnvironment returns a runtime with the test operations registered and a
+// multi-store backed by the memory engine.
+func newEnvironment(t *testing.T) (*infraRuntime.Runtime, *infraStorage.MultiStore, *infraEvent.EventBus) {
+	t.Helper()
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	rt, err := infraRuntime.NewRuntime(
+		infraComponent.NewRegistry(),
+		infraConfig.NewMemoryConfiguration(),
+		infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}),
+		bus,
+		logger,
+	)
+	require.NoError(t, err)
+
+	report := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "report"},
+		func(ctx context.Context, in reportInput) (reportOutput, error) {
+			id, _ := infraJobs.JobID(ctx)
+			for i := 1; i <= in.Steps; i++ {
+				infraJobs.ReportProgress(ctx, float64(i*100/in.Steps), "step")
+			}
+			return reportOutput{JobID: id, Steps: in.Steps}, nil
+		})
+	block := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "block"},
+		func(ctx context.Context, in any) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	fail := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "fail"},
+		func(ctx context.Context, in any) (any, error) {
+			return nil, errors.New("ledger unavailable")
+		})
+	for _, op := range []component.Component{report, block, fail} {
+		require.NoError(t, rt.Registry().Register(op))
+	}
+
+	multiStore := infraStorage.NewMultiStore(component.ComponentConfig{ID: "multi-store"}, t.TempDir())
+	require.NoError(t, multiStore.RegisterEngine(memory.NewEngine()))
+	return rt, multiStore, bus
+}
+
+func startManager(t *testing.T, rt *infraRuntime.Runtime, multiStore *infraStorage.MultiStore, options infraJobs.Options) *infraJobs.Manager {
+	t.Helper()
+	manager := infraJobs.NewManager(component.ComponentConfig{ID: "jobs"}, multiStore, options)
+	ctx := infraContext.NewContext()
+	require.NoError(t, manager.Initialize(ctx, rt))
+	require.NoError(t, manager.Start(ctx))
+	return manager
+}
+
+// await watches a job until it finishes and returns the updates.
+func await(t *testing.T, manager *infraJobs.Manager, jobID string) []jobs.Job {
+	t.Helper()
+	updates, stop, err := manager.Watch(jobID)
+	require.NoError(t, err)
+	defer stop()
+
+	var seen []jobs.Job
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case job, ok := <-updates:
+			if !ok {
+				require.NotEmpty(t, seen)
+				require.True(t, seen[len(seen)-1].Status.Finished(), "the last update is final")
+				return seen
+			}
+			seen = append(seen, job)
+		case <-timeout:
+			t.Fatalf("job %s did not finish", jobID)
+		}
+	}
+}
+
+func awaitStatus(t *testing.T, manager *infraJobs.Manager, jobID string, status jobs.Status) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		job, err := manager.Get(jobID)
+		return err == nil && job.Status == status
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestManagerRunsJobs(t *testing.T) {
+	rt, multiStore, bus := newEnvironment(t)
+
+	var mu sync.Mutex
+	var topics []string
+	for _, topic := range []string{jobs.TopicJobSubmitted, jobs.TopicJobStarted, jobs.TopicJobProgress, jobs.TopicJobSucceeded} {
+		bus.Subscribe(topic, func(evt *event.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			topics = append(topics, evt.Topic)
+		})
+	}
+
+	manager := startManager(t, rt, multiStore, infraJobs.Options{})
+	defer manager.Stop(infraContext.NewContext())
+
+	jobID, err := manager.Submit(infraContext.NewContext(), "report", component.Input{Data: reportInput{Steps: 2}})
+	require.NoError(t, err)
+
+	updates := await(t, manager, jobID)
+	final := updates[len(updates)-1]
+	assert.Equal(t, jobs.StatusSucceeded, final.Status)
+	assert.Equal(t, 1, final.Attempts)
+	assert.Equal(t, float64(100), final.Progress.Percent)
+	assert.False(t, final.FinishedAt.IsZero())
+
+	output, err := manager.Result(jobID)
+	require.NoError(t, err)
+	result, err := infraComponent.Decode[reportOutput](output.Data)
+	require.NoError(t, err)
+	assert.Equal(t, reportOutput{JobID: jobID, Steps: 2}, result)
+
+	mu.Lock()
+	assert.Equal(t, []string{
+		jobs.TopicJobSubmitted,
+		jobs.TopicJobStarted,
+		jobs.TopicJobProgress,
+		jobs.TopicJobProgress,
+		jobs.TopicJobSucceeded,
+	}, topics)
+	mu.Unlock()
+
+	listed := manager.List()
+	require.Len(t, listed, 1)
+	assert.Equal(t, jobID, listed[0].ID)
+}
+
+func TestManagerFailedJobs(t *testing.T) {
+	rt, multiStore, _ := newEnvironment(t)
+	manager := startManager(t, rt, multiStore, infraJobs.Options{})
+	defer manager.Stop(infraContext.NewContext())
+
+	jobID, err := manager.Submit(infraContext.NewContext(), "fail", component.Input{})
+	require.NoError(t, err)
+	await(t, manager, jobID)
+
+	job, err := manager.Get(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusFailed, job.Status)
+	assert.Equal(t, "ledger unavailable", job.Error)
+
+	_, err = manager.Result(jobID)
+	require.Error(t, err)
+	assert.Equal(t, "jobs.job_failed: job '"+jobID+"': ledger unavailable", err.Error())
+}
+
+func TestManagerCancel(t *testing.T) {
+	rt, multiStore, _ := newEnvironment(t)
+	manager := startManager(t, rt, multiStore, infraJobs.Options{Workers: 1})
+	defer manager.Stop(infraContext.NewContext())
+
+	running, err := manager.Submit(infraContext.NewContext(), "block", component.Input{})
+	require.NoError(t, err)
+	awaitStatus(t, manager, running, jobs.StatusRunning)
+	pending, err := manager.Submit(infraContext.NewContext(), "block", component.Input{})
+	require.NoError(t, err)
+
+	t.Run("Pending jobs never start", func(t *testing.T) {
+		require.NoError(t, manager.Cancel(pending))
+		job, err := manager.Get(pending)
+		require.NoError(t, err)
+		assert.Equal(t, jobs.StatusCancelled, job.Status)
+		assert.Equal(t, 0, job.Attempts)
+	})
+
+	t.Run("Running jobs have their context cancelled", func(t *testing.T) {
+		require.NoError(t, manager.Cancel(running))
+		await(t, manager, running)
+
+		_, err := manager.Result(running)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, jobs.ErrJobCancelled)
+	})
+
+	t.Run("Finished jobs cannot be cancelled", func(t *testing.T) {
+		err := manager.Cancel(running)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, jobs.ErrJobFinished)
+	})
+}
+
+func TestManagerRestart(t *testing.T) {
+	var calls atomic.Int32
+	submitInterrupted := func(t *testing.T) (*infraRuntime.Runtime, *infraStorage.MultiStore, string) {
+		rt, multiStore, _ := newEnvironment(t)
+		resumable := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "resumable"},
+			func(ctx context.Context, in any) (any, error) {
+				if calls.Add(1) == 1 {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+				return "done", nil
+			})
+		require.NoError(t, rt.Registry().Register(resumable))
+
+		manager := startManager(t, rt, multiStore, infraJobs.Options{})
+		jobID, err := manager.Submit(infraContext.NewContext(), "resumable", component.Input{})
+		require.NoError(t, err)
+		awaitStatus(t, manager, jobID, jobs.StatusRunning)
+		require.NoError(t, manager.Stop(infraContext.NewContext()))
+
+		job, err := manager.Get(jobID)
+		require.NoError(t, err)
+		assert.Equal(t, jobs.StatusRunning, job.Status, "interrupted jobs stay running")
+		return rt, multiStore, jobID
+	}
+
+	t.Run("Interrupted jobs are marked failed", func(t *testing.T) {
+		calls.Store(0)
+		rt, multiStore, jobID := submitInterrupted(t)
+
+		manager := startManager(t, rt, multiStore, infraJobs.Options{})
+		defer manager.Stop(infraContext.NewContext())
+
+		job, err := manager.Get(jobID)
+		require.NoError(t, err)
+		assert.Equal(t, jobs.StatusFailed, job.Status)
+		assert.Contains(t, job.Error, jobs.ErrJobInterrupted)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("Interrupted jobs are resumed", func(t *testing.T) {
+		calls.Store(0)
+		rt, multiStore, jobID := submitInterrupted(t)
+
+		manager := startManager(t, rt, multiStore, infraJobs.Options{Resume: true})
+		defer manager.Stop(infraContext.NewContext())
+		await(t, manager, jobID)
+
+		job, err := manager.Get(jobID)
+		require.NoError(t, err)
+		assert.Equal(t, jobs.StatusSucceeded, job.Status)
+		assert.Equal(t, 2, job.Attempts)
+		assert.Equal(t, "done", job.Result)
+	})
+
+	t.Run("Undecodable jobs are skipped", func(t *testing.T) {
+		calls.Store(0)
+		rt, multiStore, jobID := submitInterrupted(t)
+		store, err := multiStore.GetStore(infraJobs.DefaultStore)
+		require.NoError(t, err)
+		require.NoError(t, store.Set([]byte("job/corrupt"), []byte("{")))
+
+		manager := startManager(t, rt, multiStore, infraJobs.Options{})
+		defer manager.Stop(infraContext.NewContext())
+
+		job, err := manager.Get(jobID)
+		require.NoError(t, err)
+		assert.Equal(t, jobs.StatusFailed, job.Status)
+		_, err = manager.Get("corrupt")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+	})
+
+	t.Run("Expired jobs are pruned", func(t *testing.T) {
+		rt, multiStore, _ := newEnvironment(t)
+		manager := startManager(t, rt, multiStore, infraJobs.Options{Retention: time.Millisecond})
+		jobID, err := manager.Submit(infraContext.NewContext(), "fail", component.Input{})
+		require.NoError(t, err)
+		await(t, manager, jobID)
+		require.NoError(t, manager.Stop(infraContext.NewContext()))
+
+		time.Sleep(5 * time.Millisecond)
+		manager = startManager(t, rt, multiStore, infraJobs.Options{Retention: time.Millisecond})
+		defer manager.Stop(infraContext.NewContext())
+
+		_, err = manager.Get(jobID)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+	})
+}
+
+func TestManagerErrors(t *testing.T) {
+	rt, multiStore, _ := newEnvironment(t)
+	manager := infraJobs.NewManager(component.ComponentConfig{ID: "jobs"}, multiStore, infraJobs.Options{})
+	require.NoError(t, manager.Initialize(infraContext.NewContext(), rt))
+
+	t.Run("Submitting requires a running manager", func(t *testing.T) {
+		_, err := manager.Submit(infraContext.NewContext(), "report", component.Input{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrServiceNotRunning)
+	})
+
+	require.NoError(t, manager.Start(infraContext.NewContext()))
+	defer manager.Stop(infraContext.NewContext())
+
+	t.Run("Unknown operations are rejected", func(t *testing.T) {
+		_, err := manager.Submit(infraContext.NewContext(), "missing", component.Input{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrOperationNotFound)
+	})
+
+	t.Run("Unknown jobs are reported", func(t *testing.T) {
+		_, err := manager.Get("missing")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+		_, _, err = manager.Watch("missing")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+		assert.Contains(t, manager.Cancel("missing").Error(), jobs.ErrJobNotFound)
+	})
+
+	t.Run("Results of unfinished jobs are not available", func(t *testing.T) {
+		jobID, err := manager.Submit(infraContext.NewContext(), "block", component.Input{})
+		require.NoError(t, err)
+		_, err = manager.Result(jobID)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, jobs.ErrJobNotFinished)
+	})
+}
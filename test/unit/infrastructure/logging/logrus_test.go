@@ -17,7 +17,7 @@ func TestNewLogrusLogger(t *testing.T) {
 		name        string
 		config      loggingInfra.LogrusConfig
 		expectError bool
-		errorMsg    string
+		errorIs     error
 		description string
 	}{
 		{
@@ -55,7 +55,7 @@ func TestNewLogrusLogger(t *testing.T) {
 				Format: "text",
 			},
 			expectError: true,
-			errorMsg:    logging.ErrInvalidLogLevel,
+			errorIs:     logging.ErrInvalidLogLevel,
 			description: "Should reject invalid log level",
 		},
 		{
@@ -65,7 +65,7 @@ func TestNewLogrusLogger(t *testing.T) {
 				Format: "invalid",
 			},
 			expectError: true,
-			errorMsg:    logging.ErrInvalidLogFormat,
+			errorIs:     logging.ErrInvalidLogFormat,
 			description: "Should reject invalid format",
 		},
 	}
@@ -82,7 +82,7 @@ func TestNewLogrusLogger(t *testing.T) {
 
 			if tt.expectError {
 				assert.Error(t, err)
-				assert.Contains(t, err.Error(), tt.errorMsg)
+				assert.ErrorIs(t, err, tt.errorIs)
 				assert.Nil(t, logger)
 			} else {
 				assert.NoError(t, err)
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
+)
+
+func TestWriteText(t *testing.T) {
+	t.Run("Counters and gauges are written with their labels", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		registry.Counter("calls_total", "Number of calls.").Add(metrics.Labels{"op": "a", "code": "x"}, 2)
+		registry.Gauge("depth", "").Set(nil, 1.5)
+
+		var b strings.Builder
+		require.NoError(t, infraMetrics.WriteText(&b, registry.Snapshot()))
+		assert.Equal(t, strings.Join([]string{
+			"# HELP calls_total Number of calls.",
+			"# TYPE calls_total counter",
+			`calls_total{code="x",op="a"} 2`,
+			"# TYPE depth gauge",
+			"depth 1.5",
+			"",
+		}, "\n"), b.String())
+	})
+
+	t.Run("Histograms end with +Inf, sum and count", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		latency := registry.Histogram("latency_seconds", "Latency.", []float64{0.1, 1})
+		latency.Observe(metrics.Labels{"op": "a"}, 0.05)
+		latency.Observe(metrics.Labels{"op": "a"}, 2)
+
+		var b strings.Builder
+		require.NoError(t, infraMetrics.WriteText(&b, registry.Snapshot()))
+		assert.Equal(t, strings.Join([]string{
+			"# HELP latency_seconds Latency.",
+			"# TYPE latency_seconds histogram",
+			`latency_seconds_bucket{op="a",le="0.1"} 1`,
+			`latency_seconds_bucket{op="a",le="1"} 1`,
+			`latency_seconds_bucket{op="a",le="+Inf"} 2`,
+			`latency_seconds_sum{op="a"} 2.05`,
+			`latency_seconds_count{op="a"} 2`,
+			"",
+		}, "\n"), b.String())
+	})
+
+	t.Run("Help text and label values are escaped", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		registry.Counter("calls_total", "Line one\nback\\slash").Inc(metrics.Labels{"path": "a\"b\\c\nd"})
+
+		var b strings.Builder
+		require.NoError(t, infraMetrics.WriteText(&b, registry.Snapshot()))
+		assert.Contains(t, b.String(), `# HELP calls_total Line one\nback\\slash`)
+		assert.Contains(t, b.String(), `calls_total{path="a\"b\\c\nd"} 1`)
+	})
+}
+
+func TestMetricsServer(t *testing.T) {
+	registry := infraMetrics.NewRegistry()
+	registry.Counter("calls_total", "").Inc(nil)
+
+	t.Run("The handler serves the text format", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		infraMetrics.NewHandler(registry).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, infraMetrics.MetricsPath, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, infraMetrics.ContentType, rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "calls_total 1\n")
+	})
+
+	t.Run("The server listens until stopped", func(t *testing.T) {
+		server := infraMetrics.NewServer(component.ComponentConfig{ID: "metrics-endpoint"}, "127.0.0.1:0", registry)
+		require.NoError(t, server.Start(infraContext.NewContext()))
+
+		resp, err := http.Get("http://" + server.Addr() + infraMetrics.MetricsPath)
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), "# TYPE calls_total counter")
+
+		require.NoError(t, server.Stop(infraContext.NewContext()))
+		assert.False(t, server.IsRunning())
+		_, err = http.Get("http://" + server.Addr() + infraMetrics.MetricsPath)
+		assert.Error(t, err)
+	})
+}
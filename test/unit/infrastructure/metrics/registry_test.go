@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
+)
+
+// family returns the snapshot of the named metric.
+func family(t *testing.T, registry metrics.Registry, name string) metrics.Family {
+	for _, f := range registry.Snapshot() {
+		if f.Name == name {
+			return f
+		}
+	}
+	require.Failf(t, "metric not found", "%s", name)
+	return metrics.Family{}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("Counters sum per label set", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		calls := registry.Counter("calls_total", "Calls.")
+
+		calls.Inc(metrics.Labels{"op": "a", "region": "eu"})
+		calls.Add(metrics.Labels{"region": "eu", "op": "a"}, 2)
+		calls.Inc(metrics.Labels{"op": "b"})
+		calls.Add(metrics.Labels{"op": "b"}, -5)
+
+		f := family(t, registry, "calls_total")
+		assert.Equal(t, metrics.KindCounter, f.Kind)
+		assert.Equal(t, "Calls.", f.Help)
+		require.Len(t, f.Series, 2)
+		assert.Equal(t, metrics.Labels{"op": "a", "region": "eu"}, f.Series[0].Labels)
+		assert.Equal(t, 3.0, f.Series[0].Value)
+		assert.Equal(t, 1.0, f.Series[1].Value)
+	})
+
+	t.Run("Gauges go up and down", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		depth := registry.Gauge("depth", "")
+
+		depth.Set(nil, 5)
+		depth.Add(nil, -2)
+
+		f := family(t, registry, "depth")
+		require.Len(t, f.Series, 1)
+		assert.Nil(t, f.Series[0].Labels)
+		assert.Equal(t, 3.0, f.Series[0].Value)
+	})
+
+	t.Run("Histograms fill cumulative buckets", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		latency := registry.Histogram("latency_seconds", "", []float64{1, 0.1})
+
+		latency.Observe(nil, 0.05)
+		latency.Observe(nil, 0.5)
+		latency.Observe(nil, 5)
+
+		f := family(t, registry, "latency_seconds")
+		require.Len(t, f.Series, 1)
+		s := f.Series[0]
+		assert.Equal(t, uint64(3), s.Count)
+		assert.InDelta(t, 5.55, s.Sum, 1e-9)
+		assert.Equal(t, []metrics.Bucket{{UpperBound: 0.1, Count: 1}, {UpperBound: 1, Count: 2}}, s.Buckets)
+	})
+
+	t.Run("Nil buckets select the default buckets", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		registry.Histogram("latency_seconds", "", nil).Observe(nil, 0.2)
+
+		f := family(t, registry, "latency_seconds")
+		assert.Len(t, f.Series[0].Buckets, len(metrics.DefaultBuckets))
+	})
+
+	t.Run("Creating a metric twice returns the same metric", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		registry.Counter("calls_total", "").Inc(nil)
+		registry.Counter("calls_total", "").Inc(nil)
+
+		assert.Equal(t, 2.0, family(t, registry, "calls_total").Series[0].Value)
+	})
+
+	t.Run("Names of another kind and invalid names panic", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		registry.Counter("calls_total", "")
+
+		assertPanicsWith(t, metrics.ErrKindConflict, func() { registry.Gauge("calls_total", "") })
+		assertPanicsWith(t, metrics.ErrInvalidName, func() { registry.Counter("calls-total", "") })
+	})
+
+	t.Run("Snapshots are ordered by name and run collectors", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		registry.Counter("b_total", "")
+		up := registry.Gauge("a_up", "")
+		registry.AddCollector(func() { up.Set(nil, 1) })
+
+		families := registry.Snapshot()
+		require.Len(t, families, 2)
+		assert.Equal(t, "a_up", families[0].Name)
+		assert.Equal(t, 1.0, families[0].Series[0].Value)
+		assert.Equal(t, "b_total", families[1].Name)
+	})
+
+	t.Run("Concurrent updates are not lost", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		calls := registry.Counter("calls_total", "")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					calls.Inc(metrics.Labels{"op": "a"})
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 5000.0, family(t, registry, "calls_total").Series[0].Value)
+	})
+}
+
+// assertPanicsWith asserts that fn panics with an error matching target.
+func assertPanicsWith(t *testing.T, target error, fn func()) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		require.NotNil(t, r, "expected a panic")
+		err, ok := r.(error)
+		require.True(t, ok, "expected an error, got %v", r)
+		assert.True(t, errors.Is(err, target), "expected %v, got %v", target, err)
+	}()
+	fn()
+}
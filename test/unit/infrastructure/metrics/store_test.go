@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
+	infraStorage "github.com/fintechain/skeleton/internal/infrastructure/storage"
+	memoryStorage "github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+	"github.com/fintechain/skeleton/test/unit/mocks"
+)
+
+func TestInstrumentStore(t *testing.T) {
+	t.Run("Gets and sets are counted per store", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		store := infraMetrics.InstrumentStore(memoryStorage.NewStore("ledger", ""), registry)
+
+		require.NoError(t, store.Set([]byte("k"), []byte("v")))
+		_, err := store.Get([]byte("k"))
+		require.NoError(t, err)
+		_, err = store.Get([]byte("missing"))
+		assert.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+		labels := metrics.Labels{"store": "ledger"}
+		assert.Equal(t, 2.0, family(t, registry, metrics.StoreGets).Series[0].Value)
+		assert.Equal(t, 1.0, family(t, registry, metrics.StoreSets).Series[0].Value)
+		assert.Equal(t, labels, family(t, registry, metrics.StoreSets).Series[0].Labels)
+	})
+
+	t.Run("Transactional stores stay transactional", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		tx := mocks.NewMockTransaction(t)
+		tx.EXPECT().Set([]byte("k"), []byte("v")).Return(nil)
+		inner := &transactionalStore{Store: memoryStorage.NewStore("ledger", ""), tx: tx}
+
+		store := infraMetrics.InstrumentStore(inner, registry)
+		transactional, ok := store.(storage.Transactional)
+		require.True(t, ok)
+		assert.True(t, transactional.SupportsTransactions())
+
+		began, err := transactional.BeginTx()
+		require.NoError(t, err)
+		require.NoError(t, began.Set([]byte("k"), []byte("v")))
+		assert.Equal(t, 1.0, family(t, registry, metrics.StoreSets).Series[0].Value)
+
+		plain := infraMetrics.InstrumentStore(memoryStorage.NewStore("cache", ""), registry)
+		_, ok = plain.(storage.Transactional)
+		assert.False(t, ok)
+	})
+
+	t.Run("The multistore instruments the stores it returns", func(t *testing.T) {
+		registry := infraMetrics.NewRegistry()
+		multiStore := infraStorage.NewMultiStore(component.ComponentConfig{ID: "multi-store"}, t.TempDir())
+		require.NoError(t, multiStore.RegisterEngine(memoryStorage.NewEngine()))
+		require.NoError(t, multiStore.CreateStore("ledger", "memory", nil))
+		multiStore.SetMetrics(registry)
+
+		store, err := multiStore.GetStore("ledger")
+		require.NoError(t, err)
+		require.NoError(t, store.Set([]byte("k"), []byte("v")))
+		assert.Equal(t, metrics.Labels{"store": "ledger"}, family(t, registry, metrics.StoreSets).Series[0].Labels)
+	})
+}
+
+// transactionalStore is a memory store that begins the given transaction.
+type transactionalStore struct {
+	*memoryStorage.Store
+	tx storage.Transaction
+}
+
+func (s *transactionalStore) BeginTx() (storage.Transaction, error) { return s.tx, nil }
+func (s *transactionalStore) SupportsTransactions() bool            { return true }
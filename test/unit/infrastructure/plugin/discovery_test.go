@@ -0,0 +1,122 @@
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	"github.com/fintechain/skeleton/internal/infrastructure/plugin/remote"
+)
+
+// recordingLogger records the messages it receives.
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.record("DEBUG", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...interface{})  { l.record("INFO", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...interface{})  { l.record("WARN", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.record("ERROR", msg, args) }
+
+func (l *recordingLogger) record(level, msg string, args []interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf("%s %s %v", level, msg, args))
+}
+
+// processManifest returns a manifest for a process plugin with the given ID.
+func processManifest(id string, extra string) string {
+	return fmt.Sprintf(`{
+		"id": %q,
+		"version": "1.0.0",
+		"entrypoint": {"kind": "process", "path": "bin/plugin"}%s
+	}`, id, extra)
+}
+
+func TestDiscoveryLoadsProcessPlugins(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "pricing", processManifest("pricing-plugin",
+		`, "dependencies": [{"id": "database-plugin", "version": "^1.0"}], "required_config": ["pricing.url"]`))
+	writeManifest(t, dir, "audit", processManifest("audit-plugin", ""))
+
+	cfg := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{"pricing.url": "http://pricing"})
+	result := infraPlugin.NewDiscovery([]string{dir}, cfg, nil, nil).Discover()
+
+	require.NoError(t, result.Err())
+	require.Len(t, result.Plugins, 2)
+	require.Len(t, result.Manifests, 2)
+
+	// Subdirectories are scanned in name order.
+	assert.Equal(t, component.ComponentID("audit-plugin"), result.Plugins[0].ID())
+	assert.Equal(t, component.ComponentID("pricing-plugin"), result.Plugins[1].ID())
+
+	pricing, ok := result.Plugins[1].(*remote.ProcessPlugin)
+	require.True(t, ok)
+	assert.Equal(t, "1.0.0", pricing.Version())
+	assert.Equal(t, []plugin.Dependency{{ID: "database-plugin", VersionRange: "^1.0"}}, pricing.Dependencies())
+}
+
+func TestDiscoveryReportsFailures(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, dir, "a-valid", processManifest("valid-plugin", ""))
+	writeManifest(t, dir, "b-duplicate", processManifest("valid-plugin", ""))
+	writeManifest(t, dir, "c-invalid", `{"id": "invalid-plugin"}`)
+	writeManifest(t, dir, "d-config", processManifest("config-plugin", `, "required_config": ["config.url", "config.token"]`))
+	writeManifest(t, dir, "e-native", `{
+		"id": "native-plugin",
+		"version": "1.0.0",
+		"entrypoint": {"kind": "native", "path": "missing.so"}
+	}`)
+	missingDir := filepath.Join(dir, "does-not-exist")
+
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event_bus"})
+	var discovered, failed []*event.Event
+	bus.Subscribe(plugin.TopicPluginDiscovered, func(e *event.Event) { discovered = append(discovered, e) })
+	bus.Subscribe(plugin.TopicPluginDiscoveryFailed, func(e *event.Event) { failed = append(failed, e) })
+	logger := &recordingLogger{}
+
+	discovery := infraPlugin.NewDiscovery([]string{dir, missingDir}, infraConfig.NewMemoryConfiguration(), logger, bus)
+	result := discovery.Discover()
+
+	require.Len(t, result.Plugins, 1)
+	assert.Equal(t, component.ComponentID("valid-plugin"), result.Plugins[0].ID())
+
+	require.Len(t, result.Failures, 5)
+	assert.Equal(t, component.ComponentID("valid-plugin"), result.Failures[0].PluginID)
+	assert.Contains(t, result.Failures[0].Err.Error(), "is also declared in")
+	assert.Contains(t, result.Failures[1].Err.Error(), "entrypoint.kind is required")
+	assert.Contains(t, result.Failures[2].Err.Error(), "requires configuration config.url, config.token")
+	assert.Contains(t, result.Failures[3].Err.Error(), component.ErrPluginLoadFailed)
+	assert.Contains(t, result.Failures[3].Err.Error(), "cannot open native plugin")
+	assert.Equal(t, missingDir, result.Failures[4].Path)
+
+	err := result.Err()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrPluginDiscoveryFailed)
+
+	require.Len(t, discovered, 1)
+	assert.Equal(t, "valid-plugin", discovered[0].Payload["pluginId"])
+	assert.Equal(t, infraPlugin.DiscoverySource, discovered[0].Source)
+
+	require.Len(t, failed, 5)
+	assert.Equal(t, "config-plugin", failed[2].Payload["pluginId"])
+	assert.Contains(t, failed[2].Payload["error"], "config.url")
+
+	assert.Len(t, logger.messages, 6)
+	assert.Contains(t, logger.messages[0], "INFO Plugin discovered")
+	assert.Contains(t, logger.messages[1], "ERROR Plugin discovery failed")
+}
+
+func TestDiscoveryWithoutDirectories(t *testing.T) {
+	result := infraPlugin.NewDiscovery(nil, nil, nil, nil).Discover()
+
+	assert.Empty(t, result.Plugins)
+	assert.NoError(t, result.Err())
+}
@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+)
+
+// failingStartPlugin fails to start.
+type failingStartPlugin struct {
+	*registeringPlugin
+}
+
+func (p *failingStartPlugin) Start(ctx context.Context) error {
+	return errors.New("port in use")
+}
+
+// recordLifecycle records the lifecycle events of components whose ID starts
+// with prefix, as "<componentId> <topic>".
+func recordLifecycle(bus event.EventBus, prefix string) func() []string {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	for _, topic := range []string{
+		plugin.TopicPluginLoaded, plugin.TopicPluginStarted, plugin.TopicPluginStopped,
+		plugin.TopicPluginUnloaded, plugin.TopicPluginFailed,
+		component.TopicComponentRegistered, component.TopicComponentUnregistered,
+		component.TopicServiceStarted, component.TopicServiceStopped,
+	} {
+		bus.Subscribe(topic, func(evt *event.Event) {
+			id, _ := evt.Payload["componentId"].(string)
+			if !strings.HasPrefix(id, prefix) {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, id+" "+evt.Topic)
+		})
+	}
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), events...)
+	}
+}
+
+func TestPluginManagerLifecycleEvents(t *testing.T) {
+	t.Run("Plugin and component transitions are published", func(t *testing.T) {
+		rt, manager, _, eventBus := newTestRuntime(t)
+		ctx := infraContext.NewContext()
+		events := recordLifecycle(eventBus, "alpha")
+
+		require.NoError(t, rt.LoadPlugins(ctx, []plugin.Plugin{newRegisteringPlugin("alpha")}))
+		require.NoError(t, rt.Start(ctx))
+		require.NoError(t, manager.Unload(ctx, "alpha"))
+
+		assert.Equal(t, []string{
+			"alpha-service component.registered",
+			"alpha-operation component.registered",
+			"alpha plugin.loaded",
+			"alpha-service service.started",
+			"alpha plugin.started",
+			"alpha plugin.stopped",
+			"alpha-operation component.unregistered",
+			"alpha-service service.stopped",
+			"alpha-service component.unregistered",
+			"alpha plugin.unloaded",
+		}, events())
+	})
+
+	t.Run("Failures carry the error and action", func(t *testing.T) {
+		rt, _, _, eventBus := newTestRuntime(t)
+		ctx := infraContext.NewContext()
+
+		var failed *event.Event
+		eventBus.Subscribe(plugin.TopicPluginFailed, func(evt *event.Event) { failed = evt })
+
+		p := &failingStartPlugin{newRegisteringPlugin("beta")}
+		require.NoError(t, rt.LoadPlugins(ctx, []plugin.Plugin{p}))
+		require.Error(t, rt.Start(ctx))
+
+		require.NotNil(t, failed)
+		assert.Equal(t, "plugin-manager", failed.Source)
+		assert.Equal(t, "beta", failed.Payload["componentId"])
+		assert.Equal(t, string(plugin.TypePlugin), failed.Payload["componentType"])
+		assert.Equal(t, "start", failed.Payload["action"])
+		assert.Equal(t, "port in use", failed.Payload["error"])
+	})
+}
@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
@@ -117,6 +118,10 @@ func TestPluginManagerRemove(t *testing.T) {
 	factory := mocks.NewFactory()
 	mockPlugin := factory.PluginInterface()
 
+	// Removing a plugin unloads it: stop if running, then dispose
+	mockPlugin.On("IsRunning").Return(false)
+	mockPlugin.On("Dispose").Return(nil)
+
 	// Add plugin first
 	err := manager.Add("test-plugin", mockPlugin)
 	assert.NoError(t, err)
@@ -124,6 +129,7 @@ func TestPluginManagerRemove(t *testing.T) {
 	// Test removing existing plugin
 	err = manager.Remove("test-plugin")
 	assert.NoError(t, err)
+	mockPlugin.AssertExpectations(t)
 
 	// Verify plugin is removed
 	_, err = manager.GetPlugin("test-plugin")
@@ -209,3 +215,164 @@ func TestPluginManagerInitializeAndDispose(t *testing.T) {
 	err = manager.Dispose()
 	assert.NoError(t, err)
 }
+
+func TestPluginManagerDependencyOrder(t *testing.T) {
+	config := component.ComponentConfig{
+		ID:   "plugin-manager",
+		Name: "Plugin Manager",
+		Type: component.TypeService,
+	}
+
+	var log []string
+	manager := infraPlugin.NewManager(config)
+	factory := mocks.NewFactory()
+	ctx := infraContext.NewContext()
+
+	assert.NoError(t, manager.Add("web", newTestPlugin("web", "1.0.0", &log, plugin.Dependency{ID: "db", VersionRange: "^1.0"})))
+	assert.NoError(t, manager.Add("db", newTestPlugin("db", "1.2.0", &log)))
+
+	assert.NoError(t, manager.Initialize(ctx, factory.SystemInterface()))
+	assert.NoError(t, manager.Start(ctx))
+	assert.NoError(t, manager.Stop(ctx))
+
+	assert.Equal(t, []string{
+		"init:db", "init:web",
+		"start:db", "start:web",
+		"stop:web", "stop:db",
+	}, log)
+}
+
+func TestPluginManagerRefusesUnresolvedDependencies(t *testing.T) {
+	config := component.ComponentConfig{
+		ID:   "plugin-manager",
+		Name: "Plugin Manager",
+		Type: component.TypeService,
+	}
+
+	var log []string
+	manager := infraPlugin.NewManager(config)
+	factory := mocks.NewFactory()
+	ctx := infraContext.NewContext()
+
+	assert.NoError(t, manager.Add("web", newTestPlugin("web", "1.0.0", &log, plugin.Dependency{ID: "db", VersionRange: "^2.0"})))
+	assert.NoError(t, manager.Add("db", newTestPlugin("db", "1.2.0", &log)))
+
+	err := manager.Initialize(ctx, factory.SystemInterface())
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrPluginLoadFailed)
+
+	err = manager.Start(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "plugin 'web' requires 'db' ^2.0, found 1.2.0")
+	assert.False(t, manager.IsRunning())
+	assert.Empty(t, log)
+}
+
+func TestPluginManagerStartRollback(t *testing.T) {
+	config := component.ComponentConfig{
+		ID:   "plugin-manager",
+		Name: "Plugin Manager",
+		Type: component.TypeService,
+	}
+
+	t.Run("started plugins are stopped in reverse order", func(t *testing.T) {
+		var log []string
+		manager := infraPlugin.NewManager(config)
+		ctx := infraContext.NewContext()
+
+		a := newTestPlugin("a", "1.0.0", &log)
+		b := newTestPlugin("b", "1.0.0", &log)
+		c := newTestPlugin("c", "1.0.0", &log)
+		c.startErr = errors.New("boom")
+		assert.NoError(t, manager.Add("a", a))
+		assert.NoError(t, manager.Add("b", b))
+		assert.NoError(t, manager.Add("c", c))
+
+		err := manager.Start(ctx)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrServiceStartFailed)
+		assert.Contains(t, err.Error(), "plugin 'c' failed to start: boom")
+
+		assert.Equal(t, []string{"start:a", "start:b", "start:c", "stop:b", "stop:a"}, log)
+		assert.False(t, a.IsRunning())
+		assert.False(t, b.IsRunning())
+		assert.False(t, manager.IsRunning())
+		assert.Equal(t, component.StatusError, manager.Status())
+	})
+
+	t.Run("rollback failures are joined with the start failure", func(t *testing.T) {
+		var log []string
+		manager := infraPlugin.NewManager(config)
+		ctx := infraContext.NewContext()
+
+		startErr := errors.New("start failed")
+		stopErr := errors.New("stop failed")
+
+		a := newTestPlugin("a", "1.0.0", &log)
+		a.stopErr = stopErr
+		b := newTestPlugin("b", "1.0.0", &log)
+		b.startErr = startErr
+		assert.NoError(t, manager.Add("a", a))
+		assert.NoError(t, manager.Add("b", b))
+
+		err := manager.Start(ctx)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, startErr)
+		assert.ErrorIs(t, err, stopErr)
+		assert.Contains(t, err.Error(), "rollback of plugin 'a' failed")
+		assert.Equal(t, component.StatusError, manager.Status())
+	})
+}
+
+func TestPluginManagerStartStop(t *testing.T) {
+	config := component.ComponentConfig{
+		ID:   "plugin-manager",
+		Name: "Plugin Manager",
+		Type: component.TypeService,
+	}
+
+	t.Run("Running plugins are skipped and left running on rollback", func(t *testing.T) {
+		var log []string
+		manager := infraPlugin.NewManager(config)
+		ctx := infraContext.NewContext()
+
+		a := newTestPlugin("a", "1.0.0", &log)
+		b := newTestPlugin("b", "1.0.0", &log)
+		b.startErr = errors.New("boom")
+		assert.NoError(t, manager.Add("a", a))
+		assert.NoError(t, manager.Add("b", b))
+		assert.NoError(t, manager.StartPlugin(ctx, "a"))
+
+		err := manager.Start(ctx)
+		assert.Error(t, err)
+
+		assert.Equal(t, []string{"start:a", "start:b"}, log)
+		assert.True(t, a.IsRunning())
+	})
+
+	t.Run("Every plugin is stopped even when some fail", func(t *testing.T) {
+		var log []string
+		manager := infraPlugin.NewManager(config)
+		ctx := infraContext.NewContext()
+
+		stopErr := errors.New("stop failed")
+		a := newTestPlugin("a", "1.0.0", &log)
+		b := newTestPlugin("b", "1.0.0", &log)
+		b.stopErr = stopErr
+		c := newTestPlugin("c", "1.0.0", &log)
+		assert.NoError(t, manager.Add("a", a))
+		assert.NoError(t, manager.Add("b", b))
+		assert.NoError(t, manager.Add("c", c))
+		assert.NoError(t, manager.Start(ctx))
+
+		err := manager.Stop(ctx)
+		assert.ErrorIs(t, err, stopErr)
+		assert.ErrorIs(t, err, component.ErrServiceStopFailed)
+		assert.Contains(t, err.Error(), "plugin 'b' failed to stop")
+
+		assert.Equal(t, []string{"start:a", "start:b", "start:c", "stop:c", "stop:b", "stop:a"}, log)
+		assert.False(t, a.IsRunning())
+		assert.False(t, c.IsRunning())
+		assert.False(t, manager.IsRunning())
+	})
+}
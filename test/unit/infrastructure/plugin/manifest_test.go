@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+)
+
+// writeManifest writes a plugin.json into dir/name and returns its path.
+func writeManifest(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	pluginDir := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+	path := filepath.Join(pluginDir, infraPlugin.ManifestFileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadManifest(t *testing.T) {
+	path := writeManifest(t, t.TempDir(), "pricing", `{
+		"id": "pricing-plugin",
+		"name": "Pricing",
+		"version": "1.2.0",
+		"type": "processor",
+		"author": "Payments Team",
+		"entrypoint": {"kind": "process", "path": "bin/pricing", "args": ["--fast"], "transport": "unix"},
+		"dependencies": [
+			{"id": "database-plugin", "version": "^1.0.0"},
+			{"id": "cache-plugin", "optional": true}
+		],
+		"required_config": ["pricing.api_url"]
+	}`)
+
+	m, err := infraPlugin.LoadManifest(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pricing-plugin", m.ID)
+	assert.Equal(t, "1.2.0", m.Version)
+	assert.Equal(t, plugin.TypeProcessor, m.Type)
+	assert.Equal(t, "Payments Team", m.Author)
+	assert.Equal(t, infraPlugin.EntrypointProcess, m.Entrypoint.Kind)
+	assert.Equal(t, []string{"--fast"}, m.Entrypoint.Args)
+	assert.Equal(t, []string{"pricing.api_url"}, m.RequiredConfig)
+	assert.Equal(t, filepath.Dir(path), m.Dir)
+	assert.Equal(t, filepath.Join(filepath.Dir(path), "bin", "pricing"), m.EntrypointPath())
+
+	assert.Equal(t, []plugin.Dependency{
+		{ID: "database-plugin", VersionRange: "^1.0.0"},
+		{ID: "cache-plugin", Optional: true},
+	}, m.PluginDependencies())
+}
+
+func TestLoadManifestErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := infraPlugin.LoadManifest(filepath.Join(dir, "missing", infraPlugin.ManifestFileName))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrPluginDiscoveryFailed)
+
+	path := writeManifest(t, dir, "broken", `{"id": `)
+	_, err = infraPlugin.LoadManifest(path)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrPluginDiscoveryFailed)
+}
+
+func TestManifestValidate(t *testing.T) {
+	valid := func() *infraPlugin.Manifest {
+		return &infraPlugin.Manifest{
+			ID:         "pricing-plugin",
+			Version:    "1.0.0",
+			Entrypoint: infraPlugin.Entrypoint{Kind: infraPlugin.EntrypointNative, Path: "pricing.so"},
+		}
+	}
+
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name    string
+		modify  func(m *infraPlugin.Manifest)
+		problem string
+	}{
+		{"missing id", func(m *infraPlugin.Manifest) { m.ID = "" }, "id is required"},
+		{"missing version", func(m *infraPlugin.Manifest) { m.Version = "" }, "version is required"},
+		{"invalid version", func(m *infraPlugin.Manifest) { m.Version = "one" }, "not a semantic version"},
+		{"unknown type", func(m *infraPlugin.Manifest) { m.Type = "widget" }, "unknown plugin type 'widget'"},
+		{"missing kind", func(m *infraPlugin.Manifest) { m.Entrypoint.Kind = "" }, "entrypoint.kind is required"},
+		{"unknown kind", func(m *infraPlugin.Manifest) { m.Entrypoint.Kind = "wasm" }, "unknown entrypoint kind 'wasm'"},
+		{"missing path", func(m *infraPlugin.Manifest) { m.Entrypoint.Path = "" }, "entrypoint.path is required"},
+		{"unknown transport", func(m *infraPlugin.Manifest) { m.Entrypoint.Transport = "tcp" }, "unknown entrypoint transport 'tcp'"},
+		{"dependency without id", func(m *infraPlugin.Manifest) {
+			m.Dependencies = []infraPlugin.ManifestDependency{{Version: "^1.0"}}
+		}, "dependencies[0].id is required"},
+		{"self dependency", func(m *infraPlugin.Manifest) {
+			m.Dependencies = []infraPlugin.ManifestDependency{{ID: "pricing-plugin"}}
+		}, "plugin depends on itself"},
+		{"invalid dependency range", func(m *infraPlugin.Manifest) {
+			m.Dependencies = []infraPlugin.ManifestDependency{{ID: "db", Version: ">=abc"}}
+		}, "invalid version range '>=abc' for dependency 'db'"},
+		{"empty required config", func(m *infraPlugin.Manifest) { m.RequiredConfig = []string{" "} }, "required_config[0] is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid()
+			tt.modify(m)
+			err := m.Validate()
+			require.Error(t, err)
+			assert.ErrorIs(t, err, component.ErrInvalidItem)
+			assert.Contains(t, err.Error(), tt.problem)
+		})
+	}
+
+	t.Run("reports all problems", func(t *testing.T) {
+		err := (&infraPlugin.Manifest{}).Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "id is required; version is required; entrypoint.kind is required; entrypoint.path is required")
+	})
+}
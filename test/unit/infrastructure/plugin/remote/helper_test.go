@@ -0,0 +1,94 @@
+package remote
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	"github.com/fintechain/skeleton/internal/infrastructure/plugin/remote"
+)
+
+// helperEnv makes the test binary act as a plugin process. Its value is the
+// plugin ID the process advertises.
+const helperEnv = "SKELETON_TEST_REMOTE_PLUGIN"
+
+// faultyServiceEnv makes the helper process also expose a service that fails
+// to start, after the ticker service.
+const faultyServiceEnv = "SKELETON_TEST_REMOTE_FAULTY_SERVICE"
+
+func TestMain(m *testing.M) {
+	if id := os.Getenv(helperEnv); id != "" {
+		if err := newTestServer(component.ComponentID(id)).Serve(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// funcOperation is an operation backed by a function.
+type funcOperation struct {
+	*infraComponent.BaseOperation
+	fn func(input component.Input) (component.Output, error)
+}
+
+func newFuncOperation(id string, fn func(component.Input) (component.Output, error)) *funcOperation {
+	return &funcOperation{
+		BaseOperation: infraComponent.NewBaseOperation(component.ComponentConfig{ID: component.ComponentID(id), Name: id}),
+		fn:            fn,
+	}
+}
+
+func (o *funcOperation) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	return o.fn(input)
+}
+
+// faultyService is a service that refuses to start.
+type faultyService struct {
+	*infraComponent.BaseService
+}
+
+func (s *faultyService) Start(ctx context.Context) error {
+	return errors.New("refused")
+}
+
+// newTestServer builds the server run by the helper process.
+func newTestServer(id component.ComponentID) *remote.Server {
+	server := remote.NewServer(remote.PluginInfo{
+		ID:      id,
+		Name:    "Test Remote Plugin",
+		Version: "1.2.0",
+		Author:  "test",
+		Type:    "processor",
+	})
+
+	ticker := infraComponent.NewBaseService(component.ComponentConfig{ID: "ticker", Name: "Ticker"})
+	server.AddService(ticker)
+	if os.Getenv(faultyServiceEnv) != "" {
+		server.AddService(&faultyService{
+			BaseService: infraComponent.NewBaseService(component.ComponentConfig{ID: "faulty", Name: "Faulty"}),
+		})
+	}
+
+	server.AddOperation(newFuncOperation("echo", func(input component.Input) (component.Output, error) {
+		return component.Output{Data: map[string]any{"data": input.Data, "metadata": input.Metadata}}, nil
+	}))
+	server.AddOperation(newFuncOperation("fail", func(component.Input) (component.Output, error) {
+		return component.Output{}, errors.New("boom")
+	}))
+	server.AddOperation(newFuncOperation("pid", func(component.Input) (component.Output, error) {
+		return component.Output{Data: os.Getpid()}, nil
+	}))
+	server.AddOperation(newFuncOperation("ticker-running", func(component.Input) (component.Output, error) {
+		return component.Output{Data: ticker.IsRunning()}, nil
+	}))
+	server.AddOperation(newFuncOperation("crash", func(component.Input) (component.Output, error) {
+		os.Exit(3)
+		return component.Output{}, nil
+	}))
+
+	return server
+}
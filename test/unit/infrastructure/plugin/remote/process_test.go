@@ -0,0 +1,259 @@
+package remote
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	"github.com/fintechain/skeleton/internal/infrastructure/plugin/remote"
+)
+
+// newTestProcess creates a process plugin that runs this test binary as the
+// helper plugin advertising advertisedID. env is added to the helper's environment.
+func newTestProcess(t *testing.T, id, advertisedID string, transport remote.Transport, env ...string) *remote.ProcessPlugin {
+	config := remote.ProcessConfig{
+		ID:               component.ComponentID(id),
+		Path:             os.Args[0],
+		Env:              append([]string{helperEnv + "=" + advertisedID}, env...),
+		Transport:        transport,
+		HandshakeTimeout: 5 * time.Second,
+		RestartBackoff:   10 * time.Millisecond,
+	}
+	if transport == remote.TransportUnix {
+		// Keep the socket path short; Unix socket paths are limited to about 100 bytes.
+		dir, err := os.MkdirTemp("", "rp")
+		require.NoError(t, err)
+		t.Cleanup(func() { os.RemoveAll(dir) })
+		config.SocketDir = dir
+	}
+
+	p := remote.NewProcessPlugin(config)
+	t.Cleanup(func() { p.Dispose() })
+	return p
+}
+
+func TestProcessPluginInterface(t *testing.T) {
+	var _ plugin.DependentPlugin = (*remote.ProcessPlugin)(nil)
+}
+
+func TestProcessPluginExecutesRemoteOperations(t *testing.T) {
+	for _, transport := range []remote.Transport{remote.TransportStdio, remote.TransportUnix} {
+		t.Run(string(transport), func(t *testing.T) {
+			ctx := infraContext.NewContext()
+			system := infraComponent.NewSystem(infraComponent.NewRegistry())
+			p := newTestProcess(t, "remote-plugin", "remote-plugin", transport)
+
+			assert.Equal(t, "remote-plugin", p.Name())
+			require.NoError(t, p.Initialize(ctx, system))
+
+			assert.Equal(t, "Test Remote Plugin", p.Name())
+			assert.Equal(t, "1.2.0", p.Version())
+			assert.Equal(t, "test", p.Author())
+			assert.Equal(t, plugin.TypeProcessor, p.PluginType())
+
+			comp, err := system.Registry().Get("echo")
+			require.NoError(t, err)
+			assert.Equal(t, component.TypeOperation, comp.Type())
+			assert.Equal(t, "remote-plugin", comp.Metadata()["plugin"])
+
+			output, err := system.ExecuteOperation(ctx, "echo", component.Input{
+				Data:     map[string]any{"amount": 42},
+				Metadata: map[string]string{"trace": "abc"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{
+				"data":     map[string]any{"amount": float64(42)},
+				"metadata": map[string]any{"trace": "abc"},
+			}, output.Data)
+
+			_, err = system.ExecuteOperation(ctx, "fail", component.Input{})
+			require.Error(t, err)
+			assert.ErrorIs(t, err, component.ErrOperationFailed)
+			assert.Contains(t, err.Error(), "boom")
+
+			require.NoError(t, p.Dispose())
+			_, err = system.ExecuteOperation(ctx, "echo", component.Input{})
+			require.Error(t, err)
+			assert.ErrorIs(t, err, remote.ErrPluginUnavailable)
+		})
+	}
+}
+
+func TestProcessPluginStartsRemoteServices(t *testing.T) {
+	ctx := infraContext.NewContext()
+	system := infraComponent.NewSystem(infraComponent.NewRegistry())
+	p := newTestProcess(t, "remote-plugin", "remote-plugin", remote.TransportStdio)
+	require.NoError(t, p.Initialize(ctx, system))
+
+	ticker, err := system.Registry().Get("ticker")
+	require.NoError(t, err)
+	service := ticker.(component.Service)
+	assert.False(t, service.IsRunning())
+
+	require.NoError(t, p.Start(ctx))
+	assert.True(t, p.IsRunning())
+	assert.True(t, service.IsRunning())
+
+	output, err := system.ExecuteOperation(ctx, "ticker-running", component.Input{})
+	require.NoError(t, err)
+	assert.Equal(t, true, output.Data)
+
+	require.NoError(t, p.Stop(ctx))
+	assert.False(t, service.IsRunning())
+
+	output, err = system.ExecuteOperation(ctx, "ticker-running", component.Input{})
+	require.NoError(t, err)
+	assert.Equal(t, false, output.Data)
+}
+
+func TestProcessPluginStartRollsBackOnFailure(t *testing.T) {
+	ctx := infraContext.NewContext()
+	system := infraComponent.NewSystem(infraComponent.NewRegistry())
+	p := newTestProcess(t, "remote-plugin", "remote-plugin", remote.TransportStdio, faultyServiceEnv+"=1")
+	require.NoError(t, p.Initialize(ctx, system))
+
+	ticker, err := system.Registry().Get("ticker")
+	require.NoError(t, err)
+	service := ticker.(component.Service)
+
+	err = p.Start(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrServiceStartFailed)
+	assert.Contains(t, err.Error(), "service 'faulty'")
+	assert.False(t, p.IsRunning())
+	assert.False(t, service.IsRunning())
+
+	output, err := system.ExecuteOperation(ctx, "ticker-running", component.Input{})
+	require.NoError(t, err)
+	assert.Equal(t, false, output.Data)
+}
+
+func TestProcessPluginRestartsCrashedProcess(t *testing.T) {
+	ctx := infraContext.NewContext()
+	system := infraComponent.NewSystem(infraComponent.NewRegistry())
+	p := newTestProcess(t, "remote-plugin", "remote-plugin", remote.TransportStdio)
+	require.NoError(t, p.Initialize(ctx, system))
+	require.NoError(t, p.Start(ctx))
+
+	output, err := system.ExecuteOperation(ctx, "pid", component.Input{})
+	require.NoError(t, err)
+	firstPID := output.Data
+
+	_, err = system.ExecuteOperation(ctx, "crash", component.Input{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, remote.ErrPluginUnavailable)
+
+	require.Eventually(t, func() bool {
+		output, err = system.ExecuteOperation(ctx, "pid", component.Input{})
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.NotEqual(t, firstPID, output.Data)
+	assert.Equal(t, 1, p.Restarts())
+
+	// Services that were running before the crash are started again.
+	require.Eventually(t, func() bool {
+		output, err = system.ExecuteOperation(ctx, "ticker-running", component.Input{})
+		return err == nil && output.Data == true
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestProcessPluginResetsRestartsAfterStableUptime(t *testing.T) {
+	ctx := infraContext.NewContext()
+	system := infraComponent.NewSystem(infraComponent.NewRegistry())
+	p := remote.NewProcessPlugin(remote.ProcessConfig{
+		ID:               "remote-plugin",
+		Path:             os.Args[0],
+		Env:              []string{helperEnv + "=remote-plugin"},
+		HandshakeTimeout: 5 * time.Second,
+		MaxRestarts:      1,
+		RestartBackoff:   10 * time.Millisecond,
+		StableUptime:     100 * time.Millisecond,
+	})
+	t.Cleanup(func() { p.Dispose() })
+	require.NoError(t, p.Initialize(ctx, system))
+
+	// With a budget of one restart, the second crash is survived only because
+	// the restarted process ran for StableUptime first.
+	for i := 0; i < 2; i++ {
+		time.Sleep(150 * time.Millisecond)
+		_, err := system.ExecuteOperation(ctx, "crash", component.Input{})
+		require.Error(t, err)
+
+		require.Eventually(t, func() bool {
+			_, err := system.ExecuteOperation(ctx, "pid", component.Input{})
+			return err == nil
+		}, 5*time.Second, 20*time.Millisecond)
+		assert.Equal(t, 1, p.Restarts())
+	}
+	assert.NotEqual(t, component.StatusError, p.Status())
+}
+
+func TestProcessPluginInitializeRollsBackOnConflict(t *testing.T) {
+	ctx := infraContext.NewContext()
+	registry := infraComponent.NewRegistry()
+	system := infraComponent.NewSystem(registry)
+	existing := infraComponent.NewBaseService(component.ComponentConfig{ID: "ticker"})
+	require.NoError(t, registry.Register(existing))
+
+	p := newTestProcess(t, "remote-plugin", "remote-plugin", remote.TransportStdio)
+	err := p.Initialize(ctx, system)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrPluginLoadFailed)
+
+	// The operation proxies registered before the conflict are removed.
+	assert.Equal(t, []component.ComponentID{"ticker"}, registry.List())
+	assert.Nil(t, p.Manifest())
+
+	// The process is terminated and not restarted.
+	time.Sleep(100 * time.Millisecond)
+	assert.Zero(t, p.Restarts())
+}
+
+func TestProcessPluginRejectsMismatchedManifest(t *testing.T) {
+	ctx := infraContext.NewContext()
+	system := infraComponent.NewSystem(infraComponent.NewRegistry())
+	p := newTestProcess(t, "expected-plugin", "other-plugin", remote.TransportStdio)
+
+	err := p.Initialize(ctx, system)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrPluginLoadFailed)
+	assert.ErrorIs(t, err, remote.ErrHandshakeFailed)
+	assert.Empty(t, system.Registry().List())
+}
+
+func TestProcessPluginFailsForMissingExecutable(t *testing.T) {
+	p := remote.NewProcessPlugin(remote.ProcessConfig{ID: "missing", Path: "/nonexistent/plugin"})
+
+	err := p.Initialize(infraContext.NewContext(), infraComponent.NewSystem(infraComponent.NewRegistry()))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, remote.ErrPluginUnavailable)
+}
+
+func TestProcessPluginUnloadRemovesProxies(t *testing.T) {
+	ctx := infraContext.NewContext()
+	registry := infraComponent.NewRegistry()
+	system := infraComponent.NewSystem(registry)
+	manager := infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"})
+
+	p := newTestProcess(t, "remote-plugin", "remote-plugin", remote.TransportStdio)
+	require.NoError(t, manager.Add(p.ID(), p))
+	require.NoError(t, manager.Initialize(ctx, system))
+	require.NoError(t, manager.Start(ctx))
+	assert.ElementsMatch(t,
+		[]component.ComponentID{"ticker", "echo", "fail", "pid", "ticker-running", "crash"},
+		manager.OwnedComponents(p.ID()))
+
+	require.NoError(t, manager.Unload(ctx, p.ID()))
+	assert.Empty(t, registry.List())
+
+	_, err := system.ExecuteOperation(ctx, "echo", component.Input{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/infrastructure/plugin/remote"
+)
+
+// rawPeer speaks the protocol by hand against a server.
+type rawPeer struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+	nextID uint64
+	done   chan error
+}
+
+func newRawPeer(t *testing.T) *rawPeer {
+	hostEnd, pluginEnd := net.Pipe()
+	peer := &rawPeer{t: t, conn: hostEnd, reader: bufio.NewReader(hostEnd), done: make(chan error, 1)}
+	go func() { peer.done <- newTestServer("raw-plugin").ServeConn(pluginEnd) }()
+	t.Cleanup(func() { hostEnd.Close() })
+	return peer
+}
+
+// call sends a request and returns the decoded response.
+func (p *rawPeer) call(method string, params any) map[string]json.RawMessage {
+	p.nextID++
+	raw, err := json.Marshal(params)
+	require.NoError(p.t, err)
+	require.NoError(p.t, json.NewEncoder(p.conn).Encode(map[string]any{
+		"id": p.nextID, "method": method, "params": json.RawMessage(raw),
+	}))
+
+	line, err := p.reader.ReadBytes('\n')
+	require.NoError(p.t, err)
+
+	var resp map[string]json.RawMessage
+	require.NoError(p.t, json.Unmarshal(line, &resp))
+	assert.JSONEq(p.t, string(mustJSON(p.t, p.nextID)), string(resp["id"]))
+	return resp
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
+
+func TestServerHandshake(t *testing.T) {
+	peer := newRawPeer(t)
+
+	resp := peer.call(remote.MethodHandshake, remote.HandshakeRequest{ProtocolVersion: remote.ProtocolVersion})
+	require.Nil(t, resp["error"])
+
+	var manifest remote.Manifest
+	require.NoError(t, json.Unmarshal(resp["result"], &manifest))
+	assert.Equal(t, remote.ProtocolVersion, manifest.ProtocolVersion)
+	assert.Equal(t, "raw-plugin", manifest.ID)
+	assert.Equal(t, "1.2.0", manifest.Version)
+	assert.Equal(t, "processor", manifest.Type)
+
+	var operations []string
+	for _, op := range manifest.Operations {
+		operations = append(operations, op.ID)
+	}
+	assert.Equal(t, []string{"echo", "fail", "pid", "ticker-running", "crash"}, operations)
+	require.Len(t, manifest.Services, 1)
+	assert.Equal(t, "ticker", manifest.Services[0].ID)
+	assert.Equal(t, "Ticker", manifest.Services[0].Name)
+}
+
+func TestServerRejectsProtocolMismatch(t *testing.T) {
+	peer := newRawPeer(t)
+
+	resp := peer.call(remote.MethodHandshake, remote.HandshakeRequest{ProtocolVersion: remote.ProtocolVersion + 1})
+
+	var rpcErr remote.RPCError
+	require.NoError(t, json.Unmarshal(resp["error"], &rpcErr))
+	assert.Equal(t, remote.ErrProtocolMismatch, rpcErr.Code)
+}
+
+func TestServerExecute(t *testing.T) {
+	peer := newRawPeer(t)
+
+	resp := peer.call(remote.MethodExecute, remote.ExecuteRequest{
+		OperationID: "echo",
+		Data:        "hello",
+		Metadata:    map[string]string{"trace": "1"},
+	})
+	require.Nil(t, resp["error"])
+	assert.JSONEq(t, `{"data":{"data":"hello","metadata":{"trace":"1"}}}`, string(resp["result"]))
+
+	var rpcErr remote.RPCError
+	resp = peer.call(remote.MethodExecute, remote.ExecuteRequest{OperationID: "fail"})
+	require.NoError(t, json.Unmarshal(resp["error"], &rpcErr))
+	assert.Equal(t, component.ErrOperationFailed, rpcErr.Code)
+	assert.Equal(t, "boom", rpcErr.Message)
+
+	resp = peer.call(remote.MethodExecute, remote.ExecuteRequest{OperationID: "missing"})
+	require.NoError(t, json.Unmarshal(resp["error"], &rpcErr))
+	assert.Equal(t, component.ErrOperationNotFound, rpcErr.Code)
+
+	resp = peer.call("operation.unknown", struct{}{})
+	require.NoError(t, json.Unmarshal(resp["error"], &rpcErr))
+	assert.Equal(t, remote.ErrUnknownMethod, rpcErr.Code)
+}
+
+func TestServerServices(t *testing.T) {
+	peer := newRawPeer(t)
+
+	resp := peer.call(remote.MethodStartService, remote.ServiceRequest{ServiceID: "ticker"})
+	require.Nil(t, resp["error"])
+
+	resp = peer.call(remote.MethodExecute, remote.ExecuteRequest{OperationID: "ticker-running"})
+	assert.JSONEq(t, `{"data":true}`, string(resp["result"]))
+
+	resp = peer.call(remote.MethodStopService, remote.ServiceRequest{ServiceID: "ticker"})
+	require.Nil(t, resp["error"])
+
+	resp = peer.call(remote.MethodExecute, remote.ExecuteRequest{OperationID: "ticker-running"})
+	assert.JSONEq(t, `{"data":false}`, string(resp["result"]))
+
+	var rpcErr remote.RPCError
+	resp = peer.call(remote.MethodStartService, remote.ServiceRequest{ServiceID: "missing"})
+	require.NoError(t, json.Unmarshal(resp["error"], &rpcErr))
+	assert.Equal(t, component.ErrServiceNotFound, rpcErr.Code)
+}
+
+func TestServerShutdown(t *testing.T) {
+	peer := newRawPeer(t)
+
+	resp := peer.call(remote.MethodShutdown, struct{}{})
+	require.Nil(t, resp["error"])
+
+	select {
+	case err := <-peer.done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not return after shutdown")
+	}
+}
+
+func TestServerReturnsWhenHostDisconnects(t *testing.T) {
+	peer := newRawPeer(t)
+	peer.conn.Close()
+
+	select {
+	case err := <-peer.done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not return after disconnect")
+	}
+}
@@ -0,0 +1,189 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+)
+
+// testPlugin is a minimal plugin that declares dependencies and records lifecycle calls.
+type testPlugin struct {
+	*infraComponent.BaseService
+	deps     []plugin.Dependency
+	log      *[]string
+	startErr error
+	stopErr  error
+}
+
+func newTestPlugin(id, version string, log *[]string, deps ...plugin.Dependency) *testPlugin {
+	return &testPlugin{
+		BaseService: infraComponent.NewBaseService(component.ComponentConfig{
+			ID:      component.ComponentID(id),
+			Name:    id,
+			Version: version,
+		}),
+		deps: deps,
+		log:  log,
+	}
+}
+
+func (p *testPlugin) Author() string                    { return "test" }
+func (p *testPlugin) PluginType() plugin.PluginType     { return plugin.TypeExtension }
+func (p *testPlugin) Dependencies() []plugin.Dependency { return p.deps }
+
+func (p *testPlugin) Initialize(ctx context.Context, system component.System) error {
+	p.record("init")
+	return p.BaseService.Initialize(ctx, system)
+}
+
+func (p *testPlugin) Start(ctx context.Context) error {
+	p.record("start")
+	if p.startErr != nil {
+		return p.startErr
+	}
+	return p.BaseService.Start(ctx)
+}
+
+func (p *testPlugin) Stop(ctx context.Context) error {
+	p.record("stop")
+	if p.stopErr != nil {
+		return p.stopErr
+	}
+	return p.BaseService.Stop(ctx)
+}
+
+func (p *testPlugin) record(phase string) {
+	if p.log != nil {
+		*p.log = append(*p.log, phase+":"+string(p.ID()))
+	}
+}
+
+func pluginMap(plugins ...*testPlugin) map[component.ComponentID]plugin.Plugin {
+	result := make(map[component.ComponentID]plugin.Plugin, len(plugins))
+	for _, p := range plugins {
+		result[p.ID()] = p
+	}
+	return result
+}
+
+func TestResolveDependenciesOrder(t *testing.T) {
+	web := newTestPlugin("web", "1.0.0", nil, plugin.Dependency{ID: "db", VersionRange: "^1.0.0"})
+	db := newTestPlugin("db", "1.4.0", nil, plugin.Dependency{ID: "config"})
+	config := newTestPlugin("config", "2.0.0", nil)
+	audit := newTestPlugin("audit", "1.0.0", nil)
+
+	order, err := infraPlugin.ResolveDependencies(pluginMap(web, db, config, audit))
+	require.NoError(t, err)
+	assert.Equal(t, []component.ComponentID{"audit", "config", "db", "web"}, order)
+}
+
+func TestResolveDependenciesIsDeterministic(t *testing.T) {
+	plugins := pluginMap(
+		newTestPlugin("c", "1.0.0", nil),
+		newTestPlugin("b", "1.0.0", nil),
+		newTestPlugin("a", "1.0.0", nil, plugin.Dependency{ID: "c"}),
+	)
+
+	for i := 0; i < 20; i++ {
+		order, err := infraPlugin.ResolveDependencies(plugins)
+		require.NoError(t, err)
+		assert.Equal(t, []component.ComponentID{"b", "c", "a"}, order)
+	}
+}
+
+func TestResolveDependenciesOptional(t *testing.T) {
+	t.Run("missing optional dependency is ignored", func(t *testing.T) {
+		web := newTestPlugin("web", "1.0.0", nil, plugin.Dependency{ID: "cache", Optional: true})
+
+		order, err := infraPlugin.ResolveDependencies(pluginMap(web))
+		require.NoError(t, err)
+		assert.Equal(t, []component.ComponentID{"web"}, order)
+	})
+
+	t.Run("present optional dependency is ordered first", func(t *testing.T) {
+		web := newTestPlugin("a-web", "1.0.0", nil, plugin.Dependency{ID: "cache", Optional: true})
+		cache := newTestPlugin("cache", "1.0.0", nil)
+
+		order, err := infraPlugin.ResolveDependencies(pluginMap(web, cache))
+		require.NoError(t, err)
+		assert.Equal(t, []component.ComponentID{"cache", "a-web"}, order)
+	})
+
+	t.Run("present optional dependency must be compatible", func(t *testing.T) {
+		web := newTestPlugin("web", "1.0.0", nil, plugin.Dependency{ID: "cache", VersionRange: "^2.0", Optional: true})
+		cache := newTestPlugin("cache", "1.0.0", nil)
+
+		_, err := infraPlugin.ResolveDependencies(pluginMap(web, cache))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrPluginLoadFailed)
+		assert.Contains(t, err.Error(), "plugin 'web' requires 'cache' ^2.0, found 1.0.0")
+	})
+}
+
+func TestResolveDependenciesFailures(t *testing.T) {
+	t.Run("missing required dependency", func(t *testing.T) {
+		web := newTestPlugin("web", "1.0.0", nil, plugin.Dependency{ID: "db"})
+
+		_, err := infraPlugin.ResolveDependencies(pluginMap(web))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrPluginLoadFailed)
+		assert.Contains(t, err.Error(), "plugin 'web' requires missing plugin 'db'")
+	})
+
+	t.Run("incompatible version", func(t *testing.T) {
+		web := newTestPlugin("web", "1.0.0", nil, plugin.Dependency{ID: "db", VersionRange: ">=2.0.0"})
+		db := newTestPlugin("db", "1.5.0", nil)
+
+		_, err := infraPlugin.ResolveDependencies(pluginMap(web, db))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "plugin 'web' requires 'db' >=2.0.0, found 1.5.0")
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		web := newTestPlugin("web", "1.0.0", nil, plugin.Dependency{ID: "db", VersionRange: ">=banana"})
+		db := newTestPlugin("db", "1.5.0", nil)
+
+		_, err := infraPlugin.ResolveDependencies(pluginMap(web, db))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "declares an invalid range for 'db'")
+	})
+
+	t.Run("all problems are reported", func(t *testing.T) {
+		web := newTestPlugin("web", "1.0.0", nil,
+			plugin.Dependency{ID: "db"},
+			plugin.Dependency{ID: "cache"},
+		)
+
+		_, err := infraPlugin.ResolveDependencies(pluginMap(web))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing plugin 'db'")
+		assert.Contains(t, err.Error(), "missing plugin 'cache'")
+	})
+
+	t.Run("dependency cycle", func(t *testing.T) {
+		a := newTestPlugin("a", "1.0.0", nil, plugin.Dependency{ID: "b"})
+		b := newTestPlugin("b", "1.0.0", nil, plugin.Dependency{ID: "a"})
+		c := newTestPlugin("c", "1.0.0", nil)
+
+		_, err := infraPlugin.ResolveDependencies(pluginMap(a, b, c))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrPluginLoadFailed)
+		assert.ErrorIs(t, err, component.ErrCircularDependency)
+		assert.Contains(t, err.Error(), "a, b")
+	})
+
+	t.Run("self dependency", func(t *testing.T) {
+		a := newTestPlugin("a", "1.0.0", nil, plugin.Dependency{ID: "a"})
+
+		_, err := infraPlugin.ResolveDependencies(pluginMap(a))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "plugin 'a' depends on itself")
+	})
+}
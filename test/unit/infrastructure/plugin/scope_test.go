@@ -0,0 +1,469 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/runtime"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraStorage "github.com/fintechain/skeleton/internal/infrastructure/storage"
+	memoryStorage "github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+)
+
+// systemPlugin keeps the system it is given.
+type systemPlugin struct {
+	*infraComponent.BaseService
+	system component.System
+}
+
+func (p *systemPlugin) Author() string                { return "test" }
+func (p *systemPlugin) PluginType() plugin.PluginType { return plugin.TypeExtension }
+
+func (p *systemPlugin) Initialize(ctx context.Context, system component.System) error {
+	p.system = system
+	return p.BaseService.Initialize(ctx, system)
+}
+
+func (p *systemPlugin) env() runtime.RuntimeEnvironment {
+	return p.system.(runtime.RuntimeEnvironment)
+}
+
+// runtimeProviders are the optional providers the scoped runtime offers.
+type runtimeProviders interface {
+	runtime.PluginConfigurationProvider
+	supervisor.Provider
+	resilience.Provider
+	idempotency.Provider
+	health.Provider
+	metrics.Provider
+	tracing.Provider
+	introspection.Provider
+}
+
+func (p *systemPlugin) providers() runtimeProviders {
+	return p.system.(runtimeProviders)
+}
+
+func newSystemPlugin(id string) *systemPlugin {
+	return &systemPlugin{
+		BaseService: infraComponent.NewBaseService(component.ComponentConfig{ID: component.ComponentID(id), Name: id}),
+	}
+}
+
+// capablePlugin is a systemPlugin that declares capabilities.
+type capablePlugin struct {
+	*systemPlugin
+	caps plugin.Capabilities
+}
+
+func newCapablePlugin(id string, caps plugin.Capabilities) *capablePlugin {
+	return &capablePlugin{systemPlugin: newSystemPlugin(id), caps: caps}
+}
+
+func (p *capablePlugin) Capabilities() plugin.Capabilities { return p.caps }
+
+// newScopedRuntime loads p into a runtime with configuration and a multistore.
+func newScopedRuntime(t *testing.T, plugins ...plugin.Plugin) (*infraRuntime.Runtime, *infraEvent.EventBus) {
+	return newScopedRuntimeWith(t, infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}), plugins...)
+}
+
+// newScopedRuntimeWith is newScopedRuntime with the given plugin manager.
+func newScopedRuntimeWith(t *testing.T, manager *infraPlugin.Manager, plugins ...plugin.Plugin) (*infraRuntime.Runtime, *infraEvent.EventBus) {
+	registry := infraComponent.NewRegistry()
+	eventBus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	cfg := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+		"pricing.url":         "http://pricing",
+		"pricing.retries":     3,
+		"pricingx":            "not covered",
+		"db.password":         "secret",
+		"plugins.scoped.mode": "strict",
+		"plugins.other.token": "other-secret",
+	})
+
+	stores := infraStorage.NewMultiStore(component.ComponentConfig{ID: "stores"}, t.TempDir())
+	require.NoError(t, stores.RegisterEngine(memoryStorage.NewEngine()))
+	require.NoError(t, stores.CreateStore("orders", "memory", nil))
+	require.NoError(t, stores.CreateStore("secrets", "memory", nil))
+	require.NoError(t, registry.Register(stores))
+
+	rt, err := infraRuntime.NewRuntime(registry, cfg, manager, eventBus, logger)
+	require.NoError(t, err)
+	require.NoError(t, rt.LoadPlugins(infraContext.NewContext(), plugins))
+	return rt, eventBus
+}
+
+func TestScopedEventBus(t *testing.T) {
+	p := newCapablePlugin("scoped", plugin.Capabilities{
+		PublishTopics:   []string{"orders.*", "audit"},
+		SubscribeTopics: []string{"payments.completed"},
+	})
+	_, eventBus := newScopedRuntime(t, p)
+	bus := p.env().EventBus()
+
+	assert.NoError(t, bus.Publish(&event.Event{Topic: "orders.created"}))
+	assert.NoError(t, bus.PublishAsync(&event.Event{Topic: "audit"}))
+
+	err := bus.Publish(&event.Event{Topic: "payments.completed"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+	assert.Contains(t, err.Error(), "plugin 'scoped' may not publish to topic 'payments.completed'")
+	assert.Error(t, bus.Publish(&event.Event{Topic: "orders"}))
+	assert.Error(t, bus.PublishAsync(&event.Event{Topic: "auditing"}))
+
+	var allowed, denied int
+	bus.Subscribe("payments.completed", func(*event.Event) { allowed++ })
+	sub := bus.Subscribe("orders.created", func(*event.Event) { denied++ })
+	assert.Equal(t, "orders.created", sub.Topic())
+	sub.Cancel()
+
+	require.NoError(t, eventBus.Publish(&event.Event{Topic: "payments.completed"}))
+	require.NoError(t, eventBus.Publish(&event.Event{Topic: "orders.created"}))
+	assert.Equal(t, 1, allowed)
+	assert.Equal(t, 0, denied)
+
+	// The lifecycle of the core bus is out of reach
+	ctx := infraContext.NewContext()
+	err = bus.Dispose()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+	assert.Contains(t, err.Error(), "plugin 'scoped' may not dispose the event bus: ManageRuntime not declared")
+	assert.ErrorIs(t, bus.Stop(ctx), component.ErrCapabilityDenied)
+	assert.ErrorIs(t, bus.Start(ctx), component.ErrCapabilityDenied)
+	assert.ErrorIs(t, bus.Initialize(ctx, p.system), component.ErrCapabilityDenied)
+	require.NoError(t, eventBus.Publish(&event.Event{Topic: "payments.completed"}))
+	assert.Equal(t, 2, allowed)
+}
+
+func TestScopedConfiguration(t *testing.T) {
+	p := newCapablePlugin("scoped", plugin.Capabilities{ConfigPrefixes: []string{"pricing"}})
+	newScopedRuntime(t, p)
+	cfg := p.env().Configuration()
+
+	assert.Equal(t, "http://pricing", cfg.GetString("pricing.url"))
+	assert.Equal(t, 3, cfg.GetIntDefault("pricing.retries", 0))
+	assert.True(t, cfg.Exists("pricing.url"))
+
+	assert.Equal(t, "", cfg.GetString("db.password"))
+	assert.Equal(t, "fallback", cfg.GetStringDefault("db.password", "fallback"))
+	assert.False(t, cfg.Exists("db.password"))
+	assert.False(t, cfg.Exists("pricingx"))
+
+	_, err := cfg.GetInt("db.password")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+	assert.Contains(t, err.Error(), "may not read configuration key 'db.password'")
+
+	var value string
+	assert.Error(t, cfg.GetObject("db.password", &value))
+}
+
+func TestScopedPluginConfiguration(t *testing.T) {
+	p := newCapablePlugin("scoped", plugin.Capabilities{ConfigPrefixes: []string{"pricing"}})
+	newScopedRuntime(t, p)
+
+	// A plugin may always read its own section
+	assert.Equal(t, "strict", p.providers().PluginConfiguration("scoped").GetString("mode"))
+	assert.Equal(t, "strict", p.env().Configuration().GetString("plugins.scoped.mode"))
+
+	// Other sections must be declared
+	other := p.providers().PluginConfiguration("other")
+	assert.False(t, other.Exists("token"))
+	assert.Equal(t, "", other.GetString("token"))
+	assert.Equal(t, "", p.env().Configuration().GetString("plugins.other.token"))
+}
+
+func TestScopedStores(t *testing.T) {
+	p := newCapablePlugin("scoped", plugin.Capabilities{Stores: []string{"orders"}})
+	newScopedRuntime(t, p)
+
+	comp, err := p.system.Registry().Get("stores")
+	require.NoError(t, err)
+	stores := comp.(storage.MultiStore)
+
+	store, err := stores.GetStore("orders")
+	require.NoError(t, err)
+	assert.Equal(t, "orders", store.Name())
+
+	_, err = stores.GetStore("secrets")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+	assert.Contains(t, err.Error(), "may not access store 'secrets'")
+
+	assert.Error(t, stores.DeleteStore("secrets"))
+	assert.Error(t, stores.CreateStore("cache", "memory", nil))
+	assert.Error(t, stores.CloseAll())
+	assert.Equal(t, []string{"orders"}, stores.ListStores())
+
+	ctx := infraContext.NewContext()
+	service := comp.(storage.MultiStoreService)
+	assert.ErrorIs(t, service.Stop(ctx), component.ErrCapabilityDenied)
+	assert.ErrorIs(t, service.Start(ctx), component.ErrCapabilityDenied)
+	assert.ErrorIs(t, service.Initialize(ctx, p.system), component.ErrCapabilityDenied)
+	assert.ErrorIs(t, service.Dispose(), component.ErrCapabilityDenied)
+	_, err = stores.GetStore("orders")
+	assert.NoError(t, err)
+
+	services, err := p.system.Registry().GetByType(component.TypeService)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	_, err = services[0].(storage.MultiStore).GetStore("secrets")
+	assert.Error(t, err)
+}
+
+func TestScopedRegistry(t *testing.T) {
+	p := newCapablePlugin("scoped", plugin.Capabilities{})
+	newScopedRuntime(t, p)
+	registry := p.system.Registry()
+
+	own := infraComponent.NewBaseComponent(component.ComponentConfig{ID: "own"})
+	require.NoError(t, registry.Register(own))
+	assert.NoError(t, registry.Unregister("own"))
+
+	err := registry.Unregister("stores")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+	assert.True(t, registry.Has("stores"))
+
+	assert.Error(t, registry.Clear())
+	assert.True(t, registry.Has("stores"))
+}
+
+func TestScopedRegistryServices(t *testing.T) {
+	restricted := newCapablePlugin("restricted", plugin.Capabilities{})
+	admin := newCapablePlugin("admin", plugin.Capabilities{ManageRuntime: true})
+	rt, _ := newScopedRuntime(t, restricted, admin)
+	ctx := infraContext.NewContext()
+
+	foreign := infraComponent.NewBaseService(component.ComponentConfig{ID: "foreign"})
+	require.NoError(t, rt.Registry().Register(foreign))
+	require.NoError(t, foreign.Start(ctx))
+
+	t.Run("A foreign service cannot be controlled through the registry", func(t *testing.T) {
+		comp, err := restricted.system.Registry().Get("foreign")
+		require.NoError(t, err)
+		service := comp.(component.Service)
+
+		err = service.Stop(ctx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+		assert.Contains(t, err.Error(), "plugin 'restricted' may not stop service 'foreign' registered by someone else")
+		assert.ErrorIs(t, service.Start(ctx), component.ErrCapabilityDenied)
+		assert.ErrorIs(t, service.Dispose(), component.ErrCapabilityDenied)
+		assert.ErrorIs(t, service.Initialize(ctx, restricted.system), component.ErrCapabilityDenied)
+		assert.True(t, foreign.IsRunning())
+		assert.Equal(t, component.StatusRunning, service.Status())
+
+		found, err := restricted.system.Registry().Find(func(c component.Component) bool { return c.ID() == "foreign" })
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.ErrorIs(t, found[0].(component.Service).Stop(ctx), component.ErrCapabilityDenied)
+		assert.True(t, foreign.IsRunning())
+	})
+
+	t.Run("Own services and plugins managing the runtime get the service itself", func(t *testing.T) {
+		own := infraComponent.NewBaseService(component.ComponentConfig{ID: "own"})
+		require.NoError(t, restricted.system.Registry().Register(own))
+		comp, err := restricted.system.Registry().Get("own")
+		require.NoError(t, err)
+		assert.Same(t, own, comp)
+
+		comp, err = admin.system.Registry().Get("foreign")
+		require.NoError(t, err)
+		assert.Same(t, foreign, comp)
+	})
+}
+
+func TestScopedPluginManagement(t *testing.T) {
+	restricted := newCapablePlugin("restricted", plugin.Capabilities{})
+	manager := newCapablePlugin("manager", plugin.Capabilities{ManagePlugins: true})
+	newScopedRuntime(t, restricted, manager)
+	ctx := infraContext.NewContext()
+
+	pm := restricted.env().PluginManager()
+	assert.ElementsMatch(t, []component.ComponentID{"restricted", "manager"}, pm.ListPlugins())
+
+	_, err := pm.GetPlugin("manager")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "plugin 'restricted' may not access other plugins: ManagePlugins not declared")
+	assert.Error(t, pm.StopPlugin(ctx, "manager"))
+	assert.Error(t, pm.Unload(ctx, "manager"))
+	assert.Error(t, restricted.env().LoadPlugins(ctx, nil))
+
+	other, err := manager.env().PluginManager().GetPlugin("restricted")
+	require.NoError(t, err)
+	assert.Equal(t, component.ComponentID("restricted"), other.ID())
+	assert.NoError(t, manager.env().LoadPlugins(ctx, nil))
+}
+
+// TestScopedRuntimeCoversEveryMethod fails when a method is added to
+// RuntimeEnvironment without deciding how capability-restricted plugins see it.
+func TestScopedRuntimeCoversEveryMethod(t *testing.T) {
+	// Passed through unchanged on purpose
+	unscoped := map[string]bool{"ExecuteOperation": true, "IsRunning": true, "Logger": true, "Status": true}
+	// Checked on their arguments, see TestScopedRuntimeLifecycle and TestScopedPluginManagement
+	checked := map[string]bool{
+		"Start": true, "Stop": true, "StartService": true, "StopService": true,
+		"LoadPlugins": true, "PluginConfiguration": true,
+	}
+
+	p := newCapablePlugin("scoped", plugin.Capabilities{})
+	rt, _ := newScopedRuntime(t, p)
+	scoped := reflect.ValueOf(p.env())
+	direct := reflect.ValueOf(runtime.RuntimeEnvironment(rt))
+
+	var names []string
+	for _, iface := range []reflect.Type{
+		reflect.TypeOf((*runtime.RuntimeEnvironment)(nil)).Elem(),
+		reflect.TypeOf((*runtimeProviders)(nil)).Elem(),
+	} {
+		for i := 0; i < iface.NumMethod(); i++ {
+			names = append(names, iface.Method(i).Name)
+		}
+	}
+	for _, name := range names {
+		if unscoped[name] || checked[name] {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			method := scoped.MethodByName(name)
+			require.Zero(t, method.Type().NumIn(), "accessor %s takes arguments; list it as checked and test it", name)
+			got := method.Call(nil)[0].Interface()
+			want := direct.MethodByName(name).Call(nil)[0].Interface()
+			assert.False(t, got == want, "%s hands the unscoped service to a restricted plugin", name)
+		})
+	}
+}
+
+func TestScopedRuntimeLifecycle(t *testing.T) {
+	restricted := newCapablePlugin("restricted", plugin.Capabilities{})
+	admin := newCapablePlugin("admin", plugin.Capabilities{ManageRuntime: true})
+	newScopedRuntime(t, restricted, admin)
+	ctx := infraContext.NewContext()
+
+	for _, system := range []component.System{restricted.env(), restricted.system} {
+		err := system.StopService(ctx, "stores")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+		assert.Contains(t, err.Error(), "plugin 'restricted' may not stop service 'stores' registered by someone else")
+		assert.ErrorIs(t, system.StartService(ctx, "stores"), component.ErrCapabilityDenied)
+		assert.ErrorIs(t, system.Stop(ctx), component.ErrCapabilityDenied)
+		assert.ErrorIs(t, system.Start(ctx), component.ErrCapabilityDenied)
+	}
+
+	assert.NotErrorIs(t, restricted.env().StartService(ctx, "restricted"), component.ErrCapabilityDenied)
+	assert.NotErrorIs(t, admin.env().StartService(ctx, "stores"), component.ErrCapabilityDenied)
+}
+
+func TestScopedSupervisorAndPolicies(t *testing.T) {
+	p := newCapablePlugin("scoped", plugin.Capabilities{})
+	rt, _ := newScopedRuntime(t, p)
+	ctx := infraContext.NewContext()
+	env := p.providers()
+
+	err := env.Supervisor().Supervise("stores", supervisor.Policy{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+	assert.Contains(t, err.Error(), "may not supervise service 'stores' registered by someone else")
+	assert.ErrorIs(t, env.Supervisor().Unsupervise("stores"), component.ErrCapabilityDenied)
+	assert.ErrorIs(t, env.Supervisor().Stop(ctx), component.ErrCapabilityDenied)
+	assert.True(t, rt.Supervisor().IsRunning() == env.Supervisor().IsRunning())
+	assert.NotErrorIs(t, env.Supervisor().Supervise("scoped", supervisor.Policy{}), component.ErrCapabilityDenied)
+
+	assert.ErrorIs(t, env.Resilience().SetPolicy("stores", resilience.Policy{}), component.ErrCapabilityDenied)
+	assert.ErrorIs(t, env.Resilience().Reset("stores"), component.ErrCapabilityDenied)
+	assert.NotErrorIs(t, env.Resilience().SetPolicy("scoped", resilience.Policy{}), component.ErrCapabilityDenied)
+
+	dedup := env.Idempotency()
+	assert.ErrorIs(t, dedup.SetPolicy("stores", idempotency.Policy{}), component.ErrCapabilityDenied)
+	_, err = dedup.Lookup("stores", "key")
+	assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+	assert.ErrorIs(t, dedup.Forget("stores", "key"), component.ErrCapabilityDenied)
+	_, err = dedup.Purge()
+	assert.ErrorIs(t, err, component.ErrCapabilityDenied)
+	assert.NotErrorIs(t, dedup.SetPolicy("scoped", idempotency.Policy{}), component.ErrCapabilityDenied)
+}
+
+func TestScopedHealthMetricsAndInspector(t *testing.T) {
+	p := newCapablePlugin("scoped", plugin.Capabilities{Stores: []string{"orders"}})
+	rt, _ := newScopedRuntime(t, p)
+	ctx := infraContext.NewContext()
+	env := p.providers()
+
+	ok := func(context.Context) error { return nil }
+	env.Health().AddCheck("scoped.cache", health.KindReadiness, ok)
+	env.Health().AddCheck("stores", health.KindReadiness, func(context.Context) error { return assert.AnError })
+	reports := make(map[component.ComponentID]health.ComponentReport)
+	for _, c := range rt.Health().Readiness(ctx).Components {
+		reports[c.ID] = c
+	}
+	assert.Equal(t, health.StatusUp, reports["scoped.cache"].Status)
+	assert.NotEqual(t, assert.AnError.Error(), reports["stores"].Error)
+
+	assert.NotPanics(t, func() { env.Metrics().Counter("scoped_requests_total", "Requests.") })
+	assert.Panics(t, func() { env.Metrics().Counter(metrics.OperationExecutions, "Executions.") })
+	assert.Panics(t, func() { env.Metrics().Gauge(metrics.BuiltinPrefix+"fake", "Fake.") })
+
+	var names []string
+	for _, s := range env.Introspection().Topology().Stores {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"orders"}, names)
+	assert.Len(t, rt.Introspection().Stores(), 2)
+}
+
+func TestCapablePluginWithManifestDependencies(t *testing.T) {
+	manager := infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"})
+	result := &infraPlugin.DiscoveryResult{Manifests: []*infraPlugin.Manifest{
+		{ID: "scoped", Dependencies: []infraPlugin.ManifestDependency{{ID: "base"}}},
+	}}
+	result.AddDependencies(manager)
+
+	p := newCapablePlugin("scoped", plugin.Capabilities{PublishTopics: []string{"orders.*"}})
+	rt, _ := newScopedRuntimeWith(t, manager, p, newSystemPlugin("base"))
+
+	// The plugin keeps its capabilities
+	assert.ErrorIs(t, p.env().EventBus().Publish(&event.Event{Topic: "audit"}), component.ErrCapabilityDenied)
+	assert.NoError(t, p.env().EventBus().Publish(&event.Event{Topic: "orders.created"}))
+
+	// and the manifest dependencies apply
+	assert.Equal(t, []plugin.Dependency{{ID: "base"}}, manager.PluginDependencies("scoped"))
+	assert.Contains(t, rt.Introspection().Dependencies(), introspection.Edge{From: "scoped", To: "base", Kind: introspection.EdgeDependsOn})
+	err := manager.Unload(infraContext.NewContext(), "base")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrPluginUnloadFailed)
+	assert.Contains(t, err.Error(), "required by scoped")
+}
+
+func TestUnscopedPluginHasFullAccess(t *testing.T) {
+	p := newSystemPlugin("open")
+	newScopedRuntime(t, p)
+
+	env := p.env()
+	assert.NoError(t, env.EventBus().Publish(&event.Event{Topic: "anything"}))
+	_, err := env.PluginManager().GetPlugin("open")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", env.Configuration().GetString("db.password"))
+}
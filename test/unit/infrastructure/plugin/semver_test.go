@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"v1.2.3", "1.2.3", true},
+		{"1.2", "1.2.0", true},
+		{"1", "1.0.0", true},
+		{"1.2.3-beta.1", "1.2.3-beta.1", true},
+		{"1.2.3+build.7", "1.2.3", true},
+		{"", "", false},
+		{"1.x", "", false},
+		{"1.2.3.4", "", false},
+		{"one.two", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, err := infraPlugin.ParseVersion(tt.input)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, v.String())
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "2.0.0", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-rc.1", "1.0.0-beta", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, err := infraPlugin.ParseVersion(tt.a)
+			require.NoError(t, err)
+			b, err := infraPlugin.ParseVersion(tt.b)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, a.Compare(b))
+			assert.Equal(t, -tt.expected, b.Compare(a))
+		})
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"", "0.0.1", true},
+		{"*", "5.4.3", true},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.4", false},
+		{"=1.2.3", "1.2.3", true},
+		{"!=1.2.3", "1.2.3", false},
+		{">=1.2.0 <2.0.0", "1.9.9", true},
+		{">=1.2.0 <2.0.0", "2.0.0", false},
+		{">=1.2.0, <2.0.0", "1.1.0", false},
+		{">= 1.2.0", "1.2.0", true},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "1.2.2", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.4", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"1.x", "1.5.2", true},
+		{"1.x", "2.0.0", false},
+		{"1.2.*", "1.2.7", true},
+		{"1.2", "1.3.0", false},
+		{"^1.0 || ^3.0", "3.1.0", true},
+		{"^1.0 || ^3.0", "2.1.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			c, err := infraPlugin.ParseConstraint(tt.constraint)
+			require.NoError(t, err)
+			v, err := infraPlugin.ParseVersion(tt.version)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, c.Check(v))
+		})
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	invalid := []string{">=abc", "^1.2.3.4", "!=1.2", "^1.0 ||", ">=1.0.0 <two"}
+
+	for _, input := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, err := infraPlugin.ParseConstraint(input)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "component.invalid_item")
+		})
+	}
+}
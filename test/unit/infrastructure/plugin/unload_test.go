@@ -0,0 +1,169 @@
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/runtime"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+)
+
+// registeringPlugin registers a service and an operation and subscribes to a topic.
+type registeringPlugin struct {
+	*infraComponent.BaseService
+	service   *infraComponent.BaseService
+	operation *infraComponent.BaseOperation
+	received  int
+	initErr   error
+}
+
+func newRegisteringPlugin(id string) *registeringPlugin {
+	return &registeringPlugin{
+		BaseService: infraComponent.NewBaseService(component.ComponentConfig{ID: component.ComponentID(id), Name: id}),
+		service:     infraComponent.NewBaseService(component.ComponentConfig{ID: component.ComponentID(id + "-service")}),
+		operation:   infraComponent.NewBaseOperation(component.ComponentConfig{ID: component.ComponentID(id + "-operation")}),
+	}
+}
+
+func (p *registeringPlugin) Author() string                { return "test" }
+func (p *registeringPlugin) PluginType() plugin.PluginType { return plugin.TypeExtension }
+
+func (p *registeringPlugin) Initialize(ctx context.Context, system component.System) error {
+	if err := p.BaseService.Initialize(ctx, system); err != nil {
+		return err
+	}
+
+	registry := system.Registry()
+	if err := registry.Register(p.service); err != nil {
+		return err
+	}
+	if err := registry.Register(p.operation); err != nil {
+		return err
+	}
+
+	env := system.(runtime.RuntimeEnvironment)
+	env.EventBus().Subscribe("test.topic", func(*event.Event) { p.received++ })
+	return p.initErr
+}
+
+func (p *registeringPlugin) Start(ctx context.Context) error {
+	if err := p.BaseService.Start(ctx); err != nil {
+		return err
+	}
+	return p.service.Start(ctx)
+}
+
+func newTestRuntime(t *testing.T) (*infraRuntime.Runtime, *infraPlugin.Manager, *infraComponent.Registry, *infraEvent.EventBus) {
+	registry := infraComponent.NewRegistry()
+	manager := infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"})
+	eventBus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+
+	rt, err := infraRuntime.NewRuntime(registry, infraConfig.NewMemoryConfiguration(), manager, eventBus, logger)
+	require.NoError(t, err)
+	return rt, manager, registry, eventBus
+}
+
+func TestPluginManagerUnloadCleansUp(t *testing.T) {
+	rt, manager, registry, eventBus := newTestRuntime(t)
+	ctx := infraContext.NewContext()
+
+	p := newRegisteringPlugin("alpha")
+	require.NoError(t, rt.LoadPlugins(ctx, []plugin.Plugin{p}))
+	require.NoError(t, rt.Start(ctx))
+
+	assert.Equal(t, []component.ComponentID{"alpha-service", "alpha-operation"}, manager.OwnedComponents("alpha"))
+	assert.True(t, registry.Has("alpha-service"))
+	assert.True(t, p.service.IsRunning())
+
+	require.NoError(t, eventBus.Publish(&event.Event{Topic: "test.topic"}))
+	assert.Equal(t, 1, p.received)
+
+	require.NoError(t, manager.Unload(ctx, "alpha"))
+
+	assert.False(t, p.IsRunning())
+	assert.False(t, p.IsInitialized())
+	assert.False(t, p.service.IsRunning())
+	assert.False(t, p.service.IsInitialized())
+	assert.False(t, registry.Has("alpha-service"))
+	assert.False(t, registry.Has("alpha-operation"))
+	assert.Empty(t, manager.OwnedComponents("alpha"))
+	assert.Empty(t, manager.ListPlugins())
+
+	require.NoError(t, eventBus.Publish(&event.Event{Topic: "test.topic"}))
+	assert.Equal(t, 1, p.received, "subscription should be cancelled")
+}
+
+func TestPluginManagerInitializeFailureReleasesResources(t *testing.T) {
+	rt, manager, registry, eventBus := newTestRuntime(t)
+	ctx := infraContext.NewContext()
+
+	p := newRegisteringPlugin("alpha")
+	p.initErr = errors.New("init failed")
+
+	err := rt.LoadPlugins(ctx, []plugin.Plugin{p})
+	assert.ErrorIs(t, err, p.initErr)
+
+	assert.False(t, registry.Has("alpha-service"))
+	assert.False(t, registry.Has("alpha-operation"))
+	assert.Empty(t, manager.OwnedComponents("alpha"))
+	assert.NotContains(t, eventBus.Subscriptions(), "test.topic")
+}
+
+func TestPluginManagerSwapPlugin(t *testing.T) {
+	rt, manager, registry, _ := newTestRuntime(t)
+	ctx := infraContext.NewContext()
+
+	require.NoError(t, rt.LoadPlugins(ctx, []plugin.Plugin{newRegisteringPlugin("alpha")}))
+	require.NoError(t, rt.Start(ctx))
+	require.NoError(t, manager.Remove("alpha"))
+
+	// The same component IDs can be registered again by a replacement plugin
+	replacement := newRegisteringPlugin("alpha")
+	require.NoError(t, rt.LoadPlugins(ctx, []plugin.Plugin{replacement}))
+	require.NoError(t, manager.StartPlugin(ctx, "alpha"))
+
+	assert.True(t, registry.Has("alpha-service"))
+	assert.True(t, replacement.service.IsRunning())
+}
+
+func TestPluginManagerUnloadRequiredPlugin(t *testing.T) {
+	manager := infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"})
+	ctx := infraContext.NewContext()
+
+	require.NoError(t, manager.Add("db", newTestPlugin("db", "1.0.0", nil)))
+	require.NoError(t, manager.Add("web", newTestPlugin("web", "1.0.0", nil, plugin.Dependency{ID: "db"})))
+
+	err := manager.Unload(ctx, "db")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrPluginUnloadFailed)
+	assert.Contains(t, err.Error(), "plugin 'db' is required by web")
+	assert.Len(t, manager.ListPlugins(), 2)
+
+	// Unloading the dependent first releases the dependency
+	require.NoError(t, manager.Unload(ctx, "web"))
+	require.NoError(t, manager.Unload(ctx, "db"))
+	assert.Empty(t, manager.ListPlugins())
+}
+
+func TestPluginManagerUnloadNotFound(t *testing.T) {
+	manager := infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"})
+
+	err := manager.Unload(infraContext.NewContext(), "missing")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, component.ErrComponentNotFound)
+}
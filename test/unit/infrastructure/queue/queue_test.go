@@ -0,0 +1,695 @@
+package queue
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/queue"
+	domainRuntime "github.com/fintechain/skeleton/internal/domain/runtime"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraQueue "github.com/fintechain/skeleton/internal/infrastructure/queue"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraStorage "github.com/fintechain/skeleton/internal/infrastructure/storage"
+	"github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+)
+
+// recorder is an operation that records the inputs it receives and
+// delegates to handle, which gets the number of the call.
+type recorder struct {
+	*infraComponent.BaseOperation
+	mu     sync.Mutex
+	inputs []component.Input
+	handle func(ctx context.Context, call int) error
+}
+
+func newRecorder(id component.ComponentID, handle func(ctx context.Context, call int) error) *recorder {
+	return &recorder{
+		BaseOperation: infraComponent.NewBaseOperation(component.ComponentConfig{ID: id}),
+		handle:        handle,
+	}
+}
+
+func (r *recorder) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	r.mu.Lock()
+	r.inputs = append(r.inputs, input)
+	call := len(r.inputs)
+	r.mu.Unlock()
+	if r.handle != nil {
+		if err := r.handle(ctx, call); err != nil {
+			return component.Output{}, err
+		}
+	}
+	return component.Output{Data: input.Data}, nil
+}
+
+func (r *recorder) calls() []component.Input {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]component.Input(nil), r.inputs...)
+}
+
+// txStore is a memory store with buffered transactions, standing in for a
+// transactional engine.
+type txStore struct {
+	*memory.Store
+	commits     atomic.Int32
+	failDeletes atomic.Int32 // commits that delete a job and fail
+}
+
+func (s *txStore) SupportsTransactions() bool { return true }
+
+func (s *txStore) BeginTx() (storage.Transaction, error) {
+	return &tx{Store: s.Store, parent: s, writes: map[string][]byte{}, active: true}, nil
+}
+
+type tx struct {
+	*memory.Store
+	parent *txStore
+	writes map[string][]byte // nil values are deletions
+	active bool
+}
+
+func (t *tx) Get(key []byte) ([]byte, error) {
+	if value, ok := t.writes[string(key)]; ok {
+		if value == nil {
+			return nil, storage.ErrKeyNotFound
+		}
+		return value, nil
+	}
+	return t.Store.Get(key)
+}
+
+func (t *tx) Set(key, value []byte) error {
+	t.writes[string(key)] = value
+	return nil
+}
+
+func (t *tx) Delete(key []byte) error {
+	t.writes[string(key)] = nil
+	return nil
+}
+
+func (t *tx) Commit() error {
+	for key, value := range t.writes {
+		if value == nil && strings.HasPrefix(key, "job/") && t.parent.failDeletes.Add(-1) >= 0 {
+			t.active = false
+			return errors.New("disk full")
+		}
+	}
+	for key, value := range t.writes {
+		if value == nil {
+			t.Store.Delete([]byte(key))
+		} else {
+			t.Store.Set([]byte(key), value)
+		}
+	}
+	t.active = false
+	t.parent.commits.Add(1)
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	t.active = false
+	return nil
+}
+
+func (t *tx) IsActive() bool { return t.active }
+
+type txEngine struct {
+	stores []*txStore
+}
+
+func (e *txEngine) Name() string { return "tx-memory" }
+
+func (e *txEngine) Create(name, path string, config storage.Config) (storage.Store, error) {
+	store := &txStore{Store: memory.NewStore(name, path)}
+	e.stores = append(e.stores, store)
+	return store, nil
+}
+
+func (e *txEngine) Open(name, path string) (storage.Store, error) {
+	return e.Create(name, path, nil)
+}
+
+func (e *txEngine) Capabilities() storage.Capabilities {
+	return storage.Capabilities{Transactions: true}
+}
+
+// rangeStore is a memory store that supports range queries, and records the
+// most entries one of them visited.
+type rangeStore struct {
+	*memory.Store
+	mu         sync.Mutex
+	maxVisited int
+}
+
+func (s *rangeStore) SupportsRangeQueries() bool { return true }
+
+func (s *rangeStore) IterateRange(start, end []byte, ascending bool, fn func(key, value []byte) bool) error {
+	var keys []string
+	values := map[string][]byte{}
+	s.Store.Iterate(func(key, value []byte) bool {
+		if string(key) >= string(start) && string(key) < string(end) {
+			keys = append(keys, string(key))
+			values[string(key)] = value
+		}
+		return true
+	})
+	sort.Strings(keys)
+
+	visited := 0
+	for _, key := range keys {
+		visited++
+		if !fn([]byte(key), values[key]) {
+			break
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if visited > s.maxVisited {
+		s.maxVisited = visited
+	}
+	return nil
+}
+
+type rangeEngine struct {
+	stores []*rangeStore
+}
+
+func (e *rangeEngine) Name() string { return "range-memory" }
+
+func (e *rangeEngine) Create(name, path string, config storage.Config) (storage.Store, error) {
+	store := &rangeStore{Store: memory.NewStore(name, path)}
+	e.stores = append(e.stores, store)
+	return store, nil
+}
+
+func (e *rangeEngine) Open(name, path string) (storage.Store, error) {
+	return e.Create(name, path, nil)
+}
+
+func (e *rangeEngine) Capabilities() storage.Capabilities {
+	return storage.Capabilities{RangeQueries: true}
+}
+
+// newEnvironment returns a runtime with the operations registered and a
+// multi-store with the memory and transactional engines.
+func newEnvironment(t *testing.T, operations ...component.Operation) (*infraRuntime.Runtime, *infraStorage.MultiStore, *infraEvent.EventBus) {
+	t.Helper()
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	rt, err := infraRuntime.NewRuntime(
+		infraComponent.NewRegistry(),
+		infraConfig.NewMemoryConfiguration(),
+		infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}),
+		bus,
+		logger,
+	)
+	require.NoError(t, err)
+	for _, op := range operations {
+		require.NoError(t, rt.Registry().Register(op))
+	}
+
+	multiStore := infraStorage.NewMultiStore(component.ComponentConfig{ID: "multi-store"}, t.TempDir())
+	require.NoError(t, multiStore.RegisterEngine(memory.NewEngine()))
+	return rt, multiStore, bus
+}
+
+func newQueue(t *testing.T, rt *infraRuntime.Runtime, multiStore *infraStorage.MultiStore, options infraQueue.Options) *infraQueue.Queue {
+	t.Helper()
+	if options.PollInterval == 0 {
+		options.PollInterval = 10 * time.Millisecond
+	}
+	q := infraQueue.NewQueue(component.ComponentConfig{ID: "queue"}, multiStore, options)
+	require.NoError(t, q.Initialize(infraContext.NewContext(), rt))
+	t.Cleanup(func() { _ = q.Stop(infraContext.NewContext()) })
+	return q
+}
+
+// collect records the payloads published on the topics.
+func collect(bus *infraEvent.EventBus, topics ...string) func(topic string) []map[string]interface{} {
+	var mu sync.Mutex
+	seen := map[string][]map[string]interface{}{}
+	for _, topic := range topics {
+		bus.Subscribe(topic, func(evt *event.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen[evt.Topic] = append(seen[evt.Topic], evt.Payload)
+		})
+	}
+	return func(topic string) []map[string]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]interface{}(nil), seen[topic]...)
+	}
+}
+
+func awaitStats(t *testing.T, q *infraQueue.Queue, expected queue.Stats) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		stats, err := q.Stats()
+		return err == nil && stats == expected
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestQueueDeliversJobs(t *testing.T) {
+	op := newRecorder("transfer", nil)
+	rt, multiStore, bus := newEnvironment(t, op)
+	events := collect(bus, queue.TopicJobEnqueued, queue.TopicJobCompleted)
+	q := newQueue(t, rt, multiStore, infraQueue.Options{})
+
+	jobID, err := q.Enqueue("transfer", component.Input{
+		Data:     map[string]interface{}{"amount": 100.0},
+		Metadata: map[string]string{"tenant": "acme"},
+	}, queue.EnqueueOptions{})
+	require.NoError(t, err)
+
+	job, err := q.Get(jobID)
+	require.NoError(t, err)
+	assert.Equal(t, infraQueue.DefaultMaxAttempts, job.MaxAttempts)
+	assert.Equal(t, infraQueue.DefaultVisibilityTimeout, job.VisibilityTimeout)
+	assert.Empty(t, op.calls(), "jobs wait for the queue to start")
+
+	require.NoError(t, q.Start(infraContext.NewContext()))
+	awaitStats(t, q, queue.Stats{})
+	assert.Eventually(t, func() bool { return len(events(queue.TopicJobCompleted)) == 1 }, time.Second, 5*time.Millisecond)
+
+	calls := op.calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, map[string]interface{}{"amount": 100.0}, calls[0].Data)
+	assert.Equal(t, map[string]string{"tenant": "acme", queue.MetadataJobID: jobID, queue.MetadataAttempt: "1"}, calls[0].Metadata)
+
+	_, err = q.Get(jobID)
+	assert.Contains(t, err.Error(), queue.ErrJobNotFound, "completed jobs are removed")
+	require.Len(t, events(queue.TopicJobEnqueued), 1)
+	assert.Equal(t, jobID, events(queue.TopicJobCompleted)[0]["jobId"])
+}
+
+func TestQueuePrioritiesAndDelays(t *testing.T) {
+	op := newRecorder("notify", nil)
+	rt, multiStore, _ := newEnvironment(t, op)
+	q := newQueue(t, rt, multiStore, infraQueue.Options{Workers: 1})
+
+	for _, job := range []struct {
+		name    string
+		options queue.EnqueueOptions
+	}{
+		{"delayed", queue.EnqueueOptions{Priority: 100, Delay: 60 * time.Millisecond}},
+		{"low", queue.EnqueueOptions{Priority: -1}},
+		{"normal", queue.EnqueueOptions{}},
+		{"high", queue.EnqueueOptions{Priority: 10}},
+		{"normal-later", queue.EnqueueOptions{}},
+	} {
+		_, err := q.Enqueue("notify", component.Input{Data: job.name}, job.options)
+		require.NoError(t, err)
+	}
+
+	stats, err := q.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, queue.Stats{Ready: 4, Delayed: 1}, stats)
+
+	require.NoError(t, q.Start(infraContext.NewContext()))
+	assert.Eventually(t, func() bool { return len(op.calls()) == 5 }, time.Second, 5*time.Millisecond)
+
+	var order []any
+	for _, input := range op.calls() {
+		order = append(order, input.Data)
+	}
+	assert.Equal(t, []any{"high", "normal", "normal-later", "low", "delayed"}, order)
+}
+
+func TestQueueRetries(t *testing.T) {
+	op := newRecorder("settle", func(ctx context.Context, call int) error {
+		if call < 3 {
+			return errors.New("ledger unavailable")
+		}
+		return nil
+	})
+	rt, multiStore, bus := newEnvironment(t, op)
+	events := collect(bus, queue.TopicJobRetrying, queue.TopicJobCompleted)
+	q := newQueue(t, rt, multiStore, infraQueue.Options{InitialBackoff: 10 * time.Millisecond})
+	require.NoError(t, q.Start(infraContext.NewContext()))
+
+	started := time.Now()
+	jobID, err := q.Enqueue("settle", component.Input{}, queue.EnqueueOptions{MaxAttempts: 3})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return len(events(queue.TopicJobCompleted)) == 1 }, time.Second, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(started), 30*time.Millisecond, "backoff doubles: 10ms then 20ms")
+
+	retries := events(queue.TopicJobRetrying)
+	require.Len(t, retries, 2)
+	assert.Equal(t, "10ms", retries[0]["backoff"])
+	assert.Equal(t, "20ms", retries[1]["backoff"])
+	assert.Equal(t, "ledger unavailable", retries[1]["error"])
+
+	calls := op.calls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, jobID, calls[2].Metadata[queue.MetadataJobID])
+	assert.Equal(t, "3", calls[2].Metadata[queue.MetadataAttempt])
+}
+
+func TestQueueDeadLetters(t *testing.T) {
+	var healthy atomic.Bool
+	op := newRecorder("settle", func(ctx context.Context, call int) error {
+		if !healthy.Load() {
+			return errors.New("account frozen")
+		}
+		return nil
+	})
+	rt, multiStore, bus := newEnvironment(t, op)
+	events := collect(bus, queue.TopicJobDeadLettered, queue.TopicJobCompleted)
+	q := newQueue(t, rt, multiStore, infraQueue.Options{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	require.NoError(t, q.Start(infraContext.NewContext()))
+
+	jobID, err := q.Enqueue("settle", component.Input{Data: "payment-1"}, queue.EnqueueOptions{})
+	require.NoError(t, err)
+	awaitStats(t, q, queue.Stats{Dead: 1})
+
+	dead, err := q.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, jobID, dead[0].ID)
+	assert.Equal(t, 2, dead[0].Attempts)
+	assert.Equal(t, "account frozen", dead[0].LastError)
+	assert.False(t, dead[0].DeadAt.IsZero())
+	require.Len(t, events(queue.TopicJobDeadLettered), 1)
+
+	store, err := multiStore.GetStore(infraQueue.DefaultDeadLetterStore)
+	require.NoError(t, err)
+	found, err := store.Has([]byte("job/" + jobID))
+	require.NoError(t, err)
+	assert.True(t, found, "dead letters are kept in their own store")
+
+	t.Run("Redriven jobs run again", func(t *testing.T) {
+		healthy.Store(true)
+		require.NoError(t, q.Redrive(jobID))
+		assert.Eventually(t, func() bool { return len(events(queue.TopicJobCompleted)) == 1 }, time.Second, 5*time.Millisecond)
+		awaitStats(t, q, queue.Stats{})
+
+		calls := op.calls()
+		assert.Equal(t, "1", calls[len(calls)-1].Metadata[queue.MetadataAttempt], "attempts are reset")
+		assert.Contains(t, q.Redrive(jobID).Error(), queue.ErrJobNotFound)
+	})
+
+	t.Run("Discarded jobs are deleted", func(t *testing.T) {
+		healthy.Store(false)
+		jobID, err := q.Enqueue("settle", component.Input{}, queue.EnqueueOptions{MaxAttempts: 1})
+		require.NoError(t, err)
+		awaitStats(t, q, queue.Stats{Dead: 1})
+
+		require.NoError(t, q.Discard(jobID))
+		awaitStats(t, q, queue.Stats{})
+		assert.Contains(t, q.Discard(jobID).Error(), queue.ErrJobNotFound)
+	})
+}
+
+func TestQueueDeadLetterDuplicates(t *testing.T) {
+	op := newRecorder("settle", func(ctx context.Context, call int) error {
+		return errors.New("account frozen")
+	})
+	rt, multiStore, bus := newEnvironment(t, op)
+	engine := &txEngine{}
+	require.NoError(t, multiStore.RegisterEngine(engine))
+	events := collect(bus, queue.TopicJobDeadLettered)
+	q := newQueue(t, rt, multiStore, infraQueue.Options{Engine: "tx-memory"})
+
+	jobID, err := q.Enqueue("settle", component.Input{}, queue.EnqueueOptions{MaxAttempts: 1, VisibilityTimeout: 20 * time.Millisecond})
+	require.NoError(t, err)
+	engine.stores[0].failDeletes.Store(1)
+	require.NoError(t, q.Start(infraContext.NewContext()))
+
+	// The live record outlives the dead one written before it, until its lease expires
+	awaitStats(t, q, queue.Stats{Dead: 1})
+	assert.Len(t, op.calls(), 1)
+	require.Len(t, events(queue.TopicJobDeadLettered), 1)
+
+	dead, err := q.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, jobID, dead[0].ID)
+	assert.Equal(t, "account frozen", dead[0].LastError, "the dead record written first is kept")
+	assert.Negative(t, engine.stores[0].failDeletes.Load(), "the first delete failed and a later one succeeded")
+}
+
+func TestQueueDueIndex(t *testing.T) {
+	op := newRecorder("notify", nil)
+	rt, multiStore, _ := newEnvironment(t, op)
+	engine := &rangeEngine{}
+	require.NoError(t, multiStore.RegisterEngine(engine))
+	q := newQueue(t, rt, multiStore, infraQueue.Options{Engine: "range-memory"})
+
+	for i := 0; i < 50; i++ {
+		_, err := q.Enqueue("notify", component.Input{}, queue.EnqueueOptions{Delay: time.Hour})
+		require.NoError(t, err)
+	}
+	_, err := q.Enqueue("notify", component.Input{Data: "now"}, queue.EnqueueOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, q.Start(infraContext.NewContext()))
+	awaitStats(t, q, queue.Stats{Delayed: 50})
+	require.Len(t, op.calls(), 1)
+	assert.Equal(t, "now", op.calls()[0].Data)
+
+	live := engine.stores[0]
+	live.mu.Lock()
+	defer live.mu.Unlock()
+	assert.LessOrEqual(t, live.maxVisited, 2, "claims read the ready jobs and the next one due only")
+}
+
+func TestQueueVisibilityTimeout(t *testing.T) {
+	release := make(chan struct{})
+	op := newRecorder("export", func(ctx context.Context, call int) error {
+		if call == 1 {
+			<-release
+		}
+		return nil
+	})
+	rt, multiStore, bus := newEnvironment(t, op)
+	events := collect(bus, queue.TopicJobCompleted)
+	q := newQueue(t, rt, multiStore, infraQueue.Options{})
+	require.NoError(t, q.Start(infraContext.NewContext()))
+
+	jobID, err := q.Enqueue("export", component.Input{}, queue.EnqueueOptions{VisibilityTimeout: 30 * time.Millisecond})
+	require.NoError(t, err)
+
+	// The first delivery outlives its visibility timeout, so the job is delivered again
+	assert.Eventually(t, func() bool { return len(events(queue.TopicJobCompleted)) == 1 }, time.Second, 5*time.Millisecond)
+	calls := op.calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "2", calls[1].Metadata[queue.MetadataAttempt])
+	assert.Equal(t, jobID, calls[1].Metadata[queue.MetadataJobID])
+
+	// The late delivery no longer holds the lease and settles nothing
+	close(release)
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, events(queue.TopicJobCompleted), 1)
+	assert.Len(t, op.calls(), 2)
+}
+
+func TestQueueVisibilityTimeoutExhaustsAttempts(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	op := newRecorder("hang", func(ctx context.Context, call int) error {
+		<-release
+		return nil
+	})
+	rt, multiStore, bus := newEnvironment(t, op)
+	events := collect(bus, queue.TopicJobDeadLettered)
+	q := newQueue(t, rt, multiStore, infraQueue.Options{Workers: 4})
+	require.NoError(t, q.Start(infraContext.NewContext()))
+
+	jobID, err := q.Enqueue("hang", component.Input{}, queue.EnqueueOptions{MaxAttempts: 2, VisibilityTimeout: 20 * time.Millisecond})
+	require.NoError(t, err)
+
+	// Every delivery hangs past its visibility timeout, so the job runs out of attempts
+	assert.Eventually(t, func() bool { return len(events(queue.TopicJobDeadLettered)) == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, op.calls(), 2)
+
+	dead, err := q.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, jobID, dead[0].ID)
+	assert.Equal(t, 2, dead[0].Attempts)
+	assert.Contains(t, dead[0].LastError, string(queue.ErrLeaseLost))
+	awaitStats(t, q, queue.Stats{Dead: 1})
+}
+
+func TestQueueDurability(t *testing.T) {
+	t.Run("Jobs survive a restart", func(t *testing.T) {
+		op := newRecorder("transfer", nil)
+		rt, multiStore, _ := newEnvironment(t, op)
+
+		first := newQueue(t, rt, multiStore, infraQueue.Options{})
+		jobID, err := first.Enqueue("transfer", component.Input{Data: "t-1"}, queue.EnqueueOptions{})
+		require.NoError(t, err)
+
+		second := newQueue(t, rt, multiStore, infraQueue.Options{})
+		job, err := second.Get(jobID)
+		require.NoError(t, err)
+		assert.Equal(t, "t-1", job.Input.Data)
+
+		require.NoError(t, second.Start(infraContext.NewContext()))
+		awaitStats(t, second, queue.Stats{})
+		assert.Len(t, op.calls(), 1)
+	})
+
+	t.Run("Stopping releases deliveries in progress", func(t *testing.T) {
+		op := newRecorder("block", func(ctx context.Context, call int) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		rt, multiStore, _ := newEnvironment(t, op)
+		q := newQueue(t, rt, multiStore, infraQueue.Options{})
+		require.NoError(t, q.Start(infraContext.NewContext()))
+
+		jobID, err := q.Enqueue("block", component.Input{}, queue.EnqueueOptions{})
+		require.NoError(t, err)
+		awaitStats(t, q, queue.Stats{InFlight: 1})
+
+		require.NoError(t, q.Stop(infraContext.NewContext()))
+		job, err := q.Get(jobID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, job.Attempts, "cancelled deliveries do not count")
+		assert.True(t, job.LeasedUntil.IsZero())
+		awaitStats(t, q, queue.Stats{Ready: 1})
+	})
+
+	t.Run("Undecodable records are skipped", func(t *testing.T) {
+		op := newRecorder("transfer", nil)
+		rt, multiStore, _ := newEnvironment(t, op)
+		q := newQueue(t, rt, multiStore, infraQueue.Options{})
+		_, err := q.Enqueue("transfer", component.Input{}, queue.EnqueueOptions{})
+		require.NoError(t, err)
+
+		for _, name := range []string{infraQueue.DefaultStore, infraQueue.DefaultDeadLetterStore} {
+			store, err := multiStore.GetStore(name)
+			require.NoError(t, err)
+			require.NoError(t, store.Set([]byte("job/corrupt"), []byte("{not json")))
+		}
+		_, err = q.DeadLetters()
+		require.NoError(t, err)
+		awaitStats(t, q, queue.Stats{Ready: 1})
+
+		require.NoError(t, q.Start(infraContext.NewContext()))
+		awaitStats(t, q, queue.Stats{})
+		assert.Len(t, op.calls(), 1)
+	})
+
+	t.Run("Transactional stores are updated in transactions", func(t *testing.T) {
+		op := newRecorder("transfer", nil)
+		rt, multiStore, _ := newEnvironment(t, op)
+		engine := &txEngine{}
+		require.NoError(t, multiStore.RegisterEngine(engine))
+
+		q := newQueue(t, rt, multiStore, infraQueue.Options{Engine: "tx-memory"})
+		require.NoError(t, q.Start(infraContext.NewContext()))
+		_, err := q.Enqueue("transfer", component.Input{}, queue.EnqueueOptions{})
+		require.NoError(t, err)
+		awaitStats(t, q, queue.Stats{})
+
+		require.Len(t, engine.stores, 2)
+		assert.EqualValues(t, 3, engine.stores[0].commits.Load(), "enqueue, claim and completion")
+	})
+}
+
+func TestQueueRuntimeShutdown(t *testing.T) {
+	t.Run("Rejected deliveries are released, not dead-lettered", func(t *testing.T) {
+		op := newRecorder("transfer", nil)
+		rt, multiStore, _ := newEnvironment(t, op)
+		q := newQueue(t, rt, multiStore, infraQueue.Options{MaxAttempts: 1})
+		require.NoError(t, q.Start(infraContext.NewContext()))
+		_ = rt.Shutdown(infraContext.NewContext(), infraRuntime.ShutdownOptions{})
+
+		var ids []string
+		for i := 0; i < 3; i++ {
+			id, err := q.Enqueue("transfer", component.Input{}, queue.EnqueueOptions{})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		time.Sleep(50 * time.Millisecond)
+
+		dead, err := q.DeadLetters()
+		require.NoError(t, err)
+		assert.Empty(t, dead)
+		assert.Empty(t, op.calls())
+		for _, id := range ids {
+			job, err := q.Get(id)
+			require.NoError(t, err)
+			assert.Equal(t, 0, job.Attempts, "rejected deliveries do not count")
+		}
+		awaitStats(t, q, queue.Stats{Ready: 3})
+	})
+
+	t.Run("The stop phase releases deliveries in progress and stops claiming", func(t *testing.T) {
+		op := newRecorder("block", func(ctx context.Context, call int) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		rt, multiStore, bus := newEnvironment(t, op)
+		q := newQueue(t, rt, multiStore, infraQueue.Options{MaxAttempts: 1})
+		require.NoError(t, q.Start(infraContext.NewContext()))
+
+		jobID, err := q.Enqueue("block", component.Input{}, queue.EnqueueOptions{})
+		require.NoError(t, err)
+		awaitStats(t, q, queue.Stats{InFlight: 1})
+
+		require.NoError(t, bus.Publish(&event.Event{Topic: domainRuntime.TopicRuntimeStop}))
+		awaitStats(t, q, queue.Stats{Ready: 1})
+		time.Sleep(50 * time.Millisecond)
+		assert.Len(t, op.calls(), 1, "no job is claimed after the stop phase")
+
+		job, err := q.Get(jobID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, job.Attempts)
+		dead, err := q.DeadLetters()
+		require.NoError(t, err)
+		assert.Empty(t, dead)
+	})
+}
+
+func TestQueueErrors(t *testing.T) {
+	rt, multiStore, _ := newEnvironment(t)
+	q := newQueue(t, rt, multiStore, infraQueue.Options{})
+
+	for _, options := range []queue.EnqueueOptions{
+		{Delay: -time.Second},
+		{MaxAttempts: -1},
+		{VisibilityTimeout: -time.Second},
+	} {
+		_, err := q.Enqueue("transfer", component.Input{}, options)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, queue.ErrInvalidJob)
+	}
+
+	_, err := q.Enqueue("", component.Input{}, queue.EnqueueOptions{})
+	assert.ErrorIs(t, err, queue.ErrInvalidJob)
+
+	_, err = q.Enqueue("transfer", component.Input{Data: make(chan int)}, queue.EnqueueOptions{})
+	assert.Contains(t, err.Error(), queue.ErrInvalidJob, "input must be persistable")
+
+	_, err = q.Get("missing")
+	assert.ErrorIs(t, err, queue.ErrJobNotFound)
+
+	detached := infraQueue.NewQueue(component.ComponentConfig{ID: "detached"}, nil, infraQueue.Options{})
+	_, err = detached.Enqueue("transfer", component.Input{}, queue.EnqueueOptions{})
+	assert.ErrorIs(t, err, queue.ErrStoreUnavailable)
+}
@@ -0,0 +1,503 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraResilience "github.com/fintechain/skeleton/internal/infrastructure/resilience"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+)
+
+// scriptedOperation fails its first calls with the scripted errors, then
+// succeeds.
+type scriptedOperation struct {
+	*infraComponent.TypedOperation[any, any]
+	calls atomic.Int32
+}
+
+func newScriptedOperation(properties component.Metadata, errs ...error) *scriptedOperation {
+	op := &scriptedOperation{}
+	op.TypedOperation = infraComponent.NewTypedOperation(
+		component.ComponentConfig{ID: "quote", Name: "Quote", Properties: properties},
+		func(ctx context.Context, input any) (any, error) {
+			n := int(op.calls.Add(1))
+			if n <= len(errs) && errs[n-1] != nil {
+				return nil, errs[n-1]
+			}
+			return "ok", nil
+		},
+	)
+	return op
+}
+
+// recorder collects resilience events.
+type recorder struct {
+	mu     sync.Mutex
+	events []*event.Event
+}
+
+func newRecorder() (*recorder, *infraEvent.EventBus) {
+	r := &recorder{}
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	for _, topic := range []string{
+		resilience.TopicBreakerOpened,
+		resilience.TopicBreakerHalfOpen,
+		resilience.TopicBreakerClosed,
+		resilience.TopicOperationRetrying,
+	} {
+		bus.Subscribe(topic, func(evt *event.Event) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.events = append(r.events, evt)
+		})
+	}
+	return r, bus
+}
+
+func (r *recorder) topics() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	topics := make([]string, 0, len(r.events))
+	for _, e := range r.events {
+		topics = append(topics, e.Topic)
+	}
+	return topics
+}
+
+var errBackend = errors.New("backend unavailable")
+
+func TestGuardTimeout(t *testing.T) {
+	var cancelled atomic.Bool
+	op := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "slow"},
+		func(ctx context.Context, input any) (any, error) {
+			<-ctx.Done()
+			cancelled.Store(true)
+			return nil, ctx.Err()
+		})
+	guard := infraResilience.NewGuard(nil, nil, nil)
+	require.NoError(t, guard.SetPolicy("slow", resilience.Policy{Timeout: 20 * time.Millisecond}))
+
+	_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+	require.Error(t, err)
+	assert.Equal(t, "resilience.operation_timeout: operation 'slow' not done after 20ms", err.Error())
+	assert.Eventually(t, cancelled.Load, time.Second, 5*time.Millisecond, "the attempt context is cancelled")
+
+	state, err := guard.State("slow")
+	require.NoError(t, err)
+	assert.Equal(t, 1, state.Timeouts)
+}
+
+func TestGuardAbandonedAttempts(t *testing.T) {
+	release := make(chan struct{})
+	var finished atomic.Bool
+	op := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "stuck"},
+		func(ctx context.Context, input any) (any, error) {
+			<-release // ignores cancellation
+			finished.Store(true)
+			return nil, nil
+		})
+	guard := infraResilience.NewGuard(nil, nil, nil)
+	require.NoError(t, guard.SetPolicy("stuck", resilience.Policy{Timeout: 10 * time.Millisecond}))
+
+	_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+	assert.ErrorIs(t, err, resilience.ErrOperationTimeout)
+	assert.Equal(t, 1, guard.Abandoned())
+
+	waited := make(chan struct{})
+	go func() {
+		guard.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("Wait returned before the abandoned attempt")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-waited
+	assert.True(t, finished.Load())
+	assert.Zero(t, guard.Abandoned())
+
+	t.Run("Attempts started while waiting are waited for", func(t *testing.T) {
+		first, second := make(chan struct{}), make(chan struct{})
+		var calls atomic.Int32
+		op := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "stuck"},
+			func(ctx context.Context, input any) (any, error) {
+				if calls.Add(1) == 1 {
+					<-first
+				} else {
+					<-second
+				}
+				return nil, nil
+			})
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		require.NoError(t, guard.SetPolicy("stuck", resilience.Policy{Timeout: 10 * time.Millisecond}))
+
+		_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		assert.ErrorIs(t, err, resilience.ErrOperationTimeout)
+		waited := make(chan struct{})
+		go func() {
+			guard.Wait()
+			close(waited)
+		}()
+
+		_, err = guard.Execute(infraContext.NewContext(), op, component.Input{})
+		assert.ErrorIs(t, err, resilience.ErrOperationTimeout)
+		close(first)
+		select {
+		case <-waited:
+			t.Fatal("Wait returned before the second attempt")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(second)
+		<-waited
+		assert.Zero(t, guard.Abandoned())
+	})
+}
+
+func TestGuardPanics(t *testing.T) {
+	op := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "broken"},
+		func(ctx context.Context, input any) (any, error) { panic("boom") })
+
+	for name, policy := range map[string]resilience.Policy{
+		"Without a timeout": {Breaker: resilience.BreakerPolicy{FailureThreshold: 5}},
+		"With a timeout":    {Timeout: time.Second},
+	} {
+		t.Run(name, func(t *testing.T) {
+			guard := infraResilience.NewGuard(nil, nil, nil)
+			require.NoError(t, guard.SetPolicy("broken", policy))
+
+			_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+			require.Error(t, err)
+			assert.ErrorIs(t, err, component.ErrOperationFailed)
+			assert.Equal(t, "component.operation_failed: operation 'broken' panicked: boom", err.Error())
+
+			state, err := guard.State("broken")
+			require.NoError(t, err)
+			assert.Equal(t, 1, state.Failures)
+		})
+	}
+}
+
+func TestGuardRetry(t *testing.T) {
+	retry := resilience.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Jitter: 0.5}
+
+	t.Run("Transient errors are retried", func(t *testing.T) {
+		recorder, bus := newRecorder()
+		guard := infraResilience.NewGuard(nil, bus, nil)
+		op := newScriptedOperation(nil, infraResilience.Transient(errBackend), infraResilience.Transient(errBackend))
+		require.NoError(t, guard.SetPolicy("quote", resilience.Policy{Retry: retry}))
+
+		output, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", output.Data)
+		assert.Equal(t, []string{resilience.TopicOperationRetrying, resilience.TopicOperationRetrying}, recorder.topics())
+
+		state, err := guard.State("quote")
+		require.NoError(t, err)
+		assert.Equal(t, 3, state.Calls)
+		assert.Equal(t, 2, state.Retries)
+		assert.Equal(t, 2, state.Failures)
+	})
+
+	t.Run("Unclassified errors are not retried", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		op := newScriptedOperation(nil, errBackend)
+		require.NoError(t, guard.SetPolicy("quote", resilience.Policy{Retry: retry}))
+
+		_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		assert.Equal(t, errBackend, err)
+		assert.EqualValues(t, 1, op.calls.Load())
+	})
+
+	t.Run("Errors with a listed code are retried", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		op := newScriptedOperation(nil, failure.New("storage.connection_failed", "refused"))
+		policy := retry
+		policy.RetryOn = []string{"storage.connection_failed"}
+		require.NoError(t, guard.SetPolicy("quote", resilience.Policy{Retry: policy}))
+
+		_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, op.calls.Load())
+	})
+
+	t.Run("Exhausted retries report the last error", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		transient := infraResilience.Transient(errBackend)
+		op := newScriptedOperation(nil, transient, transient, transient)
+		require.NoError(t, guard.SetPolicy("quote", resilience.Policy{Retry: retry}))
+
+		_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, resilience.ErrRetriesExhausted)
+		assert.ErrorIs(t, err, errBackend)
+		assert.EqualValues(t, 3, op.calls.Load())
+	})
+
+	t.Run("Timeouts are retried only when the policy opts in", func(t *testing.T) {
+		for _, retryOnTimeout := range []bool{false, true} {
+			var calls atomic.Int32
+			op := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "slow"},
+				func(ctx context.Context, input any) (any, error) {
+					if calls.Add(1) == 1 {
+						<-ctx.Done()
+						return nil, ctx.Err()
+					}
+					return "ok", nil
+				})
+			guard := infraResilience.NewGuard(nil, nil, nil)
+			policy := retry
+			policy.RetryOnTimeout = retryOnTimeout
+			require.NoError(t, guard.SetPolicy("slow", resilience.Policy{Timeout: 10 * time.Millisecond, Retry: policy}))
+
+			_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+			guard.Wait()
+			if retryOnTimeout {
+				assert.NoError(t, err)
+				assert.EqualValues(t, 2, calls.Load())
+			} else {
+				assert.ErrorIs(t, err, resilience.ErrOperationTimeout)
+				assert.EqualValues(t, 1, calls.Load())
+			}
+		}
+	})
+
+	t.Run("Cancelling the context stops retrying", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		op := newScriptedOperation(nil, infraResilience.Transient(errBackend))
+		require.NoError(t, guard.SetPolicy("quote", resilience.Policy{
+			Retry: resilience.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour},
+		}))
+
+		ctx := infraContext.NewContext()
+		time.AfterFunc(10*time.Millisecond, ctx.Cancel)
+		_, err := guard.Execute(ctx, op, component.Input{})
+		assert.ErrorIs(t, err, errBackend)
+		assert.EqualValues(t, 1, op.calls.Load())
+	})
+}
+
+func TestGuardBreaker(t *testing.T) {
+	breaker := resilience.Policy{Breaker: resilience.BreakerPolicy{FailureThreshold: 2, OpenTimeout: 30 * time.Millisecond}}
+
+	t.Run("Opens, probes and closes", func(t *testing.T) {
+		recorder, bus := newRecorder()
+		guard := infraResilience.NewGuard(nil, bus, nil)
+		op := newScriptedOperation(nil, errBackend, errBackend)
+		require.NoError(t, guard.SetPolicy("quote", breaker))
+		ctx := infraContext.NewContext()
+
+		_, _ = guard.Execute(ctx, op, component.Input{})
+		_, _ = guard.Execute(ctx, op, component.Input{})
+
+		_, err := guard.Execute(ctx, op, component.Input{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, resilience.ErrCircuitOpen)
+		assert.EqualValues(t, 2, op.calls.Load(), "open breakers do not call the operation")
+
+		state, err := guard.State("quote")
+		require.NoError(t, err)
+		assert.Equal(t, resilience.BreakerOpen, state.Breaker)
+		assert.Equal(t, 1, state.Rejected)
+
+		time.Sleep(40 * time.Millisecond)
+		_, err = guard.Execute(ctx, op, component.Input{})
+		require.NoError(t, err)
+
+		state, err = guard.State("quote")
+		require.NoError(t, err)
+		assert.Equal(t, resilience.BreakerClosed, state.Breaker)
+		assert.Equal(t, []string{
+			resilience.TopicBreakerOpened,
+			resilience.TopicBreakerHalfOpen,
+			resilience.TopicBreakerClosed,
+		}, recorder.topics())
+
+		recorder.mu.Lock()
+		opened := recorder.events[0].Payload
+		recorder.mu.Unlock()
+		assert.Equal(t, "quote", opened["operationId"])
+		assert.Equal(t, "open", opened["state"])
+		assert.Equal(t, "closed", opened["previousState"])
+		assert.Equal(t, errBackend.Error(), opened["error"])
+	})
+
+	t.Run("A failed probe opens the breaker again", func(t *testing.T) {
+		recorder, bus := newRecorder()
+		guard := infraResilience.NewGuard(nil, bus, nil)
+		op := newScriptedOperation(nil, errBackend, errBackend, errBackend)
+		require.NoError(t, guard.SetPolicy("quote", breaker))
+		ctx := infraContext.NewContext()
+
+		_, _ = guard.Execute(ctx, op, component.Input{})
+		_, _ = guard.Execute(ctx, op, component.Input{})
+		time.Sleep(40 * time.Millisecond)
+		_, err := guard.Execute(ctx, op, component.Input{})
+		assert.Equal(t, errBackend, err)
+
+		state, err := guard.State("quote")
+		require.NoError(t, err)
+		assert.Equal(t, resilience.BreakerOpen, state.Breaker)
+		assert.Equal(t, []string{
+			resilience.TopicBreakerOpened,
+			resilience.TopicBreakerHalfOpen,
+			resilience.TopicBreakerOpened,
+		}, recorder.topics())
+	})
+
+	t.Run("Rejected input does not count", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		op := newScriptedOperation(nil)
+		op.SetInputSchema(component.MustParseSchema(`{"type": "string"}`))
+		require.NoError(t, guard.SetPolicy("quote", breaker))
+		ctx := infraContext.NewContext()
+
+		for i := 0; i < 3; i++ {
+			_, err := guard.Execute(ctx, op, component.Input{Data: 42})
+			assert.ErrorIs(t, err, component.ErrInputValidationFailed)
+		}
+
+		state, err := guard.State("quote")
+		require.NoError(t, err)
+		assert.Equal(t, resilience.BreakerClosed, state.Breaker)
+		assert.Zero(t, state.ConsecutiveFailures)
+	})
+
+	t.Run("Reset closes the breaker", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		op := newScriptedOperation(nil, errBackend, errBackend)
+		require.NoError(t, guard.SetPolicy("quote", resilience.Policy{Breaker: resilience.BreakerPolicy{FailureThreshold: 2, OpenTimeout: time.Hour}}))
+		ctx := infraContext.NewContext()
+
+		_, _ = guard.Execute(ctx, op, component.Input{})
+		_, _ = guard.Execute(ctx, op, component.Input{})
+		require.NoError(t, guard.Reset("quote"))
+
+		_, err := guard.Execute(ctx, op, component.Input{})
+		assert.NoError(t, err)
+		assert.Error(t, guard.Reset("unknown"))
+	})
+}
+
+func TestGuardPolicies(t *testing.T) {
+	t.Run("Policies are read from properties", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		op := newScriptedOperation(component.Metadata{
+			"resilience.timeout":                   "2s",
+			"resilience.retry.max_attempts":        3,
+			"resilience.retry.on":                  "storage.connection_failed, storage.timeout",
+			"resilience.retry.on_timeout":          "true",
+			"resilience.breaker.failure_threshold": 5,
+		})
+
+		_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		require.NoError(t, err)
+
+		state, err := guard.State("quote")
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Second, state.Policy.Timeout)
+		assert.Equal(t, 3, state.Policy.Retry.MaxAttempts)
+		assert.Equal(t, infraResilience.DefaultInitialBackoff, state.Policy.Retry.InitialBackoff)
+		assert.Equal(t, []string{"storage.connection_failed", "storage.timeout"}, state.Policy.Retry.RetryOn)
+		assert.True(t, state.Policy.Retry.RetryOnTimeout)
+		assert.Equal(t, 5, state.Policy.Breaker.FailureThreshold)
+		assert.Equal(t, infraResilience.DefaultOpenTimeout, state.Policy.Breaker.OpenTimeout)
+	})
+
+	t.Run("Configuration overrides properties", func(t *testing.T) {
+		cfg := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+			"resilience": map[string]interface{}{
+				"operations": map[string]interface{}{
+					"quote": map[string]interface{}{
+						"timeout": "500ms",
+						"breaker": map[string]interface{}{"failure_threshold": 2},
+					},
+				},
+			},
+		})
+		guard := infraResilience.NewGuard(cfg, nil, nil)
+		op := newScriptedOperation(component.Metadata{"resilience.timeout": "2s", "resilience.retry.max_attempts": 3})
+
+		_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		require.NoError(t, err)
+
+		states := guard.States()
+		require.Len(t, states, 1)
+		assert.Equal(t, 500*time.Millisecond, states[0].Policy.Timeout)
+		assert.Equal(t, 3, states[0].Policy.Retry.MaxAttempts)
+		assert.Equal(t, 2, states[0].Policy.Breaker.FailureThreshold)
+	})
+
+	t.Run("Invalid policies fail the call", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		op := newScriptedOperation(component.Metadata{"resilience.retry.jitter": 2.0})
+
+		_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, resilience.ErrInvalidPolicy)
+		assert.Zero(t, op.calls.Load())
+
+		assert.Error(t, guard.SetPolicy("quote", resilience.Policy{Timeout: -time.Second}))
+	})
+
+	t.Run("Operations without a policy run unchanged", func(t *testing.T) {
+		guard := infraResilience.NewGuard(nil, nil, nil)
+		op := newScriptedOperation(nil, errBackend)
+
+		_, err := guard.Execute(infraContext.NewContext(), op, component.Input{})
+		assert.Equal(t, errBackend, err)
+		assert.Empty(t, guard.States())
+
+		_, err = guard.State("quote")
+		assert.Error(t, err)
+	})
+}
+
+// TestRuntimeResilience tests the guard wired into the runtime
+func TestRuntimeResilience(t *testing.T) {
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	registry := infraComponent.NewRegistry()
+	rt, err := infraRuntime.NewRuntime(
+		registry,
+		infraConfig.NewMemoryConfiguration(),
+		infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}),
+		infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"}),
+		logger,
+	)
+	require.NoError(t, err)
+
+	op := newScriptedOperation(component.Metadata{
+		"resilience.retry.max_attempts":    2,
+		"resilience.retry.initial_backoff": "1ms",
+	}, infraResilience.Transient(errBackend))
+	require.NoError(t, registry.Register(op))
+
+	output, err := rt.ExecuteOperation(infraContext.NewContext(), "quote", component.Input{})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", output.Data)
+
+	state, err := rt.Resilience().State("quote")
+	require.NoError(t, err)
+	assert.Equal(t, 1, state.Retries)
+}
@@ -0,0 +1,108 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraMetrics "github.com/fintechain/skeleton/internal/infrastructure/metrics"
+)
+
+// resultOperation returns err, or succeeds when it is nil.
+type resultOperation struct {
+	*infraComponent.BaseOperation
+	err error
+}
+
+func newResultOperation(id string, err error) *resultOperation {
+	return &resultOperation{
+		BaseOperation: infraComponent.NewBaseOperation(component.ComponentConfig{ID: component.ComponentID(id), Type: component.TypeOperation}),
+		err:           err,
+	}
+}
+
+func (o *resultOperation) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	return component.Output{}, o.err
+}
+
+// value returns the value of the series of the named metric with the given
+// labels, or of the count of a histogram series.
+func value(t *testing.T, registry metrics.Registry, name string, labels metrics.Labels) float64 {
+	for _, f := range registry.Snapshot() {
+		if f.Name != name {
+			continue
+		}
+		for _, s := range f.Series {
+			if assert.ObjectsAreEqual(labels, s.Labels) {
+				if f.Kind == metrics.KindHistogram {
+					return float64(s.Count)
+				}
+				return s.Value
+			}
+		}
+	}
+	require.Failf(t, "series not found", "%s %v", name, labels)
+	return 0
+}
+
+func TestRuntimeMetrics(t *testing.T) {
+	rt, registry := newShutdownRuntime(t, false)
+	defer rt.Stop(infraContext.NewContext())
+	ctx := infraContext.NewContext()
+
+	require.NoError(t, registry.Register(newResultOperation("noop", nil)))
+	require.NoError(t, registry.Register(newResultOperation("transfer", failure.New(component.ErrInputValidationFailed, "missing amount"))))
+
+	t.Run("Operations record executions, latency and error codes", func(t *testing.T) {
+		_, err := rt.ExecuteOperation(ctx, "noop", component.Input{})
+		require.NoError(t, err)
+		_, err = rt.ExecuteOperation(ctx, "transfer", component.Input{})
+		require.Error(t, err)
+
+		m := rt.Metrics()
+		assert.Equal(t, 1.0, value(t, m, metrics.OperationExecutions, metrics.Labels{"operation": "noop"}))
+		assert.Equal(t, 1.0, value(t, m, metrics.OperationDuration, metrics.Labels{"operation": "noop"}))
+		assert.Equal(t, 1.0, value(t, m, metrics.OperationExecutions, metrics.Labels{"operation": "transfer"}))
+		assert.Equal(t, 1.0, value(t, m, metrics.OperationErrors, metrics.Labels{
+			"operation": "transfer",
+			"code":      string(component.ErrInputValidationFailed),
+		}))
+	})
+
+	t.Run("Events record publishes and handler latency per topic", func(t *testing.T) {
+		rt.EventBus().Subscribe("orders.created", func(*event.Event) {})
+		require.NoError(t, rt.EventBus().Publish(&event.Event{Topic: "orders.created"}))
+		require.NoError(t, rt.EventBus().PublishAsync(&event.Event{Topic: "orders.created"}))
+		rt.EventBus().WaitAsync()
+
+		m := rt.Metrics()
+		labels := metrics.Labels{"topic": "orders.created"}
+		assert.Equal(t, 2.0, value(t, m, metrics.EventPublishes, labels))
+		assert.Equal(t, 2.0, value(t, m, metrics.EventHandlerDuration, labels))
+		assert.Equal(t, 0.0, value(t, m, metrics.EventQueueDepth, labels))
+	})
+
+	t.Run("Services report their current status", func(t *testing.T) {
+		m := rt.Metrics()
+		assert.Equal(t, 1.0, value(t, m, metrics.ServiceStatus, metrics.Labels{"service": "event-bus", "status": "running"}))
+		assert.Equal(t, 0.0, value(t, m, metrics.ServiceStatus, metrics.Labels{"service": "event-bus", "status": "stopped"}))
+	})
+
+	t.Run("A custom registry replaces the default one", func(t *testing.T) {
+		custom := infraMetrics.NewRegistry()
+		rt.SetMetrics(custom)
+
+		_, err := rt.ExecuteOperation(ctx, "noop", component.Input{})
+		require.NoError(t, err)
+		assert.Same(t, custom, rt.Metrics())
+		assert.Equal(t, 1.0, value(t, custom, metrics.OperationExecutions, metrics.Labels{"operation": "noop"}))
+	})
+}
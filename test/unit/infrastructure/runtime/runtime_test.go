@@ -1,15 +1,19 @@
 package runtime
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
 	"github.com/fintechain/skeleton/internal/domain/config"
 	"github.com/fintechain/skeleton/internal/domain/logging"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
 	infraconfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
 	infraruntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
 	"github.com/fintechain/skeleton/pkg/event"
 	"github.com/fintechain/skeleton/pkg/plugin"
@@ -30,10 +34,15 @@ func createTestDependencies() (
 	*mocks.MockLoggerService,
 ) {
 	factory := mocks.NewFactory()
+
+	// The runtime publishes lifecycle events of its core services
+	eventBus := factory.EventBusServiceInterface()
+	eventBus.On("Publish", mock.Anything).Return(nil).Maybe()
+
 	return factory.RegistryInterface(),
 		createTestConfiguration(),
 		factory.PluginManagerInterface(),
-		factory.EventBusServiceInterface(),
+		eventBus,
 		factory.LoggerServiceInterface()
 }
 
@@ -64,7 +73,7 @@ func TestNewRuntime(t *testing.T) {
 		eventBus      event.EventBusService
 		logger        logging.LoggerService
 		expectError   bool
-		errorMsg      string
+		errorIs       error
 		description   string
 	}{
 		{
@@ -85,7 +94,7 @@ func TestNewRuntime(t *testing.T) {
 			eventBus:      validEventBus,
 			logger:        validLogger,
 			expectError:   true,
-			errorMsg:      infraruntime.ErrNilRegistry,
+			errorIs:       infraruntime.ErrNilRegistry,
 			description:   "Should return error for nil registry",
 		},
 		{
@@ -96,7 +105,7 @@ func TestNewRuntime(t *testing.T) {
 			eventBus:      validEventBus,
 			logger:        validLogger,
 			expectError:   true,
-			errorMsg:      infraruntime.ErrNilConfiguration,
+			errorIs:       infraruntime.ErrNilConfiguration,
 			description:   "Should return error for nil configuration",
 		},
 		{
@@ -107,7 +116,7 @@ func TestNewRuntime(t *testing.T) {
 			eventBus:      validEventBus,
 			logger:        validLogger,
 			expectError:   true,
-			errorMsg:      infraruntime.ErrNilPluginManager,
+			errorIs:       infraruntime.ErrNilPluginManager,
 			description:   "Should return error for nil plugin manager",
 		},
 		{
@@ -118,7 +127,7 @@ func TestNewRuntime(t *testing.T) {
 			eventBus:      nil,
 			logger:        validLogger,
 			expectError:   true,
-			errorMsg:      infraruntime.ErrNilEventBus,
+			errorIs:       infraruntime.ErrNilEventBus,
 			description:   "Should return error for nil event bus",
 		},
 		{
@@ -129,7 +138,7 @@ func TestNewRuntime(t *testing.T) {
 			eventBus:      validEventBus,
 			logger:        nil,
 			expectError:   true,
-			errorMsg:      infraruntime.ErrNilLogger,
+			errorIs:       infraruntime.ErrNilLogger,
 			description:   "Should return error for nil logger",
 		},
 	}
@@ -141,8 +150,8 @@ func TestNewRuntime(t *testing.T) {
 			if tt.expectError {
 				assert.Error(t, err)
 				assert.Nil(t, runtime)
-				if tt.errorMsg != "" {
-					assert.Contains(t, err.Error(), tt.errorMsg)
+				if tt.errorIs != nil {
+					assert.ErrorIs(t, err, tt.errorIs)
 				}
 			} else {
 				assert.NoError(t, err)
@@ -193,19 +202,147 @@ func TestRuntimeState(t *testing.T) {
 
 	// Test initial state
 	assert.False(t, runtime.IsRunning())
+	assert.Equal(t, component.StatusStopped, runtime.Status())
 
 	// Test start
 	err = runtime.Start(nil)
 	assert.NoError(t, err)
 	assert.True(t, runtime.IsRunning())
+	assert.Equal(t, component.StatusRunning, runtime.Status())
 
 	// Test stop
 	err = runtime.Stop(nil)
 	assert.NoError(t, err)
 	assert.False(t, runtime.IsRunning())
+	assert.Equal(t, component.StatusStopped, runtime.Status())
 
 	// Verify mock expectations
 	pluginManager.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
 	logger.AssertExpectations(t)
 }
+
+// TestRuntimeStartRollback tests that a failed start stops already started services
+func TestRuntimeStartRollback(t *testing.T) {
+	t.Run("started services are stopped in reverse order", func(t *testing.T) {
+		registry, config, pluginManager, eventBus, logger := createTestDependencies()
+
+		var calls []string
+		record := func(name string) func(mock.Arguments) {
+			return func(mock.Arguments) { calls = append(calls, name) }
+		}
+
+		loggerErr := errors.New("logger failed")
+		eventBus.On("Start", mock.Anything).Run(record("start:event_bus")).Return(nil)
+		pluginManager.On("Start", mock.Anything).Run(record("start:plugin_manager")).Return(nil)
+		logger.On("Start", mock.Anything).Run(record("start:logger")).Return(loggerErr)
+		pluginManager.On("Stop", mock.Anything).Run(record("stop:plugin_manager")).Return(nil)
+		eventBus.On("Stop", mock.Anything).Run(record("stop:event_bus")).Return(nil)
+
+		runtime, err := infraruntime.NewRuntime(registry, config, pluginManager, eventBus, logger)
+		assert.NoError(t, err)
+
+		err = runtime.Start(nil)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, loggerErr)
+		assert.ErrorIs(t, err, component.ErrServiceStartFailed)
+		assert.Contains(t, err.Error(), "failed to start logger")
+
+		assert.Equal(t, []string{
+			"start:event_bus", "start:plugin_manager", "start:logger",
+			"stop:plugin_manager", "stop:event_bus",
+		}, calls)
+		assert.False(t, runtime.IsRunning())
+		assert.Equal(t, component.StatusError, runtime.Status())
+
+		logger.AssertNotCalled(t, "Stop", mock.Anything)
+		pluginManager.AssertExpectations(t)
+		eventBus.AssertExpectations(t)
+	})
+
+	t.Run("rollback failures are joined with the start failure", func(t *testing.T) {
+		registry, config, pluginManager, eventBus, logger := createTestDependencies()
+
+		pluginErr := errors.New("plugin manager failed")
+		stopErr := errors.New("event bus stop failed")
+		eventBus.On("Start", mock.Anything).Return(nil)
+		pluginManager.On("Start", mock.Anything).Return(pluginErr)
+		eventBus.On("Stop", mock.Anything).Return(stopErr)
+
+		runtime, err := infraruntime.NewRuntime(registry, config, pluginManager, eventBus, logger)
+		assert.NoError(t, err)
+
+		err = runtime.Start(nil)
+		assert.ErrorIs(t, err, pluginErr)
+		assert.ErrorIs(t, err, stopErr)
+		assert.ErrorIs(t, err, component.ErrServiceStopFailed)
+		assert.Contains(t, err.Error(), "rollback: failed to stop event bus")
+		assert.Equal(t, component.StatusError, runtime.Status())
+
+		logger.AssertNotCalled(t, "Start", mock.Anything)
+	})
+}
+
+// TestRuntimePluginConfiguration tests plugin configuration sections
+func TestRuntimePluginConfiguration(t *testing.T) {
+	newRuntime := func(t *testing.T, data map[string]interface{}) (*infraruntime.Runtime, *mocks.MockPluginManager) {
+		factory := mocks.NewFactory()
+		pluginManager := factory.PluginManagerInterface()
+		runtime, err := infraruntime.NewRuntime(
+			factory.RegistryInterface(),
+			infraconfig.NewMemoryConfigurationWithData(data),
+			pluginManager,
+			factory.EventBusServiceInterface(),
+			factory.LoggerServiceInterface(),
+		)
+		assert.NoError(t, err)
+		return runtime, pluginManager
+	}
+
+	t.Run("Section is rooted at the plugin ID", func(t *testing.T) {
+		runtime, _ := newRuntime(t, map[string]interface{}{"plugins.db.driver": "mysql"})
+
+		section := runtime.PluginConfiguration("db")
+		section.SetDefault("pool", 10)
+
+		assert.Equal(t, "mysql", section.GetString("driver"))
+		assert.Equal(t, map[string]interface{}{
+			"plugins.db.driver": "mysql",
+			"plugins.db.pool":   10,
+		}, runtime.EffectiveConfiguration())
+	})
+
+	t.Run("LoadPlugins validates the rules plugins registered", func(t *testing.T) {
+		runtime, pluginManager := newRuntime(t, map[string]interface{}{"plugins.db.pool": 0})
+
+		pluginManager.On("Initialize", mock.Anything, runtime).Run(func(mock.Arguments) {
+			runtime.PluginConfiguration("db").AddRule("pool", infraconfig.IntRange(1, 100))
+		}).Return(nil)
+
+		err := runtime.LoadPlugins(nil, nil)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrPluginLoadFailed)
+		assert.Contains(t, err.Error(), "invalid plugin configuration")
+		assert.Contains(t, err.Error(), "'plugins.db.pool' must be between 1 and 100, got 0")
+	})
+}
+
+func TestRuntimeLookupErrors(t *testing.T) {
+	rt, registry := newShutdownRuntime(t, false)
+	defer rt.Stop(infraContext.NewContext())
+	ctx := infraContext.NewContext()
+	require.NoError(t, registry.Register(infraComponent.NewBaseComponent(component.ComponentConfig{ID: "plain"})))
+
+	_, err := rt.ExecuteOperation(ctx, "missing", component.Input{})
+	assert.ErrorIs(t, err, component.ErrOperationNotFound)
+	assert.ErrorIs(t, err, component.ErrItemNotFound)
+
+	_, err = rt.ExecuteOperation(ctx, "plain", component.Input{})
+	assert.ErrorIs(t, err, component.ErrInvalidComponentType)
+	assert.Contains(t, err.Error(), "component 'plain' is not an operation")
+
+	assert.ErrorIs(t, rt.StartService(ctx, "missing"), component.ErrServiceNotFound)
+	assert.ErrorIs(t, rt.StopService(ctx, "missing"), component.ErrServiceNotFound)
+	assert.ErrorIs(t, rt.StartService(ctx, "plain"), component.ErrInvalidComponentType)
+	assert.ErrorIs(t, rt.StopService(ctx, "plain"), component.ErrInvalidComponentType)
+}
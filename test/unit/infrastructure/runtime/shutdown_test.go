@@ -0,0 +1,273 @@
+package runtime
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraconfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraHealth "github.com/fintechain/skeleton/internal/infrastructure/health"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraruntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+)
+
+// blockingOperation runs until released.
+type blockingOperation struct {
+	*infraComponent.BaseOperation
+	started  chan struct{}
+	release  chan struct{}
+	finished atomic.Bool
+}
+
+func newBlockingOperation(id string) *blockingOperation {
+	return &blockingOperation{
+		BaseOperation: infraComponent.NewBaseOperation(component.ComponentConfig{ID: component.ComponentID(id), Type: component.TypeOperation}),
+		started:       make(chan struct{}, 1),
+		release:       make(chan struct{}),
+	}
+}
+
+func (o *blockingOperation) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	select {
+	case o.started <- struct{}{}:
+	default:
+	}
+	<-o.release
+	o.finished.Store(true)
+	return component.Output{Data: "done"}, nil
+}
+
+// nestingOperation waits for release, then executes another operation with
+// its own context, like a workflow step.
+type nestingOperation struct {
+	*infraComponent.BaseOperation
+	system  component.System
+	child   component.ComponentID
+	started chan struct{}
+	release chan struct{}
+}
+
+func (o *nestingOperation) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	o.started <- struct{}{}
+	<-o.release
+	return o.system.ExecuteOperation(ctx, o.child, input)
+}
+
+// hangingManager is a plugin manager whose Stop never returns.
+type hangingManager struct {
+	*infraPlugin.Manager
+}
+
+func (m *hangingManager) Stop(ctx context.Context) error {
+	select {}
+}
+
+// newShutdownRuntime creates and starts a runtime with real dependencies.
+func newShutdownRuntime(t *testing.T, hang bool) (*infraruntime.Runtime, component.Registry) {
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	pluginManager := infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"})
+	registry := infraComponent.NewRegistry()
+
+	var rt *infraruntime.Runtime
+	if hang {
+		rt, err = infraruntime.NewRuntime(registry, infraconfig.NewMemoryConfiguration(), &hangingManager{pluginManager},
+			infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"}), logger)
+	} else {
+		rt, err = infraruntime.NewRuntime(registry, infraconfig.NewMemoryConfiguration(), pluginManager,
+			infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"}), logger)
+	}
+	require.NoError(t, err)
+	rt.Health().(*infraHealth.Checker).SetOptions(infraHealth.CheckerOptions{CacheTTL: -1})
+	require.NoError(t, rt.Start(infraContext.NewContext()))
+	return rt, registry
+}
+
+func TestRuntimeShutdown(t *testing.T) {
+	t.Run("Drains in-flight operations and rejects new ones", func(t *testing.T) {
+		rt, registry := newShutdownRuntime(t, false)
+		op := newBlockingOperation("slow-op")
+		require.NoError(t, registry.Register(op))
+
+		go rt.ExecuteOperation(infraContext.NewContext(), "slow-op", component.Input{})
+		<-op.started
+
+		done := make(chan error, 1)
+		go func() {
+			done <- rt.Shutdown(infraContext.NewContext(), infraruntime.ShutdownOptions{})
+		}()
+
+		// New operations are rejected and readiness is down while draining
+		require.Eventually(t, func() bool {
+			_, err := rt.ExecuteOperation(infraContext.NewContext(), "slow-op", component.Input{})
+			return err != nil
+		}, time.Second, 5*time.Millisecond)
+		_, err := rt.ExecuteOperation(infraContext.NewContext(), "slow-op", component.Input{})
+		assert.ErrorIs(t, err, infraruntime.ErrShuttingDown)
+		report := rt.Health().Readiness(infraContext.NewContext())
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.True(t, rt.IsRunning())
+
+		close(op.release)
+		require.NoError(t, <-done)
+		assert.True(t, op.finished.Load())
+		assert.False(t, rt.IsRunning())
+		assert.Equal(t, component.StatusStopped, rt.Status())
+
+		// Starting again accepts operations
+		require.NoError(t, rt.Start(infraContext.NewContext()))
+		defer rt.Stop(infraContext.NewContext())
+		op.release = make(chan struct{})
+		close(op.release)
+		_, err = rt.ExecuteOperation(infraContext.NewContext(), "slow-op", component.Input{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Operations nested in one in flight still run", func(t *testing.T) {
+		rt, registry := newShutdownRuntime(t, false)
+		child := newBlockingOperation("child-op")
+		close(child.release)
+		parent := &nestingOperation{
+			BaseOperation: infraComponent.NewBaseOperation(component.ComponentConfig{ID: "parent-op", Type: component.TypeOperation}),
+			system:        rt,
+			child:         "child-op",
+			started:       make(chan struct{}, 1),
+			release:       make(chan struct{}),
+		}
+		require.NoError(t, registry.Register(child))
+		require.NoError(t, registry.Register(parent))
+
+		result := make(chan error, 1)
+		go func() {
+			_, err := rt.ExecuteOperation(infraContext.NewContext(), "parent-op", component.Input{})
+			result <- err
+		}()
+		<-parent.started
+
+		done := make(chan error, 1)
+		go func() {
+			done <- rt.Shutdown(infraContext.NewContext(), infraruntime.ShutdownOptions{})
+		}()
+		require.Eventually(t, func() bool {
+			_, err := rt.ExecuteOperation(infraContext.NewContext(), "child-op", component.Input{})
+			return err != nil
+		}, time.Second, 5*time.Millisecond)
+
+		close(parent.release)
+		assert.NoError(t, <-result)
+		assert.True(t, child.finished.Load())
+		require.NoError(t, <-done)
+	})
+
+	t.Run("Drain timeout does not block shutdown", func(t *testing.T) {
+		rt, registry := newShutdownRuntime(t, false)
+		op := newBlockingOperation("stuck-op")
+		require.NoError(t, registry.Register(op))
+		defer close(op.release)
+
+		go rt.ExecuteOperation(infraContext.NewContext(), "stuck-op", component.Input{})
+		<-op.started
+
+		err := rt.Shutdown(infraContext.NewContext(), infraruntime.ShutdownOptions{DrainTimeout: 20 * time.Millisecond})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, infraruntime.ErrShutdownTimeout)
+		assert.Contains(t, err.Error(), "1 still running")
+		assert.False(t, rt.IsRunning())
+	})
+
+	t.Run("Restarting after a drain timeout counts operations afresh", func(t *testing.T) {
+		rt, registry := newShutdownRuntime(t, false)
+		stuck := newBlockingOperation("stuck-op")
+		quick := newBlockingOperation("quick-op")
+		close(quick.release)
+		require.NoError(t, registry.Register(stuck))
+		require.NoError(t, registry.Register(quick))
+
+		go rt.ExecuteOperation(infraContext.NewContext(), "stuck-op", component.Input{})
+		<-stuck.started
+		err := rt.Shutdown(infraContext.NewContext(), infraruntime.ShutdownOptions{DrainTimeout: 20 * time.Millisecond})
+		assert.ErrorIs(t, err, infraruntime.ErrShutdownTimeout)
+
+		// The abandoned drain is still waiting when operations run again
+		require.NoError(t, rt.Start(infraContext.NewContext()))
+		for i := 0; i < 10; i++ {
+			_, err = rt.ExecuteOperation(infraContext.NewContext(), "quick-op", component.Input{})
+			require.NoError(t, err)
+		}
+
+		close(stuck.release)
+		require.NoError(t, rt.Shutdown(infraContext.NewContext(), infraruntime.ShutdownOptions{}))
+		assert.True(t, stuck.finished.Load())
+	})
+
+	t.Run("Waits for attempts abandoned after their timeout", func(t *testing.T) {
+		rt, registry := newShutdownRuntime(t, false)
+		op := newBlockingOperation("abandoned-op")
+		require.NoError(t, registry.Register(op))
+		require.NoError(t, rt.Resilience().SetPolicy("abandoned-op", resilience.Policy{Timeout: 10 * time.Millisecond}))
+
+		_, err := rt.ExecuteOperation(infraContext.NewContext(), "abandoned-op", component.Input{})
+		assert.ErrorIs(t, err, resilience.ErrOperationTimeout)
+
+		err = rt.Shutdown(infraContext.NewContext(), infraruntime.ShutdownOptions{DrainTimeout: 20 * time.Millisecond})
+		assert.ErrorIs(t, err, infraruntime.ErrShutdownTimeout)
+		assert.Contains(t, err.Error(), "0 still running, 1 abandoned after their timeout")
+
+		close(op.release)
+	})
+
+	t.Run("Waits for async event handlers", func(t *testing.T) {
+		rt, _ := newShutdownRuntime(t, false)
+		var handled atomic.Bool
+		rt.EventBus().SubscribeAsync("slow.topic", func(evt *event.Event) {
+			time.Sleep(50 * time.Millisecond)
+			handled.Store(true)
+		})
+		require.NoError(t, rt.EventBus().PublishAsync(&event.Event{Topic: "slow.topic"}))
+
+		require.NoError(t, rt.Shutdown(infraContext.NewContext(), infraruntime.ShutdownOptions{}))
+		assert.True(t, handled.Load())
+	})
+
+	t.Run("Hung services time out", func(t *testing.T) {
+		rt, _ := newShutdownRuntime(t, true)
+
+		started := time.Now()
+		err := rt.Shutdown(infraContext.NewContext(), infraruntime.ShutdownOptions{StopTimeout: 50 * time.Millisecond})
+
+		assert.Less(t, time.Since(started), time.Second)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "stop services")
+		assert.ErrorIs(t, err, component.ErrServiceStopFailed)
+		assert.ErrorIs(t, err, infraruntime.ErrShutdownTimeout)
+		assert.Equal(t, component.StatusError, rt.Status())
+	})
+
+	t.Run("Cancelled context aborts waits", func(t *testing.T) {
+		rt, registry := newShutdownRuntime(t, false)
+		op := newBlockingOperation("stuck-op")
+		require.NoError(t, registry.Register(op))
+		defer close(op.release)
+
+		go rt.ExecuteOperation(infraContext.NewContext(), "stuck-op", component.Input{})
+		<-op.started
+
+		ctx := infraContext.NewContext()
+		ctx.Cancel()
+		err := rt.Shutdown(ctx, infraruntime.ShutdownOptions{DrainTimeout: time.Hour})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, infraruntime.ErrShutdownAborted)
+	})
+}
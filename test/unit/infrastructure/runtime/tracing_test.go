@@ -0,0 +1,110 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
+)
+
+// publishingOperation publishes an event carrying its span context, either
+// injected by hand or by publishing with the context of the call.
+type publishingOperation struct {
+	*infraComponent.BaseOperation
+	bus         event.EventBus
+	topic       string
+	withContext bool
+}
+
+func (o *publishingOperation) Execute(ctx context.Context, input component.Input) (component.Output, error) {
+	evt := &event.Event{Topic: o.topic, Source: string(o.ID())}
+	if o.withContext {
+		return component.Output{}, o.bus.PublishContext(ctx, evt)
+	}
+	tracing.Inject(ctx, evt)
+	return component.Output{}, o.bus.Publish(evt)
+}
+
+func TestRuntimeTracing(t *testing.T) {
+	rt, registry := newShutdownRuntime(t, false)
+	defer rt.Stop(infraContext.NewContext())
+
+	exporter := infraTracing.NewMemoryExporter()
+	rt.SetTracer(infraTracing.NewTracer(exporter))
+
+	require.NoError(t, registry.Register(&publishingOperation{
+		BaseOperation: infraComponent.NewBaseOperation(component.ComponentConfig{ID: "checkout", Type: component.TypeOperation}),
+		bus:           rt.EventBus(),
+		topic:         "orders.created",
+	}))
+	require.NoError(t, registry.Register(newResultOperation("reserve-stock", nil)))
+
+	// The handler turns the event into another operation
+	rt.EventBus().Subscribe("orders.created", func(evt *event.Event) {
+		ctx := tracing.Extract(infraContext.NewContext(), evt)
+		_, err := rt.ExecuteOperation(ctx, "reserve-stock", component.Input{})
+		assert.NoError(t, err)
+	})
+
+	_, err := rt.ExecuteOperation(infraContext.NewContext(), "checkout", component.Input{})
+	require.NoError(t, err)
+
+	byName := make(map[string]tracing.SpanData)
+	for _, span := range exporter.Spans() {
+		byName[span.Name] = span
+	}
+	require.Len(t, byName, 3)
+	checkout := byName["operation checkout"]
+	delivery := byName["event orders.created"]
+	reserve := byName["operation reserve-stock"]
+
+	assert.Empty(t, checkout.ParentSpanID)
+	assert.Equal(t, "checkout", checkout.Attributes["operation.id"])
+	assert.Equal(t, checkout.Context.SpanID, delivery.ParentSpanID)
+	assert.Equal(t, delivery.Context.SpanID, reserve.ParentSpanID)
+	assert.Equal(t, checkout.Context.TraceID, reserve.Context.TraceID)
+}
+
+func TestRuntimeTracingPublishContext(t *testing.T) {
+	rt, registry := newShutdownRuntime(t, false)
+	defer rt.Stop(infraContext.NewContext())
+
+	exporter := infraTracing.NewMemoryExporter()
+	rt.SetTracer(infraTracing.NewTracer(exporter))
+
+	require.NoError(t, registry.Register(&publishingOperation{
+		BaseOperation: infraComponent.NewBaseOperation(component.ComponentConfig{ID: "checkout", Type: component.TypeOperation}),
+		bus:           rt.EventBus(),
+		topic:         "orders.created",
+		withContext:   true,
+	}))
+	require.NoError(t, registry.Register(newResultOperation("reserve-stock", nil)))
+
+	var published *event.Event
+	rt.EventBus().Subscribe("orders.created", func(evt *event.Event) {
+		published = evt
+		ctx := tracing.Extract(infraContext.NewContext(), evt)
+		_, err := rt.ExecuteOperation(ctx, "reserve-stock", component.Input{})
+		assert.NoError(t, err)
+	})
+
+	_, err := rt.ExecuteOperation(infraContext.NewContext(), "checkout", component.Input{})
+	require.NoError(t, err)
+	require.NotNil(t, published)
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 3)
+	traceIDs := make(map[tracing.TraceID]bool)
+	for _, span := range spans {
+		traceIDs[span.Context.TraceID] = true
+	}
+	assert.Len(t, traceIDs, 1, "the operations and the delivery share one trace")
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/scheduler"
+	infraScheduler "github.com/fintechain/skeleton/internal/infrastructure/scheduler"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		spec  string
+		after string
+		want  string
+	}{
+		{"*/15 * * * *", "2025-03-10 10:07", "2025-03-10 10:15"},
+		{"*/15 * * * *", "2025-03-10 10:45", "2025-03-10 11:00"},
+		{"0 2 * * *", "2025-03-10 03:00", "2025-03-11 02:00"},
+		{"30 9 * * mon-fri", "2025-03-08 12:00", "2025-03-10 09:30"}, // Saturday to Monday
+		{"0 0 * * 7", "2025-03-10 00:00", "2025-03-16 00:00"},        // 7 is Sunday
+		{"0 0 1,15 * 5", "2025-03-10 00:00", "2025-03-14 00:00"},     // day of month or Friday
+		{"0 12 1 jan,jul *", "2025-03-10 00:00", "2025-07-01 12:00"},
+		{"0-10/5 8 * * *", "2025-03-10 08:05", "2025-03-10 08:10"},
+		{"@hourly", "2025-03-10 10:00", "2025-03-10 11:00"},
+		{"@monthly", "2025-12-10 10:00", "2026-01-01 00:00"},
+		{"0 0 29 2 *", "2025-03-01 00:00", "2028-02-29 00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec+" after "+tt.after, func(t *testing.T) {
+			cron, err := infraScheduler.ParseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, at(tt.want), cron.Next(at(tt.after)))
+		})
+	}
+
+	t.Run("Expressions that never match end", func(t *testing.T) {
+		cron, err := infraScheduler.ParseCron("0 0 30 2 *")
+		require.NoError(t, err)
+		assert.True(t, cron.Next(at("2025-01-01 00:00")).IsZero())
+	})
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *", "@often"} {
+		t.Run(spec, func(t *testing.T) {
+			_, err := infraScheduler.ParseCron(spec)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, scheduler.ErrInvalidCron)
+		})
+	}
+}
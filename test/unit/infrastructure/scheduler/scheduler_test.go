@@ -0,0 +1,327 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/config"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/scheduler"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraScheduler "github.com/fintechain/skeleton/internal/infrastructure/scheduler"
+	infraStorage "github.com/fintechain/skeleton/internal/infrastructure/storage"
+	"github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+)
+
+// probe is an operation that counts its calls and concurrent runs.
+type probe struct {
+	calls   atomic.Int32
+	running atomic.Int32
+	peak    atomic.Int32
+	delay   time.Duration
+	err     error
+}
+
+func (p *probe) operation(id component.ComponentID) component.Operation {
+	return infraComponent.NewTypedOperation(component.ComponentConfig{ID: id},
+		func(ctx context.Context, in any) (any, error) {
+			p.calls.Add(1)
+			n := p.running.Add(1)
+			defer p.running.Add(-1)
+			for {
+				peak := p.peak.Load()
+				if n <= peak || p.peak.CompareAndSwap(peak, n) {
+					break
+				}
+			}
+			if p.delay > 0 {
+				select {
+				case <-time.After(p.delay):
+				case <-ctx.Done():
+				}
+			}
+			return in, p.err
+		})
+}
+
+func newRuntime(t *testing.T, cfg config.Configuration, operations ...component.Operation) (*infraRuntime.Runtime, *infraEvent.EventBus) {
+	t.Helper()
+	if cfg == nil {
+		cfg = infraConfig.NewMemoryConfiguration()
+	}
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	rt, err := infraRuntime.NewRuntime(
+		infraComponent.NewRegistry(),
+		cfg,
+		infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}),
+		bus,
+		logger,
+	)
+	require.NoError(t, err)
+	for _, op := range operations {
+		require.NoError(t, rt.Registry().Register(op))
+	}
+	return rt, bus
+}
+
+func startScheduler(t *testing.T, rt *infraRuntime.Runtime, multiStore storage.MultiStore, schedules ...scheduler.Schedule) *infraScheduler.Scheduler {
+	t.Helper()
+	s := infraScheduler.NewScheduler(component.ComponentConfig{ID: "scheduler"}, multiStore, infraScheduler.Options{})
+	ctx := infraContext.NewContext()
+	require.NoError(t, s.Initialize(ctx, rt))
+	for _, schedule := range schedules {
+		require.NoError(t, s.Add(schedule))
+	}
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() { _ = s.Stop(infraContext.NewContext()) })
+	return s
+}
+
+func TestSchedulerIntervals(t *testing.T) {
+	p := &probe{}
+	rt, bus := newRuntime(t, nil, p.operation("sync"))
+
+	var mu sync.Mutex
+	var succeeded []*event.Event
+	bus.Subscribe(scheduler.TopicRunSucceeded, func(evt *event.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		succeeded = append(succeeded, evt)
+	})
+
+	s := startScheduler(t, rt, nil, scheduler.Schedule{
+		ID:        "sync-accounts",
+		Operation: "sync",
+		Interval:  15 * time.Millisecond,
+		Jitter:    5 * time.Millisecond,
+	})
+
+	assert.Eventually(t, func() bool { return p.calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, s.Stop(infraContext.NewContext()))
+
+	state, err := s.State("sync-accounts")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, state.Runs, 3)
+	assert.False(t, state.LastRun.IsZero())
+	assert.Equal(t, scheduler.OverlapSkip, state.Schedule.Overlap, "defaults are applied")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, succeeded)
+	assert.Equal(t, "sync-accounts", succeeded[0].Payload["scheduleId"])
+	assert.Equal(t, "sync", succeeded[0].Payload["operationId"])
+}
+
+func TestSchedulerOverlap(t *testing.T) {
+	t.Run("Overlapping runs are skipped", func(t *testing.T) {
+		p := &probe{delay: 50 * time.Millisecond}
+		rt, bus := newRuntime(t, nil, p.operation("slow"))
+		var skipped atomic.Int32
+		bus.Subscribe(scheduler.TopicRunSkipped, func(*event.Event) { skipped.Add(1) })
+
+		s := startScheduler(t, rt, nil, scheduler.Schedule{ID: "slow", Operation: "slow", Interval: 10 * time.Millisecond})
+		assert.Eventually(t, func() bool { return skipped.Load() >= 2 }, time.Second, 5*time.Millisecond)
+		assert.EqualValues(t, 1, p.peak.Load())
+
+		_, err := s.Trigger(infraContext.NewContext(), "slow")
+		if err != nil {
+			assert.ErrorIs(t, err, scheduler.ErrRunSkipped)
+		}
+	})
+
+	t.Run("Overlapping runs may be allowed", func(t *testing.T) {
+		p := &probe{delay: 50 * time.Millisecond}
+		rt, _ := newRuntime(t, nil, p.operation("slow"))
+
+		startScheduler(t, rt, nil, scheduler.Schedule{
+			ID: "slow", Operation: "slow", Interval: 10 * time.Millisecond, Overlap: scheduler.OverlapAllow,
+		})
+		assert.Eventually(t, func() bool { return p.peak.Load() >= 2 }, time.Second, 5*time.Millisecond)
+	})
+}
+
+func TestSchedulerCatchUp(t *testing.T) {
+	tests := []struct {
+		policy scheduler.CatchUpPolicy
+		runs   int32
+	}{
+		{scheduler.CatchUpNone, 0},
+		{scheduler.CatchUpOnce, 1},
+		{scheduler.CatchUpAll, 6},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			p := &probe{}
+			rt, _ := newRuntime(t, nil, p.operation("report"))
+
+			multiStore := infraStorage.NewMultiStore(component.ComponentConfig{ID: "multi-store"}, t.TempDir())
+			require.NoError(t, multiStore.RegisterEngine(memory.NewEngine()))
+			require.NoError(t, multiStore.CreateStore(infraScheduler.DefaultStore, "memory", nil))
+			store, err := multiStore.GetStore(infraScheduler.DefaultStore)
+			require.NoError(t, err)
+			lastRun := time.Now().Add(-65 * time.Minute)
+			data, _ := json.Marshal(map[string]time.Time{"lastRun": lastRun})
+			require.NoError(t, store.Set([]byte("schedule/report"), data))
+
+			s := startScheduler(t, rt, multiStore, scheduler.Schedule{
+				ID: "report", Operation: "report", Interval: 10 * time.Minute, CatchUp: tt.policy,
+			})
+
+			if tt.runs > 0 {
+				assert.Eventually(t, func() bool { return p.calls.Load() == tt.runs }, time.Second, 5*time.Millisecond)
+			}
+			time.Sleep(20 * time.Millisecond)
+			assert.Equal(t, tt.runs, p.calls.Load())
+
+			state, err := s.State("report")
+			require.NoError(t, err)
+			assert.WithinDuration(t, lastRun.Add(time.Duration(tt.runs)*10*time.Minute), state.LastRun, time.Millisecond)
+
+			persisted, err := store.Get([]byte("schedule/report"))
+			require.NoError(t, err)
+			var record struct{ LastRun time.Time }
+			require.NoError(t, json.Unmarshal(persisted, &record))
+			assert.True(t, record.LastRun.Equal(state.LastRun), "the last run is persisted")
+		})
+	}
+}
+
+func TestSchedulerFromConfig(t *testing.T) {
+	cfg := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+		"scheduler": map[string]interface{}{
+			"schedules": []interface{}{
+				map[string]interface{}{
+					"id":        "nightly-report",
+					"operation": "report",
+					"cron":      "0 2 * * *",
+					"input":     map[string]interface{}{"format": "pdf"},
+					"jitter":    "30s",
+					"catch_up":  "once",
+				},
+				map[string]interface{}{
+					"id":        "cleanup",
+					"operation": "report",
+					"interval":  "1h",
+					"disabled":  true,
+				},
+			},
+		},
+	})
+	p := &probe{}
+	rt, _ := newRuntime(t, cfg, p.operation("report"))
+	s := startScheduler(t, rt, nil)
+
+	states := s.States()
+	require.Len(t, states, 2)
+	assert.Equal(t, "cleanup", states[0].Schedule.ID)
+	assert.Equal(t, time.Hour, states[0].Schedule.Interval)
+	assert.True(t, states[0].Schedule.Disabled)
+	assert.Equal(t, "0 2 * * *", states[1].Schedule.Cron)
+	assert.Equal(t, 30*time.Second, states[1].Schedule.Jitter)
+	assert.Equal(t, scheduler.CatchUpOnce, states[1].Schedule.CatchUp)
+
+	output, err := s.Trigger(infraContext.NewContext(), "nightly-report")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"format": "pdf"}, output.Data)
+}
+
+func TestSchedulerFailures(t *testing.T) {
+	p := &probe{err: errors.New("ledger offline")}
+	rt, bus := newRuntime(t, nil, p.operation("sync"))
+	var failed *event.Event
+	bus.Subscribe(scheduler.TopicRunFailed, func(evt *event.Event) { failed = evt })
+
+	s := startScheduler(t, rt, nil, scheduler.Schedule{ID: "sync", Operation: "sync", Interval: time.Hour})
+	_, err := s.Trigger(infraContext.NewContext(), "sync")
+	require.Error(t, err)
+
+	state, err := s.State("sync")
+	require.NoError(t, err)
+	assert.Equal(t, 1, state.Failures)
+	assert.Equal(t, "ledger offline", state.LastError)
+	require.NotNil(t, failed)
+	assert.Equal(t, "ledger offline", failed.Payload["error"])
+}
+
+func TestSchedulerManagement(t *testing.T) {
+	p := &probe{}
+	rt, _ := newRuntime(t, nil, p.operation("sync"))
+	s := startScheduler(t, rt, nil)
+
+	t.Run("Invalid schedules are rejected", func(t *testing.T) {
+		for _, schedule := range []scheduler.Schedule{
+			{Operation: "sync", Interval: time.Second},
+			{ID: "a", Interval: time.Second},
+			{ID: "a", Operation: "sync"},
+			{ID: "a", Operation: "sync", Cron: "* * * * *", Interval: time.Second},
+			{ID: "a", Operation: "sync", Cron: "bad"},
+			{ID: "a", Operation: "sync", Interval: time.Second, Overlap: "queue"},
+			{ID: "a", Operation: "sync", Interval: time.Second, CatchUp: "some"},
+		} {
+			err := s.Add(schedule)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, scheduler.ErrInvalidSchedule)
+		}
+	})
+
+	t.Run("Schedules are added and removed while running", func(t *testing.T) {
+		require.NoError(t, s.Add(scheduler.Schedule{ID: "sync", Operation: "sync", Interval: 10 * time.Millisecond}))
+		assert.Contains(t, s.Add(scheduler.Schedule{ID: "sync", Operation: "sync", Interval: time.Second}).Error(), scheduler.ErrScheduleExists)
+		assert.Eventually(t, func() bool { return p.calls.Load() >= 1 }, time.Second, 5*time.Millisecond)
+
+		require.NoError(t, s.Remove("sync"))
+		time.Sleep(20 * time.Millisecond)
+		calls := p.calls.Load()
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, calls, p.calls.Load(), "removed schedules stop")
+
+		_, err := s.State("sync")
+		assert.ErrorIs(t, err, scheduler.ErrScheduleNotFound)
+	})
+}
+
+func TestSchedulerRestartAfterStopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	stuck := infraComponent.NewTypedOperation(component.ComponentConfig{ID: "stuck"},
+		func(ctx context.Context, in any) (any, error) {
+			<-release // ignores cancellation
+			return in, nil
+		})
+	p := &probe{}
+	rt, _ := newRuntime(t, nil, stuck, p.operation("sync"))
+	s := startScheduler(t, rt, nil, scheduler.Schedule{ID: "stuck", Operation: "stuck", Interval: 10 * time.Millisecond})
+	assert.Eventually(t, func() bool {
+		state, _ := s.State("stuck")
+		return state.Running == 1
+	}, time.Second, 5*time.Millisecond)
+
+	ctx := infraContext.NewContextWithTimeout(20 * time.Millisecond)
+	defer ctx.Cancel()
+	assert.ErrorIs(t, s.Stop(ctx), component.ErrServiceStopFailed)
+
+	// Runs of the new start are counted apart from the stuck one
+	require.NoError(t, s.Start(infraContext.NewContext()))
+	require.NoError(t, s.Add(scheduler.Schedule{ID: "sync", Operation: "sync", Interval: 5 * time.Millisecond}))
+	assert.Eventually(t, func() bool { return p.calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, s.Stop(infraContext.NewContext()))
+
+	close(release)
+}
@@ -0,0 +1,476 @@
+package supervisor
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraSupervisor "github.com/fintechain/skeleton/internal/infrastructure/supervisor"
+	"github.com/fintechain/skeleton/test/unit/mocks"
+)
+
+// flakyService is a service whose starts can be made to fail.
+type flakyService struct {
+	*infraComponent.BaseService
+	starts   atomic.Int32
+	startErr atomic.Value
+}
+
+func newFlakyService(id string) *flakyService {
+	return &flakyService{
+		BaseService: infraComponent.NewBaseService(component.ComponentConfig{ID: component.ComponentID(id), Name: id}),
+	}
+}
+
+func (s *flakyService) Start(ctx context.Context) error {
+	s.starts.Add(1)
+	if err, ok := s.startErr.Load().(error); ok && err != nil {
+		return err
+	}
+	return s.BaseService.Start(ctx)
+}
+
+// channelService reports failures on a channel.
+type channelService struct {
+	*flakyService
+	failures chan error
+}
+
+func (s *channelService) Failures() <-chan error {
+	return s.failures
+}
+
+// recorder collects supervisor events.
+type recorder struct {
+	mu     sync.Mutex
+	events []*event.Event
+}
+
+func (r *recorder) topics() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	topics := make([]string, 0, len(r.events))
+	for _, e := range r.events {
+		topics = append(topics, e.Topic)
+	}
+	return topics
+}
+
+func (r *recorder) payloads(topic string) []map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var payloads []map[string]interface{}
+	for _, e := range r.events {
+		if e.Topic == topic {
+			payloads = append(payloads, e.Payload)
+		}
+	}
+	return payloads
+}
+
+func newRecorder(bus event.EventBus) *recorder {
+	r := &recorder{}
+	for _, topic := range []string{
+		supervisor.TopicServiceFailed, supervisor.TopicServiceExited, supervisor.TopicServiceRestarting,
+		supervisor.TopicServiceRestarted, supervisor.TopicServiceGaveUp, supervisor.TopicEscalated,
+	} {
+		bus.Subscribe(topic, func(e *event.Event) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.events = append(r.events, e)
+		})
+	}
+	return r
+}
+
+// newSupervisor creates a started supervisor over a registry holding the services.
+func newSupervisor(t *testing.T, pm plugin.PluginManager, services ...component.Service) (*infraSupervisor.Supervisor, *recorder) {
+	registry := infraComponent.NewRegistry()
+	for _, s := range services {
+		require.NoError(t, registry.Register(s))
+	}
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	rec := newRecorder(bus)
+
+	sup := infraSupervisor.NewSupervisor(component.ComponentConfig{ID: "supervisor"}, registry, pm, bus, nil)
+	require.NoError(t, sup.Start(infraContext.NewContext()))
+	t.Cleanup(func() { sup.Stop(infraContext.NewContext()) })
+	return sup, rec
+}
+
+// waitState waits until the service reaches the supervision state.
+func waitState(t *testing.T, sup *infraSupervisor.Supervisor, id component.ComponentID, state supervisor.ServiceState) supervisor.State {
+	var current supervisor.State
+	require.Eventually(t, func() bool {
+		var err error
+		current, err = sup.State(id)
+		return err == nil && current.State == state
+	}, 2*time.Second, time.Millisecond, "service %s did not reach state %s", id, state)
+	return current
+}
+
+func TestSupervise(t *testing.T) {
+	t.Run("Unknown service", func(t *testing.T) {
+		sup, _ := newSupervisor(t, nil)
+		err := sup.Supervise("missing", supervisor.Policy{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, component.ErrServiceNotFound)
+	})
+
+	t.Run("Invalid policy", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, _ := newSupervisor(t, nil, svc)
+		err := sup.Supervise("svc", supervisor.Policy{Restart: "sometimes"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, supervisor.ErrInvalidPolicy)
+	})
+
+	t.Run("Defaults and duplicates", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, _ := newSupervisor(t, nil, svc)
+		require.NoError(t, svc.Start(infraContext.NewContext()))
+		require.NoError(t, sup.Supervise("svc", supervisor.Policy{}))
+
+		state, err := sup.State("svc")
+		require.NoError(t, err)
+		assert.Equal(t, supervisor.StateRunning, state.State)
+		assert.Equal(t, supervisor.RestartOnFailure, state.Policy.Restart)
+		assert.Equal(t, supervisor.EscalateNone, state.Policy.Escalation)
+		assert.Equal(t, infraSupervisor.DefaultMaxRestarts, state.Policy.MaxRestarts)
+
+		err = sup.Supervise("svc", supervisor.Policy{})
+		assert.ErrorIs(t, err, supervisor.ErrServiceAlreadySupervised)
+
+		require.NoError(t, sup.Unsupervise("svc"))
+		_, err = sup.State("svc")
+		assert.ErrorIs(t, err, supervisor.ErrServiceNotSupervised)
+	})
+}
+
+func TestStartNotifiesWithoutLock(t *testing.T) {
+	registry := infraComponent.NewRegistry()
+	sup := infraSupervisor.NewSupervisor(component.ComponentConfig{ID: "supervisor"}, registry, nil, nil, nil)
+
+	var states []supervisor.State
+	sup.SetLifecycleListener(func(topic string, err error) {
+		if topic == component.TopicServiceStarted {
+			states = sup.States() // takes the supervisor lock
+		}
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- sup.Start(infraContext.NewContext()) }()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Start deadlocked in the started notification")
+	}
+	defer sup.Stop(infraContext.NewContext())
+
+	assert.Empty(t, states)
+	assert.True(t, sup.IsRunning())
+}
+
+func TestRestartPolicies(t *testing.T) {
+	policy := func(restart supervisor.RestartPolicy) supervisor.Policy {
+		return supervisor.Policy{Restart: restart, InitialBackoff: time.Millisecond}
+	}
+
+	t.Run("On-failure restarts a failed service", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, rec := newSupervisor(t, nil, svc)
+		require.NoError(t, svc.Start(infraContext.NewContext()))
+		require.NoError(t, sup.Supervise("svc", policy(supervisor.RestartOnFailure)))
+
+		svc.ReportFailure(errors.New("connection lost"))
+
+		state := waitState(t, sup, "svc", supervisor.StateRunning)
+		assert.Equal(t, 1, state.Restarts)
+		assert.EqualError(t, state.LastError, "connection lost")
+		assert.True(t, svc.IsRunning())
+		assert.Equal(t, component.StatusRunning, svc.Status())
+		require.Eventually(t, func() bool { return len(rec.topics()) == 3 }, time.Second, time.Millisecond)
+		assert.Equal(t, []string{
+			supervisor.TopicServiceFailed, supervisor.TopicServiceRestarting, supervisor.TopicServiceRestarted,
+		}, rec.topics())
+		assert.Equal(t, "connection lost", rec.payloads(supervisor.TopicServiceFailed)[0]["error"])
+	})
+
+	t.Run("On-failure leaves a clean exit stopped", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, rec := newSupervisor(t, nil, svc)
+		require.NoError(t, svc.Start(infraContext.NewContext()))
+		require.NoError(t, sup.Supervise("svc", policy(supervisor.RestartOnFailure)))
+
+		svc.ReportFailure(nil)
+
+		state := waitState(t, sup, "svc", supervisor.StateStopped)
+		assert.Equal(t, 0, state.Restarts)
+		assert.Equal(t, component.StatusStopped, svc.Status())
+		require.Eventually(t, func() bool { return len(rec.topics()) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, []string{supervisor.TopicServiceExited}, rec.topics())
+	})
+
+	t.Run("Always restarts a clean exit", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, _ := newSupervisor(t, nil, svc)
+		require.NoError(t, svc.Start(infraContext.NewContext()))
+		require.NoError(t, sup.Supervise("svc", policy(supervisor.RestartAlways)))
+
+		svc.ReportFailure(nil)
+
+		state := waitState(t, sup, "svc", supervisor.StateRunning)
+		assert.Equal(t, 1, state.Restarts)
+		assert.True(t, svc.IsRunning())
+	})
+
+	t.Run("Never gives up", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, rec := newSupervisor(t, nil, svc)
+		require.NoError(t, svc.Start(infraContext.NewContext()))
+		require.NoError(t, sup.Supervise("svc", policy(supervisor.RestartNever)))
+
+		svc.ReportFailure(errors.New("boom"))
+
+		waitState(t, sup, "svc", supervisor.StateFailed)
+		require.Eventually(t, func() bool { return len(rec.topics()) == 2 }, time.Second, time.Millisecond)
+		assert.Equal(t, []string{supervisor.TopicServiceFailed, supervisor.TopicServiceGaveUp}, rec.topics())
+		assert.Equal(t, int32(1), svc.starts.Load())
+	})
+
+	t.Run("Failures are read from a channel", func(t *testing.T) {
+		svc := &channelService{flakyService: newFlakyService("svc"), failures: make(chan error)}
+		sup, _ := newSupervisor(t, nil, svc)
+		require.NoError(t, sup.Supervise("svc", policy(supervisor.RestartOnFailure)))
+
+		svc.failures <- errors.New("crashed")
+
+		state := waitState(t, sup, "svc", supervisor.StateRunning)
+		assert.Equal(t, 1, state.Restarts)
+		assert.True(t, svc.IsRunning())
+	})
+}
+
+func TestRestartBudget(t *testing.T) {
+	t.Run("Backoff doubles until the budget is spent", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		svc.startErr.Store(errors.New("port in use"))
+		sup, rec := newSupervisor(t, nil, svc)
+		require.NoError(t, sup.Supervise("svc", supervisor.Policy{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     3 * time.Millisecond,
+			MaxRestarts:    3,
+		}))
+
+		sup.ReportFailure("svc", errors.New("boom"))
+
+		state := waitState(t, sup, "svc", supervisor.StateFailed)
+		require.Eventually(t, func() bool { return len(rec.payloads(supervisor.TopicServiceGaveUp)) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, 3, state.Restarts)
+		assert.Equal(t, int32(3), svc.starts.Load())
+
+		var backoffs []interface{}
+		for _, p := range rec.payloads(supervisor.TopicServiceRestarting) {
+			backoffs = append(backoffs, p["backoff"])
+		}
+		assert.Equal(t, []interface{}{"1ms", "2ms", "3ms"}, backoffs)
+
+		gaveUp := rec.payloads(supervisor.TopicServiceGaveUp)[0]["error"].(string)
+		assert.Contains(t, gaveUp, supervisor.ErrRestartBudgetExhausted)
+		assert.Contains(t, gaveUp, "port in use")
+	})
+
+	t.Run("Restarts outside the window are forgotten", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, _ := newSupervisor(t, nil, svc)
+		require.NoError(t, sup.Supervise("svc", supervisor.Policy{
+			InitialBackoff: time.Millisecond,
+			MaxRestarts:    1,
+			Window:         20 * time.Millisecond,
+		}))
+
+		sup.ReportFailure("svc", errors.New("first"))
+		waitState(t, sup, "svc", supervisor.StateRunning)
+		time.Sleep(30 * time.Millisecond)
+
+		sup.ReportFailure("svc", errors.New("second"))
+		state := waitState(t, sup, "svc", supervisor.StateRunning)
+		assert.Equal(t, 2, state.Restarts)
+	})
+
+	t.Run("Stop cancels a pending restart", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, _ := newSupervisor(t, nil, svc)
+		require.NoError(t, sup.Supervise("svc", supervisor.Policy{InitialBackoff: time.Hour}))
+
+		sup.ReportFailure("svc", errors.New("boom"))
+		waitState(t, sup, "svc", supervisor.StateRestarting)
+
+		require.NoError(t, sup.Stop(infraContext.NewContext()))
+		state := waitState(t, sup, "svc", supervisor.StateStopped)
+		assert.Equal(t, int32(0), svc.starts.Load())
+		assert.Equal(t, 1, state.Restarts)
+	})
+}
+
+func TestEscalation(t *testing.T) {
+	t.Run("Stop the declared plugin", func(t *testing.T) {
+		pm := mocks.NewFactory().PluginManagerInterface()
+		stopped := make(chan component.ComponentID, 1)
+		pm.On("StopPlugin", mock.Anything, component.ComponentID("db-plugin")).
+			Run(func(args mock.Arguments) { stopped <- args.Get(1).(component.ComponentID) }).
+			Return(nil)
+
+		svc := newFlakyService("svc")
+		sup, rec := newSupervisor(t, pm, svc)
+		require.NoError(t, sup.Supervise("svc", supervisor.Policy{
+			Restart:    supervisor.RestartNever,
+			Escalation: supervisor.EscalatePlugin,
+			Plugin:     "db-plugin",
+		}))
+
+		sup.ReportFailure("svc", errors.New("boom"))
+
+		select {
+		case id := <-stopped:
+			assert.Equal(t, component.ComponentID("db-plugin"), id)
+		case <-time.After(time.Second):
+			t.Fatal("plugin was not stopped")
+		}
+		escalated := rec.payloads(supervisor.TopicEscalated)
+		require.Len(t, escalated, 1)
+		assert.Equal(t, "stop-plugin", escalated[0]["escalation"])
+		assert.Equal(t, "db-plugin", escalated[0]["pluginId"])
+	})
+
+	t.Run("Stop the runtime", func(t *testing.T) {
+		svc := newFlakyService("svc")
+		sup, _ := newSupervisor(t, nil, svc)
+		escalated := make(chan error, 1)
+		sup.SetRuntimeEscalation(func(err error) { escalated <- err })
+		require.NoError(t, sup.Supervise("svc", supervisor.Policy{
+			Restart:    supervisor.RestartNever,
+			Escalation: supervisor.EscalateRuntime,
+		}))
+
+		sup.ReportFailure("svc", errors.New("boom"))
+
+		select {
+		case err := <-escalated:
+			assert.Contains(t, err.Error(), "service 'svc' failed: boom")
+		case <-time.After(time.Second):
+			t.Fatal("runtime escalation was not called")
+		}
+	})
+}
+
+// servicePlugin registers a service and supervises it.
+type servicePlugin struct {
+	*infraComponent.BaseService
+	service *flakyService
+	policy  supervisor.Policy
+	stops   atomic.Int32
+}
+
+func (p *servicePlugin) Author() string                { return "test" }
+func (p *servicePlugin) PluginType() plugin.PluginType { return plugin.TypeExtension }
+
+func (p *servicePlugin) Initialize(ctx context.Context, system component.System) error {
+	if err := system.Registry().Register(p.service); err != nil {
+		return err
+	}
+	return system.(interface{ Supervisor() supervisor.Supervisor }).Supervisor().Supervise(p.service.ID(), p.policy)
+}
+
+func (p *servicePlugin) Stop(ctx context.Context) error {
+	p.stops.Add(1)
+	return p.BaseService.Stop(ctx)
+}
+
+// TestRuntimeSupervisor tests the supervisor wired into the runtime
+func TestRuntimeSupervisor(t *testing.T) {
+	newRuntime := func(t *testing.T, p plugin.Plugin) *infraRuntime.Runtime {
+		logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+		require.NoError(t, err)
+		rt, err := infraRuntime.NewRuntime(
+			infraComponent.NewRegistry(),
+			infraConfig.NewMemoryConfiguration(),
+			infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}),
+			infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"}),
+			logger,
+		)
+		require.NoError(t, err)
+		require.NoError(t, rt.LoadPlugins(infraContext.NewContext(), []plugin.Plugin{p}))
+		require.NoError(t, rt.Start(infraContext.NewContext()))
+		return rt
+	}
+	newPlugin := func(policy supervisor.Policy) *servicePlugin {
+		return &servicePlugin{
+			BaseService: infraComponent.NewBaseService(component.ComponentConfig{ID: "owner-plugin", Name: "owner"}),
+			service:     newFlakyService("owned-service"),
+			policy:      policy,
+		}
+	}
+
+	t.Run("Escalation stops the owning plugin", func(t *testing.T) {
+		p := newPlugin(supervisor.Policy{Restart: supervisor.RestartNever, Escalation: supervisor.EscalatePlugin})
+		rt := newRuntime(t, p)
+		defer rt.Stop(infraContext.NewContext())
+
+		p.service.ReportFailure(errors.New("boom"))
+
+		require.Eventually(t, func() bool { return p.stops.Load() == 1 }, time.Second, time.Millisecond)
+		assert.True(t, rt.IsRunning())
+	})
+
+	t.Run("Escalation stops the runtime", func(t *testing.T) {
+		p := newPlugin(supervisor.Policy{Restart: supervisor.RestartNever, Escalation: supervisor.EscalateRuntime})
+		rt := newRuntime(t, p)
+
+		p.service.ReportFailure(errors.New("boom"))
+
+		select {
+		case err := <-rt.Failed():
+			assert.Contains(t, err.Error(), "service 'owned-service' failed: boom")
+		case <-time.After(time.Second):
+			t.Fatal("runtime was not stopped")
+		}
+		assert.False(t, rt.IsRunning())
+	})
+}
+
+func TestUnregisteredServiceIsDropped(t *testing.T) {
+	svc := newFlakyService("svc")
+	registry := infraComponent.NewRegistry()
+	require.NoError(t, registry.Register(svc))
+	sup := infraSupervisor.NewSupervisor(component.ComponentConfig{ID: "supervisor"}, registry, nil, nil, nil)
+	require.NoError(t, sup.Start(infraContext.NewContext()))
+	defer sup.Stop(infraContext.NewContext())
+	require.NoError(t, sup.Supervise("svc", supervisor.Policy{InitialBackoff: time.Millisecond}))
+
+	require.NoError(t, registry.Unregister("svc"))
+	svc.ReportFailure(errors.New("boom"))
+
+	_, err := sup.State("svc")
+	assert.ErrorIs(t, err, supervisor.ErrServiceNotSupervised)
+	assert.Equal(t, int32(0), svc.starts.Load())
+}
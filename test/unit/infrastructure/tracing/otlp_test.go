@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
+)
+
+func TestFileExporter(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	span := tracing.SpanData{
+		Name:          "operation transfer",
+		Kind:          tracing.KindInternal,
+		Context:       tracing.SpanContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"},
+		ParentSpanID:  "00f067aa0ba902b7",
+		Start:         start,
+		End:           start.Add(time.Millisecond),
+		Attributes:    map[string]interface{}{"operation.id": "transfer", "attempt": 2, "retried": true},
+		Status:        tracing.StatusError,
+		StatusMessage: "insufficient funds",
+	}
+
+	t.Run("Spans are appended as OTLP/JSON lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "traces.jsonl")
+		exporter := infraTracing.NewFileExporter(path, "ledger")
+
+		require.NoError(t, exporter.Export([]tracing.SpanData{span}))
+		require.NoError(t, exporter.Shutdown())
+		require.NoError(t, exporter.Export([]tracing.SpanData{span}))
+		require.NoError(t, exporter.Shutdown())
+
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+
+		var lines []map[string]interface{}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		require.Len(t, lines, 2)
+
+		resourceSpans := lines[0]["resourceSpans"].([]interface{})[0].(map[string]interface{})
+		resource := resourceSpans["resource"].(map[string]interface{})
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"key":   "service.name",
+			"value": map[string]interface{}{"stringValue": "ledger"},
+		}}, resource["attributes"])
+
+		scope := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})
+		otlpSpan := scope["spans"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", otlpSpan["traceId"])
+		assert.Equal(t, "b7ad6b7169203331", otlpSpan["spanId"])
+		assert.Equal(t, "00f067aa0ba902b7", otlpSpan["parentSpanId"])
+		assert.Equal(t, 1.0, otlpSpan["kind"])
+		assert.Equal(t, "1700000000000000000", otlpSpan["startTimeUnixNano"])
+		assert.Equal(t, "1700000000001000000", otlpSpan["endTimeUnixNano"])
+		assert.Equal(t, map[string]interface{}{"code": 2.0, "message": "insufficient funds"}, otlpSpan["status"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"key": "attempt", "value": map[string]interface{}{"intValue": "2"}},
+			map[string]interface{}{"key": "operation.id", "value": map[string]interface{}{"stringValue": "transfer"}},
+			map[string]interface{}{"key": "retried", "value": map[string]interface{}{"boolValue": true}},
+		}, otlpSpan["attributes"])
+	})
+
+	t.Run("Unwritable files fail the export", func(t *testing.T) {
+		exporter := infraTracing.NewFileExporter(filepath.Join(t.TempDir(), "missing", "traces.jsonl"), "ledger")
+		err := exporter.Export([]tracing.SpanData{span})
+		assert.ErrorIs(t, err, tracing.ErrExportFailed)
+	})
+}
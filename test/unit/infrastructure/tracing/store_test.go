@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/storage"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraStorage "github.com/fintechain/skeleton/internal/infrastructure/storage"
+	memoryStorage "github.com/fintechain/skeleton/internal/infrastructure/storage/memory"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
+)
+
+func TestTraceStore(t *testing.T) {
+	exporter := infraTracing.NewMemoryExporter()
+	tracer := infraTracing.NewTracer(exporter)
+
+	t.Run("Store calls are client spans", func(t *testing.T) {
+		exporter.Reset()
+		store := infraTracing.TraceStore(memoryStorage.NewStore("ledger", ""), tracer)
+
+		require.NoError(t, store.Set([]byte("k"), []byte("v")))
+		_, err := store.Get([]byte("missing"))
+		assert.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+		spans := exporter.Spans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "store.set", spans[0].Name)
+		assert.Equal(t, tracing.KindClient, spans[0].Kind)
+		assert.Equal(t, "ledger", spans[0].Attributes["store.name"])
+		assert.Empty(t, spans[0].ParentSpanID)
+
+		// Missing keys are an answer, not a failure
+		assert.Equal(t, "store.get", spans[1].Name)
+		assert.Equal(t, tracing.StatusUnset, spans[1].Status)
+	})
+
+	t.Run("Bound stores trace under the span of the context", func(t *testing.T) {
+		exporter.Reset()
+		store := infraTracing.TraceStore(memoryStorage.NewStore("ledger", ""), tracer)
+		ctx, parent := tracer.Start(infraContext.NewContext(), "operation transfer", tracing.KindInternal)
+
+		bound := infraTracing.BindStore(ctx, store)
+		_, err := bound.Has([]byte("k"))
+		require.NoError(t, err)
+		parent.End()
+
+		spans := exporter.Spans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "store.has", spans[0].Name)
+		assert.Equal(t, parent.Context().SpanID, spans[0].ParentSpanID)
+
+		plain := memoryStorage.NewStore("cache", "")
+		assert.Same(t, plain, infraTracing.BindStore(ctx, plain))
+	})
+
+	t.Run("The multistore traces the stores it returns", func(t *testing.T) {
+		exporter.Reset()
+		multiStore := infraStorage.NewMultiStore(component.ComponentConfig{ID: "multi-store"}, t.TempDir())
+		require.NoError(t, multiStore.RegisterEngine(memoryStorage.NewEngine()))
+		require.NoError(t, multiStore.CreateStore("ledger", "memory", nil))
+		multiStore.SetTracer(tracer)
+
+		store, err := multiStore.GetStore("ledger")
+		require.NoError(t, err)
+		require.NoError(t, store.Set([]byte("k"), []byte("v")))
+		require.NoError(t, store.Delete([]byte("k")))
+
+		spans := exporter.Spans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "store.delete", spans[1].Name)
+	})
+}
@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/failure"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraTracing "github.com/fintechain/skeleton/internal/infrastructure/tracing"
+)
+
+func TestTracer(t *testing.T) {
+	exporter := infraTracing.NewMemoryExporter()
+	tracer := infraTracing.NewTracer(exporter)
+
+	t.Run("Spans without a parent start a trace", func(t *testing.T) {
+		exporter.Reset()
+		ctx, span := tracer.Start(infraContext.NewContext(), "root", tracing.KindInternal)
+		span.SetAttribute("answer", 42)
+		span.End()
+		span.End()
+
+		spans := exporter.Spans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "root", spans[0].Name)
+		assert.Len(t, string(spans[0].Context.TraceID), 32)
+		assert.Len(t, string(spans[0].Context.SpanID), 16)
+		assert.Empty(t, spans[0].ParentSpanID)
+		assert.Equal(t, 42, spans[0].Attributes["answer"])
+		assert.False(t, spans[0].End.Before(spans[0].Start))
+		assert.Equal(t, span, tracing.SpanFromContext(ctx))
+	})
+
+	t.Run("Spans started from a span context are its children", func(t *testing.T) {
+		exporter.Reset()
+		ctx, parent := tracer.Start(infraContext.NewContext(), "parent", tracing.KindInternal)
+		_, child := tracer.Start(ctx, "child", tracing.KindClient)
+		child.End()
+		parent.End()
+
+		spans := exporter.Spans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, spans[1].Context.TraceID, spans[0].Context.TraceID)
+		assert.Equal(t, spans[1].Context.SpanID, spans[0].ParentSpanID)
+	})
+
+	t.Run("Errors mark the span failed with their code", func(t *testing.T) {
+		exporter.Reset()
+		_, span := tracer.Start(nil, "failing", tracing.KindInternal)
+		span.RecordError(nil)
+		span.RecordError(failure.New(component.ErrInputValidationFailed, "missing amount"))
+		span.End()
+
+		spans := exporter.Spans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, tracing.StatusError, spans[0].Status)
+		assert.Contains(t, spans[0].StatusMessage, "missing amount")
+		assert.Equal(t, string(component.ErrInputValidationFailed), spans[0].Attributes["error.code"])
+	})
+}
+
+func TestPropagation(t *testing.T) {
+	tracer := infraTracing.NewTracer()
+
+	t.Run("Span contexts round-trip through event metadata", func(t *testing.T) {
+		ctx, span := tracer.Start(infraContext.NewContext(), "publish", tracing.KindProducer)
+		evt := &event.Event{Topic: "orders.created", Metadata: map[string]string{"tenant": "acme"}}
+		original := evt.Metadata
+
+		tracing.Inject(ctx, evt)
+		assert.Equal(t, "acme", evt.Metadata["tenant"])
+		assert.NotContains(t, original, tracing.TraceParentKey)
+
+		received := tracing.Extract(infraContext.NewContext(), evt)
+		assert.Equal(t, span.Context(), tracing.SpanContextFromContext(received))
+	})
+
+	t.Run("Invalid traceparent values are ignored", func(t *testing.T) {
+		for _, value := range []string{
+			"",
+			"00-abc-def-01",
+			"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+			"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+			"00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01",
+		} {
+			_, ok := tracing.ParseTraceParent(value)
+			assert.False(t, ok, value)
+		}
+
+		sc, ok := tracing.ParseTraceParent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		require.True(t, ok)
+		assert.Equal(t, tracing.TraceID("0af7651916cd43dd8448eb211c80319c"), sc.TraceID)
+	})
+
+	t.Run("Contexts without a span inject nothing", func(t *testing.T) {
+		evt := &event.Event{Topic: "orders.created"}
+		tracing.Inject(infraContext.NewContext(), evt)
+		assert.Nil(t, evt.Metadata)
+	})
+}
+
+func TestEventBusTracing(t *testing.T) {
+	exporter := infraTracing.NewMemoryExporter()
+	tracer := infraTracing.NewTracer(exporter)
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	bus.SetTracer(tracer)
+
+	var handled tracing.SpanContext
+	bus.Subscribe("orders.created", func(evt *event.Event) {
+		handled = tracing.SpanContextFromContext(tracing.Extract(infraContext.NewContext(), evt))
+	})
+
+	ctx, publisher := tracer.Start(infraContext.NewContext(), "operation checkout", tracing.KindInternal)
+	evt := &event.Event{Topic: "orders.created", Source: "checkout"}
+	tracing.Inject(ctx, evt)
+	require.NoError(t, bus.Publish(evt))
+	publisher.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 2)
+	delivery := spans[0]
+	assert.Equal(t, "event orders.created", delivery.Name)
+	assert.Equal(t, tracing.KindConsumer, delivery.Kind)
+	assert.Equal(t, publisher.Context().TraceID, delivery.Context.TraceID)
+	assert.Equal(t, publisher.Context().SpanID, delivery.ParentSpanID)
+	assert.Equal(t, "checkout", delivery.Attributes["event.source"])
+
+	// The handler receives the span of its delivery; the published event is untouched
+	assert.Equal(t, delivery.Context, handled)
+	assert.Equal(t, tracing.FormatTraceParent(publisher.Context()), evt.Metadata[tracing.TraceParentKey])
+}
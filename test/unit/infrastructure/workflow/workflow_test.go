@@ -0,0 +1,395 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/workflow"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
+	infraLogging "github.com/fintechain/skeleton/internal/infrastructure/logging"
+	infraPlugin "github.com/fintechain/skeleton/internal/infrastructure/plugin"
+	infraRuntime "github.com/fintechain/skeleton/internal/infrastructure/runtime"
+	infraWorkflow "github.com/fintechain/skeleton/internal/infrastructure/workflow"
+)
+
+// journal records the calls of the test operations.
+type journal struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (j *journal) add(call string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.calls = append(j.calls, call)
+}
+
+func (j *journal) list() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]string(nil), j.calls...)
+}
+
+type order struct {
+	Account  string `json:"account"`
+	Amount   int    `json:"amount"`
+	Priority string `json:"priority,omitempty"`
+}
+
+// newEnvironment returns a runtime with payment operations registered.
+// "charge" fails for amounts over 100.
+func newEnvironment(t *testing.T) (*infraRuntime.Runtime, *infraEvent.EventBus, *journal) {
+	t.Helper()
+	bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+	logger, err := infraLogging.NewLogger(component.ComponentConfig{ID: "logger"}, infraLogging.NewNoOpLogger())
+	require.NoError(t, err)
+	rt, err := infraRuntime.NewRuntime(
+		infraComponent.NewRegistry(),
+		infraConfig.NewMemoryConfiguration(),
+		infraPlugin.NewManager(component.ComponentConfig{ID: "plugin-manager"}),
+		bus,
+		logger,
+	)
+	require.NoError(t, err)
+
+	j := &journal{}
+	register := func(id component.ComponentID, fn func(in map[string]any) (any, error)) {
+		op := infraComponent.NewTypedOperation(component.ComponentConfig{ID: id},
+			func(ctx context.Context, in map[string]any) (any, error) {
+				j.add(string(id))
+				return fn(in)
+			})
+		require.NoError(t, rt.Registry().Register(op))
+	}
+
+	register("reserve", func(in map[string]any) (any, error) {
+		return map[string]any{"reservationId": fmt.Sprintf("r-%v", in["account"]), "amount": in["amount"]}, nil
+	})
+	register("charge", func(in map[string]any) (any, error) {
+		if in["amount"].(float64) > 100 {
+			return nil, errors.New("card declined")
+		}
+		return map[string]any{"chargeId": fmt.Sprintf("c-%v", in["reservationId"])}, nil
+	})
+	register("release", func(in map[string]any) (any, error) {
+		j.add(fmt.Sprintf("release %v: %v", in["reservationId"], in["reason"]))
+		return nil, nil
+	})
+	register("refund", func(in map[string]any) (any, error) {
+		return nil, errors.New("refunds unavailable")
+	})
+	register("email", func(in map[string]any) (any, error) {
+		return "mailed " + in["chargeId"].(string), nil
+	})
+	register("sms", func(in map[string]any) (any, error) {
+		return "texted " + in["chargeId"].(string), nil
+	})
+	register("review", func(in map[string]any) (any, error) {
+		return "reviewed", nil
+	})
+	return rt, bus, j
+}
+
+func execute(t *testing.T, rt *infraRuntime.Runtime, flow workflow.Flow, input any) (component.Output, error) {
+	t.Helper()
+	ctx := infraContext.NewContext()
+	_, err := infraWorkflow.Register(ctx, rt, flow)
+	require.NoError(t, err)
+	return rt.ExecuteOperation(ctx, flow.ID, component.Input{Data: input})
+}
+
+func TestWorkflowSequence(t *testing.T) {
+	rt, _, j := newEnvironment(t)
+
+	output, err := execute(t, rt, workflow.Flow{
+		ID: "pay",
+		Steps: []workflow.Step{
+			{Operation: "reserve"},
+			{Operation: "charge"}, // receives the reservation
+			{Name: "receipt", Operation: "email"},
+		},
+		Output: "steps.charge.chargeId",
+	}, order{Account: "acc-1", Amount: 40})
+	require.NoError(t, err)
+
+	assert.Equal(t, "c-r-acc-1", output.Data)
+	assert.Equal(t, []string{"reserve", "charge", "email"}, j.list())
+}
+
+func TestWorkflowMapping(t *testing.T) {
+	rt, _, _ := newEnvironment(t)
+
+	output, err := execute(t, rt, workflow.Flow{
+		ID: "pay",
+		Steps: []workflow.Step{
+			{Operation: "reserve"},
+			{Operation: "charge", Input: &workflow.Mapping{
+				Fields: map[string]string{"reservationId": "steps.reserve.reservationId", "amount": "input.amount"},
+			}},
+			{Operation: "email", Input: &workflow.Mapping{From: "steps.charge"}},
+		},
+	}, order{Account: "acc-1", Amount: 40})
+	require.NoError(t, err)
+	assert.Equal(t, "mailed c-r-acc-1", output.Data)
+
+	t.Run("Missing paths fail the step", func(t *testing.T) {
+		_, err := execute(t, rt, workflow.Flow{
+			ID:    "broken",
+			Steps: []workflow.Step{{Operation: "charge", Input: &workflow.Mapping{From: "steps.missing"}}},
+		}, order{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, workflow.ErrMappingFailed)
+	})
+}
+
+func TestWorkflowParallel(t *testing.T) {
+	rt, _, j := newEnvironment(t)
+
+	output, err := execute(t, rt, workflow.Flow{
+		ID: "pay",
+		Steps: []workflow.Step{
+			{Operation: "reserve"},
+			{Operation: "charge"},
+			{Name: "notify", Parallel: []workflow.Step{
+				{Operation: "email"},
+				{Operation: "sms"},
+			}},
+		},
+	}, order{Account: "acc-1", Amount: 40})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"email": "mailed c-r-acc-1", "sms": "texted c-r-acc-1"}, output.Data)
+	assert.ElementsMatch(t, []string{"reserve", "charge", "email", "sms"}, j.list())
+}
+
+func TestWorkflowConditions(t *testing.T) {
+	flow := workflow.Flow{
+		ID: "pay",
+		Steps: []workflow.Step{
+			{Name: "route", Branches: []workflow.Branch{
+				{When: &workflow.Condition{Path: "input.priority", Equals: "high"}, Steps: []workflow.Step{{Operation: "review"}}},
+				{Steps: []workflow.Step{{Operation: "reserve"}}},
+			}},
+			{Operation: "sms", When: &workflow.Condition{Path: "steps.charge"}},
+		},
+		Output: "steps.route",
+	}
+
+	t.Run("The first matching branch runs", func(t *testing.T) {
+		rt, _, j := newEnvironment(t)
+		output, err := execute(t, rt, flow, order{Account: "acc-1", Priority: "high"})
+		require.NoError(t, err)
+		assert.Equal(t, "reviewed", output.Data)
+		assert.Equal(t, []string{"review"}, j.list(), "steps whose condition fails are skipped")
+	})
+
+	t.Run("Branches without a condition match", func(t *testing.T) {
+		rt, _, j := newEnvironment(t)
+		output, err := execute(t, rt, flow, order{Account: "acc-1"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"reservationId": "r-acc-1", "amount": float64(0)}, output.Data)
+		assert.Equal(t, []string{"reserve"}, j.list())
+	})
+}
+
+func TestWorkflowCompensation(t *testing.T) {
+	flow := workflow.Flow{
+		ID: "pay",
+		Steps: []workflow.Step{
+			{Operation: "reserve", Compensate: "release", CompensateInput: &workflow.Mapping{
+				From:   "steps.reserve",
+				Fields: map[string]string{"reason": "error"},
+			}},
+			{Operation: "charge"},
+		},
+	}
+
+	t.Run("Completed steps are compensated", func(t *testing.T) {
+		rt, bus, j := newEnvironment(t)
+		var failed *event.Event
+		bus.Subscribe(workflow.TopicFlowFailed, func(evt *event.Event) { failed = evt })
+
+		_, err := execute(t, rt, flow, order{Account: "acc-1", Amount: 500})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, workflow.ErrStepFailed)
+		assert.Contains(t, err.Error(), "card declined")
+
+		assert.Equal(t, []string{
+			"reserve",
+			"charge",
+			"release",
+			"release r-acc-1: workflow.step_failed: step 'charge': card declined",
+		}, j.list())
+		require.NotNil(t, failed)
+		assert.Equal(t, []string{"reserve"}, failed.Payload["compensated"])
+	})
+
+	t.Run("Compensations run in reverse order", func(t *testing.T) {
+		rt, _, j := newEnvironment(t)
+		reverse := workflow.Flow{
+			ID: "pay",
+			Steps: []workflow.Step{
+				{Name: "first", Operation: "reserve", Compensate: "release"},
+				{Name: "second", Operation: "review", Compensate: "release", CompensateInput: &workflow.Mapping{
+					Fields: map[string]string{"reservationId": "steps.second"},
+				}},
+				{Operation: "charge", Input: &workflow.Mapping{From: "input"}},
+			},
+		}
+
+		_, err := execute(t, rt, reverse, order{Account: "acc-1", Amount: 500})
+		require.Error(t, err)
+		calls := j.list()
+		assert.Equal(t, []string{"release reviewed: <nil>", "release", "release r-acc-1: <nil>"}, calls[len(calls)-3:])
+	})
+
+	t.Run("Compensation failures are reported", func(t *testing.T) {
+		rt, _, _ := newEnvironment(t)
+		_, err := execute(t, rt, workflow.Flow{
+			ID: "pay",
+			Steps: []workflow.Step{
+				{Operation: "reserve", Compensate: "refund"},
+				{Operation: "charge"},
+			},
+		}, order{Account: "acc-1", Amount: 500})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, workflow.ErrCompensationFailed)
+		assert.Contains(t, err.Error(), "refunds unavailable")
+	})
+}
+
+func TestWorkflowIdempotency(t *testing.T) {
+	// keyed runs a registered flow with an idempotency key
+	keyed := func(rt *infraRuntime.Runtime, flowID component.ComponentID, input any, key string) error {
+		_, err := rt.ExecuteOperation(infraContext.NewContext(), flowID, component.Input{
+			Data:     input,
+			Metadata: map[string]string{idempotency.MetadataKey: key},
+		})
+		return err
+	}
+	enable := func(t *testing.T, rt *infraRuntime.Runtime, ids ...component.ComponentID) {
+		for _, id := range ids {
+			require.NoError(t, rt.Idempotency().SetPolicy(id, idempotency.Policy{TTL: time.Hour}))
+		}
+	}
+
+	t.Run("Each step has its own key", func(t *testing.T) {
+		rt, _, j := newEnvironment(t)
+		enable(t, rt, "reserve")
+		_, err := infraWorkflow.Register(infraContext.NewContext(), rt, workflow.Flow{
+			ID: "reserve-twice",
+			Steps: []workflow.Step{
+				{Name: "first", Operation: "reserve"},
+				{Name: "second", Operation: "reserve", Input: &workflow.Mapping{From: "input"}},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, keyed(rt, "reserve-twice", order{Account: "acc-1", Amount: 10}, "req-1"))
+		assert.Equal(t, []string{"reserve", "reserve"}, j.list(), "the second step is not a replay of the first")
+
+		_, err = rt.Idempotency().Lookup("reserve", "req-1/first")
+		require.NoError(t, err)
+		_, err = rt.Idempotency().Lookup("reserve", "req-1/second")
+		require.NoError(t, err)
+
+		require.NoError(t, keyed(rt, "reserve-twice", order{Account: "acc-1", Amount: 10}, "req-1"))
+		assert.Len(t, j.list(), 2, "running the flow again replays both steps")
+	})
+
+	t.Run("A compensated flow run again with its key replays its outcome", func(t *testing.T) {
+		rt, _, j := newEnvironment(t)
+		enable(t, rt, "reserve", "charge", "release")
+		_, err := infraWorkflow.Register(infraContext.NewContext(), rt, workflow.Flow{
+			ID: "pay",
+			Steps: []workflow.Step{
+				{Operation: "reserve", Compensate: "release"},
+				{Operation: "charge"},
+			},
+		})
+		require.NoError(t, err)
+
+		err = keyed(rt, "pay", order{Account: "acc-1", Amount: 500}, "req-2")
+		require.Error(t, err)
+		calls := j.list()
+		assert.Equal(t, []string{"reserve", "charge", "release", "release r-acc-1: <nil>"}, calls)
+		_, err = rt.Idempotency().Lookup("release", "req-2/reserve/compensate")
+		require.NoError(t, err, "compensations have their own key")
+
+		err = keyed(rt, "pay", order{Account: "acc-1", Amount: 500}, "req-2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "card declined")
+		assert.Equal(t, calls, j.list(), "no step or compensation runs twice")
+	})
+}
+
+func TestWorkflowEvents(t *testing.T) {
+	rt, bus, _ := newEnvironment(t)
+	var topics []string
+	for _, topic := range []string{workflow.TopicFlowStarted, workflow.TopicFlowCompleted} {
+		bus.Subscribe(topic, func(evt *event.Event) { topics = append(topics, evt.Topic) })
+	}
+
+	_, err := execute(t, rt, workflow.Flow{ID: "pay", Steps: []workflow.Step{{Operation: "reserve"}}}, order{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{workflow.TopicFlowStarted, workflow.TopicFlowCompleted}, topics)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		flow workflow.Flow
+		want string
+	}{
+		{"no ID", workflow.Flow{Steps: []workflow.Step{{Operation: "reserve"}}}, "flow without an ID"},
+		{"no steps", workflow.Flow{ID: "pay"}, "has no steps"},
+		{"empty step", workflow.Flow{ID: "pay", Steps: []workflow.Step{{}}}, "steps[0]: needs exactly one of"},
+		{"two kinds", workflow.Flow{ID: "pay", Steps: []workflow.Step{{Operation: "reserve", Steps: []workflow.Step{{Operation: "charge"}}}}}, "needs exactly one of"},
+		{"duplicate names", workflow.Flow{ID: "pay", Steps: []workflow.Step{{Operation: "reserve"}, {Operation: "reserve"}}}, "steps[1]: duplicate step name 'reserve'"},
+		{"unnamed parallel step", workflow.Flow{ID: "pay", Steps: []workflow.Step{{Parallel: []workflow.Step{{Steps: []workflow.Step{{Operation: "sms"}}}}}}}, "parallel steps need a name"},
+		{"empty mapping", workflow.Flow{ID: "pay", Steps: []workflow.Step{{Operation: "reserve", Input: &workflow.Mapping{}}}}, "mapping needs from or fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := infraWorkflow.Validate(tt.flow)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, workflow.ErrInvalidFlow)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestFlowsFromConfig(t *testing.T) {
+	cfg := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+		"workflows": []interface{}{
+			map[string]interface{}{
+				"id": "pay",
+				"steps": []interface{}{
+					map[string]interface{}{"operation": "reserve", "compensate": "release"},
+					map[string]interface{}{"operation": "charge", "when": map[string]interface{}{"path": "input.amount"}},
+				},
+			},
+		},
+	})
+
+	flows, err := infraWorkflow.FlowsFromConfig(cfg)
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+	assert.Equal(t, component.ComponentID("pay"), flows[0].ID)
+	assert.Equal(t, component.ComponentID("release"), flows[0].Steps[0].Compensate)
+	assert.Equal(t, "input.amount", flows[0].Steps[1].When.Path)
+	assert.Equal(t, []component.ComponentID{"charge", "release", "reserve"}, infraWorkflow.Operations(flows[0]))
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"github.com/fintechain/skeleton/internal/domain/context"
 	"github.com/fintechain/skeleton/internal/domain/event"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -138,6 +139,120 @@ func (_c *MockEventBus_PublishAsync_Call) RunAndReturn(run func(event1 *event.Ev
 	return _c
 }
 
+// PublishAsyncContext provides a mock function for the type MockEventBus
+func (_mock *MockEventBus) PublishAsyncContext(ctx context.Context, event1 *event.Event) error {
+	ret := _mock.Called(ctx, event1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishAsyncContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *event.Event) error); ok {
+		r0 = returnFunc(ctx, event1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventBus_PublishAsyncContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishAsyncContext'
+type MockEventBus_PublishAsyncContext_Call struct {
+	*mock.Call
+}
+
+// PublishAsyncContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event1 *event.Event
+func (_e *MockEventBus_Expecter) PublishAsyncContext(ctx interface{}, event1 interface{}) *MockEventBus_PublishAsyncContext_Call {
+	return &MockEventBus_PublishAsyncContext_Call{Call: _e.mock.On("PublishAsyncContext", ctx, event1)}
+}
+
+func (_c *MockEventBus_PublishAsyncContext_Call) Run(run func(ctx context.Context, event1 *event.Event)) *MockEventBus_PublishAsyncContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *event.Event
+		if args[1] != nil {
+			arg1 = args[1].(*event.Event)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventBus_PublishAsyncContext_Call) Return(err error) *MockEventBus_PublishAsyncContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventBus_PublishAsyncContext_Call) RunAndReturn(run func(ctx context.Context, event1 *event.Event) error) *MockEventBus_PublishAsyncContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishContext provides a mock function for the type MockEventBus
+func (_mock *MockEventBus) PublishContext(ctx context.Context, event1 *event.Event) error {
+	ret := _mock.Called(ctx, event1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *event.Event) error); ok {
+		r0 = returnFunc(ctx, event1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventBus_PublishContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishContext'
+type MockEventBus_PublishContext_Call struct {
+	*mock.Call
+}
+
+// PublishContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event1 *event.Event
+func (_e *MockEventBus_Expecter) PublishContext(ctx interface{}, event1 interface{}) *MockEventBus_PublishContext_Call {
+	return &MockEventBus_PublishContext_Call{Call: _e.mock.On("PublishContext", ctx, event1)}
+}
+
+func (_c *MockEventBus_PublishContext_Call) Run(run func(ctx context.Context, event1 *event.Event)) *MockEventBus_PublishContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *event.Event
+		if args[1] != nil {
+			arg1 = args[1].(*event.Event)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventBus_PublishContext_Call) Return(err error) *MockEventBus_PublishContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventBus_PublishContext_Call) RunAndReturn(run func(ctx context.Context, event1 *event.Event) error) *MockEventBus_PublishContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Subscribe provides a mock function for the type MockEventBus
 func (_mock *MockEventBus) Subscribe(eventType string, handler event.EventHandler) event.Subscription {
 	ret := _mock.Called(eventType, handler)
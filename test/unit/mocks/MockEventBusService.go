@@ -463,6 +463,120 @@ func (_c *MockEventBusService_PublishAsync_Call) RunAndReturn(run func(event1 *e
 	return _c
 }
 
+// PublishAsyncContext provides a mock function for the type MockEventBusService
+func (_mock *MockEventBusService) PublishAsyncContext(ctx context.Context, event1 *event.Event) error {
+	ret := _mock.Called(ctx, event1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishAsyncContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *event.Event) error); ok {
+		r0 = returnFunc(ctx, event1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventBusService_PublishAsyncContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishAsyncContext'
+type MockEventBusService_PublishAsyncContext_Call struct {
+	*mock.Call
+}
+
+// PublishAsyncContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event1 *event.Event
+func (_e *MockEventBusService_Expecter) PublishAsyncContext(ctx interface{}, event1 interface{}) *MockEventBusService_PublishAsyncContext_Call {
+	return &MockEventBusService_PublishAsyncContext_Call{Call: _e.mock.On("PublishAsyncContext", ctx, event1)}
+}
+
+func (_c *MockEventBusService_PublishAsyncContext_Call) Run(run func(ctx context.Context, event1 *event.Event)) *MockEventBusService_PublishAsyncContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *event.Event
+		if args[1] != nil {
+			arg1 = args[1].(*event.Event)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventBusService_PublishAsyncContext_Call) Return(err error) *MockEventBusService_PublishAsyncContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventBusService_PublishAsyncContext_Call) RunAndReturn(run func(ctx context.Context, event1 *event.Event) error) *MockEventBusService_PublishAsyncContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishContext provides a mock function for the type MockEventBusService
+func (_mock *MockEventBusService) PublishContext(ctx context.Context, event1 *event.Event) error {
+	ret := _mock.Called(ctx, event1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *event.Event) error); ok {
+		r0 = returnFunc(ctx, event1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEventBusService_PublishContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishContext'
+type MockEventBusService_PublishContext_Call struct {
+	*mock.Call
+}
+
+// PublishContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event1 *event.Event
+func (_e *MockEventBusService_Expecter) PublishContext(ctx interface{}, event1 interface{}) *MockEventBusService_PublishContext_Call {
+	return &MockEventBusService_PublishContext_Call{Call: _e.mock.On("PublishContext", ctx, event1)}
+}
+
+func (_c *MockEventBusService_PublishContext_Call) Run(run func(ctx context.Context, event1 *event.Event)) *MockEventBusService_PublishContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *event.Event
+		if args[1] != nil {
+			arg1 = args[1].(*event.Event)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEventBusService_PublishContext_Call) Return(err error) *MockEventBusService_PublishContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEventBusService_PublishContext_Call) RunAndReturn(run func(ctx context.Context, event1 *event.Event) error) *MockEventBusService_PublishContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function for the type MockEventBusService
 func (_mock *MockEventBusService) Start(ctx context.Context) error {
 	ret := _mock.Called(ctx)
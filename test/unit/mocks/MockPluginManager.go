@@ -881,6 +881,63 @@ func (_c *MockPluginManager_Type_Call) RunAndReturn(run func() component.Compone
 	return _c
 }
 
+// Unload provides a mock function for the type MockPluginManager
+func (_mock *MockPluginManager) Unload(ctx context.Context, pluginID component.ComponentID) error {
+	ret := _mock.Called(ctx, pluginID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unload")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, component.ComponentID) error); ok {
+		r0 = returnFunc(ctx, pluginID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPluginManager_Unload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unload'
+type MockPluginManager_Unload_Call struct {
+	*mock.Call
+}
+
+// Unload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pluginID component.ComponentID
+func (_e *MockPluginManager_Expecter) Unload(ctx interface{}, pluginID interface{}) *MockPluginManager_Unload_Call {
+	return &MockPluginManager_Unload_Call{Call: _e.mock.On("Unload", ctx, pluginID)}
+}
+
+func (_c *MockPluginManager_Unload_Call) Run(run func(ctx context.Context, pluginID component.ComponentID)) *MockPluginManager_Unload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 component.ComponentID
+		if args[1] != nil {
+			arg1 = args[1].(component.ComponentID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPluginManager_Unload_Call) Return(err error) *MockPluginManager_Unload_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPluginManager_Unload_Call) RunAndReturn(run func(ctx context.Context, pluginID component.ComponentID) error) *MockPluginManager_Unload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Version provides a mock function for the type MockPluginManager
 func (_mock *MockPluginManager) Version() string {
 	ret := _mock.Called()
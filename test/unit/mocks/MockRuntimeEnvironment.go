@@ -9,8 +9,15 @@ import (
 	"github.com/fintechain/skeleton/internal/domain/config"
 	"github.com/fintechain/skeleton/internal/domain/context"
 	"github.com/fintechain/skeleton/internal/domain/event"
+	"github.com/fintechain/skeleton/internal/domain/health"
+	"github.com/fintechain/skeleton/internal/domain/idempotency"
+	"github.com/fintechain/skeleton/internal/domain/introspection"
 	"github.com/fintechain/skeleton/internal/domain/logging"
+	"github.com/fintechain/skeleton/internal/domain/metrics"
 	"github.com/fintechain/skeleton/internal/domain/plugin"
+	"github.com/fintechain/skeleton/internal/domain/resilience"
+	"github.com/fintechain/skeleton/internal/domain/supervisor"
+	"github.com/fintechain/skeleton/internal/domain/tracing"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -205,6 +212,142 @@ func (_c *MockRuntimeEnvironment_ExecuteOperation_Call) RunAndReturn(run func(ct
 	return _c
 }
 
+// Health provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) Health() health.Checker {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Health")
+	}
+
+	var r0 health.Checker
+	if returnFunc, ok := ret.Get(0).(func() health.Checker); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(health.Checker)
+		}
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_Health_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Health'
+type MockRuntimeEnvironment_Health_Call struct {
+	*mock.Call
+}
+
+// Health is a helper method to define mock.On call
+func (_e *MockRuntimeEnvironment_Expecter) Health() *MockRuntimeEnvironment_Health_Call {
+	return &MockRuntimeEnvironment_Health_Call{Call: _e.mock.On("Health")}
+}
+
+func (_c *MockRuntimeEnvironment_Health_Call) Run(run func()) *MockRuntimeEnvironment_Health_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Health_Call) Return(checker health.Checker) *MockRuntimeEnvironment_Health_Call {
+	_c.Call.Return(checker)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Health_Call) RunAndReturn(run func() health.Checker) *MockRuntimeEnvironment_Health_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Idempotency provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) Idempotency() idempotency.Deduplicator {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Idempotency")
+	}
+
+	var r0 idempotency.Deduplicator
+	if returnFunc, ok := ret.Get(0).(func() idempotency.Deduplicator); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(idempotency.Deduplicator)
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_Idempotency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Idempotency'
+type MockRuntimeEnvironment_Idempotency_Call struct {
+	*mock.Call
+}
+
+// Idempotency is a helper method to define mock.On call
+func (_e *MockRuntimeEnvironment_Expecter) Idempotency() *MockRuntimeEnvironment_Idempotency_Call {
+	return &MockRuntimeEnvironment_Idempotency_Call{Call: _e.mock.On("Idempotency")}
+}
+
+func (_c *MockRuntimeEnvironment_Idempotency_Call) Run(run func()) *MockRuntimeEnvironment_Idempotency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Idempotency_Call) Return(deduplicator idempotency.Deduplicator) *MockRuntimeEnvironment_Idempotency_Call {
+	_c.Call.Return(deduplicator)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Idempotency_Call) RunAndReturn(run func() idempotency.Deduplicator) *MockRuntimeEnvironment_Idempotency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Introspection provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) Introspection() introspection.Inspector {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Introspection")
+	}
+
+	var r0 introspection.Inspector
+	if returnFunc, ok := ret.Get(0).(func() introspection.Inspector); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(introspection.Inspector)
+		}
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_Introspection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Introspection'
+type MockRuntimeEnvironment_Introspection_Call struct {
+	*mock.Call
+}
+
+// Introspection is a helper method to define mock.On call
+func (_e *MockRuntimeEnvironment_Expecter) Introspection() *MockRuntimeEnvironment_Introspection_Call {
+	return &MockRuntimeEnvironment_Introspection_Call{Call: _e.mock.On("Introspection")}
+}
+
+func (_c *MockRuntimeEnvironment_Introspection_Call) Run(run func()) *MockRuntimeEnvironment_Introspection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Introspection_Call) Return(inspector introspection.Inspector) *MockRuntimeEnvironment_Introspection_Call {
+	_c.Call.Return(inspector)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Introspection_Call) RunAndReturn(run func() introspection.Inspector) *MockRuntimeEnvironment_Introspection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsRunning provides a mock function for the type MockRuntimeEnvironment
 func (_mock *MockRuntimeEnvironment) IsRunning() bool {
 	ret := _mock.Called()
@@ -352,6 +495,105 @@ func (_c *MockRuntimeEnvironment_Logger_Call) RunAndReturn(run func() logging.Lo
 	return _c
 }
 
+// Metrics provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) Metrics() metrics.Registry {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Metrics")
+	}
+
+	var r0 metrics.Registry
+	if returnFunc, ok := ret.Get(0).(func() metrics.Registry); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metrics.Registry)
+		}
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_Metrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Metrics'
+type MockRuntimeEnvironment_Metrics_Call struct {
+	*mock.Call
+}
+
+// Metrics is a helper method to define mock.On call
+func (_e *MockRuntimeEnvironment_Expecter) Metrics() *MockRuntimeEnvironment_Metrics_Call {
+	return &MockRuntimeEnvironment_Metrics_Call{Call: _e.mock.On("Metrics")}
+}
+
+func (_c *MockRuntimeEnvironment_Metrics_Call) Run(run func()) *MockRuntimeEnvironment_Metrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Metrics_Call) Return(registry metrics.Registry) *MockRuntimeEnvironment_Metrics_Call {
+	_c.Call.Return(registry)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Metrics_Call) RunAndReturn(run func() metrics.Registry) *MockRuntimeEnvironment_Metrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PluginConfiguration provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) PluginConfiguration(pluginID component.ComponentID) config.Section {
+	ret := _mock.Called(pluginID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PluginConfiguration")
+	}
+
+	var r0 config.Section
+	if returnFunc, ok := ret.Get(0).(func(component.ComponentID) config.Section); ok {
+		r0 = returnFunc(pluginID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(config.Section)
+		}
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_PluginConfiguration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PluginConfiguration'
+type MockRuntimeEnvironment_PluginConfiguration_Call struct {
+	*mock.Call
+}
+
+// PluginConfiguration is a helper method to define mock.On call
+//   - pluginID component.ComponentID
+func (_e *MockRuntimeEnvironment_Expecter) PluginConfiguration(pluginID interface{}) *MockRuntimeEnvironment_PluginConfiguration_Call {
+	return &MockRuntimeEnvironment_PluginConfiguration_Call{Call: _e.mock.On("PluginConfiguration", pluginID)}
+}
+
+func (_c *MockRuntimeEnvironment_PluginConfiguration_Call) Run(run func(pluginID component.ComponentID)) *MockRuntimeEnvironment_PluginConfiguration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 component.ComponentID
+		if args[0] != nil {
+			arg0 = args[0].(component.ComponentID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_PluginConfiguration_Call) Return(section config.Section) *MockRuntimeEnvironment_PluginConfiguration_Call {
+	_c.Call.Return(section)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_PluginConfiguration_Call) RunAndReturn(run func(pluginID component.ComponentID) config.Section) *MockRuntimeEnvironment_PluginConfiguration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PluginManager provides a mock function for the type MockRuntimeEnvironment
 func (_mock *MockRuntimeEnvironment) PluginManager() plugin.PluginManager {
 	ret := _mock.Called()
@@ -444,6 +686,50 @@ func (_c *MockRuntimeEnvironment_Registry_Call) RunAndReturn(run func() componen
 	return _c
 }
 
+// Resilience provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) Resilience() resilience.Guard {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resilience")
+	}
+
+	var r0 resilience.Guard
+	if returnFunc, ok := ret.Get(0).(func() resilience.Guard); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(resilience.Guard)
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_Resilience_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resilience'
+type MockRuntimeEnvironment_Resilience_Call struct {
+	*mock.Call
+}
+
+// Resilience is a helper method to define mock.On call
+func (_e *MockRuntimeEnvironment_Expecter) Resilience() *MockRuntimeEnvironment_Resilience_Call {
+	return &MockRuntimeEnvironment_Resilience_Call{Call: _e.mock.On("Resilience")}
+}
+
+func (_c *MockRuntimeEnvironment_Resilience_Call) Run(run func()) *MockRuntimeEnvironment_Resilience_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Resilience_Call) Return(guard resilience.Guard) *MockRuntimeEnvironment_Resilience_Call {
+	_c.Call.Return(guard)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Resilience_Call) RunAndReturn(run func() resilience.Guard) *MockRuntimeEnvironment_Resilience_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function for the type MockRuntimeEnvironment
 func (_mock *MockRuntimeEnvironment) Start(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -552,6 +838,50 @@ func (_c *MockRuntimeEnvironment_StartService_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
+// Status provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) Status() component.ServiceStatus {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 component.ServiceStatus
+	if returnFunc, ok := ret.Get(0).(func() component.ServiceStatus); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(component.ServiceStatus)
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockRuntimeEnvironment_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+func (_e *MockRuntimeEnvironment_Expecter) Status() *MockRuntimeEnvironment_Status_Call {
+	return &MockRuntimeEnvironment_Status_Call{Call: _e.mock.On("Status")}
+}
+
+func (_c *MockRuntimeEnvironment_Status_Call) Run(run func()) *MockRuntimeEnvironment_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Status_Call) Return(serviceStatus component.ServiceStatus) *MockRuntimeEnvironment_Status_Call {
+	_c.Call.Return(serviceStatus)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Status_Call) RunAndReturn(run func() component.ServiceStatus) *MockRuntimeEnvironment_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stop provides a mock function for the type MockRuntimeEnvironment
 func (_mock *MockRuntimeEnvironment) Stop(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -659,3 +989,95 @@ func (_c *MockRuntimeEnvironment_StopService_Call) RunAndReturn(run func(ctx con
 	_c.Call.Return(run)
 	return _c
 }
+
+// Supervisor provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) Supervisor() supervisor.Supervisor {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Supervisor")
+	}
+
+	var r0 supervisor.Supervisor
+	if returnFunc, ok := ret.Get(0).(func() supervisor.Supervisor); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(supervisor.Supervisor)
+		}
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_Supervisor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Supervisor'
+type MockRuntimeEnvironment_Supervisor_Call struct {
+	*mock.Call
+}
+
+// Supervisor is a helper method to define mock.On call
+func (_e *MockRuntimeEnvironment_Expecter) Supervisor() *MockRuntimeEnvironment_Supervisor_Call {
+	return &MockRuntimeEnvironment_Supervisor_Call{Call: _e.mock.On("Supervisor")}
+}
+
+func (_c *MockRuntimeEnvironment_Supervisor_Call) Run(run func()) *MockRuntimeEnvironment_Supervisor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Supervisor_Call) Return(supervisor supervisor.Supervisor) *MockRuntimeEnvironment_Supervisor_Call {
+	_c.Call.Return(supervisor)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Supervisor_Call) RunAndReturn(run func() supervisor.Supervisor) *MockRuntimeEnvironment_Supervisor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tracer provides a mock function for the type MockRuntimeEnvironment
+func (_mock *MockRuntimeEnvironment) Tracer() tracing.Tracer {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tracer")
+	}
+
+	var r0 tracing.Tracer
+	if returnFunc, ok := ret.Get(0).(func() tracing.Tracer); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(tracing.Tracer)
+		}
+	}
+	return r0
+}
+
+// MockRuntimeEnvironment_Tracer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tracer'
+type MockRuntimeEnvironment_Tracer_Call struct {
+	*mock.Call
+}
+
+// Tracer is a helper method to define mock.On call
+func (_e *MockRuntimeEnvironment_Expecter) Tracer() *MockRuntimeEnvironment_Tracer_Call {
+	return &MockRuntimeEnvironment_Tracer_Call{Call: _e.mock.On("Tracer")}
+}
+
+func (_c *MockRuntimeEnvironment_Tracer_Call) Run(run func()) *MockRuntimeEnvironment_Tracer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Tracer_Call) Return(tracer tracing.Tracer) *MockRuntimeEnvironment_Tracer_Call {
+	_c.Call.Return(tracer)
+	return _c
+}
+
+func (_c *MockRuntimeEnvironment_Tracer_Call) RunAndReturn(run func() tracing.Tracer) *MockRuntimeEnvironment_Tracer_Call {
+	_c.Call.Return(run)
+	return _c
+}
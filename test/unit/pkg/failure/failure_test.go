@@ -0,0 +1,58 @@
+package failure_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fintechain/skeleton/pkg/component"
+	"github.com/fintechain/skeleton/pkg/failure"
+)
+
+func TestError(t *testing.T) {
+	t.Run("Error text joins code, message and cause", func(t *testing.T) {
+		err := failure.Wrap(errors.New("disk full"), component.ErrServiceStartFailed, "service '%s'", "ledger")
+		assert.Equal(t, "component.service_start_failed: service 'ledger': disk full", err.Error())
+		assert.Equal(t, "component.item_not_found", failure.New(component.ErrItemNotFound, "").Error())
+	})
+
+	t.Run("Codes are sentinels for errors.Is", func(t *testing.T) {
+		err := failure.New(component.ErrItemNotFound, "'ledger'")
+		wrapped := fmt.Errorf("lookup failed: %w", err)
+
+		assert.ErrorIs(t, wrapped, component.ErrItemNotFound)
+		assert.ErrorIs(t, wrapped, failure.New(component.ErrItemNotFound, "other"))
+		assert.NotErrorIs(t, wrapped, component.ErrItemAlreadyExists)
+	})
+
+	t.Run("Every code in a chain matches", func(t *testing.T) {
+		cause := failure.New(component.ErrCircularDependency, "a -> b -> a")
+		err := failure.Wrap(cause, component.ErrServiceStartFailed, "")
+
+		assert.ErrorIs(t, err, component.ErrServiceStartFailed)
+		assert.ErrorIs(t, err, component.ErrCircularDependency)
+		assert.Equal(t, component.ErrServiceStartFailed, failure.CodeOf(err))
+	})
+
+	t.Run("errors.As extracts component and details", func(t *testing.T) {
+		err := fmt.Errorf("startup: %w", failure.New(component.ErrServiceStartFailed, "port in use").
+			WithComponent("http").
+			WithDetail("port", 8080))
+
+		var target *failure.Error
+		require.True(t, errors.As(err, &target))
+		assert.Equal(t, component.ErrServiceStartFailed, target.Code)
+		assert.Equal(t, "http", target.Component)
+		assert.Equal(t, map[string]interface{}{"port": 8080}, target.Details)
+	})
+
+	t.Run("CodeOf finds codes in joined errors and reports plain errors", func(t *testing.T) {
+		joined := errors.Join(errors.New("plain"), component.ErrServiceStopFailed)
+		assert.Equal(t, component.ErrServiceStopFailed, failure.CodeOf(joined))
+		assert.Equal(t, failure.Code(""), failure.CodeOf(errors.New("plain")))
+		assert.Equal(t, failure.Code(""), failure.CodeOf(nil))
+	})
+}
@@ -1,13 +1,26 @@
 package runtime_test
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/fintechain/skeleton/internal/domain/component"
+	"github.com/fintechain/skeleton/internal/domain/context"
+	"github.com/fintechain/skeleton/internal/domain/event"
+	infraComponent "github.com/fintechain/skeleton/internal/infrastructure/component"
+	infraConfig "github.com/fintechain/skeleton/internal/infrastructure/config"
+	infraContext "github.com/fintechain/skeleton/internal/infrastructure/context"
+	infraEvent "github.com/fintechain/skeleton/internal/infrastructure/event"
 	"github.com/fintechain/skeleton/pkg/runtime"
+	"github.com/fintechain/skeleton/pkg/supervisor"
 	"github.com/fintechain/skeleton/test/unit/mocks"
 )
 
@@ -95,6 +108,8 @@ func TestBuilderWithCustomDependencies(t *testing.T) {
 		// Configure mock plugin expectations
 		mockPlugin.On("ID").Return(component.ComponentID("test-plugin"))
 		mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+		mockConfig.On("Exists", runtime.PluginDirectoriesKey).Return(false)
+		mockConfig.On("Exists", runtime.TracingFileKey).Return(false)
 
 		builder := runtime.NewBuilder().
 			WithPlugins(mockPlugin).
@@ -114,6 +129,54 @@ func TestBuilderWithCustomDependencies(t *testing.T) {
 	})
 }
 
+// TestBuilderPluginDiscovery tests loading plugins from plugin directories
+func TestBuilderPluginDiscovery(t *testing.T) {
+	writePlugin := func(t *testing.T, dir, id, path string) {
+		pluginDir := filepath.Join(dir, id)
+		require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+		manifest := `{"id": "` + id + `", "version": "1.0.0", "entrypoint": {"kind": "process", "path": "` + path + `"}}`
+		require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "plugin.json"), []byte(manifest), 0o644))
+	}
+
+	t.Run("Discovered plugins are loaded", func(t *testing.T) {
+		dir := t.TempDir()
+		writePlugin(t, dir, "remote-plugin", "/nonexistent/plugin")
+
+		// The discovered plugin is loaded, so launching its missing executable fails.
+		_, err := runtime.NewBuilder().
+			WithPluginDirectories(dir).
+			BuildCommand("test-operation", nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load plugins")
+		assert.Contains(t, err.Error(), "remote-plugin")
+	})
+
+	t.Run("Directories are read from configuration", func(t *testing.T) {
+		dir := t.TempDir()
+		writePlugin(t, dir, "remote-plugin", "/nonexistent/plugin")
+		cfg := infraConfig.NewMemoryConfigurationWithData(map[string]interface{}{
+			runtime.PluginDirectoriesKey: []string{dir},
+		})
+
+		_, err := runtime.NewBuilder().
+			WithConfig(cfg).
+			BuildCommand("test-operation", nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load plugins")
+	})
+
+	t.Run("Discovery failures do not prevent startup", func(t *testing.T) {
+		_, err := runtime.NewBuilder().
+			WithPluginDirectories(filepath.Join(t.TempDir(), "missing")).
+			BuildCommand("test-operation", nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "operation execution failed")
+	})
+}
+
 // BenchmarkBuilderAPI benchmarks the Builder API
 func BenchmarkBuilderAPI(b *testing.B) {
 	factory := mocks.NewFactory()
@@ -132,3 +195,236 @@ func BenchmarkBuilderAPI(b *testing.B) {
 			BuildCommand("non-existent", map[string]interface{}{"test": "data"})
 	}
 }
+
+// TestBuilderBuild tests the embeddable runtime handle
+func TestBuilderBuild(t *testing.T) {
+	t.Run("Build returns a started handle", func(t *testing.T) {
+		app, err := runtime.NewBuilder().Build()
+		require.NoError(t, err)
+
+		assert.True(t, app.Runtime().IsRunning())
+		select {
+		case <-app.Done():
+			t.Fatal("handle is done while running")
+		default:
+		}
+
+		require.NoError(t, app.Stop(infraContext.NewContext()))
+		<-app.Done()
+		assert.NoError(t, app.Wait())
+		assert.False(t, app.Runtime().IsRunning())
+
+		// Stopping again does nothing; the handle can be started again
+		require.NoError(t, app.Stop(infraContext.NewContext()))
+		require.NoError(t, app.Start(infraContext.NewContext()))
+		assert.True(t, app.Runtime().IsRunning())
+		require.NoError(t, app.Start(infraContext.NewContext()))
+		require.NoError(t, app.Stop(infraContext.NewContext()))
+		assert.NoError(t, app.Wait())
+	})
+
+	t.Run("Wait returns escalated failures", func(t *testing.T) {
+		app, err := runtime.NewBuilder().Build()
+		require.NoError(t, err)
+
+		service := infraComponent.NewBaseService(component.ComponentConfig{ID: "worker"})
+		require.NoError(t, app.Runtime().Registry().Register(service))
+		require.NoError(t, app.Runtime().(supervisor.Provider).Supervisor().Supervise("worker", supervisor.Policy{
+			Restart:    supervisor.RestartNever,
+			Escalation: supervisor.EscalateRuntime,
+		}))
+		app.Runtime().(supervisor.Provider).Supervisor().ReportFailure("worker", errors.New("crashed"))
+
+		select {
+		case <-app.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("handle did not stop")
+		}
+		err = app.Wait()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "runtime stopped")
+		assert.Contains(t, err.Error(), "crashed")
+		assert.False(t, app.Runtime().IsRunning())
+	})
+
+	t.Run("Build fails when plugins fail to load", func(t *testing.T) {
+		mockPlugin := mocks.NewFactory().PluginInterface()
+		mockPlugin.On("ID").Return(component.ComponentID("broken"))
+		mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(errors.New("boom"))
+		mockPlugin.On("IsRunning").Return(false)
+		mockPlugin.On("Dispose").Return(nil)
+
+		app, err := runtime.NewBuilder().WithPlugins(mockPlugin).Build()
+		require.Error(t, err)
+		assert.Nil(t, app)
+		assert.Contains(t, err.Error(), "failed to load plugins")
+		mockPlugin.AssertCalled(t, "Dispose")
+	})
+
+	t.Run("Plugins are unloaded when startup fails", func(t *testing.T) {
+		var env runtime.RuntimeEnvironment
+		service := infraComponent.NewBaseService(component.ComponentConfig{ID: "ledger"})
+		mockPlugin := mocks.NewFactory().PluginInterface()
+		mockPlugin.On("ID").Return(component.ComponentID("ledger-plugin"))
+		mockPlugin.On("Initialize", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			require.NoError(t, args.Get(1).(component.System).Registry().Register(service))
+		}).Return(nil)
+		mockPlugin.On("Start", mock.Anything).Return(nil)
+		mockPlugin.On("Stop", mock.Anything).Return(nil)
+		mockPlugin.On("IsRunning").Return(false)
+		mockPlugin.On("Dispose").Return(nil)
+
+		app, err := runtime.NewBuilder().
+			WithPlugins(mockPlugin).
+			OnStart(func(ctx context.Context, e runtime.RuntimeEnvironment) error {
+				env = e
+				return errors.New("migration failed")
+			}).
+			Build()
+		require.Error(t, err)
+		assert.Nil(t, app)
+
+		mockPlugin.AssertCalled(t, "Dispose")
+		assert.Empty(t, env.PluginManager().ListPlugins())
+		assert.False(t, env.Registry().Has("ledger"), "components of the plugin are unregistered")
+	})
+}
+
+// TestBuilderLifecycleHooks tests hook ordering, failures, timeouts and phase events
+func TestBuilderLifecycleHooks(t *testing.T) {
+	// recorder collects hook calls and phase events in order
+	type recorder struct {
+		mu    sync.Mutex
+		calls []string
+	}
+	record := func(r *recorder, call string) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.calls = append(r.calls, call)
+	}
+	hook := func(r *recorder, name string, err error) runtime.Hook {
+		return func(ctx context.Context, env runtime.RuntimeEnvironment) error {
+			record(r, name)
+			return err
+		}
+	}
+	newBus := func(r *recorder, topics ...string) *infraEvent.EventBus {
+		bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+		for _, topic := range topics {
+			bus.Subscribe(topic, func(evt *event.Event) { record(r, evt.Topic) })
+		}
+		return bus
+	}
+
+	t.Run("Hooks run in phase order", func(t *testing.T) {
+		r := &recorder{}
+		bus := newBus(r, runtime.TopicRuntimeInit, runtime.TopicRuntimeStart, runtime.TopicRuntimeReady,
+			runtime.TopicRuntimeStop, runtime.TopicRuntimeStopped)
+
+		app, err := runtime.NewBuilder().
+			WithEventBus(bus).
+			OnInit(hook(r, "init-1", nil)).
+			OnInit(hook(r, "init-2", nil)).
+			OnStart(hook(r, "start-1", nil)).
+			OnStart(hook(r, "start-2", nil)).
+			OnReady(hook(r, "ready", nil)).
+			OnStop(hook(r, "stop-1", nil)).
+			OnStop(hook(r, "stop-2", nil)).
+			OnStopped(hook(r, "stopped", nil)).
+			Build()
+		require.NoError(t, err)
+		require.NoError(t, app.Stop(infraContext.NewContext()))
+
+		assert.Equal(t, []string{
+			runtime.TopicRuntimeInit, "init-1", "init-2",
+			runtime.TopicRuntimeStart, "start-1", "start-2",
+			runtime.TopicRuntimeReady, "ready",
+			runtime.TopicRuntimeStop, "stop-2", "stop-1",
+			runtime.TopicRuntimeStopped, "stopped",
+		}, r.calls)
+	})
+
+	t.Run("The stopped phase is published before the event bus stops", func(t *testing.T) {
+		bus := infraEvent.NewEventBus(component.ComponentConfig{ID: "event-bus"})
+		var busRunning []bool
+		bus.Subscribe(runtime.TopicRuntimeStopped, func(evt *event.Event) {
+			busRunning = append(busRunning, bus.IsRunning())
+		})
+
+		app, err := runtime.NewBuilder().WithEventBus(bus).Build()
+		require.NoError(t, err)
+		require.NoError(t, app.Stop(infraContext.NewContext()))
+
+		assert.Equal(t, []bool{true}, busRunning)
+		assert.False(t, bus.IsRunning())
+	})
+
+	t.Run("Failing start hook aborts startup", func(t *testing.T) {
+		r := &recorder{}
+		bus := newBus(r, runtime.TopicHookFailed)
+		var env runtime.RuntimeEnvironment
+
+		app, err := runtime.NewBuilder().
+			WithEventBus(bus).
+			OnStart(func(ctx context.Context, e runtime.RuntimeEnvironment) error {
+				env = e
+				return errors.New("migration failed")
+			}).
+			OnStart(hook(r, "start-2", nil)).
+			OnReady(hook(r, "ready", nil)).
+			Build()
+
+		require.Error(t, err)
+		assert.Nil(t, app)
+		assert.ErrorIs(t, err, runtime.ErrHookFailed)
+		assert.Contains(t, err.Error(), "migration failed")
+		assert.Equal(t, []string{runtime.TopicHookFailed}, r.calls)
+		assert.False(t, env.IsRunning())
+	})
+
+	t.Run("Failing init hook aborts before plugins load", func(t *testing.T) {
+		mockPlugin := mocks.NewFactory().PluginInterface()
+
+		app, err := runtime.NewBuilder().
+			WithPlugins(mockPlugin).
+			OnInit(func(ctx context.Context, env runtime.RuntimeEnvironment) error {
+				return errors.New("no license")
+			}).
+			Build()
+
+		require.Error(t, err)
+		assert.Nil(t, app)
+		mockPlugin.AssertNotCalled(t, "Initialize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Slow hooks time out", func(t *testing.T) {
+		app, err := runtime.NewBuilder().
+			WithHookTimeout(20 * time.Millisecond).
+			OnReady(func(ctx context.Context, env runtime.RuntimeEnvironment) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}).
+			Build()
+
+		require.Error(t, err)
+		assert.Nil(t, app)
+		assert.ErrorIs(t, err, runtime.ErrHookTimeout)
+	})
+
+	t.Run("Failing stop hooks do not interrupt shutdown", func(t *testing.T) {
+		r := &recorder{}
+		app, err := runtime.NewBuilder().
+			OnStop(hook(r, "stop-1", nil)).
+			OnStop(hook(r, "stop-2", errors.New("flush failed"))).
+			OnStopped(hook(r, "stopped", nil)).
+			Build()
+		require.NoError(t, err)
+
+		err = app.Stop(infraContext.NewContext())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "flush failed")
+		assert.Equal(t, []string{"stop-2", "stop-1", "stopped"}, r.calls)
+		assert.False(t, app.Runtime().IsRunning())
+		assert.Equal(t, err, app.Wait())
+	})
+}